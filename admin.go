@@ -0,0 +1,799 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/davecourtois/Globular/admin/adminpb"
+	"github.com/davecourtois/Globular/config"
+	"github.com/davecourtois/Utility"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// The metadata of the token given to the admin service.
+const adminTokenKey = "token"
+
+// The file of the admin token in the Globular directory, when it is not given
+// in GLOBULAR_ADMIN_TOKEN.
+const adminTokenName = "admin_token"
+
+// The method that give the services addresses to the processes of this host
+// without token.
+const watchServiceMethod = "/admin.AdminService/WatchService"
+
+/**
+ * The configuration values that can be set with the admin service, the others
+ * (AdminAddress, BackupRoots...) are set only in globular.json.
+ */
+var settableConfigKeys = []string{"Name", "IP", "Port", "AdminPort", "Peers", "CacheRules", "UploadMaxSize", "UploadUserMaxSize", "UploadTotalMaxSize", "UploadExpiration", "BackupInterval", "BackupRetention"}
+
+/**
+ * Start the gRpc service use to administrate the Globule. It listen at
+ * AdminAddress and the calls must give the token of GLOBULAR_ADMIN_TOKEN, or
+ * of the file admin_token that only the owner of the Globular directory can
+ * read if it is not set.
+ */
+func (self *Globule) startAdminService() error {
+	if len(self.adminToken) == 0 {
+		token, err := createAdminToken(self.path)
+		if err != nil {
+			return err
+		}
+		self.adminToken = token
+	}
+
+	lis, err := net.Listen("tcp", net.JoinHostPort(self.AdminAddress, strconv.Itoa(self.AdminPort)))
+	if err != nil {
+		return err
+	}
+
+	grpcServer := grpc.NewServer(
+		grpc.UnaryInterceptor(func(ctx context.Context, rqst interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			err := self.checkAdminToken(ctx, info.FullMethod)
+			if err != nil {
+				return nil, err
+			}
+			return handler(ctx, rqst)
+		}),
+		grpc.StreamInterceptor(func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			err := self.checkAdminToken(stream.Context(), info.FullMethod)
+			if err != nil {
+				return err
+			}
+			return handler(srv, stream)
+		}))
+	adminpb.RegisterAdminServiceServer(grpcServer, self)
+
+	go func() {
+		log.Println("admin service is starting at port ", self.AdminPort)
+		if err := grpcServer.Serve(lis); err != nil {
+			log.Println("admin service fail with error ", err)
+		}
+	}()
+
+	return nil
+}
+
+/**
+ * Return nil if the call give the admin token. The globular resolver of the
+ * services of this host watch the services addresses without it.
+ */
+func (self *Globule) checkAdminToken(ctx context.Context, method string) error {
+	if p, ok := peer.FromContext(ctx); ok && method == watchServiceMethod {
+		if addr, ok := p.Addr.(*net.TCPAddr); ok && addr.IP.IsLoopback() {
+			return nil
+		}
+	}
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(self.adminToken) > 0 {
+		for _, token := range md.Get(adminTokenKey) {
+			if subtle.ConstantTimeCompare([]byte(token), []byte(self.adminToken)) == 1 {
+				return nil
+			}
+		}
+	}
+
+	return status.Errorf(
+		codes.Unauthenticated,
+		Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the admin service need a valid token, give it with -token or GLOBULAR_ADMIN_TOKEN")))
+}
+
+/**
+ * Return the token of the file admin_token of a Globular directory, a random
+ * one is written if the file does not exist. The file can be read by it
+ * owner only.
+ */
+func createAdminToken(dir string) (string, error) {
+	token, err := readAdminToken(dir)
+	if err == nil || !os.IsNotExist(err) {
+		return token, err
+	}
+
+	data := make([]byte, 32)
+	_, err = rand.Read(data)
+	if err != nil {
+		return "", err
+	}
+
+	token = hex.EncodeToString(data)
+	err = ioutil.WriteFile(filepath.Join(dir, adminTokenName), []byte(token+"\n"), 0600)
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+/**
+ * Return the token of the file admin_token of a Globular directory.
+ */
+func readAdminToken(dir string) (string, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, adminTokenName))
+	if err != nil {
+		return "", err
+	}
+
+	token := strings.TrimSpace(string(data))
+	if len(token) == 0 {
+		return "", errors.New("the file " + filepath.Join(dir, adminTokenName) + " is empty")
+	}
+
+	return token, nil
+}
+
+/**
+ * Return the service name as it's found in the services map, the _server
+ * suffix can be omitted (echo or echo_server).
+ */
+func (self *Globule) getServiceName(name string) (string, error) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if self.services[name] != nil {
+		return name, nil
+	}
+
+	if self.services[name+"_server"] != nil {
+		return name + "_server", nil
+	}
+
+	return "", errors.New("no service found with name " + name)
+}
+
+/**
+ * Return the public information of a service.
+ */
+func (self *Globule) getServiceInfo(name string) *adminpb.ServiceInfo {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	s := self.services[name].(map[string]interface{})
+	info := &adminpb.ServiceInfo{
+		Name:     name,
+		Port:     int32(Utility.ToInt(s["Port"])),
+		Proxy:    int32(Utility.ToInt(s["Proxy"])),
+		Protocol: Utility.ToString(s["Protocol"]),
+		Version:  Utility.ToString(s["Version"]),
+		Replicas: int32(getReplicas(s)),
+		Pids:     make([]int32, 0),
+	}
+
+	for _, instance := range getInstances(s) {
+		if instance.isRunning() {
+			info.Pids = append(info.Pids, int32(instance.process.Process.Pid))
+		}
+	}
+
+	if len(info.Pids) > 0 {
+		info.Running = true
+		info.Pid = info.Pids[0]
+	}
+
+	return info
+}
+
+/**
+ * Return the information of all services sorted by name.
+ */
+func (self *Globule) getServicesInfo() []*adminpb.ServiceInfo {
+	self.mutex.Lock()
+	names := make([]string, 0)
+	for name, _ := range self.services {
+		names = append(names, name)
+	}
+	self.mutex.Unlock()
+
+	sort.Strings(names)
+	infos := make([]*adminpb.ServiceInfo, len(names))
+	for i := 0; i < len(names); i++ {
+		infos[i] = self.getServiceInfo(names[i])
+	}
+
+	return infos
+}
+
+// Return the Globule information and it services states.
+func (self *Globule) GetStatus(ctx context.Context, rqst *adminpb.GetStatusRequest) (*adminpb.GetStatusResponse, error) {
+	return &adminpb.GetStatusResponse{
+		Name:      self.Name,
+		Version:   version,
+		Ip:        self.IP,
+		Port:      int32(self.Port),
+		AdminPort: int32(self.AdminPort),
+		Pid:       int32(os.Getpid()),
+		StartTime: self.startTime.Unix(),
+		Services:  self.getServicesInfo(),
+	}, nil
+}
+
+// Return the list of services known by the Globule.
+func (self *Globule) ListServices(ctx context.Context, rqst *adminpb.ListServicesRequest) (*adminpb.ListServicesResponse, error) {
+	return &adminpb.ListServicesResponse{
+		Services: self.getServicesInfo(),
+	}, nil
+}
+
+// Start a stopped service.
+func (self *Globule) StartService(ctx context.Context, rqst *adminpb.StartServiceRequest) (*adminpb.StartServiceResponse, error) {
+	name, err := self.getServiceName(rqst.GetName())
+	if err != nil {
+		// A service added since the Globule start, ex: by the generate
+		// command, it is started when it is found.
+		self.initServices()
+		name, err = self.getServiceName(rqst.GetName())
+		if err == nil && self.isRunning(name) {
+			return &adminpb.StartServiceResponse{
+				Service: self.getServiceInfo(name),
+			}, nil
+		}
+	}
+	if err != nil {
+		return nil, status.Errorf(
+			codes.NotFound,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+
+	self.mutex.Lock()
+	s := self.services[name].(map[string]interface{})
+	self.mutex.Unlock()
+
+	err = self.startService(s)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+
+	return &adminpb.StartServiceResponse{
+		Service: self.getServiceInfo(name),
+	}, nil
+}
+
+// Stop a running service.
+func (self *Globule) StopService(ctx context.Context, rqst *adminpb.StopServiceRequest) (*adminpb.StopServiceResponse, error) {
+	name, err := self.getServiceName(rqst.GetName())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.NotFound,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+
+	err = self.stopService(name)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+
+	return &adminpb.StopServiceResponse{
+		Service: self.getServiceInfo(name),
+	}, nil
+}
+
+// Stop and start a service.
+func (self *Globule) RestartService(ctx context.Context, rqst *adminpb.RestartServiceRequest) (*adminpb.RestartServiceResponse, error) {
+	name, err := self.getServiceName(rqst.GetName())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.NotFound,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+
+	err = self.stopService(name)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+
+	self.mutex.Lock()
+	s := self.services[name].(map[string]interface{})
+	self.mutex.Unlock()
+
+	err = self.startService(s)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+
+	return &adminpb.RestartServiceResponse{
+		Service: self.getServiceInfo(name),
+	}, nil
+}
+
+// Return the output of a service process.
+func (self *Globule) GetLogs(rqst *adminpb.GetLogsRequest, stream adminpb.AdminService_GetLogsServer) error {
+	name := rqst.GetName()
+	if name != self.Name {
+		var err error
+		name, err = self.getServiceName(name)
+		if err != nil {
+			return status.Errorf(
+				codes.NotFound,
+				Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+		}
+	}
+
+	logs := self.getLogs(name)
+
+	// Register the follower before sending the past lines so no line is lost.
+	var follower chan string
+	if rqst.GetFollow() {
+		follower = logs.Follow()
+		defer logs.Unfollow(follower)
+	}
+
+	lines := logs.Tail(int(rqst.GetTail()))
+	for i := 0; i < len(lines); i++ {
+		err := stream.Send(&adminpb.GetLogsResponse{
+			Line: lines[i],
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if follower == nil {
+		return nil
+	}
+
+	for {
+		select {
+		case line := <-follower:
+			err := stream.Send(&adminpb.GetLogsResponse{
+				Line: line,
+			})
+			if err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+/**
+ * Return the Globule configuration as a map.
+ */
+func (self *Globule) getConfig() (map[string]interface{}, error) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	return self.getConfigValues()
+}
+
+// Return the configuration values, the mutex must be lock.
+func (self *Globule) getConfigValues() (map[string]interface{}, error) {
+	str, err := Utility.ToJson(self)
+	if err != nil {
+		return nil, err
+	}
+
+	config := make(map[string]interface{}, 0)
+	err = json.Unmarshal([]byte(str), &config)
+	if err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// Return a configuration value of the Globule.
+func (self *Globule) GetConfig(ctx context.Context, rqst *adminpb.GetConfigRequest) (*adminpb.GetConfigResponse, error) {
+	config, err := self.getConfig()
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+
+	var value interface{} = config
+	if len(rqst.GetKey()) > 0 {
+		for _, key := range strings.Split(rqst.GetKey(), ".") {
+			values, ok := value.(map[string]interface{})
+			if !ok || values[key] == nil {
+				return nil, status.Errorf(
+					codes.NotFound,
+					Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("no configuration value found for key "+rqst.GetKey())))
+			}
+			value = values[key]
+		}
+	}
+
+	str, err := Utility.ToJson(value)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+
+	return &adminpb.GetConfigResponse{
+		Value: str,
+	}, nil
+}
+
+// Set a configuration value of the Globule and save it. Values like the port
+// numbers are use at the next start of the Globule.
+func (self *Globule) SetConfig(ctx context.Context, rqst *adminpb.SetConfigRequest) (*adminpb.SetConfigResponse, error) {
+	if len(rqst.GetKey()) == 0 {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("no configuration key was given")))
+	}
+
+	// The trusted keys, the admin address... are set only in the file.
+	keys := strings.Split(rqst.GetKey(), ".")
+	if !contains(settableConfigKeys, keys[0]) {
+		return nil, status.Errorf(
+			codes.PermissionDenied,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the configuration value "+keys[0]+" can not be set with the admin service, change it in globular.json")))
+	}
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	values_, err := self.getConfigValues()
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+
+	// The value can be any json value, if it's not I will take it as a string.
+	var value interface{}
+	err = json.Unmarshal([]byte(rqst.GetValue()), &value)
+	if err != nil {
+		value = rqst.GetValue()
+	}
+
+	// Go to the map that contain the value.
+	values := values_
+	for i := 0; i < len(keys)-1; i++ {
+		values__, ok := values[keys[i]].(map[string]interface{})
+		if !ok {
+			return nil, status.Errorf(
+				codes.NotFound,
+				Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("no configuration value found for key "+rqst.GetKey())))
+		}
+		values = values__
+	}
+
+	if _, ok := values[keys[len(keys)-1]]; !ok {
+		return nil, status.Errorf(
+			codes.NotFound,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("no configuration value found for key "+rqst.GetKey())))
+	}
+
+	values[keys[len(keys)-1]] = value
+
+	// Set back the value in the Globule if the values are valid.
+	err = config.Validate(values_, self)
+	if err == nil {
+		var data []byte
+		data, err = json.Marshal(map[string]interface{}{keys[0]: values_[keys[0]]})
+		if err == nil {
+			err = json.Unmarshal(data, self)
+		}
+	}
+
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+
+	str, err := Utility.ToJson(self)
+	if err == nil {
+		self.writeConfig(str)
+	}
+
+	return &adminpb.SetConfigResponse{
+		Result: true,
+	}, nil
+}
+
+// Install or upgrade a service from a package. If the new version is not
+// healthy the previous one is restored.
+func (self *Globule) InstallService(stream adminpb.AdminService_InstallServiceServer) error {
+	var signature *packageSignature
+	var data bytes.Buffer
+	for {
+		rqst, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		// Receive message informations.
+		switch msg := rqst.Package.(type) {
+		case *adminpb.InstallServiceRequest_Signature:
+			signature = &packageSignature{
+				Checksum:  msg.Signature.Checksum,
+				Signature: msg.Signature.Signature,
+			}
+
+		case *adminpb.InstallServiceRequest_Data:
+			data.Write(msg.Data)
+		}
+	}
+
+	if signature == nil {
+		return status.Errorf(
+			codes.InvalidArgument,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("no package signature was given")))
+	}
+
+	name, previousVersion, err := self.installService(data.Bytes(), signature)
+	if err != nil {
+		return status.Errorf(
+			codes.Aborted,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+
+	return stream.SendAndClose(&adminpb.InstallServiceResponse{
+		Service:         self.getServiceInfo(name),
+		PreviousVersion: previousVersion,
+	})
+}
+
+// Stop a service and remove it files.
+func (self *Globule) UninstallService(ctx context.Context, rqst *adminpb.UninstallServiceRequest) (*adminpb.UninstallServiceResponse, error) {
+	name, err := self.getServiceName(rqst.GetName())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.NotFound,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+
+	err = self.uninstallService(name)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.FailedPrecondition,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+
+	return &adminpb.UninstallServiceResponse{
+		Result: true,
+	}, nil
+}
+
+// Replace the process of a service without interruption, a new executable is
+// given with a signed package to InstallService.
+func (self *Globule) UpgradeService(ctx context.Context, rqst *adminpb.UpgradeServiceRequest) (*adminpb.UpgradeServiceResponse, error) {
+	name, err := self.getServiceName(rqst.GetName())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.NotFound,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+
+	drainTimeout := upgradeDrainTimeout
+	if rqst.GetDrainTimeout() > 0 {
+		drainTimeout = time.Duration(rqst.GetDrainTimeout()) * time.Second
+	}
+
+	self.mutex.Lock()
+	path := self.services[name].(map[string]interface{})["Path"].(string)
+	self.mutex.Unlock()
+
+	err = self.upgradeService(name, path, drainTimeout)
+
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Aborted,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+
+	return &adminpb.UpgradeServiceResponse{
+		Service: self.getServiceInfo(name),
+	}, nil
+}
+
+// Exchange the nodes registries between two Globules of a cluster.
+func (self *Globule) Gossip(ctx context.Context, rqst *adminpb.GossipRequest) (*adminpb.GossipResponse, error) {
+	self.mergeNodes(rqst.GetNodes())
+
+	return &adminpb.GossipResponse{
+		Nodes: self.getNodes(),
+	}, nil
+}
+
+// Return the nodes of the cluster known by the Globule.
+func (self *Globule) GetCluster(ctx context.Context, rqst *adminpb.GetClusterRequest) (*adminpb.GetClusterResponse, error) {
+	return &adminpb.GetClusterResponse{
+		Nodes: self.getNodes(),
+	}, nil
+}
+
+// Send the addresses of a service each time they change.
+func (self *Globule) WatchService(rqst *adminpb.WatchServiceRequest, stream adminpb.AdminService_WatchServiceServer) error {
+	var addresses []string
+	for {
+		// Take the channel first to not miss a change.
+		changed := self.getRegistryChanged()
+		addresses_ := self.getServiceAddresses(rqst.GetName())
+		if addresses == nil || strings.Join(addresses, ",") != strings.Join(addresses_, ",") {
+			addresses = addresses_
+			err := stream.Send(&adminpb.WatchServiceResponse{
+				Addresses: addresses,
+			})
+
+			if err != nil {
+				return status.Errorf(
+					codes.Internal,
+					Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+			}
+		}
+
+		select {
+		case <-changed:
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// Return the web applications of the Globule.
+func (self *Globule) ListApplications(ctx context.Context, rqst *adminpb.ListApplicationsRequest) (*adminpb.ListApplicationsResponse, error) {
+	self.mutex.Lock()
+	names := make([]string, 0)
+	for name, _ := range self.Applications {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	applications := make([]*adminpb.ApplicationInfo, 0)
+	var err error
+	for _, name := range names {
+		var info *adminpb.ApplicationInfo
+		info, err = getApplicationInfo(name, self.Applications[name])
+		if err != nil {
+			break
+		}
+		applications = append(applications, info)
+	}
+	self.mutex.Unlock()
+
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+
+	return &adminpb.ListApplicationsResponse{
+		Applications: applications,
+	}, nil
+}
+
+// Add or replace a web application and save the configuration.
+func (self *Globule) SetApplication(ctx context.Context, rqst *adminpb.SetApplicationRequest) (*adminpb.SetApplicationResponse, error) {
+	if rqst.GetApplication() == nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("no application was given")))
+	}
+
+	app, err := newApplication(rqst.GetApplication())
+	if err == nil {
+		err = self.setApplication(rqst.GetApplication().GetName(), app)
+	}
+
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+
+	return &adminpb.SetApplicationResponse{
+		Result: true,
+	}, nil
+}
+
+// Remove a web application, it files are kept.
+func (self *Globule) RemoveApplication(ctx context.Context, rqst *adminpb.RemoveApplicationRequest) (*adminpb.RemoveApplicationResponse, error) {
+	err := self.removeApplication(rqst.GetName())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.NotFound,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+
+	return &adminpb.RemoveApplicationResponse{
+		Result: true,
+	}, nil
+}
+
+// Create an archive of the configurations, stores and files.
+func (self *Globule) Backup(ctx context.Context, rqst *adminpb.BackupRequest) (*adminpb.BackupResponse, error) {
+	backup, err := self.createBackup()
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+
+	return &adminpb.BackupResponse{
+		Backup: backup,
+	}, nil
+}
+
+// Return the archives of the backups directory.
+func (self *Globule) ListBackups(ctx context.Context, rqst *adminpb.ListBackupsRequest) (*adminpb.ListBackupsResponse, error) {
+	backups, err := self.listBackups()
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+
+	return &adminpb.ListBackupsResponse{
+		Backups: backups,
+	}, nil
+}
+
+// Check an archive and restore it if check is not set.
+func (self *Globule) Restore(ctx context.Context, rqst *adminpb.RestoreRequest) (*adminpb.RestoreResponse, error) {
+	manifest, err := self.restoreBackup(rqst.GetName(), rqst.GetCheck())
+	if err != nil {
+		code := codes.Aborted
+		if os.IsNotExist(err) {
+			code = codes.NotFound
+		}
+		return nil, status.Errorf(
+			code,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+
+	targets := make([]string, 0)
+	for _, entry := range manifest.Entries {
+		targets = append(targets, entry.Target)
+	}
+
+	return &adminpb.RestoreResponse{
+		Version: manifest.Version,
+		Date:    manifest.Date.Unix(),
+		Targets: targets,
+	}, nil
+}
@@ -0,0 +1,3161 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: admin/adminpb/admin.proto
+
+package adminpb
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// The public information of a service managed by the Globule.
+type ServiceInfo struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Port                 int32    `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	Proxy                int32    `protobuf:"varint,3,opt,name=proxy,proto3" json:"proxy,omitempty"`
+	Protocol             string   `protobuf:"bytes,4,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	Running              bool     `protobuf:"varint,5,opt,name=running,proto3" json:"running,omitempty"`
+	Pid                  int32    `protobuf:"varint,6,opt,name=pid,proto3" json:"pid,omitempty"`
+	Version              string   `protobuf:"bytes,7,opt,name=version,proto3" json:"version,omitempty"`
+	Replicas             int32    `protobuf:"varint,8,opt,name=replicas,proto3" json:"replicas,omitempty"`
+	Pids                 []int32  `protobuf:"varint,9,rep,packed,name=pids,proto3" json:"pids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ServiceInfo) Reset()         { *m = ServiceInfo{} }
+func (m *ServiceInfo) String() string { return proto.CompactTextString(m) }
+func (*ServiceInfo) ProtoMessage()    {}
+func (*ServiceInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{0}
+}
+
+func (m *ServiceInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ServiceInfo.Unmarshal(m, b)
+}
+func (m *ServiceInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ServiceInfo.Marshal(b, m, deterministic)
+}
+func (m *ServiceInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServiceInfo.Merge(m, src)
+}
+func (m *ServiceInfo) XXX_Size() int {
+	return xxx_messageInfo_ServiceInfo.Size(m)
+}
+func (m *ServiceInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServiceInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServiceInfo proto.InternalMessageInfo
+
+func (m *ServiceInfo) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ServiceInfo) GetPort() int32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *ServiceInfo) GetProxy() int32 {
+	if m != nil {
+		return m.Proxy
+	}
+	return 0
+}
+
+func (m *ServiceInfo) GetProtocol() string {
+	if m != nil {
+		return m.Protocol
+	}
+	return ""
+}
+
+func (m *ServiceInfo) GetRunning() bool {
+	if m != nil {
+		return m.Running
+	}
+	return false
+}
+
+func (m *ServiceInfo) GetPid() int32 {
+	if m != nil {
+		return m.Pid
+	}
+	return 0
+}
+
+func (m *ServiceInfo) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *ServiceInfo) GetReplicas() int32 {
+	if m != nil {
+		return m.Replicas
+	}
+	return 0
+}
+
+func (m *ServiceInfo) GetPids() []int32 {
+	if m != nil {
+		return m.Pids
+	}
+	return nil
+}
+
+type GetStatusRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStatusRequest) Reset()         { *m = GetStatusRequest{} }
+func (m *GetStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*GetStatusRequest) ProtoMessage()    {}
+func (*GetStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{1}
+}
+
+func (m *GetStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStatusRequest.Unmarshal(m, b)
+}
+func (m *GetStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *GetStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStatusRequest.Merge(m, src)
+}
+func (m *GetStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_GetStatusRequest.Size(m)
+}
+func (m *GetStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStatusRequest proto.InternalMessageInfo
+
+type GetStatusResponse struct {
+	Name                 string         `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version              string         `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Ip                   string         `protobuf:"bytes,3,opt,name=ip,proto3" json:"ip,omitempty"`
+	Port                 int32          `protobuf:"varint,4,opt,name=port,proto3" json:"port,omitempty"`
+	AdminPort            int32          `protobuf:"varint,5,opt,name=adminPort,proto3" json:"adminPort,omitempty"`
+	Pid                  int32          `protobuf:"varint,6,opt,name=pid,proto3" json:"pid,omitempty"`
+	StartTime            int64          `protobuf:"varint,7,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	Services             []*ServiceInfo `protobuf:"bytes,8,rep,name=services,proto3" json:"services,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *GetStatusResponse) Reset()         { *m = GetStatusResponse{} }
+func (m *GetStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*GetStatusResponse) ProtoMessage()    {}
+func (*GetStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{2}
+}
+
+func (m *GetStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStatusResponse.Unmarshal(m, b)
+}
+func (m *GetStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *GetStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStatusResponse.Merge(m, src)
+}
+func (m *GetStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_GetStatusResponse.Size(m)
+}
+func (m *GetStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStatusResponse proto.InternalMessageInfo
+
+func (m *GetStatusResponse) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GetStatusResponse) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *GetStatusResponse) GetIp() string {
+	if m != nil {
+		return m.Ip
+	}
+	return ""
+}
+
+func (m *GetStatusResponse) GetPort() int32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *GetStatusResponse) GetAdminPort() int32 {
+	if m != nil {
+		return m.AdminPort
+	}
+	return 0
+}
+
+func (m *GetStatusResponse) GetPid() int32 {
+	if m != nil {
+		return m.Pid
+	}
+	return 0
+}
+
+func (m *GetStatusResponse) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *GetStatusResponse) GetServices() []*ServiceInfo {
+	if m != nil {
+		return m.Services
+	}
+	return nil
+}
+
+type ListServicesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListServicesRequest) Reset()         { *m = ListServicesRequest{} }
+func (m *ListServicesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListServicesRequest) ProtoMessage()    {}
+func (*ListServicesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{3}
+}
+
+func (m *ListServicesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListServicesRequest.Unmarshal(m, b)
+}
+func (m *ListServicesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListServicesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListServicesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListServicesRequest.Merge(m, src)
+}
+func (m *ListServicesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListServicesRequest.Size(m)
+}
+func (m *ListServicesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListServicesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListServicesRequest proto.InternalMessageInfo
+
+type ListServicesResponse struct {
+	Services             []*ServiceInfo `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ListServicesResponse) Reset()         { *m = ListServicesResponse{} }
+func (m *ListServicesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListServicesResponse) ProtoMessage()    {}
+func (*ListServicesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{4}
+}
+
+func (m *ListServicesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListServicesResponse.Unmarshal(m, b)
+}
+func (m *ListServicesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListServicesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListServicesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListServicesResponse.Merge(m, src)
+}
+func (m *ListServicesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListServicesResponse.Size(m)
+}
+func (m *ListServicesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListServicesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListServicesResponse proto.InternalMessageInfo
+
+func (m *ListServicesResponse) GetServices() []*ServiceInfo {
+	if m != nil {
+		return m.Services
+	}
+	return nil
+}
+
+type StartServiceRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StartServiceRequest) Reset()         { *m = StartServiceRequest{} }
+func (m *StartServiceRequest) String() string { return proto.CompactTextString(m) }
+func (*StartServiceRequest) ProtoMessage()    {}
+func (*StartServiceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{5}
+}
+
+func (m *StartServiceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartServiceRequest.Unmarshal(m, b)
+}
+func (m *StartServiceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartServiceRequest.Marshal(b, m, deterministic)
+}
+func (m *StartServiceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartServiceRequest.Merge(m, src)
+}
+func (m *StartServiceRequest) XXX_Size() int {
+	return xxx_messageInfo_StartServiceRequest.Size(m)
+}
+func (m *StartServiceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartServiceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StartServiceRequest proto.InternalMessageInfo
+
+func (m *StartServiceRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type StartServiceResponse struct {
+	Service              *ServiceInfo `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *StartServiceResponse) Reset()         { *m = StartServiceResponse{} }
+func (m *StartServiceResponse) String() string { return proto.CompactTextString(m) }
+func (*StartServiceResponse) ProtoMessage()    {}
+func (*StartServiceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{6}
+}
+
+func (m *StartServiceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartServiceResponse.Unmarshal(m, b)
+}
+func (m *StartServiceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartServiceResponse.Marshal(b, m, deterministic)
+}
+func (m *StartServiceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartServiceResponse.Merge(m, src)
+}
+func (m *StartServiceResponse) XXX_Size() int {
+	return xxx_messageInfo_StartServiceResponse.Size(m)
+}
+func (m *StartServiceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartServiceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StartServiceResponse proto.InternalMessageInfo
+
+func (m *StartServiceResponse) GetService() *ServiceInfo {
+	if m != nil {
+		return m.Service
+	}
+	return nil
+}
+
+type StopServiceRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StopServiceRequest) Reset()         { *m = StopServiceRequest{} }
+func (m *StopServiceRequest) String() string { return proto.CompactTextString(m) }
+func (*StopServiceRequest) ProtoMessage()    {}
+func (*StopServiceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{7}
+}
+
+func (m *StopServiceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StopServiceRequest.Unmarshal(m, b)
+}
+func (m *StopServiceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StopServiceRequest.Marshal(b, m, deterministic)
+}
+func (m *StopServiceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StopServiceRequest.Merge(m, src)
+}
+func (m *StopServiceRequest) XXX_Size() int {
+	return xxx_messageInfo_StopServiceRequest.Size(m)
+}
+func (m *StopServiceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StopServiceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StopServiceRequest proto.InternalMessageInfo
+
+func (m *StopServiceRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type StopServiceResponse struct {
+	Service              *ServiceInfo `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *StopServiceResponse) Reset()         { *m = StopServiceResponse{} }
+func (m *StopServiceResponse) String() string { return proto.CompactTextString(m) }
+func (*StopServiceResponse) ProtoMessage()    {}
+func (*StopServiceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{8}
+}
+
+func (m *StopServiceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StopServiceResponse.Unmarshal(m, b)
+}
+func (m *StopServiceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StopServiceResponse.Marshal(b, m, deterministic)
+}
+func (m *StopServiceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StopServiceResponse.Merge(m, src)
+}
+func (m *StopServiceResponse) XXX_Size() int {
+	return xxx_messageInfo_StopServiceResponse.Size(m)
+}
+func (m *StopServiceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StopServiceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StopServiceResponse proto.InternalMessageInfo
+
+func (m *StopServiceResponse) GetService() *ServiceInfo {
+	if m != nil {
+		return m.Service
+	}
+	return nil
+}
+
+type RestartServiceRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestartServiceRequest) Reset()         { *m = RestartServiceRequest{} }
+func (m *RestartServiceRequest) String() string { return proto.CompactTextString(m) }
+func (*RestartServiceRequest) ProtoMessage()    {}
+func (*RestartServiceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{9}
+}
+
+func (m *RestartServiceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestartServiceRequest.Unmarshal(m, b)
+}
+func (m *RestartServiceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestartServiceRequest.Marshal(b, m, deterministic)
+}
+func (m *RestartServiceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestartServiceRequest.Merge(m, src)
+}
+func (m *RestartServiceRequest) XXX_Size() int {
+	return xxx_messageInfo_RestartServiceRequest.Size(m)
+}
+func (m *RestartServiceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestartServiceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestartServiceRequest proto.InternalMessageInfo
+
+func (m *RestartServiceRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type RestartServiceResponse struct {
+	Service              *ServiceInfo `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *RestartServiceResponse) Reset()         { *m = RestartServiceResponse{} }
+func (m *RestartServiceResponse) String() string { return proto.CompactTextString(m) }
+func (*RestartServiceResponse) ProtoMessage()    {}
+func (*RestartServiceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{10}
+}
+
+func (m *RestartServiceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestartServiceResponse.Unmarshal(m, b)
+}
+func (m *RestartServiceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestartServiceResponse.Marshal(b, m, deterministic)
+}
+func (m *RestartServiceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestartServiceResponse.Merge(m, src)
+}
+func (m *RestartServiceResponse) XXX_Size() int {
+	return xxx_messageInfo_RestartServiceResponse.Size(m)
+}
+func (m *RestartServiceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestartServiceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestartServiceResponse proto.InternalMessageInfo
+
+func (m *RestartServiceResponse) GetService() *ServiceInfo {
+	if m != nil {
+		return m.Service
+	}
+	return nil
+}
+
+type GetLogsRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Tail                 int32    `protobuf:"varint,2,opt,name=tail,proto3" json:"tail,omitempty"`
+	Follow               bool     `protobuf:"varint,3,opt,name=follow,proto3" json:"follow,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetLogsRequest) Reset()         { *m = GetLogsRequest{} }
+func (m *GetLogsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetLogsRequest) ProtoMessage()    {}
+func (*GetLogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{11}
+}
+
+func (m *GetLogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLogsRequest.Unmarshal(m, b)
+}
+func (m *GetLogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLogsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetLogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLogsRequest.Merge(m, src)
+}
+func (m *GetLogsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetLogsRequest.Size(m)
+}
+func (m *GetLogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLogsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLogsRequest proto.InternalMessageInfo
+
+func (m *GetLogsRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GetLogsRequest) GetTail() int32 {
+	if m != nil {
+		return m.Tail
+	}
+	return 0
+}
+
+func (m *GetLogsRequest) GetFollow() bool {
+	if m != nil {
+		return m.Follow
+	}
+	return false
+}
+
+type GetLogsResponse struct {
+	Line                 string   `protobuf:"bytes,1,opt,name=line,proto3" json:"line,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetLogsResponse) Reset()         { *m = GetLogsResponse{} }
+func (m *GetLogsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetLogsResponse) ProtoMessage()    {}
+func (*GetLogsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{12}
+}
+
+func (m *GetLogsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLogsResponse.Unmarshal(m, b)
+}
+func (m *GetLogsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLogsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetLogsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLogsResponse.Merge(m, src)
+}
+func (m *GetLogsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetLogsResponse.Size(m)
+}
+func (m *GetLogsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLogsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLogsResponse proto.InternalMessageInfo
+
+func (m *GetLogsResponse) GetLine() string {
+	if m != nil {
+		return m.Line
+	}
+	return ""
+}
+
+type GetConfigRequest struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetConfigRequest) Reset()         { *m = GetConfigRequest{} }
+func (m *GetConfigRequest) String() string { return proto.CompactTextString(m) }
+func (*GetConfigRequest) ProtoMessage()    {}
+func (*GetConfigRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{13}
+}
+
+func (m *GetConfigRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConfigRequest.Unmarshal(m, b)
+}
+func (m *GetConfigRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConfigRequest.Marshal(b, m, deterministic)
+}
+func (m *GetConfigRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConfigRequest.Merge(m, src)
+}
+func (m *GetConfigRequest) XXX_Size() int {
+	return xxx_messageInfo_GetConfigRequest.Size(m)
+}
+func (m *GetConfigRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConfigRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConfigRequest proto.InternalMessageInfo
+
+func (m *GetConfigRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+type GetConfigResponse struct {
+	Value                string   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetConfigResponse) Reset()         { *m = GetConfigResponse{} }
+func (m *GetConfigResponse) String() string { return proto.CompactTextString(m) }
+func (*GetConfigResponse) ProtoMessage()    {}
+func (*GetConfigResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{14}
+}
+
+func (m *GetConfigResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConfigResponse.Unmarshal(m, b)
+}
+func (m *GetConfigResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConfigResponse.Marshal(b, m, deterministic)
+}
+func (m *GetConfigResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConfigResponse.Merge(m, src)
+}
+func (m *GetConfigResponse) XXX_Size() int {
+	return xxx_messageInfo_GetConfigResponse.Size(m)
+}
+func (m *GetConfigResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConfigResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConfigResponse proto.InternalMessageInfo
+
+func (m *GetConfigResponse) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type SetConfigRequest struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetConfigRequest) Reset()         { *m = SetConfigRequest{} }
+func (m *SetConfigRequest) String() string { return proto.CompactTextString(m) }
+func (*SetConfigRequest) ProtoMessage()    {}
+func (*SetConfigRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{15}
+}
+
+func (m *SetConfigRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetConfigRequest.Unmarshal(m, b)
+}
+func (m *SetConfigRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetConfigRequest.Marshal(b, m, deterministic)
+}
+func (m *SetConfigRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetConfigRequest.Merge(m, src)
+}
+func (m *SetConfigRequest) XXX_Size() int {
+	return xxx_messageInfo_SetConfigRequest.Size(m)
+}
+func (m *SetConfigRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetConfigRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetConfigRequest proto.InternalMessageInfo
+
+func (m *SetConfigRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *SetConfigRequest) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type SetConfigResponse struct {
+	Result               bool     `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetConfigResponse) Reset()         { *m = SetConfigResponse{} }
+func (m *SetConfigResponse) String() string { return proto.CompactTextString(m) }
+func (*SetConfigResponse) ProtoMessage()    {}
+func (*SetConfigResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{16}
+}
+
+func (m *SetConfigResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetConfigResponse.Unmarshal(m, b)
+}
+func (m *SetConfigResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetConfigResponse.Marshal(b, m, deterministic)
+}
+func (m *SetConfigResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetConfigResponse.Merge(m, src)
+}
+func (m *SetConfigResponse) XXX_Size() int {
+	return xxx_messageInfo_SetConfigResponse.Size(m)
+}
+func (m *SetConfigResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetConfigResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetConfigResponse proto.InternalMessageInfo
+
+func (m *SetConfigResponse) GetResult() bool {
+	if m != nil {
+		return m.Result
+	}
+	return false
+}
+
+// The checksum and the signature of a service package.
+type PackageSignature struct {
+	Checksum             string   `protobuf:"bytes,1,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	Signature            string   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PackageSignature) Reset()         { *m = PackageSignature{} }
+func (m *PackageSignature) String() string { return proto.CompactTextString(m) }
+func (*PackageSignature) ProtoMessage()    {}
+func (*PackageSignature) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{17}
+}
+
+func (m *PackageSignature) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PackageSignature.Unmarshal(m, b)
+}
+func (m *PackageSignature) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PackageSignature.Marshal(b, m, deterministic)
+}
+func (m *PackageSignature) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PackageSignature.Merge(m, src)
+}
+func (m *PackageSignature) XXX_Size() int {
+	return xxx_messageInfo_PackageSignature.Size(m)
+}
+func (m *PackageSignature) XXX_DiscardUnknown() {
+	xxx_messageInfo_PackageSignature.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PackageSignature proto.InternalMessageInfo
+
+func (m *PackageSignature) GetChecksum() string {
+	if m != nil {
+		return m.Checksum
+	}
+	return ""
+}
+
+func (m *PackageSignature) GetSignature() string {
+	if m != nil {
+		return m.Signature
+	}
+	return ""
+}
+
+// The package signature must be sent first followed by the archive data.
+type InstallServiceRequest struct {
+	// Types that are valid to be assigned to Package:
+	//	*InstallServiceRequest_Signature
+	//	*InstallServiceRequest_Data
+	Package              isInstallServiceRequest_Package `protobuf_oneof:"package"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *InstallServiceRequest) Reset()         { *m = InstallServiceRequest{} }
+func (m *InstallServiceRequest) String() string { return proto.CompactTextString(m) }
+func (*InstallServiceRequest) ProtoMessage()    {}
+func (*InstallServiceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{18}
+}
+
+func (m *InstallServiceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InstallServiceRequest.Unmarshal(m, b)
+}
+func (m *InstallServiceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InstallServiceRequest.Marshal(b, m, deterministic)
+}
+func (m *InstallServiceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InstallServiceRequest.Merge(m, src)
+}
+func (m *InstallServiceRequest) XXX_Size() int {
+	return xxx_messageInfo_InstallServiceRequest.Size(m)
+}
+func (m *InstallServiceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InstallServiceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InstallServiceRequest proto.InternalMessageInfo
+
+type isInstallServiceRequest_Package interface {
+	isInstallServiceRequest_Package()
+}
+
+type InstallServiceRequest_Signature struct {
+	Signature *PackageSignature `protobuf:"bytes,1,opt,name=signature,proto3,oneof"`
+}
+
+type InstallServiceRequest_Data struct {
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3,oneof"`
+}
+
+func (*InstallServiceRequest_Signature) isInstallServiceRequest_Package() {}
+
+func (*InstallServiceRequest_Data) isInstallServiceRequest_Package() {}
+
+func (m *InstallServiceRequest) GetPackage() isInstallServiceRequest_Package {
+	if m != nil {
+		return m.Package
+	}
+	return nil
+}
+
+func (m *InstallServiceRequest) GetSignature() *PackageSignature {
+	if x, ok := m.GetPackage().(*InstallServiceRequest_Signature); ok {
+		return x.Signature
+	}
+	return nil
+}
+
+func (m *InstallServiceRequest) GetData() []byte {
+	if x, ok := m.GetPackage().(*InstallServiceRequest_Data); ok {
+		return x.Data
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*InstallServiceRequest) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*InstallServiceRequest_Signature)(nil),
+		(*InstallServiceRequest_Data)(nil),
+	}
+}
+
+type InstallServiceResponse struct {
+	Service              *ServiceInfo `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	PreviousVersion      string       `protobuf:"bytes,2,opt,name=previousVersion,proto3" json:"previousVersion,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *InstallServiceResponse) Reset()         { *m = InstallServiceResponse{} }
+func (m *InstallServiceResponse) String() string { return proto.CompactTextString(m) }
+func (*InstallServiceResponse) ProtoMessage()    {}
+func (*InstallServiceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{19}
+}
+
+func (m *InstallServiceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InstallServiceResponse.Unmarshal(m, b)
+}
+func (m *InstallServiceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InstallServiceResponse.Marshal(b, m, deterministic)
+}
+func (m *InstallServiceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InstallServiceResponse.Merge(m, src)
+}
+func (m *InstallServiceResponse) XXX_Size() int {
+	return xxx_messageInfo_InstallServiceResponse.Size(m)
+}
+func (m *InstallServiceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_InstallServiceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InstallServiceResponse proto.InternalMessageInfo
+
+func (m *InstallServiceResponse) GetService() *ServiceInfo {
+	if m != nil {
+		return m.Service
+	}
+	return nil
+}
+
+func (m *InstallServiceResponse) GetPreviousVersion() string {
+	if m != nil {
+		return m.PreviousVersion
+	}
+	return ""
+}
+
+type UninstallServiceRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UninstallServiceRequest) Reset()         { *m = UninstallServiceRequest{} }
+func (m *UninstallServiceRequest) String() string { return proto.CompactTextString(m) }
+func (*UninstallServiceRequest) ProtoMessage()    {}
+func (*UninstallServiceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{20}
+}
+
+func (m *UninstallServiceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UninstallServiceRequest.Unmarshal(m, b)
+}
+func (m *UninstallServiceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UninstallServiceRequest.Marshal(b, m, deterministic)
+}
+func (m *UninstallServiceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UninstallServiceRequest.Merge(m, src)
+}
+func (m *UninstallServiceRequest) XXX_Size() int {
+	return xxx_messageInfo_UninstallServiceRequest.Size(m)
+}
+func (m *UninstallServiceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UninstallServiceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UninstallServiceRequest proto.InternalMessageInfo
+
+func (m *UninstallServiceRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type UninstallServiceResponse struct {
+	Result               bool     `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UninstallServiceResponse) Reset()         { *m = UninstallServiceResponse{} }
+func (m *UninstallServiceResponse) String() string { return proto.CompactTextString(m) }
+func (*UninstallServiceResponse) ProtoMessage()    {}
+func (*UninstallServiceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{21}
+}
+
+func (m *UninstallServiceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UninstallServiceResponse.Unmarshal(m, b)
+}
+func (m *UninstallServiceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UninstallServiceResponse.Marshal(b, m, deterministic)
+}
+func (m *UninstallServiceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UninstallServiceResponse.Merge(m, src)
+}
+func (m *UninstallServiceResponse) XXX_Size() int {
+	return xxx_messageInfo_UninstallServiceResponse.Size(m)
+}
+func (m *UninstallServiceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UninstallServiceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UninstallServiceResponse proto.InternalMessageInfo
+
+func (m *UninstallServiceResponse) GetResult() bool {
+	if m != nil {
+		return m.Result
+	}
+	return false
+}
+
+type UpgradeServiceRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	DrainTimeout         int32    `protobuf:"varint,3,opt,name=drainTimeout,proto3" json:"drainTimeout,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpgradeServiceRequest) Reset()         { *m = UpgradeServiceRequest{} }
+func (m *UpgradeServiceRequest) String() string { return proto.CompactTextString(m) }
+func (*UpgradeServiceRequest) ProtoMessage()    {}
+func (*UpgradeServiceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{22}
+}
+
+func (m *UpgradeServiceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpgradeServiceRequest.Unmarshal(m, b)
+}
+func (m *UpgradeServiceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpgradeServiceRequest.Marshal(b, m, deterministic)
+}
+func (m *UpgradeServiceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpgradeServiceRequest.Merge(m, src)
+}
+func (m *UpgradeServiceRequest) XXX_Size() int {
+	return xxx_messageInfo_UpgradeServiceRequest.Size(m)
+}
+func (m *UpgradeServiceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpgradeServiceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpgradeServiceRequest proto.InternalMessageInfo
+
+func (m *UpgradeServiceRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *UpgradeServiceRequest) GetDrainTimeout() int32 {
+	if m != nil {
+		return m.DrainTimeout
+	}
+	return 0
+}
+
+type UpgradeServiceResponse struct {
+	Service              *ServiceInfo `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *UpgradeServiceResponse) Reset()         { *m = UpgradeServiceResponse{} }
+func (m *UpgradeServiceResponse) String() string { return proto.CompactTextString(m) }
+func (*UpgradeServiceResponse) ProtoMessage()    {}
+func (*UpgradeServiceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{23}
+}
+
+func (m *UpgradeServiceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpgradeServiceResponse.Unmarshal(m, b)
+}
+func (m *UpgradeServiceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpgradeServiceResponse.Marshal(b, m, deterministic)
+}
+func (m *UpgradeServiceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpgradeServiceResponse.Merge(m, src)
+}
+func (m *UpgradeServiceResponse) XXX_Size() int {
+	return xxx_messageInfo_UpgradeServiceResponse.Size(m)
+}
+func (m *UpgradeServiceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpgradeServiceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpgradeServiceResponse proto.InternalMessageInfo
+
+func (m *UpgradeServiceResponse) GetService() *ServiceInfo {
+	if m != nil {
+		return m.Service
+	}
+	return nil
+}
+
+// A Globule of the cluster and the services it run.
+type NodeInfo struct {
+	Id                   string         `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 string         `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Ip                   string         `protobuf:"bytes,3,opt,name=ip,proto3" json:"ip,omitempty"`
+	Port                 int32          `protobuf:"varint,4,opt,name=port,proto3" json:"port,omitempty"`
+	AdminPort            int32          `protobuf:"varint,5,opt,name=adminPort,proto3" json:"adminPort,omitempty"`
+	Heartbeat            int64          `protobuf:"varint,6,opt,name=heartbeat,proto3" json:"heartbeat,omitempty"`
+	Services             []*ServiceInfo `protobuf:"bytes,7,rep,name=services,proto3" json:"services,omitempty"`
+	Alive                bool           `protobuf:"varint,8,opt,name=alive,proto3" json:"alive,omitempty"`
+	LastUpdate           int64          `protobuf:"varint,9,opt,name=lastUpdate,proto3" json:"lastUpdate,omitempty"`
+	Generation           int64          `protobuf:"varint,10,opt,name=generation,proto3" json:"generation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *NodeInfo) Reset()         { *m = NodeInfo{} }
+func (m *NodeInfo) String() string { return proto.CompactTextString(m) }
+func (*NodeInfo) ProtoMessage()    {}
+func (*NodeInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{24}
+}
+
+func (m *NodeInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeInfo.Unmarshal(m, b)
+}
+func (m *NodeInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeInfo.Marshal(b, m, deterministic)
+}
+func (m *NodeInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeInfo.Merge(m, src)
+}
+func (m *NodeInfo) XXX_Size() int {
+	return xxx_messageInfo_NodeInfo.Size(m)
+}
+func (m *NodeInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeInfo proto.InternalMessageInfo
+
+func (m *NodeInfo) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *NodeInfo) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *NodeInfo) GetIp() string {
+	if m != nil {
+		return m.Ip
+	}
+	return ""
+}
+
+func (m *NodeInfo) GetPort() int32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *NodeInfo) GetAdminPort() int32 {
+	if m != nil {
+		return m.AdminPort
+	}
+	return 0
+}
+
+func (m *NodeInfo) GetHeartbeat() int64 {
+	if m != nil {
+		return m.Heartbeat
+	}
+	return 0
+}
+
+func (m *NodeInfo) GetServices() []*ServiceInfo {
+	if m != nil {
+		return m.Services
+	}
+	return nil
+}
+
+func (m *NodeInfo) GetAlive() bool {
+	if m != nil {
+		return m.Alive
+	}
+	return false
+}
+
+func (m *NodeInfo) GetLastUpdate() int64 {
+	if m != nil {
+		return m.LastUpdate
+	}
+	return 0
+}
+
+func (m *NodeInfo) GetGeneration() int64 {
+	if m != nil {
+		return m.Generation
+	}
+	return 0
+}
+
+type GossipRequest struct {
+	Nodes                []*NodeInfo `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *GossipRequest) Reset()         { *m = GossipRequest{} }
+func (m *GossipRequest) String() string { return proto.CompactTextString(m) }
+func (*GossipRequest) ProtoMessage()    {}
+func (*GossipRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{25}
+}
+
+func (m *GossipRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GossipRequest.Unmarshal(m, b)
+}
+func (m *GossipRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GossipRequest.Marshal(b, m, deterministic)
+}
+func (m *GossipRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GossipRequest.Merge(m, src)
+}
+func (m *GossipRequest) XXX_Size() int {
+	return xxx_messageInfo_GossipRequest.Size(m)
+}
+func (m *GossipRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GossipRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GossipRequest proto.InternalMessageInfo
+
+func (m *GossipRequest) GetNodes() []*NodeInfo {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+type GossipResponse struct {
+	Nodes                []*NodeInfo `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *GossipResponse) Reset()         { *m = GossipResponse{} }
+func (m *GossipResponse) String() string { return proto.CompactTextString(m) }
+func (*GossipResponse) ProtoMessage()    {}
+func (*GossipResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{26}
+}
+
+func (m *GossipResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GossipResponse.Unmarshal(m, b)
+}
+func (m *GossipResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GossipResponse.Marshal(b, m, deterministic)
+}
+func (m *GossipResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GossipResponse.Merge(m, src)
+}
+func (m *GossipResponse) XXX_Size() int {
+	return xxx_messageInfo_GossipResponse.Size(m)
+}
+func (m *GossipResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GossipResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GossipResponse proto.InternalMessageInfo
+
+func (m *GossipResponse) GetNodes() []*NodeInfo {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+type GetClusterRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetClusterRequest) Reset()         { *m = GetClusterRequest{} }
+func (m *GetClusterRequest) String() string { return proto.CompactTextString(m) }
+func (*GetClusterRequest) ProtoMessage()    {}
+func (*GetClusterRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{27}
+}
+
+func (m *GetClusterRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetClusterRequest.Unmarshal(m, b)
+}
+func (m *GetClusterRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetClusterRequest.Marshal(b, m, deterministic)
+}
+func (m *GetClusterRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetClusterRequest.Merge(m, src)
+}
+func (m *GetClusterRequest) XXX_Size() int {
+	return xxx_messageInfo_GetClusterRequest.Size(m)
+}
+func (m *GetClusterRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetClusterRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetClusterRequest proto.InternalMessageInfo
+
+type GetClusterResponse struct {
+	Nodes                []*NodeInfo `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *GetClusterResponse) Reset()         { *m = GetClusterResponse{} }
+func (m *GetClusterResponse) String() string { return proto.CompactTextString(m) }
+func (*GetClusterResponse) ProtoMessage()    {}
+func (*GetClusterResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{28}
+}
+
+func (m *GetClusterResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetClusterResponse.Unmarshal(m, b)
+}
+func (m *GetClusterResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetClusterResponse.Marshal(b, m, deterministic)
+}
+func (m *GetClusterResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetClusterResponse.Merge(m, src)
+}
+func (m *GetClusterResponse) XXX_Size() int {
+	return xxx_messageInfo_GetClusterResponse.Size(m)
+}
+func (m *GetClusterResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetClusterResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetClusterResponse proto.InternalMessageInfo
+
+func (m *GetClusterResponse) GetNodes() []*NodeInfo {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+type WatchServiceRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchServiceRequest) Reset()         { *m = WatchServiceRequest{} }
+func (m *WatchServiceRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchServiceRequest) ProtoMessage()    {}
+func (*WatchServiceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{29}
+}
+
+func (m *WatchServiceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchServiceRequest.Unmarshal(m, b)
+}
+func (m *WatchServiceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchServiceRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchServiceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchServiceRequest.Merge(m, src)
+}
+func (m *WatchServiceRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchServiceRequest.Size(m)
+}
+func (m *WatchServiceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchServiceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchServiceRequest proto.InternalMessageInfo
+
+func (m *WatchServiceRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type WatchServiceResponse struct {
+	Addresses            []string `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchServiceResponse) Reset()         { *m = WatchServiceResponse{} }
+func (m *WatchServiceResponse) String() string { return proto.CompactTextString(m) }
+func (*WatchServiceResponse) ProtoMessage()    {}
+func (*WatchServiceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{30}
+}
+
+func (m *WatchServiceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchServiceResponse.Unmarshal(m, b)
+}
+func (m *WatchServiceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchServiceResponse.Marshal(b, m, deterministic)
+}
+func (m *WatchServiceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchServiceResponse.Merge(m, src)
+}
+func (m *WatchServiceResponse) XXX_Size() int {
+	return xxx_messageInfo_WatchServiceResponse.Size(m)
+}
+func (m *WatchServiceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchServiceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchServiceResponse proto.InternalMessageInfo
+
+func (m *WatchServiceResponse) GetAddresses() []string {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+// A web application served by the Globule http server.
+type ApplicationInfo struct {
+	Name                 string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Hostname             string            `protobuf:"bytes,2,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	PathPrefix           string            `protobuf:"bytes,3,opt,name=pathPrefix,proto3" json:"pathPrefix,omitempty"`
+	Root                 string            `protobuf:"bytes,4,opt,name=root,proto3" json:"root,omitempty"`
+	Spa                  bool              `protobuf:"varint,5,opt,name=spa,proto3" json:"spa,omitempty"`
+	ErrorPages           map[string]string `protobuf:"bytes,6,rep,name=errorPages,proto3" json:"errorPages,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Config               string            `protobuf:"bytes,7,opt,name=config,proto3" json:"config,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ApplicationInfo) Reset()         { *m = ApplicationInfo{} }
+func (m *ApplicationInfo) String() string { return proto.CompactTextString(m) }
+func (*ApplicationInfo) ProtoMessage()    {}
+func (*ApplicationInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{31}
+}
+
+func (m *ApplicationInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApplicationInfo.Unmarshal(m, b)
+}
+func (m *ApplicationInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApplicationInfo.Marshal(b, m, deterministic)
+}
+func (m *ApplicationInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApplicationInfo.Merge(m, src)
+}
+func (m *ApplicationInfo) XXX_Size() int {
+	return xxx_messageInfo_ApplicationInfo.Size(m)
+}
+func (m *ApplicationInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApplicationInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApplicationInfo proto.InternalMessageInfo
+
+func (m *ApplicationInfo) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ApplicationInfo) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *ApplicationInfo) GetPathPrefix() string {
+	if m != nil {
+		return m.PathPrefix
+	}
+	return ""
+}
+
+func (m *ApplicationInfo) GetRoot() string {
+	if m != nil {
+		return m.Root
+	}
+	return ""
+}
+
+func (m *ApplicationInfo) GetSpa() bool {
+	if m != nil {
+		return m.Spa
+	}
+	return false
+}
+
+func (m *ApplicationInfo) GetErrorPages() map[string]string {
+	if m != nil {
+		return m.ErrorPages
+	}
+	return nil
+}
+
+func (m *ApplicationInfo) GetConfig() string {
+	if m != nil {
+		return m.Config
+	}
+	return ""
+}
+
+type ListApplicationsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListApplicationsRequest) Reset()         { *m = ListApplicationsRequest{} }
+func (m *ListApplicationsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListApplicationsRequest) ProtoMessage()    {}
+func (*ListApplicationsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{32}
+}
+
+func (m *ListApplicationsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListApplicationsRequest.Unmarshal(m, b)
+}
+func (m *ListApplicationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListApplicationsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListApplicationsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListApplicationsRequest.Merge(m, src)
+}
+func (m *ListApplicationsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListApplicationsRequest.Size(m)
+}
+func (m *ListApplicationsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListApplicationsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListApplicationsRequest proto.InternalMessageInfo
+
+type ListApplicationsResponse struct {
+	Applications         []*ApplicationInfo `protobuf:"bytes,1,rep,name=applications,proto3" json:"applications,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ListApplicationsResponse) Reset()         { *m = ListApplicationsResponse{} }
+func (m *ListApplicationsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListApplicationsResponse) ProtoMessage()    {}
+func (*ListApplicationsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{33}
+}
+
+func (m *ListApplicationsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListApplicationsResponse.Unmarshal(m, b)
+}
+func (m *ListApplicationsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListApplicationsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListApplicationsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListApplicationsResponse.Merge(m, src)
+}
+func (m *ListApplicationsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListApplicationsResponse.Size(m)
+}
+func (m *ListApplicationsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListApplicationsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListApplicationsResponse proto.InternalMessageInfo
+
+func (m *ListApplicationsResponse) GetApplications() []*ApplicationInfo {
+	if m != nil {
+		return m.Applications
+	}
+	return nil
+}
+
+type SetApplicationRequest struct {
+	Application          *ApplicationInfo `protobuf:"bytes,1,opt,name=application,proto3" json:"application,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *SetApplicationRequest) Reset()         { *m = SetApplicationRequest{} }
+func (m *SetApplicationRequest) String() string { return proto.CompactTextString(m) }
+func (*SetApplicationRequest) ProtoMessage()    {}
+func (*SetApplicationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{34}
+}
+
+func (m *SetApplicationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetApplicationRequest.Unmarshal(m, b)
+}
+func (m *SetApplicationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetApplicationRequest.Marshal(b, m, deterministic)
+}
+func (m *SetApplicationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetApplicationRequest.Merge(m, src)
+}
+func (m *SetApplicationRequest) XXX_Size() int {
+	return xxx_messageInfo_SetApplicationRequest.Size(m)
+}
+func (m *SetApplicationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetApplicationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetApplicationRequest proto.InternalMessageInfo
+
+func (m *SetApplicationRequest) GetApplication() *ApplicationInfo {
+	if m != nil {
+		return m.Application
+	}
+	return nil
+}
+
+type SetApplicationResponse struct {
+	Result               bool     `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetApplicationResponse) Reset()         { *m = SetApplicationResponse{} }
+func (m *SetApplicationResponse) String() string { return proto.CompactTextString(m) }
+func (*SetApplicationResponse) ProtoMessage()    {}
+func (*SetApplicationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{35}
+}
+
+func (m *SetApplicationResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetApplicationResponse.Unmarshal(m, b)
+}
+func (m *SetApplicationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetApplicationResponse.Marshal(b, m, deterministic)
+}
+func (m *SetApplicationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetApplicationResponse.Merge(m, src)
+}
+func (m *SetApplicationResponse) XXX_Size() int {
+	return xxx_messageInfo_SetApplicationResponse.Size(m)
+}
+func (m *SetApplicationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetApplicationResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetApplicationResponse proto.InternalMessageInfo
+
+func (m *SetApplicationResponse) GetResult() bool {
+	if m != nil {
+		return m.Result
+	}
+	return false
+}
+
+type RemoveApplicationRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveApplicationRequest) Reset()         { *m = RemoveApplicationRequest{} }
+func (m *RemoveApplicationRequest) String() string { return proto.CompactTextString(m) }
+func (*RemoveApplicationRequest) ProtoMessage()    {}
+func (*RemoveApplicationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{36}
+}
+
+func (m *RemoveApplicationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveApplicationRequest.Unmarshal(m, b)
+}
+func (m *RemoveApplicationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveApplicationRequest.Marshal(b, m, deterministic)
+}
+func (m *RemoveApplicationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveApplicationRequest.Merge(m, src)
+}
+func (m *RemoveApplicationRequest) XXX_Size() int {
+	return xxx_messageInfo_RemoveApplicationRequest.Size(m)
+}
+func (m *RemoveApplicationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveApplicationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveApplicationRequest proto.InternalMessageInfo
+
+func (m *RemoveApplicationRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type RemoveApplicationResponse struct {
+	Result               bool     `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveApplicationResponse) Reset()         { *m = RemoveApplicationResponse{} }
+func (m *RemoveApplicationResponse) String() string { return proto.CompactTextString(m) }
+func (*RemoveApplicationResponse) ProtoMessage()    {}
+func (*RemoveApplicationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{37}
+}
+
+func (m *RemoveApplicationResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveApplicationResponse.Unmarshal(m, b)
+}
+func (m *RemoveApplicationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveApplicationResponse.Marshal(b, m, deterministic)
+}
+func (m *RemoveApplicationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveApplicationResponse.Merge(m, src)
+}
+func (m *RemoveApplicationResponse) XXX_Size() int {
+	return xxx_messageInfo_RemoveApplicationResponse.Size(m)
+}
+func (m *RemoveApplicationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveApplicationResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveApplicationResponse proto.InternalMessageInfo
+
+func (m *RemoveApplicationResponse) GetResult() bool {
+	if m != nil {
+		return m.Result
+	}
+	return false
+}
+
+// An archive of the backups directory.
+type BackupInfo struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Size                 int64    `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Date                 int64    `protobuf:"varint,3,opt,name=date,proto3" json:"date,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BackupInfo) Reset()         { *m = BackupInfo{} }
+func (m *BackupInfo) String() string { return proto.CompactTextString(m) }
+func (*BackupInfo) ProtoMessage()    {}
+func (*BackupInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{38}
+}
+
+func (m *BackupInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackupInfo.Unmarshal(m, b)
+}
+func (m *BackupInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackupInfo.Marshal(b, m, deterministic)
+}
+func (m *BackupInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupInfo.Merge(m, src)
+}
+func (m *BackupInfo) XXX_Size() int {
+	return xxx_messageInfo_BackupInfo.Size(m)
+}
+func (m *BackupInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupInfo proto.InternalMessageInfo
+
+func (m *BackupInfo) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *BackupInfo) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *BackupInfo) GetDate() int64 {
+	if m != nil {
+		return m.Date
+	}
+	return 0
+}
+
+type BackupRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BackupRequest) Reset()         { *m = BackupRequest{} }
+func (m *BackupRequest) String() string { return proto.CompactTextString(m) }
+func (*BackupRequest) ProtoMessage()    {}
+func (*BackupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{39}
+}
+
+func (m *BackupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackupRequest.Unmarshal(m, b)
+}
+func (m *BackupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackupRequest.Marshal(b, m, deterministic)
+}
+func (m *BackupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupRequest.Merge(m, src)
+}
+func (m *BackupRequest) XXX_Size() int {
+	return xxx_messageInfo_BackupRequest.Size(m)
+}
+func (m *BackupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupRequest proto.InternalMessageInfo
+
+type BackupResponse struct {
+	Backup               *BackupInfo `protobuf:"bytes,1,opt,name=backup,proto3" json:"backup,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *BackupResponse) Reset()         { *m = BackupResponse{} }
+func (m *BackupResponse) String() string { return proto.CompactTextString(m) }
+func (*BackupResponse) ProtoMessage()    {}
+func (*BackupResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{40}
+}
+
+func (m *BackupResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackupResponse.Unmarshal(m, b)
+}
+func (m *BackupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackupResponse.Marshal(b, m, deterministic)
+}
+func (m *BackupResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupResponse.Merge(m, src)
+}
+func (m *BackupResponse) XXX_Size() int {
+	return xxx_messageInfo_BackupResponse.Size(m)
+}
+func (m *BackupResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupResponse proto.InternalMessageInfo
+
+func (m *BackupResponse) GetBackup() *BackupInfo {
+	if m != nil {
+		return m.Backup
+	}
+	return nil
+}
+
+type ListBackupsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListBackupsRequest) Reset()         { *m = ListBackupsRequest{} }
+func (m *ListBackupsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListBackupsRequest) ProtoMessage()    {}
+func (*ListBackupsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{41}
+}
+
+func (m *ListBackupsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListBackupsRequest.Unmarshal(m, b)
+}
+func (m *ListBackupsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListBackupsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListBackupsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListBackupsRequest.Merge(m, src)
+}
+func (m *ListBackupsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListBackupsRequest.Size(m)
+}
+func (m *ListBackupsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListBackupsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListBackupsRequest proto.InternalMessageInfo
+
+type ListBackupsResponse struct {
+	Backups              []*BackupInfo `protobuf:"bytes,1,rep,name=backups,proto3" json:"backups,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ListBackupsResponse) Reset()         { *m = ListBackupsResponse{} }
+func (m *ListBackupsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListBackupsResponse) ProtoMessage()    {}
+func (*ListBackupsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{42}
+}
+
+func (m *ListBackupsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListBackupsResponse.Unmarshal(m, b)
+}
+func (m *ListBackupsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListBackupsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListBackupsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListBackupsResponse.Merge(m, src)
+}
+func (m *ListBackupsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListBackupsResponse.Size(m)
+}
+func (m *ListBackupsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListBackupsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListBackupsResponse proto.InternalMessageInfo
+
+func (m *ListBackupsResponse) GetBackups() []*BackupInfo {
+	if m != nil {
+		return m.Backups
+	}
+	return nil
+}
+
+type RestoreRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Check                bool     `protobuf:"varint,2,opt,name=check,proto3" json:"check,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreRequest) Reset()         { *m = RestoreRequest{} }
+func (m *RestoreRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreRequest) ProtoMessage()    {}
+func (*RestoreRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{43}
+}
+
+func (m *RestoreRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreRequest.Unmarshal(m, b)
+}
+func (m *RestoreRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreRequest.Marshal(b, m, deterministic)
+}
+func (m *RestoreRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreRequest.Merge(m, src)
+}
+func (m *RestoreRequest) XXX_Size() int {
+	return xxx_messageInfo_RestoreRequest.Size(m)
+}
+func (m *RestoreRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreRequest proto.InternalMessageInfo
+
+func (m *RestoreRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RestoreRequest) GetCheck() bool {
+	if m != nil {
+		return m.Check
+	}
+	return false
+}
+
+type RestoreResponse struct {
+	Version              string   `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Date                 int64    `protobuf:"varint,2,opt,name=date,proto3" json:"date,omitempty"`
+	Targets              []string `protobuf:"bytes,3,rep,name=targets,proto3" json:"targets,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreResponse) Reset()         { *m = RestoreResponse{} }
+func (m *RestoreResponse) String() string { return proto.CompactTextString(m) }
+func (*RestoreResponse) ProtoMessage()    {}
+func (*RestoreResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2f6b6a6c24563593, []int{44}
+}
+
+func (m *RestoreResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreResponse.Unmarshal(m, b)
+}
+func (m *RestoreResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreResponse.Marshal(b, m, deterministic)
+}
+func (m *RestoreResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreResponse.Merge(m, src)
+}
+func (m *RestoreResponse) XXX_Size() int {
+	return xxx_messageInfo_RestoreResponse.Size(m)
+}
+func (m *RestoreResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreResponse proto.InternalMessageInfo
+
+func (m *RestoreResponse) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *RestoreResponse) GetDate() int64 {
+	if m != nil {
+		return m.Date
+	}
+	return 0
+}
+
+func (m *RestoreResponse) GetTargets() []string {
+	if m != nil {
+		return m.Targets
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*ServiceInfo)(nil), "admin.ServiceInfo")
+	proto.RegisterType((*GetStatusRequest)(nil), "admin.GetStatusRequest")
+	proto.RegisterType((*GetStatusResponse)(nil), "admin.GetStatusResponse")
+	proto.RegisterType((*ListServicesRequest)(nil), "admin.ListServicesRequest")
+	proto.RegisterType((*ListServicesResponse)(nil), "admin.ListServicesResponse")
+	proto.RegisterType((*StartServiceRequest)(nil), "admin.StartServiceRequest")
+	proto.RegisterType((*StartServiceResponse)(nil), "admin.StartServiceResponse")
+	proto.RegisterType((*StopServiceRequest)(nil), "admin.StopServiceRequest")
+	proto.RegisterType((*StopServiceResponse)(nil), "admin.StopServiceResponse")
+	proto.RegisterType((*RestartServiceRequest)(nil), "admin.RestartServiceRequest")
+	proto.RegisterType((*RestartServiceResponse)(nil), "admin.RestartServiceResponse")
+	proto.RegisterType((*GetLogsRequest)(nil), "admin.GetLogsRequest")
+	proto.RegisterType((*GetLogsResponse)(nil), "admin.GetLogsResponse")
+	proto.RegisterType((*GetConfigRequest)(nil), "admin.GetConfigRequest")
+	proto.RegisterType((*GetConfigResponse)(nil), "admin.GetConfigResponse")
+	proto.RegisterType((*SetConfigRequest)(nil), "admin.SetConfigRequest")
+	proto.RegisterType((*SetConfigResponse)(nil), "admin.SetConfigResponse")
+	proto.RegisterType((*PackageSignature)(nil), "admin.PackageSignature")
+	proto.RegisterType((*InstallServiceRequest)(nil), "admin.InstallServiceRequest")
+	proto.RegisterType((*InstallServiceResponse)(nil), "admin.InstallServiceResponse")
+	proto.RegisterType((*UninstallServiceRequest)(nil), "admin.UninstallServiceRequest")
+	proto.RegisterType((*UninstallServiceResponse)(nil), "admin.UninstallServiceResponse")
+	proto.RegisterType((*UpgradeServiceRequest)(nil), "admin.UpgradeServiceRequest")
+	proto.RegisterType((*UpgradeServiceResponse)(nil), "admin.UpgradeServiceResponse")
+	proto.RegisterType((*NodeInfo)(nil), "admin.NodeInfo")
+	proto.RegisterType((*GossipRequest)(nil), "admin.GossipRequest")
+	proto.RegisterType((*GossipResponse)(nil), "admin.GossipResponse")
+	proto.RegisterType((*GetClusterRequest)(nil), "admin.GetClusterRequest")
+	proto.RegisterType((*GetClusterResponse)(nil), "admin.GetClusterResponse")
+	proto.RegisterType((*WatchServiceRequest)(nil), "admin.WatchServiceRequest")
+	proto.RegisterType((*WatchServiceResponse)(nil), "admin.WatchServiceResponse")
+	proto.RegisterType((*ApplicationInfo)(nil), "admin.ApplicationInfo")
+	proto.RegisterMapType((map[string]string)(nil), "admin.ApplicationInfo.ErrorPagesEntry")
+	proto.RegisterType((*ListApplicationsRequest)(nil), "admin.ListApplicationsRequest")
+	proto.RegisterType((*ListApplicationsResponse)(nil), "admin.ListApplicationsResponse")
+	proto.RegisterType((*SetApplicationRequest)(nil), "admin.SetApplicationRequest")
+	proto.RegisterType((*SetApplicationResponse)(nil), "admin.SetApplicationResponse")
+	proto.RegisterType((*RemoveApplicationRequest)(nil), "admin.RemoveApplicationRequest")
+	proto.RegisterType((*RemoveApplicationResponse)(nil), "admin.RemoveApplicationResponse")
+	proto.RegisterType((*BackupInfo)(nil), "admin.BackupInfo")
+	proto.RegisterType((*BackupRequest)(nil), "admin.BackupRequest")
+	proto.RegisterType((*BackupResponse)(nil), "admin.BackupResponse")
+	proto.RegisterType((*ListBackupsRequest)(nil), "admin.ListBackupsRequest")
+	proto.RegisterType((*ListBackupsResponse)(nil), "admin.ListBackupsResponse")
+	proto.RegisterType((*RestoreRequest)(nil), "admin.RestoreRequest")
+	proto.RegisterType((*RestoreResponse)(nil), "admin.RestoreResponse")
+}
+
+func init() { proto.RegisterFile("admin/adminpb/admin.proto", fileDescriptor_2f6b6a6c24563593) }
+
+var fileDescriptor_2f6b6a6c24563593 = []byte{
+	// 1472 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x58, 0xef, 0x72, 0x13, 0x37,
+	0x10, 0xc7, 0x76, 0x1c, 0xdb, 0x9b, 0x10, 0x3b, 0x8a, 0x6d, 0x2e, 0x47, 0x02, 0x19, 0x4d, 0xe9,
+	0x38, 0x43, 0x9b, 0x32, 0xd0, 0x29, 0x4c, 0xda, 0xce, 0x40, 0x28, 0x01, 0x5a, 0xa6, 0x0d, 0xe7,
+	0x86, 0xfe, 0xf9, 0xa6, 0xd8, 0x8a, 0x73, 0x93, 0xcb, 0xdd, 0x71, 0x92, 0x53, 0xe8, 0x73, 0xf4,
+	0x19, 0xfa, 0x28, 0x7d, 0x8b, 0x7e, 0xea, 0x8b, 0x74, 0xa4, 0xd3, 0xe9, 0x74, 0x67, 0x99, 0xa4,
+	0xe9, 0x17, 0x38, 0xed, 0xae, 0x7e, 0xbb, 0xda, 0x95, 0x76, 0x7f, 0x31, 0xac, 0x93, 0xf1, 0x99,
+	0x1f, 0x7e, 0x26, 0xff, 0x8d, 0x8f, 0xd2, 0xff, 0x77, 0xe2, 0x24, 0xe2, 0x11, 0xaa, 0xcb, 0x05,
+	0xfe, 0xbb, 0x02, 0x4b, 0x43, 0x9a, 0x9c, 0xfb, 0x23, 0xfa, 0x32, 0x3c, 0x8e, 0x10, 0x82, 0x85,
+	0x90, 0x9c, 0x51, 0xa7, 0xb2, 0x55, 0x19, 0xb4, 0x3c, 0xf9, 0x2d, 0x64, 0x71, 0x94, 0x70, 0xa7,
+	0xba, 0x55, 0x19, 0xd4, 0x3d, 0xf9, 0x8d, 0xba, 0x50, 0x8f, 0x93, 0xe8, 0xdd, 0x7b, 0xa7, 0x26,
+	0x85, 0xe9, 0x02, 0xb9, 0xd0, 0x94, 0xe8, 0xa3, 0x28, 0x70, 0x16, 0x24, 0x82, 0x5e, 0x23, 0x07,
+	0x1a, 0xc9, 0x34, 0x0c, 0xfd, 0x70, 0xe2, 0xd4, 0xb7, 0x2a, 0x83, 0xa6, 0x97, 0x2d, 0x51, 0x07,
+	0x6a, 0xb1, 0x3f, 0x76, 0x16, 0x25, 0x92, 0xf8, 0x14, 0xb6, 0xe7, 0x34, 0x61, 0x7e, 0x14, 0x3a,
+	0x0d, 0x09, 0x93, 0x2d, 0x85, 0x87, 0x84, 0xc6, 0x81, 0x3f, 0x22, 0xcc, 0x69, 0xca, 0x0d, 0x7a,
+	0x2d, 0xe3, 0xf4, 0xc7, 0xcc, 0x69, 0x6d, 0xd5, 0x64, 0x9c, 0xfe, 0x98, 0x61, 0x04, 0x9d, 0xe7,
+	0x94, 0x0f, 0x39, 0xe1, 0x53, 0xe6, 0xd1, 0xb7, 0x53, 0xca, 0x38, 0xfe, 0xa7, 0x02, 0xab, 0x86,
+	0x90, 0xc5, 0x51, 0xc8, 0xa8, 0xf5, 0xe4, 0x46, 0x1c, 0xd5, 0x62, 0x1c, 0x2b, 0x50, 0xf5, 0x63,
+	0x79, 0xf8, 0x96, 0x57, 0xf5, 0x63, 0x9d, 0xa3, 0x05, 0x23, 0x47, 0x1b, 0xd0, 0x92, 0x49, 0x3e,
+	0x10, 0x8a, 0xba, 0x54, 0xe4, 0x02, 0xcb, 0xa9, 0x37, 0xa0, 0xc5, 0x38, 0x49, 0xf8, 0x8f, 0xfe,
+	0x19, 0x95, 0xe7, 0xae, 0x79, 0xb9, 0x00, 0xed, 0x40, 0x93, 0xa5, 0x85, 0x12, 0x27, 0xaf, 0x0d,
+	0x96, 0xee, 0xa3, 0x9d, 0xb4, 0xa0, 0x46, 0xfd, 0x3c, 0x6d, 0x83, 0x7b, 0xb0, 0xf6, 0xca, 0x67,
+	0x5c, 0x29, 0xf5, 0xe1, 0xf7, 0xa1, 0x5b, 0x14, 0xab, 0xe3, 0x9b, 0xf0, 0x95, 0x4b, 0xc0, 0x6f,
+	0xc3, 0xda, 0x50, 0xc4, 0xa6, 0xb4, 0x0a, 0xde, 0x96, 0x45, 0xfc, 0x0d, 0x74, 0x8b, 0xa6, 0xca,
+	0xe5, 0x27, 0xd0, 0x50, 0x70, 0xd2, 0xdc, 0xee, 0x31, 0x33, 0xc1, 0x03, 0x40, 0x43, 0x1e, 0xc5,
+	0x97, 0xf0, 0xf7, 0x14, 0xd6, 0x0a, 0x96, 0x57, 0x72, 0x77, 0x17, 0x7a, 0x1e, 0x65, 0x97, 0x3c,
+	0xe1, 0x3e, 0xf4, 0xcb, 0xc6, 0x57, 0x72, 0x7a, 0x00, 0x2b, 0xcf, 0x29, 0x7f, 0x15, 0x4d, 0xd8,
+	0x07, 0xbc, 0x09, 0x19, 0x27, 0x7e, 0x90, 0xbd, 0x47, 0xf1, 0x8d, 0xfa, 0xb0, 0x78, 0x1c, 0x05,
+	0x41, 0xf4, 0x9b, 0xbc, 0x93, 0x4d, 0x4f, 0xad, 0xf0, 0x1d, 0x68, 0x6b, 0xc4, 0xfc, 0xa2, 0x07,
+	0x7e, 0xa8, 0x21, 0xc5, 0x37, 0xfe, 0x48, 0x3e, 0x93, 0xa7, 0x51, 0x78, 0xec, 0x4f, 0x32, 0xd7,
+	0x1d, 0xa8, 0x9d, 0xd2, 0xf7, 0xca, 0x4c, 0x7c, 0xe2, 0x6d, 0x58, 0x35, 0xac, 0x14, 0x5c, 0x17,
+	0xea, 0xe7, 0x24, 0x98, 0x66, 0x78, 0xe9, 0x02, 0xef, 0x42, 0x67, 0x78, 0x21, 0x60, 0xbe, 0xb7,
+	0x6a, 0xee, 0xbd, 0x0b, 0xab, 0xc3, 0x19, 0x37, 0x7d, 0x58, 0x4c, 0x28, 0x9b, 0x06, 0x5c, 0xee,
+	0x6f, 0x7a, 0x6a, 0x85, 0x5f, 0x41, 0xe7, 0x80, 0x8c, 0x4e, 0xc9, 0x84, 0x0e, 0xfd, 0x49, 0x48,
+	0xf8, 0x34, 0xa1, 0xa2, 0x49, 0x8c, 0x4e, 0xe8, 0xe8, 0x94, 0x4d, 0xcf, 0x94, 0x37, 0xbd, 0x96,
+	0x8f, 0x2c, 0x33, 0x54, 0x6e, 0x73, 0x01, 0x7e, 0x0b, 0xbd, 0x97, 0x21, 0xe3, 0x24, 0x08, 0x4a,
+	0x55, 0x7f, 0x68, 0x6e, 0x4b, 0x2b, 0x79, 0x43, 0x55, 0xb2, 0xec, 0xfe, 0xc5, 0x35, 0x03, 0x11,
+	0x75, 0x61, 0x61, 0x4c, 0x38, 0x91, 0xae, 0x96, 0x5f, 0x5c, 0xf3, 0xe4, 0x6a, 0xaf, 0x05, 0x8d,
+	0x38, 0xdd, 0x86, 0x63, 0xe8, 0x97, 0x5d, 0x5e, 0xe5, 0xee, 0xa0, 0x01, 0xb4, 0xe3, 0x84, 0x9e,
+	0xfb, 0xd1, 0x94, 0xbd, 0x29, 0xf4, 0xac, 0xb2, 0x18, 0x7f, 0x0a, 0x37, 0x0e, 0x43, 0xdf, 0x7a,
+	0x4c, 0xdb, 0xe5, 0xbe, 0x0f, 0xce, 0xac, 0xf9, 0x05, 0x55, 0x39, 0x84, 0xde, 0x61, 0x3c, 0x49,
+	0xc8, 0x98, 0x5e, 0xec, 0x00, 0x61, 0x58, 0x1e, 0x27, 0xc4, 0x0f, 0x45, 0x9b, 0x8b, 0xa6, 0x5c,
+	0x8d, 0x94, 0x82, 0xec, 0xdb, 0x85, 0x66, 0xb5, 0x53, 0x13, 0xef, 0xac, 0x0c, 0x7b, 0xa5, 0x77,
+	0xf6, 0x47, 0x15, 0x9a, 0xdf, 0x47, 0x63, 0x29, 0x95, 0xad, 0x7c, 0xac, 0x02, 0xaa, 0xfa, 0x63,
+	0x1d, 0x62, 0xd5, 0x08, 0xf1, 0xff, 0xb7, 0xfb, 0x0d, 0x68, 0x9d, 0x50, 0x92, 0xf0, 0x23, 0x4a,
+	0xb8, 0x6c, 0xfa, 0x35, 0x2f, 0x17, 0x14, 0xba, 0x6f, 0xe3, 0xe2, 0xee, 0x2b, 0x1e, 0x0e, 0x09,
+	0xfc, 0x73, 0x2a, 0x67, 0x60, 0xd3, 0x4b, 0x17, 0xe8, 0x16, 0x40, 0x40, 0x18, 0x3f, 0x8c, 0xc7,
+	0x84, 0x53, 0xa7, 0x25, 0x9d, 0x18, 0x12, 0xa1, 0x9f, 0xd0, 0x90, 0x26, 0x84, 0x8b, 0xdb, 0x01,
+	0xa9, 0x3e, 0x97, 0xe0, 0x2f, 0xe0, 0xfa, 0xf3, 0x88, 0x31, 0x3f, 0xce, 0xaa, 0x75, 0x07, 0xea,
+	0x61, 0x34, 0xd6, 0x13, 0xa1, 0xad, 0x62, 0xca, 0x52, 0xe7, 0xa5, 0x5a, 0xfc, 0x10, 0x56, 0xb2,
+	0x7d, 0xaa, 0x1c, 0x97, 0xdc, 0xb8, 0x96, 0x36, 0x94, 0x60, 0xca, 0x38, 0x4d, 0xb2, 0x09, 0xf5,
+	0x25, 0x20, 0x53, 0xf8, 0xdf, 0x10, 0xb7, 0x61, 0xed, 0x27, 0xc2, 0x47, 0x27, 0x97, 0xb8, 0xd7,
+	0x9f, 0x43, 0xb7, 0x68, 0xaa, 0x3c, 0xc9, 0x3a, 0x8e, 0x13, 0xca, 0x98, 0xf2, 0xd6, 0xf2, 0x72,
+	0x01, 0xfe, 0xb3, 0x0a, 0xed, 0x27, 0xb1, 0x64, 0x1c, 0x22, 0x67, 0x73, 0x49, 0x93, 0x0b, 0xcd,
+	0x93, 0x88, 0x71, 0xe3, 0x26, 0xe9, 0xb5, 0xa8, 0x43, 0x4c, 0xf8, 0xc9, 0x41, 0x42, 0x8f, 0xfd,
+	0x77, 0xea, 0x56, 0x19, 0x12, 0x81, 0x97, 0x44, 0x11, 0x57, 0x14, 0x4a, 0x7e, 0x8b, 0xe6, 0xc9,
+	0x62, 0xa2, 0xa8, 0x93, 0xf8, 0x44, 0xfb, 0x00, 0x34, 0x49, 0xa2, 0xe4, 0x80, 0x4c, 0x28, 0x73,
+	0x16, 0x65, 0x5a, 0x3e, 0x56, 0x69, 0x29, 0x45, 0xb8, 0xf3, 0x4c, 0x1b, 0x3e, 0x0b, 0x79, 0xf2,
+	0xde, 0x33, 0x76, 0x8a, 0x37, 0x3c, 0x92, 0xbd, 0x56, 0x71, 0x2d, 0xb5, 0x72, 0xbf, 0x86, 0x76,
+	0x69, 0xdb, 0x65, 0x3b, 0xf8, 0x6e, 0xf5, 0x51, 0x05, 0xaf, 0xc3, 0x0d, 0x41, 0x34, 0x8c, 0x48,
+	0x34, 0x07, 0x79, 0x03, 0xce, 0xac, 0x4a, 0x65, 0x7f, 0x17, 0x96, 0x89, 0x21, 0x57, 0xe5, 0xee,
+	0xdb, 0xcf, 0xe5, 0x15, 0x6c, 0xf1, 0x6b, 0xe8, 0x0d, 0xa9, 0x09, 0x9b, 0x95, 0xff, 0x11, 0x2c,
+	0x19, 0x86, 0xaa, 0x43, 0xcc, 0xc3, 0x34, 0x4d, 0xf1, 0x3d, 0xe8, 0x97, 0x21, 0x2f, 0x68, 0x7d,
+	0x3b, 0xe0, 0x78, 0xf4, 0x2c, 0x3a, 0xa7, 0x96, 0x38, 0x6c, 0xd7, 0xf0, 0x01, 0xac, 0x5b, 0xec,
+	0x2f, 0x70, 0xf2, 0x02, 0x60, 0x8f, 0x8c, 0x4e, 0xa7, 0xf1, 0x87, 0x48, 0x3b, 0xf3, 0x7f, 0x4f,
+	0xeb, 0x52, 0xf3, 0xe4, 0xb7, 0x90, 0xc9, 0xce, 0x50, 0x4b, 0x65, 0xe2, 0x1b, 0xb7, 0xe1, 0x7a,
+	0x8a, 0x94, 0x3f, 0xbf, 0x95, 0x4c, 0xa0, 0x82, 0xd8, 0x86, 0xc5, 0x23, 0x29, 0x51, 0x89, 0x5b,
+	0x55, 0x89, 0xcb, 0x23, 0xf0, 0x94, 0x01, 0xee, 0x02, 0x12, 0x95, 0x4d, 0x35, 0xba, 0xde, 0x7b,
+	0xb0, 0x56, 0x90, 0x2a, 0xdc, 0xbb, 0xd0, 0x48, 0xb7, 0x65, 0x55, 0xb6, 0x00, 0x67, 0x16, 0x78,
+	0x17, 0x56, 0x3c, 0xca, 0x78, 0x94, 0x7c, 0x70, 0x94, 0x74, 0xa1, 0x2e, 0x27, 0xbd, 0x3c, 0x76,
+	0xd3, 0x4b, 0x17, 0xf8, 0x17, 0x68, 0xeb, 0xbd, 0xca, 0xb7, 0xc1, 0xec, 0x2b, 0x45, 0x66, 0x9f,
+	0x25, 0xa9, 0x9a, 0x27, 0x49, 0x58, 0x73, 0x92, 0x4c, 0x28, 0x67, 0x4e, 0x4d, 0x36, 0x84, 0x6c,
+	0x79, 0xff, 0xaf, 0x25, 0x58, 0x7e, 0x22, 0x82, 0x56, 0x5d, 0x04, 0x3d, 0x86, 0x96, 0xfe, 0xdb,
+	0x02, 0x65, 0x14, 0xa1, 0xfc, 0x27, 0x88, 0xeb, 0xcc, 0x2a, 0xd2, 0xc0, 0xf0, 0x35, 0xf4, 0x12,
+	0x96, 0x4d, 0x86, 0x8e, 0x5c, 0x65, 0x6b, 0x61, 0xf3, 0xee, 0x4d, 0xab, 0xce, 0x84, 0x32, 0x99,
+	0xb7, 0x86, 0xb2, 0x30, 0x77, 0xf7, 0xa6, 0x55, 0xa7, 0xa1, 0xf6, 0x61, 0xc9, 0x20, 0xd5, 0x68,
+	0x5d, 0x5b, 0x97, 0x29, 0xb9, 0xeb, 0xda, 0x54, 0x1a, 0xe7, 0x87, 0xb4, 0x8e, 0x46, 0x50, 0x1b,
+	0xca, 0xde, 0x4a, 0xb7, 0xdd, 0xcd, 0x39, 0x5a, 0x0d, 0xf8, 0x15, 0x34, 0x14, 0xc3, 0x45, 0xbd,
+	0x3c, 0xab, 0x06, 0x87, 0x76, 0xfb, 0x65, 0x71, 0xb6, 0xf7, 0x5e, 0x45, 0x95, 0x2b, 0xe5, 0x9a,
+	0x66, 0xb9, 0x0a, 0xcc, 0xd5, 0x75, 0x66, 0x15, 0xda, 0xff, 0x63, 0x68, 0x0d, 0x67, 0x10, 0x86,
+	0xf3, 0x10, 0x86, 0x16, 0x84, 0xd7, 0xb0, 0x52, 0x64, 0x80, 0x3a, 0x25, 0x56, 0x2e, 0xea, 0x6e,
+	0xce, 0xd1, 0x66, 0x80, 0x83, 0x0a, 0x3a, 0x84, 0x4e, 0x99, 0xb3, 0xa1, 0x5b, 0x6a, 0xdb, 0x1c,
+	0xee, 0xe7, 0xde, 0x9e, 0xab, 0x37, 0x8b, 0x57, 0xe4, 0x5f, 0x3a, 0x52, 0x2b, 0xdb, 0x73, 0x37,
+	0xe7, 0x68, 0x35, 0xe0, 0x43, 0x58, 0x4c, 0x99, 0x03, 0xea, 0x66, 0x29, 0x36, 0x09, 0x88, 0xdb,
+	0x2b, 0x49, 0xf5, 0xc6, 0xa7, 0x00, 0x39, 0x49, 0x40, 0x66, 0x7d, 0x0a, 0x64, 0xc2, 0x5d, 0xb7,
+	0x68, 0x34, 0xc8, 0x77, 0xb0, 0x6c, 0x32, 0x00, 0xfd, 0x3c, 0x2c, 0x0c, 0xc2, 0xbd, 0x69, 0xd5,
+	0x19, 0x37, 0xe9, 0x10, 0x3a, 0xe5, 0xa1, 0xa6, 0x53, 0x3e, 0x67, 0x10, 0xba, 0xb7, 0xe7, 0xea,
+	0xcd, 0x94, 0x17, 0x07, 0x90, 0x4e, 0xb9, 0x75, 0xd4, 0xb9, 0x9b, 0x73, 0xb4, 0x1a, 0xf0, 0x67,
+	0x58, 0x9d, 0x99, 0x37, 0xe8, 0xb6, 0x7e, 0x65, 0xf6, 0xc9, 0xe5, 0x6e, 0xcd, 0x37, 0x30, 0x8b,
+	0x99, 0x76, 0x6e, 0x5d, 0xcc, 0xc2, 0x64, 0x71, 0x7b, 0x25, 0xa9, 0xd9, 0x5b, 0x8c, 0xf9, 0xa0,
+	0x7b, 0xcb, 0xec, 0x24, 0x71, 0x5d, 0x9b, 0x4a, 0xe3, 0xec, 0x42, 0x43, 0xf5, 0x79, 0xdd, 0x0a,
+	0x8a, 0x33, 0xc3, 0xed, 0x97, 0xc5, 0xd9, 0xde, 0xbd, 0xd6, 0xaf, 0x0d, 0xf5, 0x33, 0xd9, 0xd1,
+	0xa2, 0xfc, 0xcd, 0xea, 0xc1, 0xbf, 0x03, 0x00, 0xd9, 0x34, 0x28, 0xe9, 0x3e, 0x13, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AdminServiceClient interface {
+	// Return the Globule information and it services states.
+	GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusResponse, error)
+	// Return the list of services known by the Globule.
+	ListServices(ctx context.Context, in *ListServicesRequest, opts ...grpc.CallOption) (*ListServicesResponse, error)
+	// Start a stopped service.
+	StartService(ctx context.Context, in *StartServiceRequest, opts ...grpc.CallOption) (*StartServiceResponse, error)
+	// Stop a running service.
+	StopService(ctx context.Context, in *StopServiceRequest, opts ...grpc.CallOption) (*StopServiceResponse, error)
+	// Stop and start a service.
+	RestartService(ctx context.Context, in *RestartServiceRequest, opts ...grpc.CallOption) (*RestartServiceResponse, error)
+	// Return the output of a service process.
+	GetLogs(ctx context.Context, in *GetLogsRequest, opts ...grpc.CallOption) (AdminService_GetLogsClient, error)
+	// Return a configuration value of the Globule.
+	GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*GetConfigResponse, error)
+	// Set a configuration value of the Globule and save it.
+	SetConfig(ctx context.Context, in *SetConfigRequest, opts ...grpc.CallOption) (*SetConfigResponse, error)
+	// Install or upgrade a service from a package. If the new version is not
+	// healthy the previous one is restored.
+	InstallService(ctx context.Context, opts ...grpc.CallOption) (AdminService_InstallServiceClient, error)
+	// Stop a service and remove it files.
+	UninstallService(ctx context.Context, in *UninstallServiceRequest, opts ...grpc.CallOption) (*UninstallServiceResponse, error)
+	// Replace the process of a service without interruption. The new process
+	// start at a free port and receive the connections once it is healthy,
+	// the old one is stopped when it connections are closed.
+	UpgradeService(ctx context.Context, in *UpgradeServiceRequest, opts ...grpc.CallOption) (*UpgradeServiceResponse, error)
+	// Exchange the nodes registries between two Globules of a cluster.
+	Gossip(ctx context.Context, in *GossipRequest, opts ...grpc.CallOption) (*GossipResponse, error)
+	// Return the nodes of the cluster known by the Globule.
+	GetCluster(ctx context.Context, in *GetClusterRequest, opts ...grpc.CallOption) (*GetClusterResponse, error)
+	// Send the addresses of a service, then the new addresses each time they
+	// change. Use by the globular resolver.
+	WatchService(ctx context.Context, in *WatchServiceRequest, opts ...grpc.CallOption) (AdminService_WatchServiceClient, error)
+	// Return the web applications of the Globule.
+	ListApplications(ctx context.Context, in *ListApplicationsRequest, opts ...grpc.CallOption) (*ListApplicationsResponse, error)
+	// Add or replace a web application and save the configuration.
+	SetApplication(ctx context.Context, in *SetApplicationRequest, opts ...grpc.CallOption) (*SetApplicationResponse, error)
+	// Remove a web application, it files are kept.
+	RemoveApplication(ctx context.Context, in *RemoveApplicationRequest, opts ...grpc.CallOption) (*RemoveApplicationResponse, error)
+	// Stop the services, copy their configurations and stores then start
+	// them again and copy the files roots in an archive of the backups
+	// directory. The oldest archives are removed.
+	Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*BackupResponse, error)
+	// Return the archives of the backups directory.
+	ListBackups(ctx context.Context, in *ListBackupsRequest, opts ...grpc.CallOption) (*ListBackupsResponse, error)
+	// Check an archive and replace the configurations, stores and files by
+	// it content. The services are stopped while they are replaced.
+	Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreResponse, error)
+}
+
+type adminServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAdminServiceClient(cc *grpc.ClientConn) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusResponse, error) {
+	out := new(GetStatusResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/GetStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListServices(ctx context.Context, in *ListServicesRequest, opts ...grpc.CallOption) (*ListServicesResponse, error) {
+	out := new(ListServicesResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/ListServices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) StartService(ctx context.Context, in *StartServiceRequest, opts ...grpc.CallOption) (*StartServiceResponse, error) {
+	out := new(StartServiceResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/StartService", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) StopService(ctx context.Context, in *StopServiceRequest, opts ...grpc.CallOption) (*StopServiceResponse, error) {
+	out := new(StopServiceResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/StopService", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) RestartService(ctx context.Context, in *RestartServiceRequest, opts ...grpc.CallOption) (*RestartServiceResponse, error) {
+	out := new(RestartServiceResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/RestartService", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetLogs(ctx context.Context, in *GetLogsRequest, opts ...grpc.CallOption) (AdminService_GetLogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_AdminService_serviceDesc.Streams[0], "/admin.AdminService/GetLogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminServiceGetLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AdminService_GetLogsClient interface {
+	Recv() (*GetLogsResponse, error)
+	grpc.ClientStream
+}
+
+type adminServiceGetLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminServiceGetLogsClient) Recv() (*GetLogsResponse, error) {
+	m := new(GetLogsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminServiceClient) GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*GetConfigResponse, error) {
+	out := new(GetConfigResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/GetConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetConfig(ctx context.Context, in *SetConfigRequest, opts ...grpc.CallOption) (*SetConfigResponse, error) {
+	out := new(SetConfigResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/SetConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) InstallService(ctx context.Context, opts ...grpc.CallOption) (AdminService_InstallServiceClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_AdminService_serviceDesc.Streams[1], "/admin.AdminService/InstallService", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminServiceInstallServiceClient{stream}
+	return x, nil
+}
+
+type AdminService_InstallServiceClient interface {
+	Send(*InstallServiceRequest) error
+	CloseAndRecv() (*InstallServiceResponse, error)
+	grpc.ClientStream
+}
+
+type adminServiceInstallServiceClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminServiceInstallServiceClient) Send(m *InstallServiceRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *adminServiceInstallServiceClient) CloseAndRecv() (*InstallServiceResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(InstallServiceResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminServiceClient) UninstallService(ctx context.Context, in *UninstallServiceRequest, opts ...grpc.CallOption) (*UninstallServiceResponse, error) {
+	out := new(UninstallServiceResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/UninstallService", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) UpgradeService(ctx context.Context, in *UpgradeServiceRequest, opts ...grpc.CallOption) (*UpgradeServiceResponse, error) {
+	out := new(UpgradeServiceResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/UpgradeService", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) Gossip(ctx context.Context, in *GossipRequest, opts ...grpc.CallOption) (*GossipResponse, error) {
+	out := new(GossipResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/Gossip", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetCluster(ctx context.Context, in *GetClusterRequest, opts ...grpc.CallOption) (*GetClusterResponse, error) {
+	out := new(GetClusterResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/GetCluster", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) WatchService(ctx context.Context, in *WatchServiceRequest, opts ...grpc.CallOption) (AdminService_WatchServiceClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_AdminService_serviceDesc.Streams[2], "/admin.AdminService/WatchService", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminServiceWatchServiceClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AdminService_WatchServiceClient interface {
+	Recv() (*WatchServiceResponse, error)
+	grpc.ClientStream
+}
+
+type adminServiceWatchServiceClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminServiceWatchServiceClient) Recv() (*WatchServiceResponse, error) {
+	m := new(WatchServiceResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminServiceClient) ListApplications(ctx context.Context, in *ListApplicationsRequest, opts ...grpc.CallOption) (*ListApplicationsResponse, error) {
+	out := new(ListApplicationsResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/ListApplications", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetApplication(ctx context.Context, in *SetApplicationRequest, opts ...grpc.CallOption) (*SetApplicationResponse, error) {
+	out := new(SetApplicationResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/SetApplication", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) RemoveApplication(ctx context.Context, in *RemoveApplicationRequest, opts ...grpc.CallOption) (*RemoveApplicationResponse, error) {
+	out := new(RemoveApplicationResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/RemoveApplication", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*BackupResponse, error) {
+	out := new(BackupResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/Backup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListBackups(ctx context.Context, in *ListBackupsRequest, opts ...grpc.CallOption) (*ListBackupsResponse, error) {
+	out := new(ListBackupsResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/ListBackups", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreResponse, error) {
+	out := new(RestoreResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/Restore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+type AdminServiceServer interface {
+	// Return the Globule information and it services states.
+	GetStatus(context.Context, *GetStatusRequest) (*GetStatusResponse, error)
+	// Return the list of services known by the Globule.
+	ListServices(context.Context, *ListServicesRequest) (*ListServicesResponse, error)
+	// Start a stopped service.
+	StartService(context.Context, *StartServiceRequest) (*StartServiceResponse, error)
+	// Stop a running service.
+	StopService(context.Context, *StopServiceRequest) (*StopServiceResponse, error)
+	// Stop and start a service.
+	RestartService(context.Context, *RestartServiceRequest) (*RestartServiceResponse, error)
+	// Return the output of a service process.
+	GetLogs(*GetLogsRequest, AdminService_GetLogsServer) error
+	// Return a configuration value of the Globule.
+	GetConfig(context.Context, *GetConfigRequest) (*GetConfigResponse, error)
+	// Set a configuration value of the Globule and save it.
+	SetConfig(context.Context, *SetConfigRequest) (*SetConfigResponse, error)
+	// Install or upgrade a service from a package. If the new version is not
+	// healthy the previous one is restored.
+	InstallService(AdminService_InstallServiceServer) error
+	// Stop a service and remove it files.
+	UninstallService(context.Context, *UninstallServiceRequest) (*UninstallServiceResponse, error)
+	// Replace the process of a service without interruption. The new process
+	// start at a free port and receive the connections once it is healthy,
+	// the old one is stopped when it connections are closed.
+	UpgradeService(context.Context, *UpgradeServiceRequest) (*UpgradeServiceResponse, error)
+	// Exchange the nodes registries between two Globules of a cluster.
+	Gossip(context.Context, *GossipRequest) (*GossipResponse, error)
+	// Return the nodes of the cluster known by the Globule.
+	GetCluster(context.Context, *GetClusterRequest) (*GetClusterResponse, error)
+	// Send the addresses of a service, then the new addresses each time they
+	// change. Use by the globular resolver.
+	WatchService(*WatchServiceRequest, AdminService_WatchServiceServer) error
+	// Return the web applications of the Globule.
+	ListApplications(context.Context, *ListApplicationsRequest) (*ListApplicationsResponse, error)
+	// Add or replace a web application and save the configuration.
+	SetApplication(context.Context, *SetApplicationRequest) (*SetApplicationResponse, error)
+	// Remove a web application, it files are kept.
+	RemoveApplication(context.Context, *RemoveApplicationRequest) (*RemoveApplicationResponse, error)
+	// Stop the services, copy their configurations and stores then start
+	// them again and copy the files roots in an archive of the backups
+	// directory. The oldest archives are removed.
+	Backup(context.Context, *BackupRequest) (*BackupResponse, error)
+	// Return the archives of the backups directory.
+	ListBackups(context.Context, *ListBackupsRequest) (*ListBackupsResponse, error)
+	// Check an archive and replace the configurations, stores and files by
+	// it content. The services are stopped while they are replaced.
+	Restore(context.Context, *RestoreRequest) (*RestoreResponse, error)
+}
+
+// UnimplementedAdminServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedAdminServiceServer struct {
+}
+
+func (*UnimplementedAdminServiceServer) GetStatus(ctx context.Context, req *GetStatusRequest) (*GetStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
+}
+func (*UnimplementedAdminServiceServer) ListServices(ctx context.Context, req *ListServicesRequest) (*ListServicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListServices not implemented")
+}
+func (*UnimplementedAdminServiceServer) StartService(ctx context.Context, req *StartServiceRequest) (*StartServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartService not implemented")
+}
+func (*UnimplementedAdminServiceServer) StopService(ctx context.Context, req *StopServiceRequest) (*StopServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopService not implemented")
+}
+func (*UnimplementedAdminServiceServer) RestartService(ctx context.Context, req *RestartServiceRequest) (*RestartServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestartService not implemented")
+}
+func (*UnimplementedAdminServiceServer) GetLogs(req *GetLogsRequest, srv AdminService_GetLogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetLogs not implemented")
+}
+func (*UnimplementedAdminServiceServer) GetConfig(ctx context.Context, req *GetConfigRequest) (*GetConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConfig not implemented")
+}
+func (*UnimplementedAdminServiceServer) SetConfig(ctx context.Context, req *SetConfigRequest) (*SetConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetConfig not implemented")
+}
+func (*UnimplementedAdminServiceServer) InstallService(srv AdminService_InstallServiceServer) error {
+	return status.Errorf(codes.Unimplemented, "method InstallService not implemented")
+}
+func (*UnimplementedAdminServiceServer) UninstallService(ctx context.Context, req *UninstallServiceRequest) (*UninstallServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UninstallService not implemented")
+}
+func (*UnimplementedAdminServiceServer) UpgradeService(ctx context.Context, req *UpgradeServiceRequest) (*UpgradeServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpgradeService not implemented")
+}
+func (*UnimplementedAdminServiceServer) Gossip(ctx context.Context, req *GossipRequest) (*GossipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Gossip not implemented")
+}
+func (*UnimplementedAdminServiceServer) GetCluster(ctx context.Context, req *GetClusterRequest) (*GetClusterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCluster not implemented")
+}
+func (*UnimplementedAdminServiceServer) WatchService(req *WatchServiceRequest, srv AdminService_WatchServiceServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchService not implemented")
+}
+func (*UnimplementedAdminServiceServer) ListApplications(ctx context.Context, req *ListApplicationsRequest) (*ListApplicationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListApplications not implemented")
+}
+func (*UnimplementedAdminServiceServer) SetApplication(ctx context.Context, req *SetApplicationRequest) (*SetApplicationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetApplication not implemented")
+}
+func (*UnimplementedAdminServiceServer) RemoveApplication(ctx context.Context, req *RemoveApplicationRequest) (*RemoveApplicationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveApplication not implemented")
+}
+func (*UnimplementedAdminServiceServer) Backup(ctx context.Context, req *BackupRequest) (*BackupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Backup not implemented")
+}
+func (*UnimplementedAdminServiceServer) ListBackups(ctx context.Context, req *ListBackupsRequest) (*ListBackupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBackups not implemented")
+}
+func (*UnimplementedAdminServiceServer) Restore(ctx context.Context, req *RestoreRequest) (*RestoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
+
+func RegisterAdminServiceServer(s *grpc.Server, srv AdminServiceServer) {
+	s.RegisterService(&_AdminService_serviceDesc, srv)
+}
+
+func _AdminService_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/GetStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetStatus(ctx, req.(*GetStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListServicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListServices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/ListServices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListServices(ctx, req.(*ListServicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_StartService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).StartService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/StartService",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).StartService(ctx, req.(*StartServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_StopService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).StopService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/StopService",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).StopService(ctx, req.(*StopServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RestartService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestartServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RestartService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/RestartService",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RestartService(ctx, req.(*RestartServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminServiceServer).GetLogs(m, &adminServiceGetLogsServer{stream})
+}
+
+type AdminService_GetLogsServer interface {
+	Send(*GetLogsResponse) error
+	grpc.ServerStream
+}
+
+type adminServiceGetLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *adminServiceGetLogsServer) Send(m *GetLogsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _AdminService_GetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/GetConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetConfig(ctx, req.(*GetConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/SetConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetConfig(ctx, req.(*SetConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_InstallService_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AdminServiceServer).InstallService(&adminServiceInstallServiceServer{stream})
+}
+
+type AdminService_InstallServiceServer interface {
+	SendAndClose(*InstallServiceResponse) error
+	Recv() (*InstallServiceRequest, error)
+	grpc.ServerStream
+}
+
+type adminServiceInstallServiceServer struct {
+	grpc.ServerStream
+}
+
+func (x *adminServiceInstallServiceServer) SendAndClose(m *InstallServiceResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *adminServiceInstallServiceServer) Recv() (*InstallServiceRequest, error) {
+	m := new(InstallServiceRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _AdminService_UninstallService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UninstallServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).UninstallService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/UninstallService",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).UninstallService(ctx, req.(*UninstallServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_UpgradeService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpgradeServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).UpgradeService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/UpgradeService",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).UpgradeService(ctx, req.(*UpgradeServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_Gossip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GossipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).Gossip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/Gossip",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).Gossip(ctx, req.(*GossipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetCluster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetClusterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetCluster(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/GetCluster",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetCluster(ctx, req.(*GetClusterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_WatchService_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchServiceRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminServiceServer).WatchService(m, &adminServiceWatchServiceServer{stream})
+}
+
+type AdminService_WatchServiceServer interface {
+	Send(*WatchServiceResponse) error
+	grpc.ServerStream
+}
+
+type adminServiceWatchServiceServer struct {
+	grpc.ServerStream
+}
+
+func (x *adminServiceWatchServiceServer) Send(m *WatchServiceResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _AdminService_ListApplications_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListApplicationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListApplications(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/ListApplications",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListApplications(ctx, req.(*ListApplicationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetApplication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetApplicationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetApplication(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/SetApplication",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetApplication(ctx, req.(*SetApplicationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RemoveApplication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveApplicationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RemoveApplication(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/RemoveApplication",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RemoveApplication(ctx, req.(*RemoveApplicationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_Backup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).Backup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/Backup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).Backup(ctx, req.(*BackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListBackups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBackupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListBackups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/ListBackups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListBackups(ctx, req.(*ListBackupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/Restore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).Restore(ctx, req.(*RestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _AdminService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "admin.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetStatus",
+			Handler:    _AdminService_GetStatus_Handler,
+		},
+		{
+			MethodName: "ListServices",
+			Handler:    _AdminService_ListServices_Handler,
+		},
+		{
+			MethodName: "StartService",
+			Handler:    _AdminService_StartService_Handler,
+		},
+		{
+			MethodName: "StopService",
+			Handler:    _AdminService_StopService_Handler,
+		},
+		{
+			MethodName: "RestartService",
+			Handler:    _AdminService_RestartService_Handler,
+		},
+		{
+			MethodName: "GetConfig",
+			Handler:    _AdminService_GetConfig_Handler,
+		},
+		{
+			MethodName: "SetConfig",
+			Handler:    _AdminService_SetConfig_Handler,
+		},
+		{
+			MethodName: "UninstallService",
+			Handler:    _AdminService_UninstallService_Handler,
+		},
+		{
+			MethodName: "UpgradeService",
+			Handler:    _AdminService_UpgradeService_Handler,
+		},
+		{
+			MethodName: "Gossip",
+			Handler:    _AdminService_Gossip_Handler,
+		},
+		{
+			MethodName: "GetCluster",
+			Handler:    _AdminService_GetCluster_Handler,
+		},
+		{
+			MethodName: "ListApplications",
+			Handler:    _AdminService_ListApplications_Handler,
+		},
+		{
+			MethodName: "SetApplication",
+			Handler:    _AdminService_SetApplication_Handler,
+		},
+		{
+			MethodName: "RemoveApplication",
+			Handler:    _AdminService_RemoveApplication_Handler,
+		},
+		{
+			MethodName: "Backup",
+			Handler:    _AdminService_Backup_Handler,
+		},
+		{
+			MethodName: "ListBackups",
+			Handler:    _AdminService_ListBackups_Handler,
+		},
+		{
+			MethodName: "Restore",
+			Handler:    _AdminService_Restore_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetLogs",
+			Handler:       _AdminService_GetLogs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "InstallService",
+			Handler:       _AdminService_InstallService_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "WatchService",
+			Handler:       _AdminService_WatchService_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "admin/adminpb/admin.proto",
+}
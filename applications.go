@@ -0,0 +1,243 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/davecourtois/Globular/admin/adminpb"
+)
+
+/**
+ * A web application served by the Globule http server. An application is
+ * serve for it hostname and/or under it path prefix from it own root, the
+ * other requests are serve from WebRoot. ex:
+ *
+ *	"Applications": {
+ *		"website": {"Hostname": "www.example.com", "Root": "website", "Spa": true},
+ *		"brisoutil": {"PathPrefix": "/brisoutil", "Root": "brisoutil", "ErrorPages": {"404": "404.html"}}
+ *	}
+ */
+type Application struct {
+	Hostname   string                 // The application is serve for that host only if set.
+	PathPrefix string                 // The application is serve under that path if set, ex: /blog
+	Root       string                 // The directory of the files, relative to WebRoot or absolute.
+	Spa        bool                   // The unknown paths without extension give index.html.
+	ErrorPages map[string]string      // The page by http status, ex: 404 -> errors/404.html
+	Config     map[string]interface{} // The values added to the Globule configuration given to the application.
+}
+
+/**
+ * Return the application of a request, nil if the request is for WebRoot.
+ */
+func (self *Globule) getApplication(r *http.Request) *Application {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	var application *Application
+	score := -1
+	for _, app := range self.Applications {
+		score_ := matchRoute(r, app.Hostname, strings.TrimSuffix(app.PathPrefix, "/"))
+		if score_ > score {
+			application = app
+			score = score_
+		}
+	}
+
+	return application
+}
+
+/**
+ * Return the directory of the application files.
+ */
+func (self *Globule) getApplicationRoot(app *Application) string {
+	if filepath.IsAbs(app.Root) {
+		return app.Root
+	}
+
+	return filepath.Join(self.webRoot, app.Root)
+}
+
+/**
+ * Answer the request of an application file that does not exist. The
+ * configuration is generated if the application does not have one, the
+ * single page applications receive index.html and the others the error page.
+ */
+func (self *Globule) serveApplicationNotFound(app *Application, dir string, upath string, w http.ResponseWriter, r *http.Request) {
+	if upath == "/config.json" {
+		self.serveConfig(app, w, r)
+		return
+	}
+
+	if app.Spa && len(path.Ext(upath)) == 0 {
+		index := filepath.Join(dir, "index.html")
+		info, err := os.Stat(index)
+		if err == nil && !info.IsDir() {
+			self.serveAsset(w, r, dir, index, r.URL.Path, info, nil)
+			return
+		}
+	}
+
+	self.serveApplicationError(app, dir, http.StatusNotFound, "File "+upath+" not found!", w)
+}
+
+/**
+ * Write an error with the application error page of the status if it has
+ * one.
+ */
+func (self *Globule) serveApplicationError(app *Application, dir string, code int, msg string, w http.ResponseWriter) {
+	page := app.ErrorPages[strconv.Itoa(code)]
+	if len(page) > 0 {
+		data, err := ioutil.ReadFile(filepath.Join(dir, filepath.FromSlash(path.Clean("/"+page))))
+		if err == nil {
+			w.Header().Set("Content-Type", "text/html")
+			w.WriteHeader(code)
+			w.Write(data)
+			return
+		}
+	}
+
+	http.Error(w, msg, code)
+}
+
+/**
+ * Answer the configuration script of WebRoot if app is nil, or of an
+ * application that does not have one.
+ */
+func (self *Globule) serveConfig(app *Application, w http.ResponseWriter, r *http.Request) {
+	code, err := self.getApplicationConfig(app)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/javascript")
+	http.ServeContent(w, r, "config.json", time.Now(), strings.NewReader(code))
+}
+
+/**
+ * Return the script that set the configuration of the browsers, the Globule
+ * configuration with the application values if app is not nil.
+ */
+func (self *Globule) getApplicationConfig(app *Application) (string, error) {
+	config, err := self.getConfig()
+	if err != nil {
+		return "", err
+	}
+
+	self.mutex.Lock()
+	if app != nil {
+		for key, value := range app.Config {
+			config[key] = value
+		}
+	}
+	self.mutex.Unlock()
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+
+	return "window.globularConfig = " + string(data), nil
+}
+
+/**
+ * Add or replace an application and save the configuration.
+ */
+func (self *Globule) setApplication(name string, app *Application) error {
+	if len(name) == 0 {
+		return errors.New("no application name was given")
+	}
+
+	if len(app.Hostname) == 0 && len(app.PathPrefix) == 0 {
+		return errors.New("the application " + name + " need an hostname or a path prefix")
+	}
+
+	if len(app.PathPrefix) > 0 && !strings.HasPrefix(app.PathPrefix, "/") {
+		return errors.New("the path prefix " + app.PathPrefix + " must start with /")
+	}
+
+	info, err := os.Stat(self.getApplicationRoot(app))
+	if err != nil {
+		return err
+	}
+
+	if !info.IsDir() {
+		return errors.New(self.getApplicationRoot(app) + " is not a directory")
+	}
+
+	self.mutex.Lock()
+	self.Applications[name] = app
+	self.mutex.Unlock()
+
+	self.saveConfig()
+
+	return nil
+}
+
+/**
+ * Remove an application and save the configuration, it files are kept.
+ */
+func (self *Globule) removeApplication(name string) error {
+	self.mutex.Lock()
+	_, ok := self.Applications[name]
+	delete(self.Applications, name)
+	self.mutex.Unlock()
+
+	if !ok {
+		return errors.New("no application found with name " + name)
+	}
+
+	self.saveConfig()
+
+	return nil
+}
+
+/**
+ * Convert an application from and to it admin service message.
+ */
+func getApplicationInfo(name string, app *Application) (*adminpb.ApplicationInfo, error) {
+	config := ""
+	if len(app.Config) > 0 {
+		data, err := json.Marshal(app.Config)
+		if err != nil {
+			return nil, err
+		}
+		config = string(data)
+	}
+
+	return &adminpb.ApplicationInfo{
+		Name:       name,
+		Hostname:   app.Hostname,
+		PathPrefix: app.PathPrefix,
+		Root:       app.Root,
+		Spa:        app.Spa,
+		ErrorPages: app.ErrorPages,
+		Config:     config,
+	}, nil
+}
+
+func newApplication(info *adminpb.ApplicationInfo) (*Application, error) {
+	app := &Application{
+		Hostname:   info.Hostname,
+		PathPrefix: info.PathPrefix,
+		Root:       info.Root,
+		Spa:        info.Spa,
+		ErrorPages: info.ErrorPages,
+	}
+
+	if len(info.Config) > 0 {
+		err := json.Unmarshal([]byte(info.Config), &app.Config)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return app, nil
+}
@@ -0,0 +1,471 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andybalholm/brotli"
+	"github.com/davecourtois/Utility"
+)
+
+const (
+	// The files bigger than that are served from the disk.
+	maxCachedAssetSize = 8 << 20
+
+	// The cache is emptied when it content take more than that.
+	maxAssetCacheSize = 256 << 20
+
+	// The files a rewritten asset depend on are checked at most once by
+	// that delay.
+	assetDepsCheckDelay = time.Second
+
+	// The smaller content are not compressed.
+	minCompressSize = 1024
+
+	// The Cache-Control of the files that match no rule, the browser keep
+	// them but check the ETag at each use.
+	defaultCacheControl = "no-cache"
+)
+
+/**
+ * A Cache-Control value for the paths that match a pattern. The pattern is
+ * match with path.Match against the request path, or against the file name
+ * if it contain no slash. ex:
+ *
+ *	"CacheRules": [
+ *		{"Pattern": "/js/lib/*", "CacheControl": "public, max-age=31536000, immutable"},
+ *		{"Pattern": "*.png", "CacheControl": "public, max-age=86400"}
+ *	]
+ *
+ * The first rule that match is use.
+ */
+type CacheRule struct {
+	Pattern      string
+	CacheControl string
+}
+
+/**
+ * A file as it is served, rewritten if it need to, with it ETag and it
+ * compressed versions. The asset is replaced when the file change.
+ */
+type asset struct {
+	modTime time.Time
+	size    int64
+
+	// The served content, nil if the file is too big to be kept in memory.
+	content   []byte
+	rewritten bool
+
+	// The modification time of the other files use to rewrite the content,
+	// zero for the files that does not exist.
+	deps    map[string]time.Time
+	checked time.Time
+
+	etag      string
+	encodings map[string][]byte
+}
+
+/**
+ * The key of an asset, the imports of a file are resolved from the root it
+ * is served from so a file served from two roots has two assets.
+ */
+type assetKey struct {
+	root string
+	name string
+}
+
+/**
+ * The assets by root and file name.
+ */
+type assetCache struct {
+	mutex  sync.Mutex
+	assets map[assetKey]*asset
+	size   int
+}
+
+var assets = &assetCache{assets: make(map[assetKey]*asset, 0)}
+
+/**
+ * Return the asset of a file served from root, rewrite give the content to
+ * serve from the file content and the files it depend on. It is read again
+ * only if the file or one of those files change.
+ */
+func (self *assetCache) get(root string, name string, info os.FileInfo, rewrite func([]byte) ([]byte, bool, []string)) (*asset, error) {
+	key := assetKey{root: root, name: name}
+
+	self.mutex.Lock()
+	a := self.assets[key]
+	self.mutex.Unlock()
+
+	if a != nil && a.modTime.Equal(info.ModTime()) && a.size == info.Size() && self.checkDeps(a) {
+		return a, nil
+	}
+
+	a = &asset{modTime: info.ModTime(), size: info.Size(), encodings: make(map[string][]byte, 0), deps: make(map[string]time.Time, 0), checked: time.Now()}
+	if info.Size() > maxCachedAssetSize {
+		// The ETag of a big file is the hash of it content, it is not kept.
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		hash := sha256.New()
+		_, err = io.Copy(hash, f)
+		if err != nil {
+			return nil, err
+		}
+		a.etag = `"` + hex.EncodeToString(hash.Sum(nil))[:32] + `"`
+	} else {
+		data, err := ioutil.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+
+		a.content = data
+		if rewrite != nil {
+			var deps []string
+			a.content, a.rewritten, deps = rewrite(data)
+			for _, dep := range deps {
+				a.deps[dep] = getModTime(dep)
+			}
+		}
+
+		hash := sha256.Sum256(a.content)
+		a.etag = `"` + hex.EncodeToString(hash[:])[:32] + `"`
+	}
+
+	self.mutex.Lock()
+	if self.size > maxAssetCacheSize {
+		self.assets = make(map[assetKey]*asset, 0)
+		self.size = 0
+	}
+	self.assets[key] = a
+	self.size += len(a.content)
+	self.mutex.Unlock()
+
+	return a, nil
+}
+
+/**
+ * Return false if a file the asset depend on has change.
+ */
+func (self *assetCache) checkDeps(a *asset) bool {
+	self.mutex.Lock()
+	if len(a.deps) == 0 || time.Since(a.checked) < assetDepsCheckDelay {
+		self.mutex.Unlock()
+		return true
+	}
+	a.checked = time.Now()
+	self.mutex.Unlock()
+
+	for dep, modTime := range a.deps {
+		if !getModTime(dep).Equal(modTime) {
+			return false
+		}
+	}
+
+	return true
+}
+
+/**
+ * Return the modification time of a file, zero if it does not exist.
+ */
+func getModTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return info.ModTime()
+}
+
+/**
+ * Return the content of an asset compressed with an encoding, the
+ * compression is done once.
+ */
+func (self *assetCache) encode(a *asset, encoding string) ([]byte, error) {
+	self.mutex.Lock()
+	data := a.encodings[encoding]
+	self.mutex.Unlock()
+
+	if data != nil {
+		return data, nil
+	}
+
+	data, err := compress(a.content, encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	self.mutex.Lock()
+	a.encodings[encoding] = data
+	self.size += len(data)
+	self.mutex.Unlock()
+
+	return data, nil
+}
+
+/**
+ * Compress data with gzip or br.
+ */
+func compress(data []byte, encoding string) ([]byte, error) {
+	var buffer bytes.Buffer
+	var w io.WriteCloser
+	if encoding == "br" {
+		w = brotli.NewWriterLevel(&buffer, brotli.DefaultCompression)
+	} else {
+		w, _ = gzip.NewWriterLevel(&buffer, gzip.DefaultCompression)
+	}
+
+	_, err := w.Write(data)
+	if err == nil {
+		err = w.Close()
+	}
+
+	return buffer.Bytes(), err
+}
+
+/**
+ * Return the encoding to use for a response, the one accepted by the client
+ * with the best quality among encodings, an empty string if none.
+ */
+func negotiateEncoding(r *http.Request, encodings []string) string {
+	qualities := make(map[string]float64, 0)
+	for _, value := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		values := strings.Split(strings.TrimSpace(value), ";")
+		quality := 1.0
+		for _, param := range values[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				q, err := strconv.ParseFloat(param[2:], 64)
+				if err == nil {
+					quality = q
+				}
+			}
+		}
+		qualities[strings.ToLower(strings.TrimSpace(values[0]))] = quality
+	}
+
+	encoding := ""
+	best := 0.0
+	for _, encoding_ := range encodings {
+		quality, ok := qualities[encoding_]
+		if !ok {
+			quality = qualities["*"]
+		}
+
+		if quality > best {
+			encoding = encoding_
+			best = quality
+		}
+	}
+
+	return encoding
+}
+
+/**
+ * Return true if a content type gain to be compressed.
+ */
+func isCompressible(contentType string) bool {
+	contentType = strings.TrimSpace(strings.Split(contentType, ";")[0])
+	if strings.HasPrefix(contentType, "text/") {
+		return true
+	}
+
+	switch contentType {
+	case "application/javascript", "application/json", "application/xml", "image/svg+xml", "application/wasm", "application/text":
+		return true
+	}
+
+	return false
+}
+
+/**
+ * Return the content type of a file from it extension.
+ */
+func getContentType(name string) string {
+	if strings.HasSuffix(name, "config.json") {
+		return "application/javascript" // the config is a script.
+	}
+
+	switch path.Ext(name) {
+	case ".js", ".mjs":
+		return "application/javascript"
+	case ".css":
+		return "text/css"
+	case ".html", ".htm":
+		return "text/html"
+	}
+
+	return mime.TypeByExtension(path.Ext(name))
+}
+
+/**
+ * Return the Cache-Control of a path from the Globule rules.
+ */
+func (self *Globule) getCacheControl(upath string) string {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	for _, rule := range self.CacheRules {
+		value := upath
+		if !strings.Contains(rule.Pattern, "/") {
+			value = path.Base(upath)
+		}
+
+		match, err := path.Match(rule.Pattern, value)
+		if err == nil && match {
+			return rule.CacheControl
+		}
+	}
+
+	return defaultCacheControl
+}
+
+/**
+ * Serve a file of root with it ETag and Cache-Control, compressed if the
+ * client accept it. The precompressed files (name.br, name.gz) are served
+ * when they exist, the other compressible files are compressed once and kept
+ * in memory.
+ */
+func (self *Globule) serveAsset(w http.ResponseWriter, r *http.Request, root string, name string, upath string, info os.FileInfo, rewrite func([]byte) ([]byte, bool, []string)) {
+	a, err := assets.get(root, name, info, rewrite)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	contentType := getContentType(name)
+	if len(contentType) > 0 {
+		w.Header().Set("Content-Type", contentType)
+	}
+	w.Header().Set("Cache-Control", self.getCacheControl(upath))
+
+	compressible := isCompressible(contentType)
+	if compressible {
+		w.Header().Add("Vary", "Accept-Encoding")
+	}
+
+	// The precompressed files, they are not use for rewritten files.
+	if !a.rewritten {
+		available := make([]string, 0)
+		for _, encoding := range []string{"br", "gzip"} {
+			if Utility.Exists(name + encodingExtension(encoding)) {
+				available = append(available, encoding)
+			}
+		}
+
+		encoding := negotiateEncoding(r, available)
+		if len(encoding) > 0 {
+			f, err := os.Open(name + encodingExtension(encoding))
+			if err == nil {
+				defer f.Close()
+				w.Header().Set("Vary", "Accept-Encoding")
+				w.Header().Set("Content-Encoding", encoding)
+				w.Header().Set("ETag", strings.TrimSuffix(a.etag, `"`)+"-"+encoding+`"`)
+				http.ServeContent(w, r, name, a.modTime, f)
+				return
+			}
+		}
+	}
+
+	// The big files are read from the disk.
+	if a.content == nil {
+		f, err := os.Open(name)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		defer f.Close()
+
+		w.Header().Set("ETag", a.etag)
+		http.ServeContent(w, r, name, a.modTime, f)
+		return
+	}
+
+	content := a.content
+	etag := a.etag
+	if compressible && len(a.content) >= minCompressSize {
+		encoding := negotiateEncoding(r, []string{"br", "gzip"})
+		if len(encoding) > 0 {
+			data, err := assets.encode(a, encoding)
+			if err == nil {
+				content = data
+				etag = strings.TrimSuffix(a.etag, `"`) + "-" + encoding + `"`
+				w.Header().Set("Content-Encoding", encoding)
+			}
+		}
+	}
+
+	w.Header().Set("ETag", etag)
+	http.ServeContent(w, r, name, a.modTime, bytes.NewReader(content))
+}
+
+/**
+ * Return the extension of the precompressed files of an encoding.
+ */
+func encodingExtension(encoding string) string {
+	if encoding == "br" {
+		return ".br"
+	}
+	return ".gz"
+}
+
+/**
+ * Compress the responses of a handler if the client accept it. The response
+ * is kept in memory until the handler return, so use it with handlers that
+ * write small responses like the /api/ JSON.
+ */
+func compressHandler(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w_ := &bufferedResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		handler(w_, r)
+
+		content := w_.buffer.Bytes()
+		if w.Header().Get("Content-Encoding") == "" && isCompressible(w.Header().Get("Content-Type")) {
+			w.Header().Add("Vary", "Accept-Encoding")
+			encoding := negotiateEncoding(r, []string{"br", "gzip"})
+			if len(encoding) > 0 && len(content) >= minCompressSize {
+				data, err := compress(content, encoding)
+				if err == nil {
+					content = data
+					w.Header().Set("Content-Encoding", encoding)
+				}
+			}
+		}
+
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		w.WriteHeader(w_.status)
+		w.Write(content)
+	}
+}
+
+/**
+ * Keep the response of a handler in memory.
+ */
+type bufferedResponseWriter struct {
+	http.ResponseWriter
+	buffer bytes.Buffer
+	status int
+}
+
+func (self *bufferedResponseWriter) Write(data []byte) (int, error) {
+	return self.buffer.Write(data)
+}
+
+func (self *bufferedResponseWriter) WriteHeader(status int) {
+	self.status = status
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+/**
+ * Serve a file of root with the request headers and return the response.
+ */
+func serveTestAsset(t *testing.T, g *Globule, root string, name string, headers map[string]string, rewrite func([]byte) ([]byte, bool, []string)) *httptest.ResponseRecorder {
+	info, err := os.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/"+filepath.Base(name), nil)
+	for key, value := range headers {
+		r.Header.Set(key, value)
+	}
+
+	w := httptest.NewRecorder()
+	g.serveAsset(w, r, root, name, r.URL.Path, info, rewrite)
+
+	return w
+}
+
+// The ETag change with the file, a request with the current one receive
+// 304.
+func TestServeAssetETag(t *testing.T) {
+	root := writeFiles(t, map[string]string{"style.css": strings.Repeat("body { margin: 0; }\n", 100)})
+	defer os.RemoveAll(root)
+
+	g := new(Globule)
+	name := filepath.Join(root, "style.css")
+
+	w := serveTestAsset(t, g, root, name, nil, nil)
+	etag := w.Header().Get("ETag")
+	if w.Code != http.StatusOK || len(etag) == 0 || w.Header().Get("Cache-Control") != defaultCacheControl || w.Header().Get("Content-Type") != "text/css" {
+		t.Fatalf("unexpected response %d %v", w.Code, w.Header())
+	}
+
+	w = serveTestAsset(t, g, root, name, map[string]string{"If-None-Match": etag}, nil)
+	if w.Code != http.StatusNotModified {
+		t.Fatalf("unexpected status %d", w.Code)
+	}
+
+	err := ioutil.WriteFile(name, []byte(strings.Repeat("body { margin: 1px; }\n", 100)), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Second)
+	os.Chtimes(name, later, later)
+
+	w = serveTestAsset(t, g, root, name, map[string]string{"If-None-Match": etag}, nil)
+	if w.Code != http.StatusOK || w.Header().Get("ETag") == etag || !strings.Contains(w.Body.String(), "1px") {
+		t.Fatalf("the file change is not served %d", w.Code)
+	}
+}
+
+// The precompressed files are served to the clients that accept their
+// encoding, the other compressible files are compressed.
+func TestServeAssetEncodings(t *testing.T) {
+	content := strings.Repeat("console.log('hello');\n", 100)
+	root := writeFiles(t, map[string]string{
+		"both.js":         content,
+		"both.js.br":      "br content",
+		"both.js.gz":      "gz content",
+		"gz.js":           content,
+		"gz.js.gz":        "gz content",
+		"none.js":         content,
+		"small.js":        "console.log('hello');",
+		"image.png":       content,
+		"rewritten.js":    content,
+		"rewritten.js.gz": "gz content",
+	})
+	defer os.RemoveAll(root)
+
+	rewrite := func(data []byte) ([]byte, bool, []string) {
+		return append([]byte("// rewritten\n"), data...), true, nil
+	}
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		encoding       string
+		body           string // The body of the precompressed files.
+	}{
+		{"both.js", "gzip, deflate, br", "br", "br content"},
+		{"both.js", "gzip", "gzip", "gz content"},
+		{"both.js", "br;q=0.5, gzip;q=0.8", "gzip", "gz content"},
+		{"both.js", "*", "br", "br content"},
+		{"both.js", "br;q=0, *", "gzip", "gz content"},
+		{"both.js", "", "", ""},
+		{"both.js", "identity", "", ""},
+		{"gz.js", "br, gzip", "gzip", "gz content"},
+		{"gz.js", "br", "br", ""},
+		{"none.js", "gzip", "gzip", ""},
+		{"small.js", "gzip", "", ""},
+		{"image.png", "gzip", "", ""},
+		{"rewritten.js", "gzip", "gzip", ""},
+	}
+
+	g := new(Globule)
+	for _, test := range tests {
+		name := filepath.Join(root, test.name)
+		var rewrite_ func([]byte) ([]byte, bool, []string)
+		if test.name == "rewritten.js" {
+			rewrite_ = rewrite
+		}
+
+		w := serveTestAsset(t, g, root, name, map[string]string{"Accept-Encoding": test.acceptEncoding}, rewrite_)
+		encoding := w.Header().Get("Content-Encoding")
+		if w.Code != http.StatusOK || encoding != test.encoding {
+			t.Errorf("%s with %q: unexpected response %d encoding %q, %q is expected", test.name, test.acceptEncoding, w.Code, encoding, test.encoding)
+			continue
+		}
+
+		if len(encoding) > 0 && !strings.HasSuffix(w.Header().Get("ETag"), "-"+encoding+`"`) {
+			t.Errorf("%s with %q: the ETag %s is not the one of the encoding", test.name, test.acceptEncoding, w.Header().Get("ETag"))
+		}
+
+		if len(test.body) > 0 {
+			if w.Body.String() != test.body {
+				t.Errorf("%s with %q: the precompressed file is not served", test.name, test.acceptEncoding)
+			}
+		} else if encoding == "gzip" {
+			reader, err := gzip.NewReader(bytes.NewReader(w.Body.Bytes()))
+			if err == nil {
+				data, _ := ioutil.ReadAll(reader)
+				if !strings.HasSuffix(string(data), content) || (test.name == "rewritten.js") != strings.HasPrefix(string(data), "// rewritten") {
+					t.Errorf("%s with %q: unexpected content", test.name, test.acceptEncoding)
+				}
+			} else {
+				t.Errorf("%s with %q: %v", test.name, test.acceptEncoding, err)
+			}
+		}
+	}
+}
+
+// The first rule that match the path, or the file name for the patterns
+// without slash, give the Cache-Control.
+func TestGetCacheControl(t *testing.T) {
+	g := new(Globule)
+	g.CacheRules = []CacheRule{
+		{Pattern: "/js/lib/*", CacheControl: "public, max-age=31536000, immutable"},
+		{Pattern: "*.png", CacheControl: "public, max-age=86400"},
+	}
+
+	tests := []struct {
+		upath        string
+		cacheControl string
+	}{
+		{"/js/lib/lit.js", "public, max-age=31536000, immutable"},
+		{"/js/lib/logo.png", "public, max-age=31536000, immutable"},
+		{"/js/lib/directives/class-map.js", defaultCacheControl},
+		{"/img/logo.png", "public, max-age=86400"},
+		{"/logo.png", "public, max-age=86400"},
+		{"/index.html", defaultCacheControl},
+	}
+
+	for _, test := range tests {
+		cacheControl := g.getCacheControl(test.upath)
+		if cacheControl != test.cacheControl {
+			t.Errorf("the Cache-Control of %s is %q, %q is expected", test.upath, cacheControl, test.cacheControl)
+		}
+	}
+}
+
+// A file served from two roots is rewritten for each of them.
+func TestAssetCacheRoots(t *testing.T) {
+	root := writeFiles(t, map[string]string{"app/main.js": "main"})
+	defer os.RemoveAll(root)
+
+	name := filepath.Join(root, "app", "main.js")
+	info, err := os.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, root_ := range []string{root, filepath.Join(root, "app"), root} {
+		a, err := assets.get(root_, name, info, func(data []byte) ([]byte, bool, []string) {
+			return []byte(root_), true, nil
+		})
+		if err != nil || string(a.content) != root_ {
+			t.Fatalf("the asset of %s is the one of an other root %s %v", root_, a.content, err)
+		}
+	}
+}
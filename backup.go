@@ -0,0 +1,986 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/davecourtois/Globular/admin/adminpb"
+	"github.com/davecourtois/Globular/config"
+	"github.com/davecourtois/Globular/event/event_client"
+	"github.com/davecourtois/Utility"
+)
+
+/**
+ * A backup is a tar.gz archive of the state of the Globular installation:
+ *
+ *	config/<name>.json  the globular.json of the Globule and config.json of the services
+ *	leveldb/<n>/...     the LevelDB stores open by the storage service
+ *	files/<n>/...       the directories of BackupRoots and the file service root
+ *	manifest.json       where each entry is restored and the sha256 of the files
+ *
+ * The services with LevelDB stores are stopped while the stores are copied
+ * and started again before the files are copied, the others continue to
+ * serve. A restore check the whole
+ * archive before it replace anything, the replaced files are put back if it
+ * fail.
+ */
+
+const (
+	// The name of the manifest in the archive.
+	backupManifestName = "manifest.json"
+
+	// The extension of the archives.
+	backupExtension = ".tar.gz"
+
+	// The time given to the services processes to exit.
+	backupStopTimeout = 10 * time.Second
+)
+
+/**
+ * A directory or a file of the archive and where it is restored.
+ */
+type backupEntry struct {
+	Kind    string // config, leveldb or files
+	Path    string // The path in the archive.
+	Target  string // The path restored, relative to the Globular directory if it is in it.
+	Service string // The service of a configuration or a store, empty for the Globule.
+}
+
+/**
+ * The content of an archive.
+ */
+type backupManifest struct {
+	Version  string // The Globular version.
+	Name     string // The Globule name.
+	Date     time.Time
+	Services []string // The services stopped while their stores were copied.
+	Entries  []*backupEntry
+	Files    map[string]string // The sha256 of the archive files by path.
+}
+
+/**
+ * Write the files of a backup and keep their sha256.
+ */
+type backupWriter struct {
+	tw    *tar.Writer
+	files map[string]string
+	skip  string // A directory not to copy, the backups one.
+}
+
+/**
+ * Add a file to the archive.
+ */
+func (self *backupWriter) addFile(name string, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	err = self.tw.WriteHeader(&tar.Header{Name: name, Mode: int64(info.Mode().Perm()), Size: info.Size(), ModTime: info.ModTime(), Typeflag: tar.TypeReg})
+	if err != nil {
+		return err
+	}
+
+	// A file that grow while it is copied is cut at it size.
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(self.tw, hash), io.LimitReader(f, info.Size()))
+	if err != nil {
+		return err
+	}
+
+	self.files[name] = hex.EncodeToString(hash.Sum(nil))
+
+	return nil
+}
+
+/**
+ * Add a directory and it files to the archive under a prefix, the links and
+ * the other special files are ignored.
+ */
+func (self *backupWriter) addDir(prefix string, dir string) error {
+	return filepath.Walk(dir, func(path_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() && len(self.skip) > 0 && path_ == self.skip {
+			return filepath.SkipDir
+		}
+
+		rel, err := filepath.Rel(dir, path_)
+		if err != nil {
+			return err
+		}
+		name := path.Join(prefix, filepath.ToSlash(rel))
+
+		if info.IsDir() {
+			return self.tw.WriteHeader(&tar.Header{Name: name + "/", Mode: int64(info.Mode().Perm()), ModTime: info.ModTime(), Typeflag: tar.TypeDir})
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		return self.addFile(name, path_)
+	})
+}
+
+/**
+ * Return the directory of the archives.
+ */
+func (self *Globule) getBackupDir() string {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if filepath.IsAbs(self.BackupDir) {
+		return self.BackupDir
+	}
+
+	return filepath.Join(self.path, self.BackupDir)
+}
+
+/**
+ * Return the target of an entry for a path, relative to the Globular
+ * directory if it is in it so the archive can be restored in an other
+ * installation.
+ */
+func (self *Globule) getBackupTarget(path_ string) string {
+	if isUnder(self.path, path_) {
+		rel, err := filepath.Rel(self.path, path_)
+		if err == nil {
+			return filepath.ToSlash(rel)
+		}
+	}
+
+	return filepath.ToSlash(path_)
+}
+
+/**
+ * Return the path where an entry is restored.
+ */
+func (self *Globule) getBackupTargetPath(target string) (string, error) {
+	path_ := filepath.FromSlash(target)
+	if filepath.IsAbs(path_) {
+		return filepath.Clean(path_), nil
+	}
+
+	path_ = filepath.Join(self.path, path_)
+	if !isUnder(self.path, path_) || path_ == self.path {
+		return "", errors.New("the target " + target + " is not in the Globular directory")
+	}
+
+	return path_, nil
+}
+
+/**
+ * Return the directories to copy with the configurations, the BackupRoots
+ * and the file service root. The directories in an other one are copied
+ * with it.
+ */
+func (self *Globule) getBackupRoots() ([]string, error) {
+	self.mutex.Lock()
+	roots := make([]string, 0)
+	for _, root := range self.BackupRoots {
+		if !filepath.IsAbs(root) {
+			root = filepath.Join(self.path, root)
+		}
+		roots = append(roots, filepath.Clean(root))
+	}
+
+	for _, s := range self.services {
+		if root, ok := s.(map[string]interface{})["Root"].(string); ok && len(root) > 0 {
+			info, err := os.Stat(root)
+			if err == nil && info.IsDir() {
+				roots = append(roots, filepath.Clean(root))
+			}
+		}
+	}
+	self.mutex.Unlock()
+
+	backupDir := self.getBackupDir()
+	for _, root := range roots {
+		if isUnder(root, self.path) || isUnder(root, backupDir) {
+			return nil, errors.New("the backup root " + root + " contain the Globular directory or the backups")
+		}
+	}
+
+	// The shortest first so the nested one are found.
+	sort.Slice(roots, func(i, j int) bool { return len(roots[i]) < len(roots[j]) })
+	roots_ := make([]string, 0)
+	for _, root := range roots {
+		nested := false
+		for _, root_ := range roots_ {
+			if isUnder(root_, root) {
+				nested = true
+				break
+			}
+		}
+
+		if !nested {
+			roots_ = append(roots_, root)
+		}
+	}
+
+	return roots_, nil
+}
+
+/**
+ * Return the directories of the LevelDB stores of a service, the
+ * connections of the storage service keep the options they are open with,
+ * {"path":"/var/data", "name":"sessions"} for /var/data/sessions.
+ */
+func getLevelDBStores(configPath string) []string {
+	stores := make([]string, 0)
+
+	data, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		return stores
+	}
+
+	config := make(map[string]interface{}, 0)
+	json.Unmarshal(data, &config)
+	connections, _ := config["Connections"].(map[string]interface{})
+
+	for _, connection := range connections {
+		connection_, _ := connection.(map[string]interface{})
+		options, _ := connection_["Options"].(string)
+		if len(options) == 0 {
+			continue
+		}
+
+		var values struct {
+			Path string `json:"path"`
+			Name string `json:"name"`
+		}
+
+		err = json.Unmarshal([]byte(options), &values)
+		if err != nil || len(values.Path) == 0 || len(values.Name) == 0 {
+			continue
+		}
+
+		dir, err := filepath.Abs(filepath.Join(values.Path, values.Name))
+		if err != nil {
+			continue
+		}
+
+		info, err := os.Stat(dir)
+		if err == nil && info.IsDir() {
+			stores = append(stores, dir)
+		}
+	}
+
+	sort.Strings(stores)
+
+	return stores
+}
+
+/**
+ * Stop the running services and wait their processes to exit, with storesOnly
+ * only the services that have LevelDB stores. Return the names of the stopped
+ * services.
+ */
+func (self *Globule) quiesceServices(storesOnly bool) []string {
+	self.mutex.Lock()
+	names := make([]string, 0)
+	configs := make(map[string]string, 0)
+	for name, s := range self.services {
+		if len(getInstances(s.(map[string]interface{}))) > 0 {
+			names = append(names, name)
+			configs[name] = filepath.Join(filepath.Dir(s.(map[string]interface{})["Path"].(string)), "config.json")
+		}
+	}
+	self.mutex.Unlock()
+
+	if storesOnly {
+		// The configurations are copied while the services run.
+		names_ := make([]string, 0)
+		for _, name := range names {
+			if len(getLevelDBStores(configs[name])) > 0 {
+				names_ = append(names_, name)
+			}
+		}
+		names = names_
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		self.mutex.Lock()
+		instances := getInstances(self.services[name].(map[string]interface{}))
+		self.mutex.Unlock()
+
+		err := self.stopService(name)
+		if err != nil {
+			log.Println("Fail to stop service ", name, " with error ", err)
+		}
+
+		for _, instance := range instances {
+			select {
+			case <-instance.exited:
+			case <-time.After(backupStopTimeout):
+				log.Println("The process ", instance.process.Process.Pid, " of ", name, " does not exit")
+			}
+		}
+	}
+
+	return names
+}
+
+/**
+ * Start the services stopped by quiesceServices.
+ */
+func (self *Globule) resumeServices(names []string) {
+	for _, name := range names {
+		self.mutex.Lock()
+		s, _ := self.services[name].(map[string]interface{})
+		self.mutex.Unlock()
+
+		if s == nil {
+			continue
+		}
+
+		err := self.startService(s)
+		if err != nil {
+			log.Println("Fail to start service: ", name, " with error ", err)
+		}
+	}
+}
+
+/**
+ * Take the backup lock, only one backup or restore run at time.
+ */
+func (self *Globule) lockBackup() error {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if self.backupRunning {
+		return errors.New("a backup or a restore is already running")
+	}
+
+	self.backupRunning = true
+
+	return nil
+}
+
+func (self *Globule) unlockBackup() {
+	self.mutex.Lock()
+	self.backupRunning = false
+	self.mutex.Unlock()
+}
+
+/**
+ * Create an archive of the installation in the backups directory and remove
+ * the oldest ones. Return the archive information.
+ */
+func (self *Globule) createBackup() (*adminpb.BackupInfo, error) {
+	err := self.lockBackup()
+	if err != nil {
+		return nil, err
+	}
+	defer self.unlockBackup()
+
+	roots, err := self.getBackupRoots()
+	if err != nil {
+		return nil, err
+	}
+
+	backupDir := self.getBackupDir()
+	err = os.MkdirAll(backupDir, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &backupManifest{Version: version, Name: self.Name, Date: time.Now(), Entries: make([]*backupEntry, 0)}
+	name := manifest.Name + "_" + manifest.Date.Format("20060102_150405") + backupExtension
+	archivePath := filepath.Join(backupDir, name)
+
+	// The archive is written aside and renamed once it is complete.
+	f, err := os.Create(archivePath + ".tmp")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(archivePath + ".tmp")
+	defer f.Close()
+
+	gw := gzip.NewWriter(f)
+	w := &backupWriter{tw: tar.NewWriter(gw), files: make(map[string]string, 0), skip: backupDir}
+
+	// The stores are copied while nothing write them.
+	manifest.Services = self.quiesceServices(true)
+	err = self.backupConfigs(w, manifest)
+	self.resumeServices(manifest.Services)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, root := range roots {
+		entry := &backupEntry{Kind: "files", Path: "files/" + strconv.Itoa(i), Target: self.getBackupTarget(root)}
+		err = w.addDir(entry.Path, root)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Entries = append(manifest.Entries, entry)
+	}
+
+	manifest.Files = w.files
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	err = w.tw.WriteHeader(&tar.Header{Name: backupManifestName, Mode: 0644, Size: int64(len(data)), ModTime: manifest.Date, Typeflag: tar.TypeReg})
+	if err == nil {
+		_, err = w.tw.Write(data)
+	}
+	if err == nil {
+		err = w.tw.Close()
+	}
+	if err == nil {
+		err = gw.Close()
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	if err == nil {
+		err = f.Close()
+	}
+	if err == nil {
+		err = os.Rename(archivePath+".tmp", archivePath)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(archivePath)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Println("Backup ", name, " is created with ", len(manifest.Files), " files")
+	event_client.Publish(event_client.BackupCreated, map[string]interface{}{"name": name, "size": info.Size()})
+
+	self.pruneBackups()
+
+	return &adminpb.BackupInfo{Name: name, Size: info.Size(), Date: info.ModTime().Unix()}, nil
+}
+
+/**
+ * Add the configurations of the Globule and of the services, and the LevelDB
+ * stores of the services, to the archive.
+ */
+func (self *Globule) backupConfigs(w *backupWriter, manifest *backupManifest) error {
+	configs := map[string]string{"": getConfigPath(self.path)}
+	self.mutex.Lock()
+	for name, s := range self.services {
+		configs[name] = filepath.Join(filepath.Dir(s.(map[string]interface{})["Path"].(string)), "config.json")
+	}
+	self.mutex.Unlock()
+
+	names := make([]string, 0)
+	for name, _ := range configs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	copied := make(map[string]bool, 0)
+	for _, name := range names {
+		if copied[configs[name]] || !Utility.Exists(configs[name]) {
+			continue
+		}
+		copied[configs[name]] = true
+
+		entry := &backupEntry{Kind: "config", Path: "config/" + name + ".json", Target: self.getBackupTarget(configs[name]), Service: name}
+		if len(name) == 0 {
+			entry.Path = "config/globule.json"
+		}
+
+		err := w.addFile(entry.Path, configs[name])
+		if err != nil {
+			return err
+		}
+		manifest.Entries = append(manifest.Entries, entry)
+
+		for _, store := range getLevelDBStores(configs[name]) {
+			entry := &backupEntry{Kind: "leveldb", Path: "leveldb/" + strconv.Itoa(len(manifest.Entries)), Target: self.getBackupTarget(store), Service: name}
+			err = w.addDir(entry.Path, store)
+			if err != nil {
+				return err
+			}
+			manifest.Entries = append(manifest.Entries, entry)
+		}
+	}
+
+	return nil
+}
+
+/**
+ * Return the archives of the backups directory, the newest first.
+ */
+func (self *Globule) listBackups() ([]*adminpb.BackupInfo, error) {
+	backups := make([]*adminpb.BackupInfo, 0)
+
+	files, err := ioutil.ReadDir(self.getBackupDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return backups, nil
+		}
+		return nil, err
+	}
+
+	for _, info := range files {
+		if !info.Mode().IsRegular() || !strings.HasSuffix(info.Name(), backupExtension) {
+			continue
+		}
+		backups = append(backups, &adminpb.BackupInfo{Name: info.Name(), Size: info.Size(), Date: info.ModTime().Unix()})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		if backups[i].Date == backups[j].Date {
+			return backups[i].Name > backups[j].Name
+		}
+		return backups[i].Date > backups[j].Date
+	})
+
+	return backups, nil
+}
+
+/**
+ * Remove the archives older than the BackupRetention newest ones.
+ */
+func (self *Globule) pruneBackups() {
+	self.mutex.Lock()
+	retention := self.BackupRetention
+	self.mutex.Unlock()
+
+	if retention <= 0 {
+		return
+	}
+
+	backups, err := self.listBackups()
+	if err != nil || len(backups) <= retention {
+		return
+	}
+
+	for _, backup := range backups[retention:] {
+		err := os.Remove(filepath.Join(self.getBackupDir(), backup.Name))
+		if err != nil {
+			log.Println("Fail to remove backup ", backup.Name, " with error ", err)
+		} else {
+			log.Println("Backup ", backup.Name, " is remove")
+		}
+	}
+}
+
+/**
+ * Create a backup each BackupInterval seconds, the time of the last one is
+ * the time of the newest archive.
+ */
+func (self *Globule) scheduleBackups() {
+	var attempt time.Time
+	for {
+		time.Sleep(time.Minute)
+
+		self.mutex.Lock()
+		interval := time.Duration(self.BackupInterval) * time.Second
+		self.mutex.Unlock()
+
+		if interval <= 0 || time.Since(attempt) < interval {
+			continue
+		}
+
+		backups, err := self.listBackups()
+		if err == nil && len(backups) > 0 && time.Since(time.Unix(backups[0].Date, 0)) < interval {
+			continue
+		}
+
+		attempt = time.Now()
+		_, err = self.createBackup()
+		if err != nil {
+			log.Println("Fail to create the scheduled backup with error ", err)
+		}
+	}
+}
+
+/**
+ * Restore an archive of the backups directory. The archive is extracted and
+ * checked, then the services are stopped, the entries replaced and the
+ * configurations read again. With check only the archive is checked.
+ */
+func (self *Globule) restoreBackup(name string, check bool) (*backupManifest, error) {
+	if len(name) == 0 || filepath.Base(name) != name || !strings.HasSuffix(name, backupExtension) {
+		return nil, errors.New("the backup " + name + " is not an archive name of the backups directory")
+	}
+
+	err := self.lockBackup()
+	if err != nil {
+		return nil, err
+	}
+	defer self.unlockBackup()
+
+	// The staging directory is in the Globular directory so the entries are
+	// moved and not copied. It name start with a dot so no service is found
+	// in it.
+	dir, err := ioutil.TempDir(self.path, ".restore_")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	manifest, err := extractBackup(filepath.Join(self.getBackupDir(), name), filepath.Join(dir, "archive"))
+	if err != nil {
+		return nil, err
+	}
+
+	err = self.checkBackup(manifest, filepath.Join(dir, "archive"))
+	if err != nil {
+		return nil, err
+	}
+
+	if check {
+		return manifest, nil
+	}
+
+	stopped := self.quiesceServices(false)
+	err = self.replayBackup(manifest, dir)
+	if err == nil {
+		self.reloadConfigs()
+	}
+	self.resumeServices(stopped)
+
+	if err != nil {
+		return nil, err
+	}
+
+	log.Println("Backup ", name, " is restored")
+	event_client.Publish(event_client.BackupRestored, map[string]interface{}{"name": name})
+
+	return manifest, nil
+}
+
+/**
+ * Extract an archive in a directory and check the sha256 of it files.
+ */
+func extractBackup(archivePath string, dir string) (*backupManifest, error) {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer gr.Close()
+
+	sums := make(map[string]string, 0)
+	var manifest *backupManifest
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		name := path.Clean(strings.TrimPrefix(header.Name, "./"))
+		if name == "." || strings.HasPrefix(name, "/") || name == ".." || strings.HasPrefix(name, "../") {
+			return nil, errors.New("the archive contain the invalid path " + header.Name)
+		}
+
+		if name == backupManifestName {
+			manifest = new(backupManifest)
+			err = json.NewDecoder(tr).Decode(manifest)
+			if err != nil {
+				return nil, errors.New("the archive manifest is not valid: " + err.Error())
+			}
+			continue
+		}
+
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(target, 0755)
+			if err != nil {
+				return nil, err
+			}
+		case tar.TypeReg, tar.TypeRegA:
+			err = os.MkdirAll(filepath.Dir(target), 0755)
+			if err != nil {
+				return nil, err
+			}
+
+			out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(header.Mode).Perm()|0600)
+			if err != nil {
+				return nil, err
+			}
+
+			hash := sha256.New()
+			_, err = io.Copy(io.MultiWriter(out, hash), tr)
+			out.Close()
+			if err != nil {
+				return nil, err
+			}
+			sums[name] = hex.EncodeToString(hash.Sum(nil))
+		default:
+			return nil, errors.New("the archive contain the unsupported file " + header.Name)
+		}
+	}
+
+	if manifest == nil {
+		return nil, errors.New("the archive has no " + backupManifestName)
+	}
+
+	for name, sum := range manifest.Files {
+		if sums[name] != sum {
+			if len(sums[name]) == 0 {
+				return nil, errors.New("the file " + name + " is missing from the archive")
+			}
+			return nil, errors.New("the file " + name + " is corrupted")
+		}
+	}
+
+	for name, _ := range sums {
+		if _, ok := manifest.Files[name]; !ok {
+			return nil, errors.New("the file " + name + " is not in the archive manifest")
+		}
+	}
+
+	return manifest, nil
+}
+
+/**
+ * Check the entries of an extracted archive can be restored.
+ */
+func (self *Globule) checkBackup(manifest *backupManifest, dir string) error {
+	backupDir := self.getBackupDir()
+	for _, entry := range manifest.Entries {
+		if entry.Kind != "config" && entry.Kind != "leveldb" && entry.Kind != "files" {
+			return errors.New("the archive contain the unknown entry kind " + entry.Kind)
+		}
+
+		if !strings.HasPrefix(entry.Path, entry.Kind+"/") || path.Clean(entry.Path) != entry.Path {
+			return errors.New("the archive contain the invalid entry " + entry.Path)
+		}
+
+		if !Utility.Exists(filepath.Join(dir, filepath.FromSlash(entry.Path))) {
+			return errors.New("the entry " + entry.Path + " is missing from the archive")
+		}
+
+		// The Globule configuration is checked and upgraded, the services
+		// check their own when they start.
+		if entry.Kind == "config" && len(entry.Service) == 0 {
+			err := config.Load(filepath.Join(dir, filepath.FromSlash(entry.Path)), new(Globule), configVersion)
+			if err != nil {
+				return err
+			}
+		}
+
+		target, err := self.getBackupTargetPath(entry.Target)
+		if err != nil {
+			return err
+		}
+
+		if isUnder(target, self.path) || isUnder(target, backupDir) {
+			return errors.New("the target " + entry.Target + " contain the Globular directory or the backups")
+		}
+	}
+
+	return nil
+}
+
+/**
+ * Replace the targets of the entries by the archive content, the files
+ * first so the configurations they contain are replaced by the saved ones.
+ * The current files are moved in the staging directory and put back if an
+ * entry can not be restored.
+ */
+func (self *Globule) replayBackup(manifest *backupManifest, dir string) error {
+	type replaced struct {
+		target string
+		saved  string // Empty if the target does not exist.
+	}
+	done := make([]*replaced, 0)
+
+	rollback := func() {
+		for i := len(done) - 1; i >= 0; i-- {
+			os.RemoveAll(done[i].target)
+			if len(done[i].saved) > 0 {
+				err := movePath(done[i].saved, done[i].target)
+				if err != nil {
+					log.Println("Fail to put back ", done[i].target, " with error ", err)
+				}
+			}
+		}
+	}
+
+	entries := make([]*backupEntry, len(manifest.Entries))
+	copy(entries, manifest.Entries)
+	kinds := map[string]int{"files": 0, "leveldb": 1, "config": 2}
+	sort.SliceStable(entries, func(i, j int) bool { return kinds[entries[i].Kind] < kinds[entries[j].Kind] })
+
+	for i, entry := range entries {
+		target, _ := self.getBackupTargetPath(entry.Target)
+		if entry.Kind == "config" && len(entry.Service) == 0 {
+			// The archives of the previous versions have it in WebRoot.
+			target = getConfigPath(self.path)
+		}
+		r := &replaced{target: target}
+
+		if Utility.Exists(target) {
+			r.saved = filepath.Join(dir, "previous", strconv.Itoa(i))
+			err := os.MkdirAll(filepath.Dir(r.saved), 0755)
+			if err == nil {
+				err = movePath(target, r.saved)
+			}
+			if err != nil {
+				rollback()
+				return err
+			}
+		}
+		done = append(done, r)
+
+		err := os.MkdirAll(filepath.Dir(target), 0755)
+		if err == nil {
+			err = movePath(filepath.Join(dir, "archive", filepath.FromSlash(entry.Path)), target)
+		}
+		if err != nil {
+			rollback()
+			return err
+		}
+	}
+
+	return nil
+}
+
+/**
+ * Read the configurations of the Globule and of the services again after a
+ * restore. The ports of the Globule are use at it next start.
+ */
+func (self *Globule) reloadConfigs() {
+	// The configuration of an older version is upgraded.
+	self.mutex.Lock()
+	applications := self.Applications
+	self.Applications = make(map[string]*Application, 0)
+	err := config.Load(getConfigPath(self.path), self, configVersion)
+	if err != nil {
+		self.Applications = applications
+	}
+	self.mutex.Unlock()
+	if err != nil {
+		log.Println("Fail to read the Globule configuration with error ", err)
+	}
+
+	self.mutex.Lock()
+	for name, s := range self.services {
+		s := s.(map[string]interface{})
+		data, err := ioutil.ReadFile(filepath.Join(filepath.Dir(s["Path"].(string)), "config.json"))
+		if err != nil {
+			continue
+		}
+
+		values := make(map[string]interface{}, 0)
+		err = json.Unmarshal(data, &values)
+		if err != nil {
+			log.Println("Fail to read the configuration of ", name, " with error ", err)
+			continue
+		}
+
+		// The values set by the Globule are kept.
+		for key, value := range values {
+			if key != "Path" && key != "Version" && key != "Instances" {
+				s[key] = value
+			}
+		}
+
+		self.Services[name] = getPublicInfo(s)
+	}
+	self.mutex.Unlock()
+
+	self.saveConfig()
+}
+
+/**
+ * Move a file or a directory, it is copied if it can not be renamed.
+ */
+func movePath(src string, dst string) error {
+	err := os.Rename(src, dst)
+	if err == nil {
+		return nil
+	}
+
+	err = copyPath(src, dst)
+	if err != nil {
+		os.RemoveAll(dst)
+		return err
+	}
+
+	return os.RemoveAll(src)
+}
+
+/**
+ * Copy a file or a directory.
+ */
+func copyPath(src string, dst string) error {
+	return filepath.Walk(src, func(path_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path_)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		if info.IsDir() {
+			return os.MkdirAll(target, info.Mode().Perm()|0700)
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		in, err := os.Open(path_)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+
+		out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm())
+		if err != nil {
+			return err
+		}
+
+		_, err = io.Copy(out, in)
+		if err == nil {
+			err = out.Close()
+		} else {
+			out.Close()
+		}
+
+		return err
+	})
+}
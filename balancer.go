@@ -0,0 +1,272 @@
+package main
+
+import (
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"os/exec"
+	"strconv"
+	"sync"
+	"time"
+)
+
+/**
+ * The web proxy listen the proxy port of a service and send the grpc-web
+ * requests to the grpcwebproxy with the less requests in progress. There is
+ * one grpcwebproxy by replica, they are all connected to the service router
+ * that give each of them a different replica.
+ */
+type webProxy struct {
+	mutex    sync.Mutex
+	listener net.Listener
+	closed   bool
+
+	// The grpcwebproxy options.
+	path            string
+	backendAddress  string
+	allowAllOrigins string
+
+	// The number of grpcwebproxy to keep running.
+	replicas  int
+	processes []*webProxyProcess
+
+	// Use to choose between processes with the same number of requests.
+	next int
+}
+
+/**
+ * A grpcwebproxy process and the number of requests it handle.
+ */
+type webProxyProcess struct {
+	process *exec.Cmd
+	port    int
+	proxy   *httputil.ReverseProxy
+	calls   int
+
+	// Set when the process does not accept connections, it receive no
+	// requests until it accept them again.
+	unhealthy bool
+}
+
+/**
+ * Start listening at the proxy port of a service, backendAddress is the
+ * address of the service router.
+ */
+func newWebProxy(port int, path string, backendAddress string, allowAllOrigins string) (*webProxy, error) {
+	listener, err := net.Listen("tcp", ":"+strconv.Itoa(port))
+	if err != nil {
+		return nil, err
+	}
+
+	proxy := new(webProxy)
+	proxy.listener = listener
+	proxy.path = path
+	proxy.backendAddress = backendAddress
+	proxy.allowAllOrigins = allowAllOrigins
+	proxy.processes = make([]*webProxyProcess, 0)
+
+	go http.Serve(listener, proxy)
+
+	return proxy, nil
+}
+
+func (self *webProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	self.mutex.Lock()
+	var process *webProxyProcess
+	self.next++
+	for i := 0; i < len(self.processes); i++ {
+		p := self.processes[(self.next+i)%len(self.processes)]
+		if p.unhealthy {
+			continue
+		}
+
+		if process == nil || p.calls < process.calls {
+			process = p
+		}
+	}
+
+	if process != nil {
+		process.calls++
+	}
+	self.mutex.Unlock()
+
+	if process == nil {
+		http.Error(w, "no healthy grpcwebproxy is running", http.StatusServiceUnavailable)
+		return
+	}
+
+	process.proxy.ServeHTTP(w, r)
+
+	self.mutex.Lock()
+	process.calls--
+	self.mutex.Unlock()
+}
+
+/**
+ * Start or stop grpcwebproxy processes to have one by replica. A stopped
+ * process receive no more requests, it is killed when the requests in
+ * progress are done or after drainTimeout.
+ */
+func (self *webProxy) setReplicas(replicas int, drainTimeout time.Duration) error {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if self.closed {
+		return errors.New("the web proxy is closed")
+	}
+
+	self.replicas = replicas
+	for len(self.processes) > replicas {
+		last := self.processes[len(self.processes)-1]
+		self.processes = self.processes[:len(self.processes)-1]
+		go self.drainProcess(last, drainTimeout)
+	}
+
+	for len(self.processes) < replicas {
+		process, err := self.startProcess()
+		if err != nil {
+			return err
+		}
+		self.processes = append(self.processes, process)
+	}
+
+	return nil
+}
+
+/**
+ * Wait until the requests in progress on a grpcwebproxy that is no more used
+ * are done, then kill it. The process is killed anyway after timeout.
+ */
+func (self *webProxy) drainProcess(p *webProxyProcess, timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+	for {
+		self.mutex.Lock()
+		calls := p.calls
+		self.mutex.Unlock()
+
+		if calls == 0 {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			log.Println("grpcwebproxy ", p.process.Process.Pid, " still has ", calls, " requests after ", timeout)
+			break
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	log.Println("kill proxy process ", p.process.Process.Pid)
+	p.process.Process.Kill()
+}
+
+/**
+ * Start a grpcwebproxy at a free port.
+ */
+func (self *webProxy) startProcess() (*webProxyProcess, error) {
+	port, err := getFreePort()
+	if err != nil {
+		return nil, err
+	}
+
+	process := exec.Command(self.path, "--backend_addr="+self.backendAddress, "--server_http_debug_port="+strconv.Itoa(port), "--run_tls_server=false", "--allow_all_origins="+self.allowAllOrigins)
+	err = process.Start()
+	if err != nil {
+		return nil, err
+	}
+
+	target, _ := url.Parse("http://localhost:" + strconv.Itoa(port))
+	proxy := httputil.NewSingleHostReverseProxy(target)
+
+	// The server streams must be sent as they come.
+	proxy.FlushInterval = -1
+
+	p := &webProxyProcess{process: process, port: port, proxy: proxy}
+
+	// A grpcwebproxy that exit is replaced.
+	go func() {
+		err := process.Wait()
+		self.mutex.Lock()
+		index := -1
+		for i, p_ := range self.processes {
+			if p_ == p {
+				index = i
+			}
+		}
+		if index == -1 {
+			self.mutex.Unlock()
+			return // stopped.
+		}
+		self.processes = append(self.processes[:index], self.processes[index+1:]...)
+		replicas := self.replicas
+		self.mutex.Unlock()
+
+		log.Println("grpcwebproxy ", process.Process.Pid, " exit ", err)
+		time.Sleep(time.Second)
+		err = self.setReplicas(replicas, upgradeDrainTimeout)
+		if err != nil {
+			log.Println("Fail to restart grpcwebproxy with error ", err)
+		}
+	}()
+
+	return p, nil
+}
+
+/**
+ * Probe the grpcwebproxy processes, the ones that does not accept
+ * connections receive no requests until they accept them again.
+ */
+func (self *webProxy) checkHealth() {
+	self.mutex.Lock()
+	processes := make([]*webProxyProcess, len(self.processes))
+	copy(processes, self.processes)
+	self.mutex.Unlock()
+
+	for _, p := range processes {
+		err := probeHealth(p.port, "")
+
+		self.mutex.Lock()
+		changed := p.unhealthy != (err != nil)
+		p.unhealthy = err != nil
+		self.mutex.Unlock()
+
+		if changed && err != nil {
+			log.Println("grpcwebproxy ", p.process.Process.Pid, " is unhealthy: ", err)
+		} else if changed {
+			log.Println("grpcwebproxy ", p.process.Process.Pid, " is healthy again")
+		}
+	}
+}
+
+/**
+ * Stop listening and kill the grpcwebproxy processes.
+ */
+func (self *webProxy) close() {
+	self.listener.Close()
+
+	self.mutex.Lock()
+	processes := self.processes
+	self.processes = make([]*webProxyProcess, 0)
+	self.closed = true
+	self.mutex.Unlock()
+
+	for _, p := range processes {
+		log.Println("kill proxy process ", p.process.Process.Pid)
+		p.process.Process.Kill()
+	}
+}
+
+/**
+ * Return the path of the grpcwebproxy executable.
+ */
+func getWebProxyPath(dir string) string {
+	path := dir + string(os.PathSeparator) + "bin" + string(os.PathSeparator) + "grpcwebproxy"
+	if string(os.PathSeparator) == "\\" {
+		path += ".exe" // in case of windows.
+	}
+	return path
+}
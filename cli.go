@@ -0,0 +1,679 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/davecourtois/Globular/admin/adminpb"
+	"github.com/davecourtois/Utility"
+)
+
+/**
+ * Print the command line usage.
+ */
+func usage() {
+	fmt.Fprintln(os.Stderr, `Usage: Globular [command] [options]
+
+Commands:
+  serve                                   start the Globule (default command)
+  status                                  print the Globule and services states
+  services list|start|stop|restart name   manage the services
+  services install|upgrade file           install a service package
+  services restart name -rolling          restart a service without interruption
+  services uninstall name                 remove a service installed from a package
+  logs name [-follow] [-tail n]           print a service output
+  config get [key]                        print a configuration value
+  config set key value                    set a configuration value
+  cluster                                 print the nodes of the cluster
+  apps list|set|remove [name]             manage the web applications
+  backup create|list                      create or list the backups
+  backup restore name [-check]            restore a backup, or only check it
+  generate name [-dir path]               create a new service from templates
+  package dir -key file [-o file]         create and sign a service package
+  keygen name                             create a key pair to sign packages
+  version                                 print the Globular version
+
+The options -address and -admin_port (or GLOBULAR_ADDRESS and
+GLOBULAR_ADMIN_PORT) give the Globule to connect to, -token (or
+GLOBULAR_ADMIN_TOKEN) it token, the one of the admin_token file of the local
+installation by default. Run a command with -h to see it options.`)
+}
+
+/**
+ * Start the Globule. The flags take precedence over the environment and
+ * the config.json values.
+ */
+func serve(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	port := fs.Int("port", 0, "the http port")
+	adminPort := fs.Int("admin_port", 0, "the admin service port")
+	ip := fs.String("ip", "", "the address given to the clients")
+	fs.Parse(args)
+
+	g, err := NewGlobule()
+	if err != nil {
+		return err
+	}
+
+	// Only the flags given on the command line are set.
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "port":
+			g.Port = *port
+		case "admin_port":
+			g.AdminPort = *adminPort
+		case "ip":
+			g.IP = *ip
+		}
+	})
+
+	g.Listen()
+	return nil
+}
+
+/**
+ * Parse the flags and return the positional arguments, they can be given
+ * before, between or after the options.
+ */
+func parseFlags(fs *flag.FlagSet, args []string) []string {
+	positionals := make([]string, 0)
+	for {
+		fs.Parse(args)
+		args = fs.Args()
+		if len(args) == 0 {
+			break
+		}
+		positionals = append(positionals, args[0])
+		args = args[1:]
+	}
+
+	return positionals
+}
+
+/**
+ * Register the flags use to connect to the admin service and return a function
+ * that create the client.
+ */
+func adminFlags(fs *flag.FlagSet) func() *Admin_Client {
+	address := os.Getenv("GLOBULAR_ADDRESS")
+	if len(address) == 0 {
+		address = "localhost"
+	}
+
+	address_ := fs.String("address", address, "the address of the Globule")
+	adminPort := fs.Int("admin_port", getAdminPort(), "the admin service port")
+	token := fs.String("token", "", "the token of the admin service, GLOBULAR_ADMIN_TOKEN or the admin_token file by default")
+
+	return func() *Admin_Client {
+		client := NewAdmin_Client(*address_ + ":" + strconv.Itoa(*adminPort))
+		if len(*token) > 0 {
+			client.token = *token
+		} else if len(client.token) == 0 {
+			client.token = getAdminToken()
+		}
+		return client
+	}
+}
+
+/**
+ * Return the token of the admin_token file of the local installation, it can
+ * be read only by the user that run the Globule.
+ */
+func getAdminToken() string {
+	dir, err := filepath.Abs(filepath.Dir(os.Args[0]))
+	if err != nil {
+		return ""
+	}
+
+	token, _ := readAdminToken(dir)
+	return token
+}
+
+/**
+ * Return the admin port from the environment, from the globular.json of the
+ * local installation or the default one.
+ */
+func getAdminPort() int {
+	if port, err := strconv.Atoi(os.Getenv("GLOBULAR_ADMIN_PORT")); err == nil {
+		return port
+	}
+
+	config := struct{ AdminPort int }{10015}
+	dir, err := filepath.Abs(filepath.Dir(os.Args[0]))
+	if err == nil {
+		file, err := ioutil.ReadFile(getConfigPath(dir))
+		if err == nil {
+			json.Unmarshal(file, &config)
+		}
+	}
+
+	return config.AdminPort
+}
+
+/**
+ * Print the services informations as a table.
+ */
+func printServices(services []*adminpb.ServiceInfo) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tVERSION\tPORT\tPROXY\tSTATE\tREPLICAS\tPID")
+	for _, s := range services {
+		state := "stopped"
+		pid := "-"
+		version := s.Version
+		if len(version) == 0 {
+			version = "-"
+		}
+		port, proxy := strconv.Itoa(int(s.Port)), strconv.Itoa(int(s.Proxy))
+		if s.Protocol == "http" {
+			port, proxy = "-", "-" // reach by the Globule http port.
+		}
+		if s.Running {
+			state = "running"
+			pids := make([]string, len(s.Pids))
+			for i, pid := range s.Pids {
+				pids[i] = strconv.Itoa(int(pid))
+			}
+			pid = strings.Join(pids, ",")
+		}
+		replicas := strconv.Itoa(len(s.Pids)) + "/" + strconv.Itoa(int(s.Replicas))
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", s.Name, version, port, proxy, state, replicas, pid)
+	}
+	w.Flush()
+}
+
+/**
+ * Print a value as json.
+ */
+func printJson(value interface{}) error {
+	str, err := Utility.ToJson(value)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(str)
+	return nil
+}
+
+/**
+ * Print the Globule status.
+ */
+func printStatus(args []string) error {
+	fs := flag.NewFlagSet("status", flag.ExitOnError)
+	client := adminFlags(fs)
+	asJson := fs.Bool("json", false, "print the status as json")
+	parseFlags(fs, args)
+
+	c := client()
+	defer c.Close()
+
+	status, err := c.GetStatus()
+	if err != nil {
+		return err
+	}
+
+	if *asJson {
+		return printJson(status)
+	}
+
+	fmt.Printf("%s %s is running (pid %d) since %s\n", status.Name, status.Version, status.Pid, time.Unix(status.StartTime, 0).Format(time.RFC3339))
+	fmt.Printf("http port %d, admin port %d, address %s\n\n", status.Port, status.AdminPort, status.Ip)
+	printServices(status.Services)
+
+	return nil
+}
+
+/**
+ * The services commands: list, start, stop and restart.
+ */
+func manageServices(args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: Globular services list|start|stop|restart|install|upgrade|uninstall [name|file]")
+	}
+
+	action := args[0]
+	fs := flag.NewFlagSet("services "+action, flag.ExitOnError)
+	client := adminFlags(fs)
+	asJson := fs.Bool("json", false, "print the result as json")
+	signaturePath := fs.String("sig", "", "the package signature file, the package path followed by .sig by default")
+	rolling := fs.Bool("rolling", false, "restart without interruption, the new process replace the old one once it is ready")
+	drainTimeout := fs.Int("drain", 30, "the seconds given to the old process to finish it requests")
+	args = parseFlags(fs, args[1:])
+
+	c := client()
+	defer c.Close()
+
+	if action == "list" {
+		services, err := c.ListServices()
+		if err != nil {
+			return err
+		}
+
+		if *asJson {
+			return printJson(services)
+		}
+
+		printServices(services)
+		return nil
+	}
+
+	if len(args) != 1 {
+		return errors.New("usage: Globular services " + action + " name")
+	}
+
+	var service *adminpb.ServiceInfo
+	var err error
+	switch action {
+	case "start":
+		service, err = c.StartService(args[0])
+	case "stop":
+		service, err = c.StopService(args[0])
+	case "restart":
+		if *rolling {
+			service, err = c.UpgradeService(args[0], *drainTimeout)
+		} else {
+			service, err = c.RestartService(args[0])
+		}
+	case "install", "upgrade":
+		var rsp *adminpb.InstallServiceResponse
+		rsp, err = installPackage(c, args[0], *signaturePath)
+		if err == nil {
+			service = rsp.Service
+			if len(rsp.PreviousVersion) > 0 {
+				fmt.Println("upgrade", service.Name, "from", rsp.PreviousVersion, "to", service.Version)
+			}
+		}
+	case "uninstall":
+		err = c.UninstallService(args[0])
+		if err == nil {
+			fmt.Println(args[0], "is uninstalled")
+			return nil
+		}
+	default:
+		return errors.New("unknown services command " + action)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if *asJson {
+		return printJson(service)
+	}
+
+	printServices([]*adminpb.ServiceInfo{service})
+	return nil
+}
+
+/**
+ * Print the output of a service.
+ */
+func printLogs(args []string) error {
+	fs := flag.NewFlagSet("logs", flag.ExitOnError)
+	client := adminFlags(fs)
+	follow := fs.Bool("follow", false, "wait for new lines")
+	tail := fs.Int("tail", 0, "the number of lines to print, 0 for all")
+
+	args = parseFlags(fs, args)
+	if len(args) != 1 {
+		return errors.New("usage: Globular logs name [-follow] [-tail n]")
+	}
+
+	c := client()
+	defer c.Close()
+
+	return c.GetLogs(args[0], *tail, *follow, func(line string) {
+		fmt.Println(line)
+	})
+}
+
+/**
+ * The config commands: get and set.
+ */
+func manageConfig(args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: Globular config get [key] | config set key value")
+	}
+
+	action := args[0]
+	fs := flag.NewFlagSet("config "+action, flag.ExitOnError)
+	client := adminFlags(fs)
+	args = parseFlags(fs, args[1:])
+
+	c := client()
+	defer c.Close()
+
+	switch action {
+	case "get":
+		key := ""
+		if len(args) > 0 {
+			key = args[0]
+		}
+		value, err := c.GetConfig(key)
+		if err != nil {
+			return err
+		}
+		fmt.Println(value)
+	case "set":
+		if len(args) != 2 {
+			return errors.New("usage: Globular config set key value")
+		}
+		return c.SetConfig(args[0], args[1])
+	default:
+		return errors.New("unknown config command " + action)
+	}
+
+	return nil
+}
+
+/**
+ * Print the nodes of the cluster and their services.
+ */
+func printCluster(args []string) error {
+	fs := flag.NewFlagSet("cluster", flag.ExitOnError)
+	client := adminFlags(fs)
+	asJson := fs.Bool("json", false, "print the nodes as json")
+	parseFlags(fs, args)
+
+	c := client()
+	defer c.Close()
+
+	nodes, err := c.GetCluster()
+	if err != nil {
+		return err
+	}
+
+	if *asJson {
+		return printJson(nodes)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tPORT\tSTATE\tLAST UPDATE\tSERVICES")
+	for _, node := range nodes {
+		state := "dead"
+		if node.Alive {
+			state = "alive"
+		}
+
+		services := make([]string, 0)
+		for _, s := range node.Services {
+			if s.Running {
+				services = append(services, s.Name)
+			}
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\n", node.Id, node.Name, node.Port, state, time.Unix(node.LastUpdate, 0).Format(time.RFC3339), strings.Join(services, ","))
+	}
+	w.Flush()
+
+	return nil
+}
+
+/**
+ * The web applications commands: list, set and remove.
+ */
+func manageApplications(args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: Globular apps list | apps set name -root dir [-hostname host] [-prefix path] | apps remove name")
+	}
+
+	action := args[0]
+	fs := flag.NewFlagSet("apps "+action, flag.ExitOnError)
+	client := adminFlags(fs)
+	asJson := fs.Bool("json", false, "print the applications as json")
+	hostname := fs.String("hostname", "", "serve the application for that host")
+	prefix := fs.String("prefix", "", "serve the application under that path, ex: /blog")
+	root := fs.String("root", "", "the directory of the application files, relative to WebRoot or absolute")
+	spa := fs.Bool("spa", false, "give index.html for the unknown paths without extension")
+	errorPages := fs.String("errors", "", "the error pages by status, ex: 404=404.html,500=500.html")
+	config := fs.String("config", "", "the json values added to the configuration given to the application")
+	args = parseFlags(fs, args[1:])
+
+	c := client()
+	defer c.Close()
+
+	switch action {
+	case "list":
+		applications, err := c.ListApplications()
+		if err != nil {
+			return err
+		}
+
+		if *asJson {
+			return printJson(applications)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tHOSTNAME\tPREFIX\tROOT\tSPA")
+		for _, app := range applications {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%t\n", app.Name, orDash(app.Hostname), orDash(app.PathPrefix), app.Root, app.Spa)
+		}
+		w.Flush()
+	case "set":
+		if len(args) != 1 {
+			return errors.New("usage: Globular apps set name -root dir [-hostname host] [-prefix path] [-spa] [-errors 404=404.html] [-config json]")
+		}
+
+		pages := make(map[string]string, 0)
+		for _, page := range strings.Split(*errorPages, ",") {
+			values := strings.SplitN(page, "=", 2)
+			if len(values) == 2 {
+				pages[values[0]] = values[1]
+			}
+		}
+
+		return c.SetApplication(&adminpb.ApplicationInfo{
+			Name:       args[0],
+			Hostname:   *hostname,
+			PathPrefix: *prefix,
+			Root:       *root,
+			Spa:        *spa,
+			ErrorPages: pages,
+			Config:     *config,
+		})
+	case "remove":
+		if len(args) != 1 {
+			return errors.New("usage: Globular apps remove name")
+		}
+		return c.RemoveApplication(args[0])
+	default:
+		return errors.New("unknown apps command " + action)
+	}
+
+	return nil
+}
+
+/**
+ * The backups commands: create, list and restore.
+ */
+func manageBackups(args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: Globular backup create | backup list | backup restore name [-check]")
+	}
+
+	action := args[0]
+	fs := flag.NewFlagSet("backup "+action, flag.ExitOnError)
+	client := adminFlags(fs)
+	asJson := fs.Bool("json", false, "print the backups as json")
+	check := fs.Bool("check", false, "only check the archive, nothing is replaced")
+	args = parseFlags(fs, args[1:])
+
+	c := client()
+	defer c.Close()
+
+	switch action {
+	case "create":
+		backup, err := c.Backup()
+		if err != nil {
+			return err
+		}
+		fmt.Println("Backup", backup.Name, "is created,", backup.Size, "bytes")
+	case "list":
+		backups, err := c.ListBackups()
+		if err != nil {
+			return err
+		}
+
+		if *asJson {
+			return printJson(backups)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tSIZE\tDATE")
+		for _, backup := range backups {
+			fmt.Fprintf(w, "%s\t%d\t%s\n", backup.Name, backup.Size, time.Unix(backup.Date, 0).Format(time.RFC3339))
+		}
+		w.Flush()
+	case "restore":
+		if len(args) != 1 {
+			return errors.New("usage: Globular backup restore name [-check]")
+		}
+
+		rsp, err := c.Restore(args[0], *check)
+		if err != nil {
+			return err
+		}
+
+		if *check {
+			fmt.Println("Backup", args[0], "of Globular", rsp.Version, "is valid, it restore:")
+		} else {
+			fmt.Println("Backup", args[0], "of Globular", rsp.Version, "is restored:")
+		}
+		for _, target := range rsp.Targets {
+			fmt.Println("  " + target)
+		}
+	default:
+		return errors.New("unknown backup command " + action)
+	}
+
+	return nil
+}
+
+/**
+ * Return value or - if it is empty, for the tables.
+ */
+func orDash(value string) string {
+	if len(value) == 0 {
+		return "-"
+	}
+
+	return value
+}
+
+/**
+ * Send a package and it signature to the Globule.
+ */
+func installPackage(c *Admin_Client, path string, signaturePath string) (*adminpb.InstallServiceResponse, error) {
+	if len(signaturePath) == 0 {
+		signaturePath = path + ".sig"
+	}
+
+	data, err := ioutil.ReadFile(signaturePath)
+	if err != nil {
+		return nil, err
+	}
+
+	signature := new(packageSignature)
+	err = json.Unmarshal(data, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.InstallService(path, signature.Checksum, signature.Signature)
+}
+
+/**
+ * Create a package from a directory that contain a manifest.json and sign it.
+ * The signature is written next to the package with the .sig extension.
+ */
+func createPackageFile(args []string) error {
+	fs := flag.NewFlagSet("package", flag.ExitOnError)
+	keyPath := fs.String("key", "", "the private key file use to sign the package")
+	output := fs.String("o", "", "the package file, name.version.tar.gz by default")
+	args = parseFlags(fs, args)
+
+	if len(args) != 1 || len(*keyPath) == 0 {
+		return errors.New("usage: Globular package dir -key file [-o file]")
+	}
+
+	manifest, err := readManifest(filepath.Join(args[0], "manifest.json"))
+	if err != nil {
+		return err
+	}
+
+	key, err := ioutil.ReadFile(*keyPath)
+	if err != nil {
+		return err
+	}
+
+	data, err := createPackage(args[0])
+	if err != nil {
+		return err
+	}
+
+	signature, err := signPackage(data, string(key))
+	if err != nil {
+		return err
+	}
+
+	if len(*output) == 0 {
+		*output = manifest.Name + "." + manifest.Version + ".tar.gz"
+	}
+
+	err = ioutil.WriteFile(*output, data, 0644)
+	if err != nil {
+		return err
+	}
+
+	str, err := Utility.ToJson(signature)
+	if err != nil {
+		return err
+	}
+
+	err = ioutil.WriteFile(*output+".sig", []byte(str), 0644)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("create", *output, "and", *output+".sig")
+	return nil
+}
+
+/**
+ * Create an ed25519 key pair, name.key is the private key use to sign the
+ * packages and name.pub must be added to the public_keys of the Globules.
+ */
+func generateKeys(args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: Globular keygen name")
+	}
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return err
+	}
+
+	err = ioutil.WriteFile(args[0]+".key", []byte(base64.StdEncoding.EncodeToString(privateKey)), 0600)
+	if err != nil {
+		return err
+	}
+
+	err = ioutil.WriteFile(args[0]+".pub", []byte(base64.StdEncoding.EncodeToString(publicKey)+"\n"), 0644)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("create", args[0]+".key", "and", args[0]+".pub")
+	return nil
+}
@@ -301,6 +301,206 @@ proto.file.FileServicePromiseClient.prototype.rename =
 };
 
 
+/**
+ * @const
+ * @type {!grpc.web.AbstractClientBase.MethodInfo<
+ *   !proto.file.CopyRequest,
+ *   !proto.file.CopyResponse>}
+ */
+const methodInfo_FileService_Copy = new grpc.web.AbstractClientBase.MethodInfo(
+  proto.file.CopyResponse,
+  /** @param {!proto.file.CopyRequest} request */
+  function(request) {
+    return request.serializeBinary();
+  },
+  proto.file.CopyResponse.deserializeBinary
+);
+
+
+/**
+ * @param {!proto.file.CopyRequest} request The request proto
+ * @param {?Object<string, string>} metadata User defined
+ *     call metadata
+ * @return {!grpc.web.ClientReadableStream<!proto.file.CopyResponse>}
+ *     The XHR Node Readable Stream
+ */
+proto.file.FileServiceClient.prototype.copy =
+    function(request, metadata) {
+  return this.client_.serverStreaming(this.hostname_ +
+      '/file.FileService/Copy',
+      request,
+      metadata || {},
+      methodInfo_FileService_Copy);
+};
+
+
+/**
+ * @param {!proto.file.CopyRequest} request The request proto
+ * @param {?Object<string, string>} metadata User defined
+ *     call metadata
+ * @return {!grpc.web.ClientReadableStream<!proto.file.CopyResponse>}
+ *     The XHR Node Readable Stream
+ */
+proto.file.FileServicePromiseClient.prototype.copy =
+    function(request, metadata) {
+  return this.client_.serverStreaming(this.hostname_ +
+      '/file.FileService/Copy',
+      request,
+      metadata || {},
+      methodInfo_FileService_Copy);
+};
+
+
+/**
+ * @const
+ * @type {!grpc.web.AbstractClientBase.MethodInfo<
+ *   !proto.file.MoveRequest,
+ *   !proto.file.MoveResponse>}
+ */
+const methodInfo_FileService_Move = new grpc.web.AbstractClientBase.MethodInfo(
+  proto.file.MoveResponse,
+  /** @param {!proto.file.MoveRequest} request */
+  function(request) {
+    return request.serializeBinary();
+  },
+  proto.file.MoveResponse.deserializeBinary
+);
+
+
+/**
+ * @param {!proto.file.MoveRequest} request The request proto
+ * @param {?Object<string, string>} metadata User defined
+ *     call metadata
+ * @return {!grpc.web.ClientReadableStream<!proto.file.MoveResponse>}
+ *     The XHR Node Readable Stream
+ */
+proto.file.FileServiceClient.prototype.move =
+    function(request, metadata) {
+  return this.client_.serverStreaming(this.hostname_ +
+      '/file.FileService/Move',
+      request,
+      metadata || {},
+      methodInfo_FileService_Move);
+};
+
+
+/**
+ * @param {!proto.file.MoveRequest} request The request proto
+ * @param {?Object<string, string>} metadata User defined
+ *     call metadata
+ * @return {!grpc.web.ClientReadableStream<!proto.file.MoveResponse>}
+ *     The XHR Node Readable Stream
+ */
+proto.file.FileServicePromiseClient.prototype.move =
+    function(request, metadata) {
+  return this.client_.serverStreaming(this.hostname_ +
+      '/file.FileService/Move',
+      request,
+      metadata || {},
+      methodInfo_FileService_Move);
+};
+
+
+/**
+ * @const
+ * @type {!grpc.web.AbstractClientBase.MethodInfo<
+ *   !proto.file.WatchDirRequest,
+ *   !proto.file.WatchDirResponse>}
+ */
+const methodInfo_FileService_WatchDir = new grpc.web.AbstractClientBase.MethodInfo(
+  proto.file.WatchDirResponse,
+  /** @param {!proto.file.WatchDirRequest} request */
+  function(request) {
+    return request.serializeBinary();
+  },
+  proto.file.WatchDirResponse.deserializeBinary
+);
+
+
+/**
+ * @param {!proto.file.WatchDirRequest} request The request proto
+ * @param {?Object<string, string>} metadata User defined
+ *     call metadata
+ * @return {!grpc.web.ClientReadableStream<!proto.file.WatchDirResponse>}
+ *     The XHR Node Readable Stream
+ */
+proto.file.FileServiceClient.prototype.watchDir =
+    function(request, metadata) {
+  return this.client_.serverStreaming(this.hostname_ +
+      '/file.FileService/WatchDir',
+      request,
+      metadata || {},
+      methodInfo_FileService_WatchDir);
+};
+
+
+/**
+ * @param {!proto.file.WatchDirRequest} request The request proto
+ * @param {?Object<string, string>} metadata User defined
+ *     call metadata
+ * @return {!grpc.web.ClientReadableStream<!proto.file.WatchDirResponse>}
+ *     The XHR Node Readable Stream
+ */
+proto.file.FileServicePromiseClient.prototype.watchDir =
+    function(request, metadata) {
+  return this.client_.serverStreaming(this.hostname_ +
+      '/file.FileService/WatchDir',
+      request,
+      metadata || {},
+      methodInfo_FileService_WatchDir);
+};
+
+
+/**
+ * @const
+ * @type {!grpc.web.AbstractClientBase.MethodInfo<
+ *   !proto.file.SearchRequest,
+ *   !proto.file.SearchResponse>}
+ */
+const methodInfo_FileService_Search = new grpc.web.AbstractClientBase.MethodInfo(
+  proto.file.SearchResponse,
+  /** @param {!proto.file.SearchRequest} request */
+  function(request) {
+    return request.serializeBinary();
+  },
+  proto.file.SearchResponse.deserializeBinary
+);
+
+
+/**
+ * @param {!proto.file.SearchRequest} request The request proto
+ * @param {?Object<string, string>} metadata User defined
+ *     call metadata
+ * @return {!grpc.web.ClientReadableStream<!proto.file.SearchResponse>}
+ *     The XHR Node Readable Stream
+ */
+proto.file.FileServiceClient.prototype.search =
+    function(request, metadata) {
+  return this.client_.serverStreaming(this.hostname_ +
+      '/file.FileService/Search',
+      request,
+      metadata || {},
+      methodInfo_FileService_Search);
+};
+
+
+/**
+ * @param {!proto.file.SearchRequest} request The request proto
+ * @param {?Object<string, string>} metadata User defined
+ *     call metadata
+ * @return {!grpc.web.ClientReadableStream<!proto.file.SearchResponse>}
+ *     The XHR Node Readable Stream
+ */
+proto.file.FileServicePromiseClient.prototype.search =
+    function(request, metadata) {
+  return this.client_.serverStreaming(this.hostname_ +
+      '/file.FileService/Search',
+      request,
+      metadata || {},
+      methodInfo_FileService_Search);
+};
+
+
 /**
  * @const
  * @type {!grpc.web.AbstractClientBase.MethodInfo<
@@ -461,5 +661,310 @@ proto.file.FileServicePromiseClient.prototype.deleteFile =
 };
 
 
+/**
+ * @const
+ * @type {!grpc.web.AbstractClientBase.MethodInfo<
+ *   !proto.file.GetThumbnailsRequest,
+ *   !proto.file.GetThumbnailsResponse>}
+ */
+const methodInfo_FileService_GetThumbnails = new grpc.web.AbstractClientBase.MethodInfo(
+  proto.file.GetThumbnailsResponse,
+  /** @param {!proto.file.GetThumbnailsRequest} request */
+  function(request) {
+    return request.serializeBinary();
+  },
+  proto.file.GetThumbnailsResponse.deserializeBinary
+);
+
+
+/**
+ * @param {!proto.file.GetThumbnailsRequest} request The request proto
+ * @param {?Object<string, string>} metadata User defined
+ *     call metadata
+ * @return {!grpc.web.ClientReadableStream<!proto.file.GetThumbnailsResponse>}
+ *     The XHR Node Readable Stream
+ */
+proto.file.FileServiceClient.prototype.getThumbnails =
+    function(request, metadata) {
+  return this.client_.serverStreaming(this.hostname_ +
+      '/file.FileService/GetThumbnails',
+      request,
+      metadata || {},
+      methodInfo_FileService_GetThumbnails);
+};
+
+
+/**
+ * @param {!proto.file.GetThumbnailsRequest} request The request proto
+ * @param {?Object<string, string>} metadata User defined
+ *     call metadata
+ * @return {!grpc.web.ClientReadableStream<!proto.file.GetThumbnailsResponse>}
+ *     The XHR Node Readable Stream
+ */
+proto.file.FileServicePromiseClient.prototype.getThumbnails =
+    function(request, metadata) {
+  return this.client_.serverStreaming(this.hostname_ +
+      '/file.FileService/GetThumbnails',
+      request,
+      metadata || {},
+      methodInfo_FileService_GetThumbnails);
+};
+
+
+/**
+ * @const
+ * @type {!grpc.web.AbstractClientBase.MethodInfo<
+ *   !proto.file.GetThumbnailRequest,
+ *   !proto.file.GetThumbnailResponse>}
+ */
+const methodInfo_FileService_GetThumbnail = new grpc.web.AbstractClientBase.MethodInfo(
+  proto.file.GetThumbnailResponse,
+  /** @param {!proto.file.GetThumbnailRequest} request */
+  function(request) {
+    return request.serializeBinary();
+  },
+  proto.file.GetThumbnailResponse.deserializeBinary
+);
+
+
+/**
+ * @param {!proto.file.GetThumbnailRequest} request The
+ *     request proto
+ * @param {?Object<string, string>} metadata User defined
+ *     call metadata
+ * @param {function(?grpc.web.Error, ?proto.file.GetThumbnailResponse)}
+ *     callback The callback function(error, response)
+ * @return {!grpc.web.ClientReadableStream<!proto.file.GetThumbnailResponse>|undefined}
+ *     The XHR Node Readable Stream
+ */
+proto.file.FileServiceClient.prototype.getThumbnail =
+    function(request, metadata, callback) {
+  return this.client_.rpcCall(this.hostname_ +
+      '/file.FileService/GetThumbnail',
+      request,
+      metadata || {},
+      methodInfo_FileService_GetThumbnail,
+      callback);
+};
+
+
+/**
+ * @param {!proto.file.GetThumbnailRequest} request The
+ *     request proto
+ * @param {?Object<string, string>} metadata User defined
+ *     call metadata
+ * @return {!Promise<!proto.file.GetThumbnailResponse>}
+ *     A native promise that resolves to the response
+ */
+proto.file.FileServicePromiseClient.prototype.getThumbnail =
+    function(request, metadata) {
+  return this.client_.unaryCall(this.hostname_ +
+      '/file.FileService/GetThumbnail',
+      request,
+      metadata || {},
+      methodInfo_FileService_GetThumbnail);
+};
+
+
+/**
+ * @const
+ * @type {!grpc.web.AbstractClientBase.MethodInfo<
+ *   !proto.file.ImageTransformRequest,
+ *   !proto.file.ImageTransformResponse>}
+ */
+const methodInfo_FileService_ImageTransform = new grpc.web.AbstractClientBase.MethodInfo(
+  proto.file.ImageTransformResponse,
+  /** @param {!proto.file.ImageTransformRequest} request */
+  function(request) {
+    return request.serializeBinary();
+  },
+  proto.file.ImageTransformResponse.deserializeBinary
+);
+
+
+/**
+ * @param {!proto.file.ImageTransformRequest} request The request proto
+ * @param {?Object<string, string>} metadata User defined
+ *     call metadata
+ * @return {!grpc.web.ClientReadableStream<!proto.file.ImageTransformResponse>}
+ *     The XHR Node Readable Stream
+ */
+proto.file.FileServiceClient.prototype.imageTransform =
+    function(request, metadata) {
+  return this.client_.serverStreaming(this.hostname_ +
+      '/file.FileService/ImageTransform',
+      request,
+      metadata || {},
+      methodInfo_FileService_ImageTransform);
+};
+
+
+/**
+ * @param {!proto.file.ImageTransformRequest} request The request proto
+ * @param {?Object<string, string>} metadata User defined
+ *     call metadata
+ * @return {!grpc.web.ClientReadableStream<!proto.file.ImageTransformResponse>}
+ *     The XHR Node Readable Stream
+ */
+proto.file.FileServicePromiseClient.prototype.imageTransform =
+    function(request, metadata) {
+  return this.client_.serverStreaming(this.hostname_ +
+      '/file.FileService/ImageTransform',
+      request,
+      metadata || {},
+      methodInfo_FileService_ImageTransform);
+};
+
+
+/**
+ * @const
+ * @type {!grpc.web.AbstractClientBase.MethodInfo<
+ *   !proto.file.CreateArchiveRequest,
+ *   !proto.file.CreateArchiveResponse>}
+ */
+const methodInfo_FileService_CreateArchive = new grpc.web.AbstractClientBase.MethodInfo(
+  proto.file.CreateArchiveResponse,
+  /** @param {!proto.file.CreateArchiveRequest} request */
+  function(request) {
+    return request.serializeBinary();
+  },
+  proto.file.CreateArchiveResponse.deserializeBinary
+);
+
+
+/**
+ * @param {!proto.file.CreateArchiveRequest} request The request proto
+ * @param {?Object<string, string>} metadata User defined
+ *     call metadata
+ * @return {!grpc.web.ClientReadableStream<!proto.file.CreateArchiveResponse>}
+ *     The XHR Node Readable Stream
+ */
+proto.file.FileServiceClient.prototype.createArchive =
+    function(request, metadata) {
+  return this.client_.serverStreaming(this.hostname_ +
+      '/file.FileService/CreateArchive',
+      request,
+      metadata || {},
+      methodInfo_FileService_CreateArchive);
+};
+
+
+/**
+ * @param {!proto.file.CreateArchiveRequest} request The request proto
+ * @param {?Object<string, string>} metadata User defined
+ *     call metadata
+ * @return {!grpc.web.ClientReadableStream<!proto.file.CreateArchiveResponse>}
+ *     The XHR Node Readable Stream
+ */
+proto.file.FileServicePromiseClient.prototype.createArchive =
+    function(request, metadata) {
+  return this.client_.serverStreaming(this.hostname_ +
+      '/file.FileService/CreateArchive',
+      request,
+      metadata || {},
+      methodInfo_FileService_CreateArchive);
+};
+
+
+/**
+ * @const
+ * @type {!grpc.web.AbstractClientBase.MethodInfo<
+ *   !proto.file.ExtractArchiveRequest,
+ *   !proto.file.ExtractArchiveResponse>}
+ */
+const methodInfo_FileService_ExtractArchive = new grpc.web.AbstractClientBase.MethodInfo(
+  proto.file.ExtractArchiveResponse,
+  /** @param {!proto.file.ExtractArchiveRequest} request */
+  function(request) {
+    return request.serializeBinary();
+  },
+  proto.file.ExtractArchiveResponse.deserializeBinary
+);
+
+
+/**
+ * @param {!proto.file.ExtractArchiveRequest} request The request proto
+ * @param {?Object<string, string>} metadata User defined
+ *     call metadata
+ * @return {!grpc.web.ClientReadableStream<!proto.file.ExtractArchiveResponse>}
+ *     The XHR Node Readable Stream
+ */
+proto.file.FileServiceClient.prototype.extractArchive =
+    function(request, metadata) {
+  return this.client_.serverStreaming(this.hostname_ +
+      '/file.FileService/ExtractArchive',
+      request,
+      metadata || {},
+      methodInfo_FileService_ExtractArchive);
+};
+
+
+/**
+ * @param {!proto.file.ExtractArchiveRequest} request The request proto
+ * @param {?Object<string, string>} metadata User defined
+ *     call metadata
+ * @return {!grpc.web.ClientReadableStream<!proto.file.ExtractArchiveResponse>}
+ *     The XHR Node Readable Stream
+ */
+proto.file.FileServicePromiseClient.prototype.extractArchive =
+    function(request, metadata) {
+  return this.client_.serverStreaming(this.hostname_ +
+      '/file.FileService/ExtractArchive',
+      request,
+      metadata || {},
+      methodInfo_FileService_ExtractArchive);
+};
+
+
+/**
+ * @const
+ * @type {!grpc.web.AbstractClientBase.MethodInfo<
+ *   !proto.file.ListArchiveRequest,
+ *   !proto.file.ListArchiveResponse>}
+ */
+const methodInfo_FileService_ListArchive = new grpc.web.AbstractClientBase.MethodInfo(
+  proto.file.ListArchiveResponse,
+  /** @param {!proto.file.ListArchiveRequest} request */
+  function(request) {
+    return request.serializeBinary();
+  },
+  proto.file.ListArchiveResponse.deserializeBinary
+);
+
+
+/**
+ * @param {!proto.file.ListArchiveRequest} request The request proto
+ * @param {?Object<string, string>} metadata User defined
+ *     call metadata
+ * @return {!grpc.web.ClientReadableStream<!proto.file.ListArchiveResponse>}
+ *     The XHR Node Readable Stream
+ */
+proto.file.FileServiceClient.prototype.listArchive =
+    function(request, metadata) {
+  return this.client_.serverStreaming(this.hostname_ +
+      '/file.FileService/ListArchive',
+      request,
+      metadata || {},
+      methodInfo_FileService_ListArchive);
+};
+
+
+/**
+ * @param {!proto.file.ListArchiveRequest} request The request proto
+ * @param {?Object<string, string>} metadata User defined
+ *     call metadata
+ * @return {!grpc.web.ClientReadableStream<!proto.file.ListArchiveResponse>}
+ *     The XHR Node Readable Stream
+ */
+proto.file.FileServicePromiseClient.prototype.listArchive =
+    function(request, metadata) {
+  return this.client_.serverStreaming(this.hostname_ +
+      '/file.FileService/ListArchive',
+      request,
+      metadata || {},
+      methodInfo_FileService_ListArchive);
+};
+
+
 module.exports = proto.file;
 
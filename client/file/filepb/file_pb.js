@@ -11,23 +11,58 @@ var jspb = require('google-protobuf');
 var goog = jspb;
 var global = Function('return this')();
 
+goog.exportSymbol('proto.file.ArchiveEntry', null, global);
+goog.exportSymbol('proto.file.ArchiveFormat', null, global);
+goog.exportSymbol('proto.file.ConflictPolicy', null, global);
+goog.exportSymbol('proto.file.CopyRequest', null, global);
+goog.exportSymbol('proto.file.CopyResponse', null, global);
+goog.exportSymbol('proto.file.CreateArchiveRequest', null, global);
+goog.exportSymbol('proto.file.CreateArchiveResponse', null, global);
 goog.exportSymbol('proto.file.CreateDirRequest', null, global);
 goog.exportSymbol('proto.file.CreateDirResponse', null, global);
+goog.exportSymbol('proto.file.CropOperation', null, global);
 goog.exportSymbol('proto.file.DeleteDirRequest', null, global);
 goog.exportSymbol('proto.file.DeleteDirResponse', null, global);
 goog.exportSymbol('proto.file.DeleteFileRequest', null, global);
 goog.exportSymbol('proto.file.DeleteFileResponse', null, global);
 goog.exportSymbol('proto.file.Empty', null, global);
+goog.exportSymbol('proto.file.ExtractArchiveRequest', null, global);
+goog.exportSymbol('proto.file.ExtractArchiveResponse', null, global);
+goog.exportSymbol('proto.file.FileChange', null, global);
+goog.exportSymbol('proto.file.FileChangeType', null, global);
+goog.exportSymbol('proto.file.FileInfo', null, global);
+goog.exportSymbol('proto.file.FlipDirection', null, global);
 goog.exportSymbol('proto.file.GetFileInfoRequest', null, global);
 goog.exportSymbol('proto.file.GetFileInfoResponse', null, global);
+goog.exportSymbol('proto.file.GetThumbnailRequest', null, global);
+goog.exportSymbol('proto.file.GetThumbnailResponse', null, global);
+goog.exportSymbol('proto.file.GetThumbnailsRequest', null, global);
+goog.exportSymbol('proto.file.GetThumbnailsResponse', null, global);
+goog.exportSymbol('proto.file.ImageFormat', null, global);
+goog.exportSymbol('proto.file.ImageOperation', null, global);
+goog.exportSymbol('proto.file.ImageTransformRequest', null, global);
+goog.exportSymbol('proto.file.ImageTransformResponse', null, global);
+goog.exportSymbol('proto.file.ListArchiveRequest', null, global);
+goog.exportSymbol('proto.file.ListArchiveResponse', null, global);
+goog.exportSymbol('proto.file.MoveRequest', null, global);
+goog.exportSymbol('proto.file.MoveResponse', null, global);
 goog.exportSymbol('proto.file.ReadDirRequest', null, global);
 goog.exportSymbol('proto.file.ReadDirResponse', null, global);
 goog.exportSymbol('proto.file.ReadFileRequest', null, global);
 goog.exportSymbol('proto.file.ReadFileResponse', null, global);
 goog.exportSymbol('proto.file.RenameRequest', null, global);
 goog.exportSymbol('proto.file.RenameResponse', null, global);
+goog.exportSymbol('proto.file.ResizeMode', null, global);
+goog.exportSymbol('proto.file.ResizeOperation', null, global);
 goog.exportSymbol('proto.file.SaveFileRequest', null, global);
 goog.exportSymbol('proto.file.SaveFileResponse', null, global);
+goog.exportSymbol('proto.file.SaveMode', null, global);
+goog.exportSymbol('proto.file.SearchRequest', null, global);
+goog.exportSymbol('proto.file.SearchResponse', null, global);
+goog.exportSymbol('proto.file.SortOrder', null, global);
+goog.exportSymbol('proto.file.TransferProgress', null, global);
+goog.exportSymbol('proto.file.WatchDirRequest', null, global);
+goog.exportSymbol('proto.file.WatchDirResponse', null, global);
 
 /**
  * Generated by JsPbCodeGenerator.
@@ -155,12 +190,12 @@ proto.file.Empty.serializeBinaryToWriter = function(message, writer) {
  * @extends {jspb.Message}
  * @constructor
  */
-proto.file.ReadDirRequest = function(opt_data) {
+proto.file.FileInfo = function(opt_data) {
   jspb.Message.initialize(this, opt_data, 0, -1, null, null);
 };
-goog.inherits(proto.file.ReadDirRequest, jspb.Message);
+goog.inherits(proto.file.FileInfo, jspb.Message);
 if (goog.DEBUG && !COMPILED) {
-  proto.file.ReadDirRequest.displayName = 'proto.file.ReadDirRequest';
+  proto.file.FileInfo.displayName = 'proto.file.FileInfo';
 }
 
 
@@ -175,8 +210,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
  *     for transitional soy proto support: http://goto/soy-param-migration
  * @return {!Object}
  */
-proto.file.ReadDirRequest.prototype.toObject = function(opt_includeInstance) {
-  return proto.file.ReadDirRequest.toObject(opt_includeInstance, this);
+proto.file.FileInfo.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.FileInfo.toObject(opt_includeInstance, this);
 };
 
 
@@ -185,16 +220,22 @@ proto.file.ReadDirRequest.prototype.toObject = function(opt_includeInstance) {
  * @param {boolean|undefined} includeInstance Whether to include the JSPB
  *     instance for transitional soy proto support:
  *     http://goto/soy-param-migration
- * @param {!proto.file.ReadDirRequest} msg The msg instance to transform.
+ * @param {!proto.file.FileInfo} msg The msg instance to transform.
  * @return {!Object}
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.file.ReadDirRequest.toObject = function(includeInstance, msg) {
+proto.file.FileInfo.toObject = function(includeInstance, msg) {
   var f, obj = {
-    path: jspb.Message.getFieldWithDefault(msg, 1, ""),
-    recursive: jspb.Message.getFieldWithDefault(msg, 2, false),
-    thumnailwidth: jspb.Message.getFieldWithDefault(msg, 3, 0),
-    thumnailheight: jspb.Message.getFieldWithDefault(msg, 4, 0)
+    name: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    path: jspb.Message.getFieldWithDefault(msg, 2, ""),
+    size: jspb.Message.getFieldWithDefault(msg, 3, 0),
+    mode: jspb.Message.getFieldWithDefault(msg, 4, 0),
+    modtime: jspb.Message.getFieldWithDefault(msg, 5, 0),
+    isdir: jspb.Message.getFieldWithDefault(msg, 6, false),
+    mime: jspb.Message.getFieldWithDefault(msg, 7, ""),
+    thumbnail: jspb.Message.getFieldWithDefault(msg, 8, ""),
+    depth: jspb.Message.getFieldWithDefault(msg, 9, 0),
+    error: jspb.Message.getFieldWithDefault(msg, 10, "")
   };
 
   if (includeInstance) {
@@ -208,23 +249,23 @@ proto.file.ReadDirRequest.toObject = function(includeInstance, msg) {
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.file.ReadDirRequest}
+ * @return {!proto.file.FileInfo}
  */
-proto.file.ReadDirRequest.deserializeBinary = function(bytes) {
+proto.file.FileInfo.deserializeBinary = function(bytes) {
   var reader = new jspb.BinaryReader(bytes);
-  var msg = new proto.file.ReadDirRequest;
-  return proto.file.ReadDirRequest.deserializeBinaryFromReader(msg, reader);
+  var msg = new proto.file.FileInfo;
+  return proto.file.FileInfo.deserializeBinaryFromReader(msg, reader);
 };
 
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.file.ReadDirRequest} msg The message object to deserialize into.
+ * @param {!proto.file.FileInfo} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.file.ReadDirRequest}
+ * @return {!proto.file.FileInfo}
  */
-proto.file.ReadDirRequest.deserializeBinaryFromReader = function(msg, reader) {
+proto.file.FileInfo.deserializeBinaryFromReader = function(msg, reader) {
   while (reader.nextField()) {
     if (reader.isEndGroup()) {
       break;
@@ -233,19 +274,43 @@ proto.file.ReadDirRequest.deserializeBinaryFromReader = function(msg, reader) {
     switch (field) {
     case 1:
       var value = /** @type {string} */ (reader.readString());
-      msg.setPath(value);
+      msg.setName(value);
       break;
     case 2:
-      var value = /** @type {boolean} */ (reader.readBool());
-      msg.setRecursive(value);
+      var value = /** @type {string} */ (reader.readString());
+      msg.setPath(value);
       break;
     case 3:
-      var value = /** @type {number} */ (reader.readInt32());
-      msg.setThumnailwidth(value);
+      var value = /** @type {number} */ (reader.readInt64());
+      msg.setSize(value);
       break;
     case 4:
+      var value = /** @type {number} */ (reader.readUint32());
+      msg.setMode(value);
+      break;
+    case 5:
+      var value = /** @type {number} */ (reader.readInt64());
+      msg.setModtime(value);
+      break;
+    case 6:
+      var value = /** @type {boolean} */ (reader.readBool());
+      msg.setIsdir(value);
+      break;
+    case 7:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setMime(value);
+      break;
+    case 8:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setThumbnail(value);
+      break;
+    case 9:
       var value = /** @type {number} */ (reader.readInt32());
-      msg.setThumnailheight(value);
+      msg.setDepth(value);
+      break;
+    case 10:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setError(value);
       break;
     default:
       reader.skipField();
@@ -260,9 +325,9 @@ proto.file.ReadDirRequest.deserializeBinaryFromReader = function(msg, reader) {
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.file.ReadDirRequest.prototype.serializeBinary = function() {
+proto.file.FileInfo.prototype.serializeBinary = function() {
   var writer = new jspb.BinaryWriter();
-  proto.file.ReadDirRequest.serializeBinaryToWriter(this, writer);
+  proto.file.FileInfo.serializeBinaryToWriter(this, writer);
   return writer.getResultBuffer();
 };
 
@@ -270,268 +335,234 @@ proto.file.ReadDirRequest.prototype.serializeBinary = function() {
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.file.ReadDirRequest} message
+ * @param {!proto.file.FileInfo} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.file.ReadDirRequest.serializeBinaryToWriter = function(message, writer) {
+proto.file.FileInfo.serializeBinaryToWriter = function(message, writer) {
   var f = undefined;
-  f = message.getPath();
+  f = message.getName();
   if (f.length > 0) {
     writer.writeString(
       1,
       f
     );
   }
-  f = message.getRecursive();
-  if (f) {
-    writer.writeBool(
+  f = message.getPath();
+  if (f.length > 0) {
+    writer.writeString(
       2,
       f
     );
   }
-  f = message.getThumnailwidth();
+  f = message.getSize();
   if (f !== 0) {
-    writer.writeInt32(
+    writer.writeInt64(
       3,
       f
     );
   }
-  f = message.getThumnailheight();
+  f = message.getMode();
   if (f !== 0) {
-    writer.writeInt32(
+    writer.writeUint32(
       4,
       f
     );
   }
+  f = message.getModtime();
+  if (f !== 0) {
+    writer.writeInt64(
+      5,
+      f
+    );
+  }
+  f = message.getIsdir();
+  if (f) {
+    writer.writeBool(
+      6,
+      f
+    );
+  }
+  f = message.getMime();
+  if (f.length > 0) {
+    writer.writeString(
+      7,
+      f
+    );
+  }
+  f = message.getThumbnail();
+  if (f.length > 0) {
+    writer.writeString(
+      8,
+      f
+    );
+  }
+  f = message.getDepth();
+  if (f !== 0) {
+    writer.writeInt32(
+      9,
+      f
+    );
+  }
+  f = message.getError();
+  if (f.length > 0) {
+    writer.writeString(
+      10,
+      f
+    );
+  }
 };
 
 
 /**
- * optional string path = 1;
+ * optional string name = 1;
  * @return {string}
  */
-proto.file.ReadDirRequest.prototype.getPath = function() {
+proto.file.FileInfo.prototype.getName = function() {
   return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
 };
 
 
 /** @param {string} value */
-proto.file.ReadDirRequest.prototype.setPath = function(value) {
+proto.file.FileInfo.prototype.setName = function(value) {
   jspb.Message.setProto3StringField(this, 1, value);
 };
 
 
 /**
- * optional bool recursive = 2;
- * Note that Boolean fields may be set to 0/1 when serialized from a Java server.
- * You should avoid comparisons like {@code val === true/false} in those cases.
- * @return {boolean}
+ * optional string path = 2;
+ * @return {string}
  */
-proto.file.ReadDirRequest.prototype.getRecursive = function() {
-  return /** @type {boolean} */ (jspb.Message.getFieldWithDefault(this, 2, false));
+proto.file.FileInfo.prototype.getPath = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
 };
 
 
-/** @param {boolean} value */
-proto.file.ReadDirRequest.prototype.setRecursive = function(value) {
-  jspb.Message.setProto3BooleanField(this, 2, value);
+/** @param {string} value */
+proto.file.FileInfo.prototype.setPath = function(value) {
+  jspb.Message.setProto3StringField(this, 2, value);
 };
 
 
 /**
- * optional int32 thumnailWidth = 3;
+ * optional int64 size = 3;
  * @return {number}
  */
-proto.file.ReadDirRequest.prototype.getThumnailwidth = function() {
+proto.file.FileInfo.prototype.getSize = function() {
   return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
 };
 
 
 /** @param {number} value */
-proto.file.ReadDirRequest.prototype.setThumnailwidth = function(value) {
+proto.file.FileInfo.prototype.setSize = function(value) {
   jspb.Message.setProto3IntField(this, 3, value);
 };
 
 
 /**
- * optional int32 thumnailHeight = 4;
+ * optional uint32 mode = 4;
  * @return {number}
  */
-proto.file.ReadDirRequest.prototype.getThumnailheight = function() {
+proto.file.FileInfo.prototype.getMode = function() {
   return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 4, 0));
 };
 
 
 /** @param {number} value */
-proto.file.ReadDirRequest.prototype.setThumnailheight = function(value) {
+proto.file.FileInfo.prototype.setMode = function(value) {
   jspb.Message.setProto3IntField(this, 4, value);
 };
 
 
-
 /**
- * Generated by JsPbCodeGenerator.
- * @param {Array=} opt_data Optional initial data array, typically from a
- * server response, or constructed directly in Javascript. The array is used
- * in place and becomes part of the constructed object. It is not cloned.
- * If no data is provided, the constructed object will be empty, but still
- * valid.
- * @extends {jspb.Message}
- * @constructor
+ * optional int64 modTime = 5;
+ * @return {number}
  */
-proto.file.ReadDirResponse = function(opt_data) {
-  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+proto.file.FileInfo.prototype.getModtime = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 5, 0));
 };
-goog.inherits(proto.file.ReadDirResponse, jspb.Message);
-if (goog.DEBUG && !COMPILED) {
-  proto.file.ReadDirResponse.displayName = 'proto.file.ReadDirResponse';
-}
 
 
-if (jspb.Message.GENERATE_TO_OBJECT) {
-/**
- * Creates an object representation of this proto suitable for use in Soy templates.
- * Field names that are reserved in JavaScript and will be renamed to pb_name.
- * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
- * For the list of reserved names please see:
- *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
- * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
- *     for transitional soy proto support: http://goto/soy-param-migration
- * @return {!Object}
- */
-proto.file.ReadDirResponse.prototype.toObject = function(opt_includeInstance) {
-  return proto.file.ReadDirResponse.toObject(opt_includeInstance, this);
+/** @param {number} value */
+proto.file.FileInfo.prototype.setModtime = function(value) {
+  jspb.Message.setProto3IntField(this, 5, value);
 };
 
 
 /**
- * Static version of the {@see toObject} method.
- * @param {boolean|undefined} includeInstance Whether to include the JSPB
- *     instance for transitional soy proto support:
- *     http://goto/soy-param-migration
- * @param {!proto.file.ReadDirResponse} msg The msg instance to transform.
- * @return {!Object}
- * @suppress {unusedLocalVariables} f is only used for nested messages
+ * optional bool isDir = 6;
+ * Note that Boolean fields may be set to 0/1 when serialized from a Java server.
+ * You should avoid comparisons like {@code val === true/false} in those cases.
+ * @return {boolean}
  */
-proto.file.ReadDirResponse.toObject = function(includeInstance, msg) {
-  var f, obj = {
-    data: msg.getData_asB64()
-  };
+proto.file.FileInfo.prototype.getIsdir = function() {
+  return /** @type {boolean} */ (jspb.Message.getFieldWithDefault(this, 6, false));
+};
 
-  if (includeInstance) {
-    obj.$jspbMessageInstance = msg;
-  }
-  return obj;
+
+/** @param {boolean} value */
+proto.file.FileInfo.prototype.setIsdir = function(value) {
+  jspb.Message.setProto3BooleanField(this, 6, value);
 };
-}
 
 
 /**
- * Deserializes binary data (in protobuf wire format).
- * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.file.ReadDirResponse}
+ * optional string mime = 7;
+ * @return {string}
  */
-proto.file.ReadDirResponse.deserializeBinary = function(bytes) {
-  var reader = new jspb.BinaryReader(bytes);
-  var msg = new proto.file.ReadDirResponse;
-  return proto.file.ReadDirResponse.deserializeBinaryFromReader(msg, reader);
+proto.file.FileInfo.prototype.getMime = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 7, ""));
 };
 
 
-/**
- * Deserializes binary data (in protobuf wire format) from the
- * given reader into the given message object.
- * @param {!proto.file.ReadDirResponse} msg The message object to deserialize into.
- * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.file.ReadDirResponse}
- */
-proto.file.ReadDirResponse.deserializeBinaryFromReader = function(msg, reader) {
-  while (reader.nextField()) {
-    if (reader.isEndGroup()) {
-      break;
-    }
-    var field = reader.getFieldNumber();
-    switch (field) {
-    case 1:
-      var value = /** @type {!Uint8Array} */ (reader.readBytes());
-      msg.setData(value);
-      break;
-    default:
-      reader.skipField();
-      break;
-    }
-  }
-  return msg;
+/** @param {string} value */
+proto.file.FileInfo.prototype.setMime = function(value) {
+  jspb.Message.setProto3StringField(this, 7, value);
 };
 
 
 /**
- * Serializes the message to binary data (in protobuf wire format).
- * @return {!Uint8Array}
+ * optional string thumbnail = 8;
+ * @return {string}
  */
-proto.file.ReadDirResponse.prototype.serializeBinary = function() {
-  var writer = new jspb.BinaryWriter();
-  proto.file.ReadDirResponse.serializeBinaryToWriter(this, writer);
-  return writer.getResultBuffer();
+proto.file.FileInfo.prototype.getThumbnail = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 8, ""));
 };
 
 
-/**
- * Serializes the given message to binary data (in protobuf wire
- * format), writing to the given BinaryWriter.
- * @param {!proto.file.ReadDirResponse} message
- * @param {!jspb.BinaryWriter} writer
- * @suppress {unusedLocalVariables} f is only used for nested messages
- */
-proto.file.ReadDirResponse.serializeBinaryToWriter = function(message, writer) {
-  var f = undefined;
-  f = message.getData_asU8();
-  if (f.length > 0) {
-    writer.writeBytes(
-      1,
-      f
-    );
-  }
+/** @param {string} value */
+proto.file.FileInfo.prototype.setThumbnail = function(value) {
+  jspb.Message.setProto3StringField(this, 8, value);
 };
 
 
 /**
- * optional bytes data = 1;
- * @return {string}
+ * optional int32 depth = 9;
+ * @return {number}
  */
-proto.file.ReadDirResponse.prototype.getData = function() {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+proto.file.FileInfo.prototype.getDepth = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 9, 0));
 };
 
 
-/**
- * optional bytes data = 1;
- * This is a type-conversion wrapper around `getData()`
- * @return {string}
- */
-proto.file.ReadDirResponse.prototype.getData_asB64 = function() {
-  return /** @type {string} */ (jspb.Message.bytesAsB64(
-      this.getData()));
+/** @param {number} value */
+proto.file.FileInfo.prototype.setDepth = function(value) {
+  jspb.Message.setProto3IntField(this, 9, value);
 };
 
 
 /**
- * optional bytes data = 1;
- * Note that Uint8Array is not supported on all browsers.
- * @see http://caniuse.com/Uint8Array
- * This is a type-conversion wrapper around `getData()`
- * @return {!Uint8Array}
+ * optional string error = 10;
+ * @return {string}
  */
-proto.file.ReadDirResponse.prototype.getData_asU8 = function() {
-  return /** @type {!Uint8Array} */ (jspb.Message.bytesAsU8(
-      this.getData()));
+proto.file.FileInfo.prototype.getError = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 10, ""));
 };
 
 
-/** @param {!(string|Uint8Array)} value */
-proto.file.ReadDirResponse.prototype.setData = function(value) {
-  jspb.Message.setProto3BytesField(this, 1, value);
+/** @param {string} value */
+proto.file.FileInfo.prototype.setError = function(value) {
+  jspb.Message.setProto3StringField(this, 10, value);
 };
 
 
@@ -546,12 +577,12 @@ proto.file.ReadDirResponse.prototype.setData = function(value) {
  * @extends {jspb.Message}
  * @constructor
  */
-proto.file.CreateDirRequest = function(opt_data) {
+proto.file.ReadDirRequest = function(opt_data) {
   jspb.Message.initialize(this, opt_data, 0, -1, null, null);
 };
-goog.inherits(proto.file.CreateDirRequest, jspb.Message);
+goog.inherits(proto.file.ReadDirRequest, jspb.Message);
 if (goog.DEBUG && !COMPILED) {
-  proto.file.CreateDirRequest.displayName = 'proto.file.CreateDirRequest';
+  proto.file.ReadDirRequest.displayName = 'proto.file.ReadDirRequest';
 }
 
 
@@ -566,8 +597,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
  *     for transitional soy proto support: http://goto/soy-param-migration
  * @return {!Object}
  */
-proto.file.CreateDirRequest.prototype.toObject = function(opt_includeInstance) {
-  return proto.file.CreateDirRequest.toObject(opt_includeInstance, this);
+proto.file.ReadDirRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.ReadDirRequest.toObject(opt_includeInstance, this);
 };
 
 
@@ -576,14 +607,21 @@ proto.file.CreateDirRequest.prototype.toObject = function(opt_includeInstance) {
  * @param {boolean|undefined} includeInstance Whether to include the JSPB
  *     instance for transitional soy proto support:
  *     http://goto/soy-param-migration
- * @param {!proto.file.CreateDirRequest} msg The msg instance to transform.
+ * @param {!proto.file.ReadDirRequest} msg The msg instance to transform.
  * @return {!Object}
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.file.CreateDirRequest.toObject = function(includeInstance, msg) {
+proto.file.ReadDirRequest.toObject = function(includeInstance, msg) {
   var f, obj = {
     path: jspb.Message.getFieldWithDefault(msg, 1, ""),
-    name: jspb.Message.getFieldWithDefault(msg, 2, "")
+    recursive: jspb.Message.getFieldWithDefault(msg, 2, false),
+    thumnailwidth: jspb.Message.getFieldWithDefault(msg, 3, 0),
+    thumnailheight: jspb.Message.getFieldWithDefault(msg, 4, 0),
+    sort: jspb.Message.getFieldWithDefault(msg, 5, 0),
+    descending: jspb.Message.getFieldWithDefault(msg, 6, false),
+    pagesize: jspb.Message.getFieldWithDefault(msg, 7, 0),
+    pagetoken: jspb.Message.getFieldWithDefault(msg, 8, ""),
+    depth: jspb.Message.getFieldWithDefault(msg, 9, 0)
   };
 
   if (includeInstance) {
@@ -597,23 +635,23 @@ proto.file.CreateDirRequest.toObject = function(includeInstance, msg) {
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.file.CreateDirRequest}
+ * @return {!proto.file.ReadDirRequest}
  */
-proto.file.CreateDirRequest.deserializeBinary = function(bytes) {
+proto.file.ReadDirRequest.deserializeBinary = function(bytes) {
   var reader = new jspb.BinaryReader(bytes);
-  var msg = new proto.file.CreateDirRequest;
-  return proto.file.CreateDirRequest.deserializeBinaryFromReader(msg, reader);
+  var msg = new proto.file.ReadDirRequest;
+  return proto.file.ReadDirRequest.deserializeBinaryFromReader(msg, reader);
 };
 
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.file.CreateDirRequest} msg The message object to deserialize into.
+ * @param {!proto.file.ReadDirRequest} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.file.CreateDirRequest}
+ * @return {!proto.file.ReadDirRequest}
  */
-proto.file.CreateDirRequest.deserializeBinaryFromReader = function(msg, reader) {
+proto.file.ReadDirRequest.deserializeBinaryFromReader = function(msg, reader) {
   while (reader.nextField()) {
     if (reader.isEndGroup()) {
       break;
@@ -625,8 +663,36 @@ proto.file.CreateDirRequest.deserializeBinaryFromReader = function(msg, reader)
       msg.setPath(value);
       break;
     case 2:
+      var value = /** @type {boolean} */ (reader.readBool());
+      msg.setRecursive(value);
+      break;
+    case 3:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setThumnailwidth(value);
+      break;
+    case 4:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setThumnailheight(value);
+      break;
+    case 5:
+      var value = /** @type {!proto.file.SortOrder} */ (reader.readEnum());
+      msg.setSort(value);
+      break;
+    case 6:
+      var value = /** @type {boolean} */ (reader.readBool());
+      msg.setDescending(value);
+      break;
+    case 7:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setPagesize(value);
+      break;
+    case 8:
       var value = /** @type {string} */ (reader.readString());
-      msg.setName(value);
+      msg.setPagetoken(value);
+      break;
+    case 9:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setDepth(value);
       break;
     default:
       reader.skipField();
@@ -641,9 +707,9 @@ proto.file.CreateDirRequest.deserializeBinaryFromReader = function(msg, reader)
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.file.CreateDirRequest.prototype.serializeBinary = function() {
+proto.file.ReadDirRequest.prototype.serializeBinary = function() {
   var writer = new jspb.BinaryWriter();
-  proto.file.CreateDirRequest.serializeBinaryToWriter(this, writer);
+  proto.file.ReadDirRequest.serializeBinaryToWriter(this, writer);
   return writer.getResultBuffer();
 };
 
@@ -651,11 +717,11 @@ proto.file.CreateDirRequest.prototype.serializeBinary = function() {
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.file.CreateDirRequest} message
+ * @param {!proto.file.ReadDirRequest} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.file.CreateDirRequest.serializeBinaryToWriter = function(message, writer) {
+proto.file.ReadDirRequest.serializeBinaryToWriter = function(message, writer) {
   var f = undefined;
   f = message.getPath();
   if (f.length > 0) {
@@ -664,10 +730,59 @@ proto.file.CreateDirRequest.serializeBinaryToWriter = function(message, writer)
       f
     );
   }
-  f = message.getName();
+  f = message.getRecursive();
+  if (f) {
+    writer.writeBool(
+      2,
+      f
+    );
+  }
+  f = message.getThumnailwidth();
+  if (f !== 0) {
+    writer.writeInt32(
+      3,
+      f
+    );
+  }
+  f = message.getThumnailheight();
+  if (f !== 0) {
+    writer.writeInt32(
+      4,
+      f
+    );
+  }
+  f = message.getSort();
+  if (f !== 0.0) {
+    writer.writeEnum(
+      5,
+      f
+    );
+  }
+  f = message.getDescending();
+  if (f) {
+    writer.writeBool(
+      6,
+      f
+    );
+  }
+  f = message.getPagesize();
+  if (f !== 0) {
+    writer.writeInt32(
+      7,
+      f
+    );
+  }
+  f = message.getPagetoken();
   if (f.length > 0) {
     writer.writeString(
-      2,
+      8,
+      f
+    );
+  }
+  f = message.getDepth();
+  if (f !== 0) {
+    writer.writeInt32(
+      9,
       f
     );
   }
@@ -678,50 +793,6170 @@ proto.file.CreateDirRequest.serializeBinaryToWriter = function(message, writer)
  * optional string path = 1;
  * @return {string}
  */
-proto.file.CreateDirRequest.prototype.getPath = function() {
+proto.file.ReadDirRequest.prototype.getPath = function() {
   return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
 };
 
 
 /** @param {string} value */
-proto.file.CreateDirRequest.prototype.setPath = function(value) {
+proto.file.ReadDirRequest.prototype.setPath = function(value) {
   jspb.Message.setProto3StringField(this, 1, value);
 };
 
 
 /**
- * optional string name = 2;
+ * optional bool recursive = 2;
+ * Note that Boolean fields may be set to 0/1 when serialized from a Java server.
+ * You should avoid comparisons like {@code val === true/false} in those cases.
+ * @return {boolean}
+ */
+proto.file.ReadDirRequest.prototype.getRecursive = function() {
+  return /** @type {boolean} */ (jspb.Message.getFieldWithDefault(this, 2, false));
+};
+
+
+/** @param {boolean} value */
+proto.file.ReadDirRequest.prototype.setRecursive = function(value) {
+  jspb.Message.setProto3BooleanField(this, 2, value);
+};
+
+
+/**
+ * optional int32 thumnailWidth = 3;
+ * @return {number}
+ */
+proto.file.ReadDirRequest.prototype.getThumnailwidth = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+};
+
+
+/** @param {number} value */
+proto.file.ReadDirRequest.prototype.setThumnailwidth = function(value) {
+  jspb.Message.setProto3IntField(this, 3, value);
+};
+
+
+/**
+ * optional int32 thumnailHeight = 4;
+ * @return {number}
+ */
+proto.file.ReadDirRequest.prototype.getThumnailheight = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 4, 0));
+};
+
+
+/** @param {number} value */
+proto.file.ReadDirRequest.prototype.setThumnailheight = function(value) {
+  jspb.Message.setProto3IntField(this, 4, value);
+};
+
+
+/**
+ * optional SortOrder sort = 5;
+ * @return {!proto.file.SortOrder}
+ */
+proto.file.ReadDirRequest.prototype.getSort = function() {
+  return /** @type {!proto.file.SortOrder} */ (jspb.Message.getFieldWithDefault(this, 5, 0));
+};
+
+
+/** @param {!proto.file.SortOrder} value */
+proto.file.ReadDirRequest.prototype.setSort = function(value) {
+  jspb.Message.setProto3EnumField(this, 5, value);
+};
+
+
+/**
+ * optional bool descending = 6;
+ * Note that Boolean fields may be set to 0/1 when serialized from a Java server.
+ * You should avoid comparisons like {@code val === true/false} in those cases.
+ * @return {boolean}
+ */
+proto.file.ReadDirRequest.prototype.getDescending = function() {
+  return /** @type {boolean} */ (jspb.Message.getFieldWithDefault(this, 6, false));
+};
+
+
+/** @param {boolean} value */
+proto.file.ReadDirRequest.prototype.setDescending = function(value) {
+  jspb.Message.setProto3BooleanField(this, 6, value);
+};
+
+
+/**
+ * optional int32 pageSize = 7;
+ * @return {number}
+ */
+proto.file.ReadDirRequest.prototype.getPagesize = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 7, 0));
+};
+
+
+/** @param {number} value */
+proto.file.ReadDirRequest.prototype.setPagesize = function(value) {
+  jspb.Message.setProto3IntField(this, 7, value);
+};
+
+
+/**
+ * optional string pageToken = 8;
  * @return {string}
  */
-proto.file.CreateDirRequest.prototype.getName = function() {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+proto.file.ReadDirRequest.prototype.getPagetoken = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 8, ""));
 };
 
 
 /** @param {string} value */
-proto.file.CreateDirRequest.prototype.setName = function(value) {
-  jspb.Message.setProto3StringField(this, 2, value);
+proto.file.ReadDirRequest.prototype.setPagetoken = function(value) {
+  jspb.Message.setProto3StringField(this, 8, value);
+};
+
+
+/**
+ * optional int32 depth = 9;
+ * @return {number}
+ */
+proto.file.ReadDirRequest.prototype.getDepth = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 9, 0));
+};
+
+
+/** @param {number} value */
+proto.file.ReadDirRequest.prototype.setDepth = function(value) {
+  jspb.Message.setProto3IntField(this, 9, value);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.file.ReadDirResponse = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.file.ReadDirResponse, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.file.ReadDirResponse.displayName = 'proto.file.ReadDirResponse';
+}
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.file.ReadDirResponse.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.ReadDirResponse.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.file.ReadDirResponse} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.ReadDirResponse.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    info: (f = msg.getInfo()) && proto.file.FileInfo.toObject(includeInstance, f),
+    nextpagetoken: jspb.Message.getFieldWithDefault(msg, 3, "")
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.file.ReadDirResponse}
+ */
+proto.file.ReadDirResponse.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.file.ReadDirResponse;
+  return proto.file.ReadDirResponse.deserializeBinaryFromReader(msg, reader);
 };
 
 
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.file.ReadDirResponse} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.file.ReadDirResponse}
+ */
+proto.file.ReadDirResponse.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 2:
+      var value = new proto.file.FileInfo;
+      reader.readMessage(value,proto.file.FileInfo.deserializeBinaryFromReader);
+      msg.setInfo(value);
+      break;
+    case 3:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setNextpagetoken(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.file.ReadDirResponse.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.file.ReadDirResponse.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.file.ReadDirResponse} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.ReadDirResponse.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getInfo();
+  if (f != null) {
+    writer.writeMessage(
+      2,
+      f,
+      proto.file.FileInfo.serializeBinaryToWriter
+    );
+  }
+  f = message.getNextpagetoken();
+  if (f.length > 0) {
+    writer.writeString(
+      3,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional FileInfo info = 2;
+ * @return {?proto.file.FileInfo}
+ */
+proto.file.ReadDirResponse.prototype.getInfo = function() {
+  return /** @type{?proto.file.FileInfo} */ (
+    jspb.Message.getWrapperField(this, proto.file.FileInfo, 2));
+};
+
+
+/** @param {?proto.file.FileInfo|undefined} value */
+proto.file.ReadDirResponse.prototype.setInfo = function(value) {
+  jspb.Message.setWrapperField(this, 2, value);
+};
+
+
+proto.file.ReadDirResponse.prototype.clearInfo = function() {
+  this.setInfo(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {!boolean}
+ */
+proto.file.ReadDirResponse.prototype.hasInfo = function() {
+  return jspb.Message.getField(this, 2) != null;
+};
+
+
+/**
+ * optional string nextPageToken = 3;
+ * @return {string}
+ */
+proto.file.ReadDirResponse.prototype.getNextpagetoken = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
+};
+
+
+/** @param {string} value */
+proto.file.ReadDirResponse.prototype.setNextpagetoken = function(value) {
+  jspb.Message.setProto3StringField(this, 3, value);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.file.CreateDirRequest = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.file.CreateDirRequest, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.file.CreateDirRequest.displayName = 'proto.file.CreateDirRequest';
+}
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.file.CreateDirRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.CreateDirRequest.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.file.CreateDirRequest} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.CreateDirRequest.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    path: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    name: jspb.Message.getFieldWithDefault(msg, 2, "")
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.file.CreateDirRequest}
+ */
+proto.file.CreateDirRequest.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.file.CreateDirRequest;
+  return proto.file.CreateDirRequest.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.file.CreateDirRequest} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.file.CreateDirRequest}
+ */
+proto.file.CreateDirRequest.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setPath(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setName(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.file.CreateDirRequest.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.file.CreateDirRequest.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.file.CreateDirRequest} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.CreateDirRequest.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getPath();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getName();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional string path = 1;
+ * @return {string}
+ */
+proto.file.CreateDirRequest.prototype.getPath = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/** @param {string} value */
+proto.file.CreateDirRequest.prototype.setPath = function(value) {
+  jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional string name = 2;
+ * @return {string}
+ */
+proto.file.CreateDirRequest.prototype.getName = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/** @param {string} value */
+proto.file.CreateDirRequest.prototype.setName = function(value) {
+  jspb.Message.setProto3StringField(this, 2, value);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.file.CreateDirResponse = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.file.CreateDirResponse, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.file.CreateDirResponse.displayName = 'proto.file.CreateDirResponse';
+}
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.file.CreateDirResponse.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.CreateDirResponse.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.file.CreateDirResponse} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.CreateDirResponse.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    result: jspb.Message.getFieldWithDefault(msg, 1, false)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.file.CreateDirResponse}
+ */
+proto.file.CreateDirResponse.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.file.CreateDirResponse;
+  return proto.file.CreateDirResponse.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.file.CreateDirResponse} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.file.CreateDirResponse}
+ */
+proto.file.CreateDirResponse.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {boolean} */ (reader.readBool());
+      msg.setResult(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.file.CreateDirResponse.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.file.CreateDirResponse.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.file.CreateDirResponse} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.CreateDirResponse.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getResult();
+  if (f) {
+    writer.writeBool(
+      1,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional bool result = 1;
+ * Note that Boolean fields may be set to 0/1 when serialized from a Java server.
+ * You should avoid comparisons like {@code val === true/false} in those cases.
+ * @return {boolean}
+ */
+proto.file.CreateDirResponse.prototype.getResult = function() {
+  return /** @type {boolean} */ (jspb.Message.getFieldWithDefault(this, 1, false));
+};
+
+
+/** @param {boolean} value */
+proto.file.CreateDirResponse.prototype.setResult = function(value) {
+  jspb.Message.setProto3BooleanField(this, 1, value);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.file.DeleteDirRequest = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.file.DeleteDirRequest, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.file.DeleteDirRequest.displayName = 'proto.file.DeleteDirRequest';
+}
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.file.DeleteDirRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.DeleteDirRequest.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.file.DeleteDirRequest} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.DeleteDirRequest.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    path: jspb.Message.getFieldWithDefault(msg, 1, "")
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.file.DeleteDirRequest}
+ */
+proto.file.DeleteDirRequest.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.file.DeleteDirRequest;
+  return proto.file.DeleteDirRequest.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.file.DeleteDirRequest} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.file.DeleteDirRequest}
+ */
+proto.file.DeleteDirRequest.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setPath(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.file.DeleteDirRequest.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.file.DeleteDirRequest.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.file.DeleteDirRequest} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.DeleteDirRequest.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getPath();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional string path = 1;
+ * @return {string}
+ */
+proto.file.DeleteDirRequest.prototype.getPath = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/** @param {string} value */
+proto.file.DeleteDirRequest.prototype.setPath = function(value) {
+  jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.file.DeleteDirResponse = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.file.DeleteDirResponse, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.file.DeleteDirResponse.displayName = 'proto.file.DeleteDirResponse';
+}
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.file.DeleteDirResponse.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.DeleteDirResponse.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.file.DeleteDirResponse} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.DeleteDirResponse.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    result: jspb.Message.getFieldWithDefault(msg, 1, false)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.file.DeleteDirResponse}
+ */
+proto.file.DeleteDirResponse.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.file.DeleteDirResponse;
+  return proto.file.DeleteDirResponse.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.file.DeleteDirResponse} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.file.DeleteDirResponse}
+ */
+proto.file.DeleteDirResponse.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {boolean} */ (reader.readBool());
+      msg.setResult(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.file.DeleteDirResponse.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.file.DeleteDirResponse.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.file.DeleteDirResponse} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.DeleteDirResponse.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getResult();
+  if (f) {
+    writer.writeBool(
+      1,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional bool result = 1;
+ * Note that Boolean fields may be set to 0/1 when serialized from a Java server.
+ * You should avoid comparisons like {@code val === true/false} in those cases.
+ * @return {boolean}
+ */
+proto.file.DeleteDirResponse.prototype.getResult = function() {
+  return /** @type {boolean} */ (jspb.Message.getFieldWithDefault(this, 1, false));
+};
+
+
+/** @param {boolean} value */
+proto.file.DeleteDirResponse.prototype.setResult = function(value) {
+  jspb.Message.setProto3BooleanField(this, 1, value);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.file.RenameRequest = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.file.RenameRequest, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.file.RenameRequest.displayName = 'proto.file.RenameRequest';
+}
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.file.RenameRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.RenameRequest.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.file.RenameRequest} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.RenameRequest.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    path: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    newName: jspb.Message.getFieldWithDefault(msg, 2, ""),
+    oldName: jspb.Message.getFieldWithDefault(msg, 3, "")
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.file.RenameRequest}
+ */
+proto.file.RenameRequest.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.file.RenameRequest;
+  return proto.file.RenameRequest.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.file.RenameRequest} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.file.RenameRequest}
+ */
+proto.file.RenameRequest.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setPath(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setNewName(value);
+      break;
+    case 3:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setOldName(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.file.RenameRequest.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.file.RenameRequest.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.file.RenameRequest} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.RenameRequest.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getPath();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getNewName();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+  f = message.getOldName();
+  if (f.length > 0) {
+    writer.writeString(
+      3,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional string path = 1;
+ * @return {string}
+ */
+proto.file.RenameRequest.prototype.getPath = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/** @param {string} value */
+proto.file.RenameRequest.prototype.setPath = function(value) {
+  jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional string new_name = 2;
+ * @return {string}
+ */
+proto.file.RenameRequest.prototype.getNewName = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/** @param {string} value */
+proto.file.RenameRequest.prototype.setNewName = function(value) {
+  jspb.Message.setProto3StringField(this, 2, value);
+};
+
+
+/**
+ * optional string old_name = 3;
+ * @return {string}
+ */
+proto.file.RenameRequest.prototype.getOldName = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
+};
+
+
+/** @param {string} value */
+proto.file.RenameRequest.prototype.setOldName = function(value) {
+  jspb.Message.setProto3StringField(this, 3, value);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.file.RenameResponse = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.file.RenameResponse, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.file.RenameResponse.displayName = 'proto.file.RenameResponse';
+}
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.file.RenameResponse.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.RenameResponse.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.file.RenameResponse} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.RenameResponse.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    result: jspb.Message.getFieldWithDefault(msg, 1, false)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.file.RenameResponse}
+ */
+proto.file.RenameResponse.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.file.RenameResponse;
+  return proto.file.RenameResponse.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.file.RenameResponse} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.file.RenameResponse}
+ */
+proto.file.RenameResponse.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {boolean} */ (reader.readBool());
+      msg.setResult(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.file.RenameResponse.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.file.RenameResponse.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.file.RenameResponse} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.RenameResponse.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getResult();
+  if (f) {
+    writer.writeBool(
+      1,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional bool result = 1;
+ * Note that Boolean fields may be set to 0/1 when serialized from a Java server.
+ * You should avoid comparisons like {@code val === true/false} in those cases.
+ * @return {boolean}
+ */
+proto.file.RenameResponse.prototype.getResult = function() {
+  return /** @type {boolean} */ (jspb.Message.getFieldWithDefault(this, 1, false));
+};
+
+
+/** @param {boolean} value */
+proto.file.RenameResponse.prototype.setResult = function(value) {
+  jspb.Message.setProto3BooleanField(this, 1, value);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.file.TransferProgress = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.file.TransferProgress, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.file.TransferProgress.displayName = 'proto.file.TransferProgress';
+}
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.file.TransferProgress.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.TransferProgress.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.file.TransferProgress} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.TransferProgress.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    path: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    files: jspb.Message.getFieldWithDefault(msg, 2, 0),
+    totalfiles: jspb.Message.getFieldWithDefault(msg, 3, 0),
+    size: jspb.Message.getFieldWithDefault(msg, 4, 0),
+    totalsize: jspb.Message.getFieldWithDefault(msg, 5, 0),
+    skipped: jspb.Message.getFieldWithDefault(msg, 6, 0),
+    destination: jspb.Message.getFieldWithDefault(msg, 7, "")
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.file.TransferProgress}
+ */
+proto.file.TransferProgress.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.file.TransferProgress;
+  return proto.file.TransferProgress.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.file.TransferProgress} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.file.TransferProgress}
+ */
+proto.file.TransferProgress.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setPath(value);
+      break;
+    case 2:
+      var value = /** @type {number} */ (reader.readInt64());
+      msg.setFiles(value);
+      break;
+    case 3:
+      var value = /** @type {number} */ (reader.readInt64());
+      msg.setTotalfiles(value);
+      break;
+    case 4:
+      var value = /** @type {number} */ (reader.readInt64());
+      msg.setSize(value);
+      break;
+    case 5:
+      var value = /** @type {number} */ (reader.readInt64());
+      msg.setTotalsize(value);
+      break;
+    case 6:
+      var value = /** @type {number} */ (reader.readInt64());
+      msg.setSkipped(value);
+      break;
+    case 7:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setDestination(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.file.TransferProgress.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.file.TransferProgress.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.file.TransferProgress} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.TransferProgress.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getPath();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getFiles();
+  if (f !== 0) {
+    writer.writeInt64(
+      2,
+      f
+    );
+  }
+  f = message.getTotalfiles();
+  if (f !== 0) {
+    writer.writeInt64(
+      3,
+      f
+    );
+  }
+  f = message.getSize();
+  if (f !== 0) {
+    writer.writeInt64(
+      4,
+      f
+    );
+  }
+  f = message.getTotalsize();
+  if (f !== 0) {
+    writer.writeInt64(
+      5,
+      f
+    );
+  }
+  f = message.getSkipped();
+  if (f !== 0) {
+    writer.writeInt64(
+      6,
+      f
+    );
+  }
+  f = message.getDestination();
+  if (f.length > 0) {
+    writer.writeString(
+      7,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional string path = 1;
+ * @return {string}
+ */
+proto.file.TransferProgress.prototype.getPath = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/** @param {string} value */
+proto.file.TransferProgress.prototype.setPath = function(value) {
+  jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional int64 files = 2;
+ * @return {number}
+ */
+proto.file.TransferProgress.prototype.getFiles = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 2, 0));
+};
+
+
+/** @param {number} value */
+proto.file.TransferProgress.prototype.setFiles = function(value) {
+  jspb.Message.setProto3IntField(this, 2, value);
+};
+
+
+/**
+ * optional int64 totalFiles = 3;
+ * @return {number}
+ */
+proto.file.TransferProgress.prototype.getTotalfiles = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+};
+
+
+/** @param {number} value */
+proto.file.TransferProgress.prototype.setTotalfiles = function(value) {
+  jspb.Message.setProto3IntField(this, 3, value);
+};
+
+
+/**
+ * optional int64 size = 4;
+ * @return {number}
+ */
+proto.file.TransferProgress.prototype.getSize = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 4, 0));
+};
+
+
+/** @param {number} value */
+proto.file.TransferProgress.prototype.setSize = function(value) {
+  jspb.Message.setProto3IntField(this, 4, value);
+};
+
+
+/**
+ * optional int64 totalSize = 5;
+ * @return {number}
+ */
+proto.file.TransferProgress.prototype.getTotalsize = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 5, 0));
+};
+
+
+/** @param {number} value */
+proto.file.TransferProgress.prototype.setTotalsize = function(value) {
+  jspb.Message.setProto3IntField(this, 5, value);
+};
+
+
+/**
+ * optional int64 skipped = 6;
+ * @return {number}
+ */
+proto.file.TransferProgress.prototype.getSkipped = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 6, 0));
+};
+
+
+/** @param {number} value */
+proto.file.TransferProgress.prototype.setSkipped = function(value) {
+  jspb.Message.setProto3IntField(this, 6, value);
+};
+
+
+/**
+ * optional string destination = 7;
+ * @return {string}
+ */
+proto.file.TransferProgress.prototype.getDestination = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 7, ""));
+};
+
+
+/** @param {string} value */
+proto.file.TransferProgress.prototype.setDestination = function(value) {
+  jspb.Message.setProto3StringField(this, 7, value);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.file.CopyRequest = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.file.CopyRequest, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.file.CopyRequest.displayName = 'proto.file.CopyRequest';
+}
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.file.CopyRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.CopyRequest.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.file.CopyRequest} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.CopyRequest.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    source: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    destination: jspb.Message.getFieldWithDefault(msg, 2, ""),
+    conflict: jspb.Message.getFieldWithDefault(msg, 3, 0)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.file.CopyRequest}
+ */
+proto.file.CopyRequest.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.file.CopyRequest;
+  return proto.file.CopyRequest.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.file.CopyRequest} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.file.CopyRequest}
+ */
+proto.file.CopyRequest.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setSource(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setDestination(value);
+      break;
+    case 3:
+      var value = /** @type {!proto.file.ConflictPolicy} */ (reader.readEnum());
+      msg.setConflict(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.file.CopyRequest.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.file.CopyRequest.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.file.CopyRequest} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.CopyRequest.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getSource();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getDestination();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+  f = message.getConflict();
+  if (f !== 0.0) {
+    writer.writeEnum(
+      3,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional string source = 1;
+ * @return {string}
+ */
+proto.file.CopyRequest.prototype.getSource = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/** @param {string} value */
+proto.file.CopyRequest.prototype.setSource = function(value) {
+  jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional string destination = 2;
+ * @return {string}
+ */
+proto.file.CopyRequest.prototype.getDestination = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/** @param {string} value */
+proto.file.CopyRequest.prototype.setDestination = function(value) {
+  jspb.Message.setProto3StringField(this, 2, value);
+};
+
+
+/**
+ * optional ConflictPolicy conflict = 3;
+ * @return {!proto.file.ConflictPolicy}
+ */
+proto.file.CopyRequest.prototype.getConflict = function() {
+  return /** @type {!proto.file.ConflictPolicy} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+};
+
+
+/** @param {!proto.file.ConflictPolicy} value */
+proto.file.CopyRequest.prototype.setConflict = function(value) {
+  jspb.Message.setProto3EnumField(this, 3, value);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.file.CopyResponse = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.file.CopyResponse, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.file.CopyResponse.displayName = 'proto.file.CopyResponse';
+}
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.file.CopyResponse.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.CopyResponse.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.file.CopyResponse} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.CopyResponse.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    progress: (f = msg.getProgress()) && proto.file.TransferProgress.toObject(includeInstance, f)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.file.CopyResponse}
+ */
+proto.file.CopyResponse.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.file.CopyResponse;
+  return proto.file.CopyResponse.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.file.CopyResponse} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.file.CopyResponse}
+ */
+proto.file.CopyResponse.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = new proto.file.TransferProgress;
+      reader.readMessage(value,proto.file.TransferProgress.deserializeBinaryFromReader);
+      msg.setProgress(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.file.CopyResponse.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.file.CopyResponse.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.file.CopyResponse} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.CopyResponse.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getProgress();
+  if (f != null) {
+    writer.writeMessage(
+      1,
+      f,
+      proto.file.TransferProgress.serializeBinaryToWriter
+    );
+  }
+};
+
+
+/**
+ * optional TransferProgress progress = 1;
+ * @return {?proto.file.TransferProgress}
+ */
+proto.file.CopyResponse.prototype.getProgress = function() {
+  return /** @type{?proto.file.TransferProgress} */ (
+    jspb.Message.getWrapperField(this, proto.file.TransferProgress, 1));
+};
+
+
+/** @param {?proto.file.TransferProgress|undefined} value */
+proto.file.CopyResponse.prototype.setProgress = function(value) {
+  jspb.Message.setWrapperField(this, 1, value);
+};
+
+
+proto.file.CopyResponse.prototype.clearProgress = function() {
+  this.setProgress(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {!boolean}
+ */
+proto.file.CopyResponse.prototype.hasProgress = function() {
+  return jspb.Message.getField(this, 1) != null;
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.file.MoveRequest = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.file.MoveRequest, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.file.MoveRequest.displayName = 'proto.file.MoveRequest';
+}
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.file.MoveRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.MoveRequest.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.file.MoveRequest} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.MoveRequest.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    source: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    destination: jspb.Message.getFieldWithDefault(msg, 2, ""),
+    conflict: jspb.Message.getFieldWithDefault(msg, 3, 0)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.file.MoveRequest}
+ */
+proto.file.MoveRequest.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.file.MoveRequest;
+  return proto.file.MoveRequest.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.file.MoveRequest} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.file.MoveRequest}
+ */
+proto.file.MoveRequest.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setSource(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setDestination(value);
+      break;
+    case 3:
+      var value = /** @type {!proto.file.ConflictPolicy} */ (reader.readEnum());
+      msg.setConflict(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.file.MoveRequest.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.file.MoveRequest.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.file.MoveRequest} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.MoveRequest.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getSource();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getDestination();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+  f = message.getConflict();
+  if (f !== 0.0) {
+    writer.writeEnum(
+      3,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional string source = 1;
+ * @return {string}
+ */
+proto.file.MoveRequest.prototype.getSource = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/** @param {string} value */
+proto.file.MoveRequest.prototype.setSource = function(value) {
+  jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional string destination = 2;
+ * @return {string}
+ */
+proto.file.MoveRequest.prototype.getDestination = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/** @param {string} value */
+proto.file.MoveRequest.prototype.setDestination = function(value) {
+  jspb.Message.setProto3StringField(this, 2, value);
+};
+
+
+/**
+ * optional ConflictPolicy conflict = 3;
+ * @return {!proto.file.ConflictPolicy}
+ */
+proto.file.MoveRequest.prototype.getConflict = function() {
+  return /** @type {!proto.file.ConflictPolicy} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+};
+
+
+/** @param {!proto.file.ConflictPolicy} value */
+proto.file.MoveRequest.prototype.setConflict = function(value) {
+  jspb.Message.setProto3EnumField(this, 3, value);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.file.MoveResponse = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.file.MoveResponse, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.file.MoveResponse.displayName = 'proto.file.MoveResponse';
+}
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.file.MoveResponse.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.MoveResponse.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.file.MoveResponse} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.MoveResponse.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    progress: (f = msg.getProgress()) && proto.file.TransferProgress.toObject(includeInstance, f)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.file.MoveResponse}
+ */
+proto.file.MoveResponse.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.file.MoveResponse;
+  return proto.file.MoveResponse.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.file.MoveResponse} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.file.MoveResponse}
+ */
+proto.file.MoveResponse.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = new proto.file.TransferProgress;
+      reader.readMessage(value,proto.file.TransferProgress.deserializeBinaryFromReader);
+      msg.setProgress(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.file.MoveResponse.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.file.MoveResponse.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.file.MoveResponse} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.MoveResponse.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getProgress();
+  if (f != null) {
+    writer.writeMessage(
+      1,
+      f,
+      proto.file.TransferProgress.serializeBinaryToWriter
+    );
+  }
+};
+
+
+/**
+ * optional TransferProgress progress = 1;
+ * @return {?proto.file.TransferProgress}
+ */
+proto.file.MoveResponse.prototype.getProgress = function() {
+  return /** @type{?proto.file.TransferProgress} */ (
+    jspb.Message.getWrapperField(this, proto.file.TransferProgress, 1));
+};
+
+
+/** @param {?proto.file.TransferProgress|undefined} value */
+proto.file.MoveResponse.prototype.setProgress = function(value) {
+  jspb.Message.setWrapperField(this, 1, value);
+};
+
+
+proto.file.MoveResponse.prototype.clearProgress = function() {
+  this.setProgress(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {!boolean}
+ */
+proto.file.MoveResponse.prototype.hasProgress = function() {
+  return jspb.Message.getField(this, 1) != null;
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.file.FileChange = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.file.FileChange, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.file.FileChange.displayName = 'proto.file.FileChange';
+}
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.file.FileChange.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.FileChange.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.file.FileChange} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.FileChange.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    type: jspb.Message.getFieldWithDefault(msg, 1, 0),
+    path: jspb.Message.getFieldWithDefault(msg, 2, ""),
+    oldpath: jspb.Message.getFieldWithDefault(msg, 3, ""),
+    isdir: jspb.Message.getFieldWithDefault(msg, 4, false)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.file.FileChange}
+ */
+proto.file.FileChange.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.file.FileChange;
+  return proto.file.FileChange.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.file.FileChange} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.file.FileChange}
+ */
+proto.file.FileChange.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {!proto.file.FileChangeType} */ (reader.readEnum());
+      msg.setType(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setPath(value);
+      break;
+    case 3:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setOldpath(value);
+      break;
+    case 4:
+      var value = /** @type {boolean} */ (reader.readBool());
+      msg.setIsdir(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.file.FileChange.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.file.FileChange.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.file.FileChange} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.FileChange.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getType();
+  if (f !== 0.0) {
+    writer.writeEnum(
+      1,
+      f
+    );
+  }
+  f = message.getPath();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+  f = message.getOldpath();
+  if (f.length > 0) {
+    writer.writeString(
+      3,
+      f
+    );
+  }
+  f = message.getIsdir();
+  if (f) {
+    writer.writeBool(
+      4,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional FileChangeType type = 1;
+ * @return {!proto.file.FileChangeType}
+ */
+proto.file.FileChange.prototype.getType = function() {
+  return /** @type {!proto.file.FileChangeType} */ (jspb.Message.getFieldWithDefault(this, 1, 0));
+};
+
+
+/** @param {!proto.file.FileChangeType} value */
+proto.file.FileChange.prototype.setType = function(value) {
+  jspb.Message.setProto3EnumField(this, 1, value);
+};
+
+
+/**
+ * optional string path = 2;
+ * @return {string}
+ */
+proto.file.FileChange.prototype.getPath = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/** @param {string} value */
+proto.file.FileChange.prototype.setPath = function(value) {
+  jspb.Message.setProto3StringField(this, 2, value);
+};
+
+
+/**
+ * optional string oldPath = 3;
+ * @return {string}
+ */
+proto.file.FileChange.prototype.getOldpath = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
+};
+
+
+/** @param {string} value */
+proto.file.FileChange.prototype.setOldpath = function(value) {
+  jspb.Message.setProto3StringField(this, 3, value);
+};
+
+
+/**
+ * optional bool isDir = 4;
+ * Note that Boolean fields may be set to 0/1 when serialized from a Java server.
+ * You should avoid comparisons like {@code val === true/false} in those cases.
+ * @return {boolean}
+ */
+proto.file.FileChange.prototype.getIsdir = function() {
+  return /** @type {boolean} */ (jspb.Message.getFieldWithDefault(this, 4, false));
+};
+
+
+/** @param {boolean} value */
+proto.file.FileChange.prototype.setIsdir = function(value) {
+  jspb.Message.setProto3BooleanField(this, 4, value);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.file.WatchDirRequest = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.file.WatchDirRequest, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.file.WatchDirRequest.displayName = 'proto.file.WatchDirRequest';
+}
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.file.WatchDirRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.WatchDirRequest.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.file.WatchDirRequest} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.WatchDirRequest.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    path: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    recursive: jspb.Message.getFieldWithDefault(msg, 2, false),
+    delay: jspb.Message.getFieldWithDefault(msg, 3, 0)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.file.WatchDirRequest}
+ */
+proto.file.WatchDirRequest.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.file.WatchDirRequest;
+  return proto.file.WatchDirRequest.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.file.WatchDirRequest} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.file.WatchDirRequest}
+ */
+proto.file.WatchDirRequest.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setPath(value);
+      break;
+    case 2:
+      var value = /** @type {boolean} */ (reader.readBool());
+      msg.setRecursive(value);
+      break;
+    case 3:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setDelay(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.file.WatchDirRequest.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.file.WatchDirRequest.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.file.WatchDirRequest} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.WatchDirRequest.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getPath();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getRecursive();
+  if (f) {
+    writer.writeBool(
+      2,
+      f
+    );
+  }
+  f = message.getDelay();
+  if (f !== 0) {
+    writer.writeInt32(
+      3,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional string path = 1;
+ * @return {string}
+ */
+proto.file.WatchDirRequest.prototype.getPath = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/** @param {string} value */
+proto.file.WatchDirRequest.prototype.setPath = function(value) {
+  jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional bool recursive = 2;
+ * Note that Boolean fields may be set to 0/1 when serialized from a Java server.
+ * You should avoid comparisons like {@code val === true/false} in those cases.
+ * @return {boolean}
+ */
+proto.file.WatchDirRequest.prototype.getRecursive = function() {
+  return /** @type {boolean} */ (jspb.Message.getFieldWithDefault(this, 2, false));
+};
+
+
+/** @param {boolean} value */
+proto.file.WatchDirRequest.prototype.setRecursive = function(value) {
+  jspb.Message.setProto3BooleanField(this, 2, value);
+};
+
+
+/**
+ * optional int32 delay = 3;
+ * @return {number}
+ */
+proto.file.WatchDirRequest.prototype.getDelay = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+};
+
+
+/** @param {number} value */
+proto.file.WatchDirRequest.prototype.setDelay = function(value) {
+  jspb.Message.setProto3IntField(this, 3, value);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.file.WatchDirResponse = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.file.WatchDirResponse.repeatedFields_, null);
+};
+goog.inherits(proto.file.WatchDirResponse, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.file.WatchDirResponse.displayName = 'proto.file.WatchDirResponse';
+}
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.file.WatchDirResponse.repeatedFields_ = [1];
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.file.WatchDirResponse.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.WatchDirResponse.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.file.WatchDirResponse} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.WatchDirResponse.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    changesList: jspb.Message.toObjectList(msg.getChangesList(),
+    proto.file.FileChange.toObject, includeInstance)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.file.WatchDirResponse}
+ */
+proto.file.WatchDirResponse.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.file.WatchDirResponse;
+  return proto.file.WatchDirResponse.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.file.WatchDirResponse} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.file.WatchDirResponse}
+ */
+proto.file.WatchDirResponse.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = new proto.file.FileChange;
+      reader.readMessage(value,proto.file.FileChange.deserializeBinaryFromReader);
+      msg.addChanges(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.file.WatchDirResponse.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.file.WatchDirResponse.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.file.WatchDirResponse} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.WatchDirResponse.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getChangesList();
+  if (f.length > 0) {
+    writer.writeRepeatedMessage(
+      1,
+      f,
+      proto.file.FileChange.serializeBinaryToWriter
+    );
+  }
+};
+
+
+/**
+ * repeated FileChange changes = 1;
+ * @return {!Array<!proto.file.FileChange>}
+ */
+proto.file.WatchDirResponse.prototype.getChangesList = function() {
+  return /** @type{!Array<!proto.file.FileChange>} */ (
+    jspb.Message.getRepeatedWrapperField(this, proto.file.FileChange, 1));
+};
+
+
+/** @param {!Array<!proto.file.FileChange>} value */
+proto.file.WatchDirResponse.prototype.setChangesList = function(value) {
+  jspb.Message.setRepeatedWrapperField(this, 1, value);
+};
+
+
+/**
+ * @param {!proto.file.FileChange=} opt_value
+ * @param {number=} opt_index
+ * @return {!proto.file.FileChange}
+ */
+proto.file.WatchDirResponse.prototype.addChanges = function(opt_value, opt_index) {
+  return jspb.Message.addToRepeatedWrapperField(this, 1, opt_value, proto.file.FileChange, opt_index);
+};
+
+
+proto.file.WatchDirResponse.prototype.clearChangesList = function() {
+  this.setChangesList([]);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.file.SearchRequest = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.file.SearchRequest, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.file.SearchRequest.displayName = 'proto.file.SearchRequest';
+}
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.file.SearchRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.SearchRequest.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.file.SearchRequest} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.SearchRequest.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    path: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    name: jspb.Message.getFieldWithDefault(msg, 2, ""),
+    regex: jspb.Message.getFieldWithDefault(msg, 3, ""),
+    mime: jspb.Message.getFieldWithDefault(msg, 4, ""),
+    minsize: jspb.Message.getFieldWithDefault(msg, 5, 0),
+    maxsize: jspb.Message.getFieldWithDefault(msg, 6, 0),
+    modifiedafter: jspb.Message.getFieldWithDefault(msg, 7, 0),
+    modifiedbefore: jspb.Message.getFieldWithDefault(msg, 8, 0),
+    content: jspb.Message.getFieldWithDefault(msg, 9, ""),
+    ignorecase: jspb.Message.getFieldWithDefault(msg, 10, false),
+    limit: jspb.Message.getFieldWithDefault(msg, 11, 0)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.file.SearchRequest}
+ */
+proto.file.SearchRequest.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.file.SearchRequest;
+  return proto.file.SearchRequest.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.file.SearchRequest} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.file.SearchRequest}
+ */
+proto.file.SearchRequest.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setPath(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setName(value);
+      break;
+    case 3:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setRegex(value);
+      break;
+    case 4:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setMime(value);
+      break;
+    case 5:
+      var value = /** @type {number} */ (reader.readInt64());
+      msg.setMinsize(value);
+      break;
+    case 6:
+      var value = /** @type {number} */ (reader.readInt64());
+      msg.setMaxsize(value);
+      break;
+    case 7:
+      var value = /** @type {number} */ (reader.readInt64());
+      msg.setModifiedafter(value);
+      break;
+    case 8:
+      var value = /** @type {number} */ (reader.readInt64());
+      msg.setModifiedbefore(value);
+      break;
+    case 9:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setContent(value);
+      break;
+    case 10:
+      var value = /** @type {boolean} */ (reader.readBool());
+      msg.setIgnorecase(value);
+      break;
+    case 11:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setLimit(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.file.SearchRequest.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.file.SearchRequest.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.file.SearchRequest} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.SearchRequest.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getPath();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getName();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+  f = message.getRegex();
+  if (f.length > 0) {
+    writer.writeString(
+      3,
+      f
+    );
+  }
+  f = message.getMime();
+  if (f.length > 0) {
+    writer.writeString(
+      4,
+      f
+    );
+  }
+  f = message.getMinsize();
+  if (f !== 0) {
+    writer.writeInt64(
+      5,
+      f
+    );
+  }
+  f = message.getMaxsize();
+  if (f !== 0) {
+    writer.writeInt64(
+      6,
+      f
+    );
+  }
+  f = message.getModifiedafter();
+  if (f !== 0) {
+    writer.writeInt64(
+      7,
+      f
+    );
+  }
+  f = message.getModifiedbefore();
+  if (f !== 0) {
+    writer.writeInt64(
+      8,
+      f
+    );
+  }
+  f = message.getContent();
+  if (f.length > 0) {
+    writer.writeString(
+      9,
+      f
+    );
+  }
+  f = message.getIgnorecase();
+  if (f) {
+    writer.writeBool(
+      10,
+      f
+    );
+  }
+  f = message.getLimit();
+  if (f !== 0) {
+    writer.writeInt32(
+      11,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional string path = 1;
+ * @return {string}
+ */
+proto.file.SearchRequest.prototype.getPath = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/** @param {string} value */
+proto.file.SearchRequest.prototype.setPath = function(value) {
+  jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional string name = 2;
+ * @return {string}
+ */
+proto.file.SearchRequest.prototype.getName = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/** @param {string} value */
+proto.file.SearchRequest.prototype.setName = function(value) {
+  jspb.Message.setProto3StringField(this, 2, value);
+};
+
+
+/**
+ * optional string regex = 3;
+ * @return {string}
+ */
+proto.file.SearchRequest.prototype.getRegex = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
+};
+
+
+/** @param {string} value */
+proto.file.SearchRequest.prototype.setRegex = function(value) {
+  jspb.Message.setProto3StringField(this, 3, value);
+};
+
+
+/**
+ * optional string mime = 4;
+ * @return {string}
+ */
+proto.file.SearchRequest.prototype.getMime = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 4, ""));
+};
+
+
+/** @param {string} value */
+proto.file.SearchRequest.prototype.setMime = function(value) {
+  jspb.Message.setProto3StringField(this, 4, value);
+};
+
+
+/**
+ * optional int64 minSize = 5;
+ * @return {number}
+ */
+proto.file.SearchRequest.prototype.getMinsize = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 5, 0));
+};
+
+
+/** @param {number} value */
+proto.file.SearchRequest.prototype.setMinsize = function(value) {
+  jspb.Message.setProto3IntField(this, 5, value);
+};
+
+
+/**
+ * optional int64 maxSize = 6;
+ * @return {number}
+ */
+proto.file.SearchRequest.prototype.getMaxsize = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 6, 0));
+};
+
+
+/** @param {number} value */
+proto.file.SearchRequest.prototype.setMaxsize = function(value) {
+  jspb.Message.setProto3IntField(this, 6, value);
+};
+
+
+/**
+ * optional int64 modifiedAfter = 7;
+ * @return {number}
+ */
+proto.file.SearchRequest.prototype.getModifiedafter = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 7, 0));
+};
+
+
+/** @param {number} value */
+proto.file.SearchRequest.prototype.setModifiedafter = function(value) {
+  jspb.Message.setProto3IntField(this, 7, value);
+};
+
+
+/**
+ * optional int64 modifiedBefore = 8;
+ * @return {number}
+ */
+proto.file.SearchRequest.prototype.getModifiedbefore = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 8, 0));
+};
+
+
+/** @param {number} value */
+proto.file.SearchRequest.prototype.setModifiedbefore = function(value) {
+  jspb.Message.setProto3IntField(this, 8, value);
+};
+
+
+/**
+ * optional string content = 9;
+ * @return {string}
+ */
+proto.file.SearchRequest.prototype.getContent = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 9, ""));
+};
+
+
+/** @param {string} value */
+proto.file.SearchRequest.prototype.setContent = function(value) {
+  jspb.Message.setProto3StringField(this, 9, value);
+};
+
+
+/**
+ * optional bool ignoreCase = 10;
+ * Note that Boolean fields may be set to 0/1 when serialized from a Java server.
+ * You should avoid comparisons like {@code val === true/false} in those cases.
+ * @return {boolean}
+ */
+proto.file.SearchRequest.prototype.getIgnorecase = function() {
+  return /** @type {boolean} */ (jspb.Message.getFieldWithDefault(this, 10, false));
+};
+
+
+/** @param {boolean} value */
+proto.file.SearchRequest.prototype.setIgnorecase = function(value) {
+  jspb.Message.setProto3BooleanField(this, 10, value);
+};
+
+
+/**
+ * optional int32 limit = 11;
+ * @return {number}
+ */
+proto.file.SearchRequest.prototype.getLimit = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 11, 0));
+};
+
+
+/** @param {number} value */
+proto.file.SearchRequest.prototype.setLimit = function(value) {
+  jspb.Message.setProto3IntField(this, 11, value);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.file.SearchResponse = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.file.SearchResponse, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.file.SearchResponse.displayName = 'proto.file.SearchResponse';
+}
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.file.SearchResponse.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.SearchResponse.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.file.SearchResponse} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.SearchResponse.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    path: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    size: jspb.Message.getFieldWithDefault(msg, 2, 0),
+    modtime: jspb.Message.getFieldWithDefault(msg, 3, 0),
+    isdir: jspb.Message.getFieldWithDefault(msg, 4, false),
+    mime: jspb.Message.getFieldWithDefault(msg, 5, ""),
+    line: jspb.Message.getFieldWithDefault(msg, 6, 0),
+    match: jspb.Message.getFieldWithDefault(msg, 7, "")
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.file.SearchResponse}
+ */
+proto.file.SearchResponse.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.file.SearchResponse;
+  return proto.file.SearchResponse.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.file.SearchResponse} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.file.SearchResponse}
+ */
+proto.file.SearchResponse.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setPath(value);
+      break;
+    case 2:
+      var value = /** @type {number} */ (reader.readInt64());
+      msg.setSize(value);
+      break;
+    case 3:
+      var value = /** @type {number} */ (reader.readInt64());
+      msg.setModtime(value);
+      break;
+    case 4:
+      var value = /** @type {boolean} */ (reader.readBool());
+      msg.setIsdir(value);
+      break;
+    case 5:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setMime(value);
+      break;
+    case 6:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setLine(value);
+      break;
+    case 7:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setMatch(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.file.SearchResponse.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.file.SearchResponse.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.file.SearchResponse} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.SearchResponse.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getPath();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getSize();
+  if (f !== 0) {
+    writer.writeInt64(
+      2,
+      f
+    );
+  }
+  f = message.getModtime();
+  if (f !== 0) {
+    writer.writeInt64(
+      3,
+      f
+    );
+  }
+  f = message.getIsdir();
+  if (f) {
+    writer.writeBool(
+      4,
+      f
+    );
+  }
+  f = message.getMime();
+  if (f.length > 0) {
+    writer.writeString(
+      5,
+      f
+    );
+  }
+  f = message.getLine();
+  if (f !== 0) {
+    writer.writeInt32(
+      6,
+      f
+    );
+  }
+  f = message.getMatch();
+  if (f.length > 0) {
+    writer.writeString(
+      7,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional string path = 1;
+ * @return {string}
+ */
+proto.file.SearchResponse.prototype.getPath = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/** @param {string} value */
+proto.file.SearchResponse.prototype.setPath = function(value) {
+  jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional int64 size = 2;
+ * @return {number}
+ */
+proto.file.SearchResponse.prototype.getSize = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 2, 0));
+};
+
+
+/** @param {number} value */
+proto.file.SearchResponse.prototype.setSize = function(value) {
+  jspb.Message.setProto3IntField(this, 2, value);
+};
+
+
+/**
+ * optional int64 modTime = 3;
+ * @return {number}
+ */
+proto.file.SearchResponse.prototype.getModtime = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+};
+
+
+/** @param {number} value */
+proto.file.SearchResponse.prototype.setModtime = function(value) {
+  jspb.Message.setProto3IntField(this, 3, value);
+};
+
+
+/**
+ * optional bool isDir = 4;
+ * Note that Boolean fields may be set to 0/1 when serialized from a Java server.
+ * You should avoid comparisons like {@code val === true/false} in those cases.
+ * @return {boolean}
+ */
+proto.file.SearchResponse.prototype.getIsdir = function() {
+  return /** @type {boolean} */ (jspb.Message.getFieldWithDefault(this, 4, false));
+};
+
+
+/** @param {boolean} value */
+proto.file.SearchResponse.prototype.setIsdir = function(value) {
+  jspb.Message.setProto3BooleanField(this, 4, value);
+};
+
+
+/**
+ * optional string mime = 5;
+ * @return {string}
+ */
+proto.file.SearchResponse.prototype.getMime = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 5, ""));
+};
+
+
+/** @param {string} value */
+proto.file.SearchResponse.prototype.setMime = function(value) {
+  jspb.Message.setProto3StringField(this, 5, value);
+};
+
+
+/**
+ * optional int32 line = 6;
+ * @return {number}
+ */
+proto.file.SearchResponse.prototype.getLine = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 6, 0));
+};
+
+
+/** @param {number} value */
+proto.file.SearchResponse.prototype.setLine = function(value) {
+  jspb.Message.setProto3IntField(this, 6, value);
+};
+
+
+/**
+ * optional string match = 7;
+ * @return {string}
+ */
+proto.file.SearchResponse.prototype.getMatch = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 7, ""));
+};
+
+
+/** @param {string} value */
+proto.file.SearchResponse.prototype.setMatch = function(value) {
+  jspb.Message.setProto3StringField(this, 7, value);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.file.GetFileInfoRequest = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.file.GetFileInfoRequest, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.file.GetFileInfoRequest.displayName = 'proto.file.GetFileInfoRequest';
+}
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.file.GetFileInfoRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.GetFileInfoRequest.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.file.GetFileInfoRequest} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.GetFileInfoRequest.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    path: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    thumnailwidth: jspb.Message.getFieldWithDefault(msg, 2, 0),
+    thumnailheight: jspb.Message.getFieldWithDefault(msg, 3, 0)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.file.GetFileInfoRequest}
+ */
+proto.file.GetFileInfoRequest.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.file.GetFileInfoRequest;
+  return proto.file.GetFileInfoRequest.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.file.GetFileInfoRequest} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.file.GetFileInfoRequest}
+ */
+proto.file.GetFileInfoRequest.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setPath(value);
+      break;
+    case 2:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setThumnailwidth(value);
+      break;
+    case 3:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setThumnailheight(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.file.GetFileInfoRequest.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.file.GetFileInfoRequest.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.file.GetFileInfoRequest} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.GetFileInfoRequest.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getPath();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getThumnailwidth();
+  if (f !== 0) {
+    writer.writeInt32(
+      2,
+      f
+    );
+  }
+  f = message.getThumnailheight();
+  if (f !== 0) {
+    writer.writeInt32(
+      3,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional string path = 1;
+ * @return {string}
+ */
+proto.file.GetFileInfoRequest.prototype.getPath = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/** @param {string} value */
+proto.file.GetFileInfoRequest.prototype.setPath = function(value) {
+  jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional int32 thumnailWidth = 2;
+ * @return {number}
+ */
+proto.file.GetFileInfoRequest.prototype.getThumnailwidth = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 2, 0));
+};
+
+
+/** @param {number} value */
+proto.file.GetFileInfoRequest.prototype.setThumnailwidth = function(value) {
+  jspb.Message.setProto3IntField(this, 2, value);
+};
+
+
+/**
+ * optional int32 thumnailHeight = 3;
+ * @return {number}
+ */
+proto.file.GetFileInfoRequest.prototype.getThumnailheight = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+};
+
+
+/** @param {number} value */
+proto.file.GetFileInfoRequest.prototype.setThumnailheight = function(value) {
+  jspb.Message.setProto3IntField(this, 3, value);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.file.GetFileInfoResponse = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.file.GetFileInfoResponse, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.file.GetFileInfoResponse.displayName = 'proto.file.GetFileInfoResponse';
+}
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.file.GetFileInfoResponse.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.GetFileInfoResponse.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.file.GetFileInfoResponse} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.GetFileInfoResponse.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    data: jspb.Message.getFieldWithDefault(msg, 1, "")
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.file.GetFileInfoResponse}
+ */
+proto.file.GetFileInfoResponse.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.file.GetFileInfoResponse;
+  return proto.file.GetFileInfoResponse.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.file.GetFileInfoResponse} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.file.GetFileInfoResponse}
+ */
+proto.file.GetFileInfoResponse.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setData(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.file.GetFileInfoResponse.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.file.GetFileInfoResponse.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.file.GetFileInfoResponse} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.GetFileInfoResponse.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getData();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional string data = 1;
+ * @return {string}
+ */
+proto.file.GetFileInfoResponse.prototype.getData = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/** @param {string} value */
+proto.file.GetFileInfoResponse.prototype.setData = function(value) {
+  jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.file.ReadFileRequest = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.file.ReadFileRequest, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.file.ReadFileRequest.displayName = 'proto.file.ReadFileRequest';
+}
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.file.ReadFileRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.ReadFileRequest.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.file.ReadFileRequest} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.ReadFileRequest.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    path: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    offset: jspb.Message.getFieldWithDefault(msg, 2, 0),
+    length: jspb.Message.getFieldWithDefault(msg, 3, 0),
+    chunksize: jspb.Message.getFieldWithDefault(msg, 4, 0),
+    checksum: jspb.Message.getFieldWithDefault(msg, 5, false)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.file.ReadFileRequest}
+ */
+proto.file.ReadFileRequest.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.file.ReadFileRequest;
+  return proto.file.ReadFileRequest.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.file.ReadFileRequest} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.file.ReadFileRequest}
+ */
+proto.file.ReadFileRequest.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setPath(value);
+      break;
+    case 2:
+      var value = /** @type {number} */ (reader.readInt64());
+      msg.setOffset(value);
+      break;
+    case 3:
+      var value = /** @type {number} */ (reader.readInt64());
+      msg.setLength(value);
+      break;
+    case 4:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setChunksize(value);
+      break;
+    case 5:
+      var value = /** @type {boolean} */ (reader.readBool());
+      msg.setChecksum(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.file.ReadFileRequest.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.file.ReadFileRequest.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.file.ReadFileRequest} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.ReadFileRequest.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getPath();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getOffset();
+  if (f !== 0) {
+    writer.writeInt64(
+      2,
+      f
+    );
+  }
+  f = message.getLength();
+  if (f !== 0) {
+    writer.writeInt64(
+      3,
+      f
+    );
+  }
+  f = message.getChunksize();
+  if (f !== 0) {
+    writer.writeInt32(
+      4,
+      f
+    );
+  }
+  f = message.getChecksum();
+  if (f) {
+    writer.writeBool(
+      5,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional string path = 1;
+ * @return {string}
+ */
+proto.file.ReadFileRequest.prototype.getPath = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/** @param {string} value */
+proto.file.ReadFileRequest.prototype.setPath = function(value) {
+  jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional int64 offset = 2;
+ * @return {number}
+ */
+proto.file.ReadFileRequest.prototype.getOffset = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 2, 0));
+};
+
+
+/** @param {number} value */
+proto.file.ReadFileRequest.prototype.setOffset = function(value) {
+  jspb.Message.setProto3IntField(this, 2, value);
+};
+
+
+/**
+ * optional int64 length = 3;
+ * @return {number}
+ */
+proto.file.ReadFileRequest.prototype.getLength = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+};
+
+
+/** @param {number} value */
+proto.file.ReadFileRequest.prototype.setLength = function(value) {
+  jspb.Message.setProto3IntField(this, 3, value);
+};
+
+
+/**
+ * optional int32 chunkSize = 4;
+ * @return {number}
+ */
+proto.file.ReadFileRequest.prototype.getChunksize = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 4, 0));
+};
+
+
+/** @param {number} value */
+proto.file.ReadFileRequest.prototype.setChunksize = function(value) {
+  jspb.Message.setProto3IntField(this, 4, value);
+};
+
+
+/**
+ * optional bool checksum = 5;
+ * Note that Boolean fields may be set to 0/1 when serialized from a Java server.
+ * You should avoid comparisons like {@code val === true/false} in those cases.
+ * @return {boolean}
+ */
+proto.file.ReadFileRequest.prototype.getChecksum = function() {
+  return /** @type {boolean} */ (jspb.Message.getFieldWithDefault(this, 5, false));
+};
+
+
+/** @param {boolean} value */
+proto.file.ReadFileRequest.prototype.setChecksum = function(value) {
+  jspb.Message.setProto3BooleanField(this, 5, value);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.file.ReadFileResponse = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.file.ReadFileResponse, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.file.ReadFileResponse.displayName = 'proto.file.ReadFileResponse';
+}
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.file.ReadFileResponse.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.ReadFileResponse.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.file.ReadFileResponse} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.ReadFileResponse.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    data: msg.getData_asB64(),
+    size: jspb.Message.getFieldWithDefault(msg, 2, 0),
+    modtimenano: jspb.Message.getFieldWithDefault(msg, 3, 0),
+    sha256: msg.getSha256_asB64()
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.file.ReadFileResponse}
+ */
+proto.file.ReadFileResponse.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.file.ReadFileResponse;
+  return proto.file.ReadFileResponse.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.file.ReadFileResponse} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.file.ReadFileResponse}
+ */
+proto.file.ReadFileResponse.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {!Uint8Array} */ (reader.readBytes());
+      msg.setData(value);
+      break;
+    case 2:
+      var value = /** @type {number} */ (reader.readInt64());
+      msg.setSize(value);
+      break;
+    case 3:
+      var value = /** @type {number} */ (reader.readInt64());
+      msg.setModtimenano(value);
+      break;
+    case 4:
+      var value = /** @type {!Uint8Array} */ (reader.readBytes());
+      msg.setSha256(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.file.ReadFileResponse.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.file.ReadFileResponse.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.file.ReadFileResponse} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.ReadFileResponse.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getData_asU8();
+  if (f.length > 0) {
+    writer.writeBytes(
+      1,
+      f
+    );
+  }
+  f = message.getSize();
+  if (f !== 0) {
+    writer.writeInt64(
+      2,
+      f
+    );
+  }
+  f = message.getModtimenano();
+  if (f !== 0) {
+    writer.writeInt64(
+      3,
+      f
+    );
+  }
+  f = message.getSha256_asU8();
+  if (f.length > 0) {
+    writer.writeBytes(
+      4,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional bytes data = 1;
+ * @return {string}
+ */
+proto.file.ReadFileResponse.prototype.getData = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/**
+ * optional bytes data = 1;
+ * This is a type-conversion wrapper around `getData()`
+ * @return {string}
+ */
+proto.file.ReadFileResponse.prototype.getData_asB64 = function() {
+  return /** @type {string} */ (jspb.Message.bytesAsB64(
+      this.getData()));
+};
+
+
+/**
+ * optional bytes data = 1;
+ * Note that Uint8Array is not supported on all browsers.
+ * @see http://caniuse.com/Uint8Array
+ * This is a type-conversion wrapper around `getData()`
+ * @return {!Uint8Array}
+ */
+proto.file.ReadFileResponse.prototype.getData_asU8 = function() {
+  return /** @type {!Uint8Array} */ (jspb.Message.bytesAsU8(
+      this.getData()));
+};
+
+
+/** @param {!(string|Uint8Array)} value */
+proto.file.ReadFileResponse.prototype.setData = function(value) {
+  jspb.Message.setProto3BytesField(this, 1, value);
+};
+
+
+/**
+ * optional int64 size = 2;
+ * @return {number}
+ */
+proto.file.ReadFileResponse.prototype.getSize = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 2, 0));
+};
+
+
+/** @param {number} value */
+proto.file.ReadFileResponse.prototype.setSize = function(value) {
+  jspb.Message.setProto3IntField(this, 2, value);
+};
+
+
+/**
+ * optional int64 modTimeNano = 3;
+ * @return {number}
+ */
+proto.file.ReadFileResponse.prototype.getModtimenano = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+};
+
+
+/** @param {number} value */
+proto.file.ReadFileResponse.prototype.setModtimenano = function(value) {
+  jspb.Message.setProto3IntField(this, 3, value);
+};
+
+
+/**
+ * optional bytes sha256 = 4;
+ * @return {string}
+ */
+proto.file.ReadFileResponse.prototype.getSha256 = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 4, ""));
+};
+
+
+/**
+ * optional bytes sha256 = 4;
+ * This is a type-conversion wrapper around `getSha256()`
+ * @return {string}
+ */
+proto.file.ReadFileResponse.prototype.getSha256_asB64 = function() {
+  return /** @type {string} */ (jspb.Message.bytesAsB64(
+      this.getSha256()));
+};
+
+
+/**
+ * optional bytes sha256 = 4;
+ * Note that Uint8Array is not supported on all browsers.
+ * @see http://caniuse.com/Uint8Array
+ * This is a type-conversion wrapper around `getSha256()`
+ * @return {!Uint8Array}
+ */
+proto.file.ReadFileResponse.prototype.getSha256_asU8 = function() {
+  return /** @type {!Uint8Array} */ (jspb.Message.bytesAsU8(
+      this.getSha256()));
+};
+
+
+/** @param {!(string|Uint8Array)} value */
+proto.file.ReadFileResponse.prototype.setSha256 = function(value) {
+  jspb.Message.setProto3BytesField(this, 4, value);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.file.SaveFileRequest = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, proto.file.SaveFileRequest.oneofGroups_);
+};
+goog.inherits(proto.file.SaveFileRequest, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.file.SaveFileRequest.displayName = 'proto.file.SaveFileRequest';
+}
+/**
+ * Oneof group definitions for this message. Each group defines the field
+ * numbers belonging to that group. When of these fields' value is set, all
+ * other fields in the group are cleared. During deserialization, if multiple
+ * fields are encountered for a group, only the last value seen will be kept.
+ * @private {!Array<!Array<number>>}
+ * @const
+ */
+proto.file.SaveFileRequest.oneofGroups_ = [[1,2]];
+
+/**
+ * @enum {number}
+ */
+proto.file.SaveFileRequest.FileCase = {
+  FILE_NOT_SET: 0,
+  PATH: 1,
+  DATA: 2
+};
+
+/**
+ * @return {proto.file.SaveFileRequest.FileCase}
+ */
+proto.file.SaveFileRequest.prototype.getFileCase = function() {
+  return /** @type {proto.file.SaveFileRequest.FileCase} */(jspb.Message.computeOneofCase(this, proto.file.SaveFileRequest.oneofGroups_[0]));
+};
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.file.SaveFileRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.SaveFileRequest.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.file.SaveFileRequest} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.SaveFileRequest.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    path: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    data: msg.getData_asB64(),
+    size: jspb.Message.getFieldWithDefault(msg, 3, 0),
+    sha256: msg.getSha256_asB64(),
+    mode: jspb.Message.getFieldWithDefault(msg, 5, 0)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.file.SaveFileRequest}
+ */
+proto.file.SaveFileRequest.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.file.SaveFileRequest;
+  return proto.file.SaveFileRequest.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.file.SaveFileRequest} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.file.SaveFileRequest}
+ */
+proto.file.SaveFileRequest.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setPath(value);
+      break;
+    case 2:
+      var value = /** @type {!Uint8Array} */ (reader.readBytes());
+      msg.setData(value);
+      break;
+    case 3:
+      var value = /** @type {number} */ (reader.readInt64());
+      msg.setSize(value);
+      break;
+    case 4:
+      var value = /** @type {!Uint8Array} */ (reader.readBytes());
+      msg.setSha256(value);
+      break;
+    case 5:
+      var value = /** @type {!proto.file.SaveMode} */ (reader.readEnum());
+      msg.setMode(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.file.SaveFileRequest.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.file.SaveFileRequest.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.file.SaveFileRequest} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.SaveFileRequest.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = /** @type {string} */ (jspb.Message.getField(message, 1));
+  if (f != null) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = /** @type {!(string|Uint8Array)} */ (jspb.Message.getField(message, 2));
+  if (f != null) {
+    writer.writeBytes(
+      2,
+      f
+    );
+  }
+  f = message.getSize();
+  if (f !== 0) {
+    writer.writeInt64(
+      3,
+      f
+    );
+  }
+  f = message.getSha256_asU8();
+  if (f.length > 0) {
+    writer.writeBytes(
+      4,
+      f
+    );
+  }
+  f = message.getMode();
+  if (f !== 0.0) {
+    writer.writeEnum(
+      5,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional string path = 1;
+ * @return {string}
+ */
+proto.file.SaveFileRequest.prototype.getPath = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/** @param {string} value */
+proto.file.SaveFileRequest.prototype.setPath = function(value) {
+  jspb.Message.setOneofField(this, 1, proto.file.SaveFileRequest.oneofGroups_[0], value);
+};
+
+
+proto.file.SaveFileRequest.prototype.clearPath = function() {
+  jspb.Message.setOneofField(this, 1, proto.file.SaveFileRequest.oneofGroups_[0], undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {!boolean}
+ */
+proto.file.SaveFileRequest.prototype.hasPath = function() {
+  return jspb.Message.getField(this, 1) != null;
+};
+
+
+/**
+ * optional bytes data = 2;
+ * @return {string}
+ */
+proto.file.SaveFileRequest.prototype.getData = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/**
+ * optional bytes data = 2;
+ * This is a type-conversion wrapper around `getData()`
+ * @return {string}
+ */
+proto.file.SaveFileRequest.prototype.getData_asB64 = function() {
+  return /** @type {string} */ (jspb.Message.bytesAsB64(
+      this.getData()));
+};
+
+
+/**
+ * optional bytes data = 2;
+ * Note that Uint8Array is not supported on all browsers.
+ * @see http://caniuse.com/Uint8Array
+ * This is a type-conversion wrapper around `getData()`
+ * @return {!Uint8Array}
+ */
+proto.file.SaveFileRequest.prototype.getData_asU8 = function() {
+  return /** @type {!Uint8Array} */ (jspb.Message.bytesAsU8(
+      this.getData()));
+};
+
+
+/** @param {!(string|Uint8Array)} value */
+proto.file.SaveFileRequest.prototype.setData = function(value) {
+  jspb.Message.setOneofField(this, 2, proto.file.SaveFileRequest.oneofGroups_[0], value);
+};
+
+
+proto.file.SaveFileRequest.prototype.clearData = function() {
+  jspb.Message.setOneofField(this, 2, proto.file.SaveFileRequest.oneofGroups_[0], undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {!boolean}
+ */
+proto.file.SaveFileRequest.prototype.hasData = function() {
+  return jspb.Message.getField(this, 2) != null;
+};
+
+
+/**
+ * optional int64 size = 3;
+ * @return {number}
+ */
+proto.file.SaveFileRequest.prototype.getSize = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+};
+
+
+/** @param {number} value */
+proto.file.SaveFileRequest.prototype.setSize = function(value) {
+  jspb.Message.setProto3IntField(this, 3, value);
+};
+
+
+/**
+ * optional bytes sha256 = 4;
+ * @return {string}
+ */
+proto.file.SaveFileRequest.prototype.getSha256 = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 4, ""));
+};
+
+
+/**
+ * optional bytes sha256 = 4;
+ * This is a type-conversion wrapper around `getSha256()`
+ * @return {string}
+ */
+proto.file.SaveFileRequest.prototype.getSha256_asB64 = function() {
+  return /** @type {string} */ (jspb.Message.bytesAsB64(
+      this.getSha256()));
+};
+
+
+/**
+ * optional bytes sha256 = 4;
+ * Note that Uint8Array is not supported on all browsers.
+ * @see http://caniuse.com/Uint8Array
+ * This is a type-conversion wrapper around `getSha256()`
+ * @return {!Uint8Array}
+ */
+proto.file.SaveFileRequest.prototype.getSha256_asU8 = function() {
+  return /** @type {!Uint8Array} */ (jspb.Message.bytesAsU8(
+      this.getSha256()));
+};
+
+
+/** @param {!(string|Uint8Array)} value */
+proto.file.SaveFileRequest.prototype.setSha256 = function(value) {
+  jspb.Message.setProto3BytesField(this, 4, value);
+};
+
+
+/**
+ * optional SaveMode mode = 5;
+ * @return {!proto.file.SaveMode}
+ */
+proto.file.SaveFileRequest.prototype.getMode = function() {
+  return /** @type {!proto.file.SaveMode} */ (jspb.Message.getFieldWithDefault(this, 5, 0));
+};
+
+
+/** @param {!proto.file.SaveMode} value */
+proto.file.SaveFileRequest.prototype.setMode = function(value) {
+  jspb.Message.setProto3EnumField(this, 5, value);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.file.SaveFileResponse = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.file.SaveFileResponse, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.file.SaveFileResponse.displayName = 'proto.file.SaveFileResponse';
+}
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.file.SaveFileResponse.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.SaveFileResponse.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.file.SaveFileResponse} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.SaveFileResponse.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    result: jspb.Message.getFieldWithDefault(msg, 1, false),
+    size: jspb.Message.getFieldWithDefault(msg, 2, 0)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.file.SaveFileResponse}
+ */
+proto.file.SaveFileResponse.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.file.SaveFileResponse;
+  return proto.file.SaveFileResponse.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.file.SaveFileResponse} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.file.SaveFileResponse}
+ */
+proto.file.SaveFileResponse.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {boolean} */ (reader.readBool());
+      msg.setResult(value);
+      break;
+    case 2:
+      var value = /** @type {number} */ (reader.readInt64());
+      msg.setSize(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.file.SaveFileResponse.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.file.SaveFileResponse.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.file.SaveFileResponse} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.SaveFileResponse.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getResult();
+  if (f) {
+    writer.writeBool(
+      1,
+      f
+    );
+  }
+  f = message.getSize();
+  if (f !== 0) {
+    writer.writeInt64(
+      2,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional bool result = 1;
+ * Note that Boolean fields may be set to 0/1 when serialized from a Java server.
+ * You should avoid comparisons like {@code val === true/false} in those cases.
+ * @return {boolean}
+ */
+proto.file.SaveFileResponse.prototype.getResult = function() {
+  return /** @type {boolean} */ (jspb.Message.getFieldWithDefault(this, 1, false));
+};
+
+
+/** @param {boolean} value */
+proto.file.SaveFileResponse.prototype.setResult = function(value) {
+  jspb.Message.setProto3BooleanField(this, 1, value);
+};
+
+
+/**
+ * optional int64 size = 2;
+ * @return {number}
+ */
+proto.file.SaveFileResponse.prototype.getSize = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 2, 0));
+};
+
+
+/** @param {number} value */
+proto.file.SaveFileResponse.prototype.setSize = function(value) {
+  jspb.Message.setProto3IntField(this, 2, value);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.file.DeleteFileRequest = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.file.DeleteFileRequest, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.file.DeleteFileRequest.displayName = 'proto.file.DeleteFileRequest';
+}
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.file.DeleteFileRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.DeleteFileRequest.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.file.DeleteFileRequest} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.DeleteFileRequest.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    path: jspb.Message.getFieldWithDefault(msg, 1, "")
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.file.DeleteFileRequest}
+ */
+proto.file.DeleteFileRequest.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.file.DeleteFileRequest;
+  return proto.file.DeleteFileRequest.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.file.DeleteFileRequest} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.file.DeleteFileRequest}
+ */
+proto.file.DeleteFileRequest.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setPath(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.file.DeleteFileRequest.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.file.DeleteFileRequest.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.file.DeleteFileRequest} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.DeleteFileRequest.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getPath();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional string path = 1;
+ * @return {string}
+ */
+proto.file.DeleteFileRequest.prototype.getPath = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/** @param {string} value */
+proto.file.DeleteFileRequest.prototype.setPath = function(value) {
+  jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.file.DeleteFileResponse = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.file.DeleteFileResponse, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.file.DeleteFileResponse.displayName = 'proto.file.DeleteFileResponse';
+}
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.file.DeleteFileResponse.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.DeleteFileResponse.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.file.DeleteFileResponse} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.DeleteFileResponse.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    result: jspb.Message.getFieldWithDefault(msg, 1, false)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.file.DeleteFileResponse}
+ */
+proto.file.DeleteFileResponse.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.file.DeleteFileResponse;
+  return proto.file.DeleteFileResponse.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.file.DeleteFileResponse} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.file.DeleteFileResponse}
+ */
+proto.file.DeleteFileResponse.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {boolean} */ (reader.readBool());
+      msg.setResult(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.file.DeleteFileResponse.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.file.DeleteFileResponse.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.file.DeleteFileResponse} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.DeleteFileResponse.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getResult();
+  if (f) {
+    writer.writeBool(
+      1,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional bool result = 1;
+ * Note that Boolean fields may be set to 0/1 when serialized from a Java server.
+ * You should avoid comparisons like {@code val === true/false} in those cases.
+ * @return {boolean}
+ */
+proto.file.DeleteFileResponse.prototype.getResult = function() {
+  return /** @type {boolean} */ (jspb.Message.getFieldWithDefault(this, 1, false));
+};
+
+
+/** @param {boolean} value */
+proto.file.DeleteFileResponse.prototype.setResult = function(value) {
+  jspb.Message.setProto3BooleanField(this, 1, value);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.file.GetThumbnailRequest = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.file.GetThumbnailRequest, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.file.GetThumbnailRequest.displayName = 'proto.file.GetThumbnailRequest';
+}
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.file.GetThumbnailRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.GetThumbnailRequest.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.file.GetThumbnailRequest} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.GetThumbnailRequest.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    path: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    width: jspb.Message.getFieldWithDefault(msg, 2, 0),
+    height: jspb.Message.getFieldWithDefault(msg, 3, 0)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.file.GetThumbnailRequest}
+ */
+proto.file.GetThumbnailRequest.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.file.GetThumbnailRequest;
+  return proto.file.GetThumbnailRequest.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.file.GetThumbnailRequest} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.file.GetThumbnailRequest}
+ */
+proto.file.GetThumbnailRequest.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setPath(value);
+      break;
+    case 2:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setWidth(value);
+      break;
+    case 3:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setHeight(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.file.GetThumbnailRequest.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.file.GetThumbnailRequest.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.file.GetThumbnailRequest} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.GetThumbnailRequest.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getPath();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getWidth();
+  if (f !== 0) {
+    writer.writeInt32(
+      2,
+      f
+    );
+  }
+  f = message.getHeight();
+  if (f !== 0) {
+    writer.writeInt32(
+      3,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional string path = 1;
+ * @return {string}
+ */
+proto.file.GetThumbnailRequest.prototype.getPath = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/** @param {string} value */
+proto.file.GetThumbnailRequest.prototype.setPath = function(value) {
+  jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional int32 width = 2;
+ * @return {number}
+ */
+proto.file.GetThumbnailRequest.prototype.getWidth = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 2, 0));
+};
+
+
+/** @param {number} value */
+proto.file.GetThumbnailRequest.prototype.setWidth = function(value) {
+  jspb.Message.setProto3IntField(this, 2, value);
+};
+
+
+/**
+ * optional int32 height = 3;
+ * @return {number}
+ */
+proto.file.GetThumbnailRequest.prototype.getHeight = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+};
+
+
+/** @param {number} value */
+proto.file.GetThumbnailRequest.prototype.setHeight = function(value) {
+  jspb.Message.setProto3IntField(this, 3, value);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.file.GetThumbnailResponse = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.file.GetThumbnailResponse, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.file.GetThumbnailResponse.displayName = 'proto.file.GetThumbnailResponse';
+}
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.file.GetThumbnailResponse.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.GetThumbnailResponse.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.file.GetThumbnailResponse} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.GetThumbnailResponse.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    data: msg.getData_asB64(),
+    mime: jspb.Message.getFieldWithDefault(msg, 2, ""),
+    width: jspb.Message.getFieldWithDefault(msg, 3, 0),
+    height: jspb.Message.getFieldWithDefault(msg, 4, 0)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.file.GetThumbnailResponse}
+ */
+proto.file.GetThumbnailResponse.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.file.GetThumbnailResponse;
+  return proto.file.GetThumbnailResponse.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.file.GetThumbnailResponse} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.file.GetThumbnailResponse}
+ */
+proto.file.GetThumbnailResponse.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {!Uint8Array} */ (reader.readBytes());
+      msg.setData(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setMime(value);
+      break;
+    case 3:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setWidth(value);
+      break;
+    case 4:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setHeight(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.file.GetThumbnailResponse.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.file.GetThumbnailResponse.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.file.GetThumbnailResponse} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.GetThumbnailResponse.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getData_asU8();
+  if (f.length > 0) {
+    writer.writeBytes(
+      1,
+      f
+    );
+  }
+  f = message.getMime();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+  f = message.getWidth();
+  if (f !== 0) {
+    writer.writeInt32(
+      3,
+      f
+    );
+  }
+  f = message.getHeight();
+  if (f !== 0) {
+    writer.writeInt32(
+      4,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional bytes data = 1;
+ * @return {string}
+ */
+proto.file.GetThumbnailResponse.prototype.getData = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/**
+ * optional bytes data = 1;
+ * This is a type-conversion wrapper around `getData()`
+ * @return {string}
+ */
+proto.file.GetThumbnailResponse.prototype.getData_asB64 = function() {
+  return /** @type {string} */ (jspb.Message.bytesAsB64(
+      this.getData()));
+};
+
+
+/**
+ * optional bytes data = 1;
+ * Note that Uint8Array is not supported on all browsers.
+ * @see http://caniuse.com/Uint8Array
+ * This is a type-conversion wrapper around `getData()`
+ * @return {!Uint8Array}
+ */
+proto.file.GetThumbnailResponse.prototype.getData_asU8 = function() {
+  return /** @type {!Uint8Array} */ (jspb.Message.bytesAsU8(
+      this.getData()));
+};
+
+
+/** @param {!(string|Uint8Array)} value */
+proto.file.GetThumbnailResponse.prototype.setData = function(value) {
+  jspb.Message.setProto3BytesField(this, 1, value);
+};
+
+
+/**
+ * optional string mime = 2;
+ * @return {string}
+ */
+proto.file.GetThumbnailResponse.prototype.getMime = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/** @param {string} value */
+proto.file.GetThumbnailResponse.prototype.setMime = function(value) {
+  jspb.Message.setProto3StringField(this, 2, value);
+};
+
+
+/**
+ * optional int32 width = 3;
+ * @return {number}
+ */
+proto.file.GetThumbnailResponse.prototype.getWidth = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+};
+
+
+/** @param {number} value */
+proto.file.GetThumbnailResponse.prototype.setWidth = function(value) {
+  jspb.Message.setProto3IntField(this, 3, value);
+};
+
+
+/**
+ * optional int32 height = 4;
+ * @return {number}
+ */
+proto.file.GetThumbnailResponse.prototype.getHeight = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 4, 0));
+};
+
+
+/** @param {number} value */
+proto.file.GetThumbnailResponse.prototype.setHeight = function(value) {
+  jspb.Message.setProto3IntField(this, 4, value);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.file.ResizeOperation = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.file.ResizeOperation, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.file.ResizeOperation.displayName = 'proto.file.ResizeOperation';
+}
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.file.ResizeOperation.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.ResizeOperation.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.file.ResizeOperation} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.ResizeOperation.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    width: jspb.Message.getFieldWithDefault(msg, 1, 0),
+    height: jspb.Message.getFieldWithDefault(msg, 2, 0),
+    mode: jspb.Message.getFieldWithDefault(msg, 3, 0)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.file.ResizeOperation}
+ */
+proto.file.ResizeOperation.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.file.ResizeOperation;
+  return proto.file.ResizeOperation.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.file.ResizeOperation} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.file.ResizeOperation}
+ */
+proto.file.ResizeOperation.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setWidth(value);
+      break;
+    case 2:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setHeight(value);
+      break;
+    case 3:
+      var value = /** @type {!proto.file.ResizeMode} */ (reader.readEnum());
+      msg.setMode(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.file.ResizeOperation.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.file.ResizeOperation.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.file.ResizeOperation} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.ResizeOperation.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getWidth();
+  if (f !== 0) {
+    writer.writeInt32(
+      1,
+      f
+    );
+  }
+  f = message.getHeight();
+  if (f !== 0) {
+    writer.writeInt32(
+      2,
+      f
+    );
+  }
+  f = message.getMode();
+  if (f !== 0.0) {
+    writer.writeEnum(
+      3,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional int32 width = 1;
+ * @return {number}
+ */
+proto.file.ResizeOperation.prototype.getWidth = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 1, 0));
+};
+
+
+/** @param {number} value */
+proto.file.ResizeOperation.prototype.setWidth = function(value) {
+  jspb.Message.setProto3IntField(this, 1, value);
+};
+
+
+/**
+ * optional int32 height = 2;
+ * @return {number}
+ */
+proto.file.ResizeOperation.prototype.getHeight = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 2, 0));
+};
+
+
+/** @param {number} value */
+proto.file.ResizeOperation.prototype.setHeight = function(value) {
+  jspb.Message.setProto3IntField(this, 2, value);
+};
+
+
+/**
+ * optional ResizeMode mode = 3;
+ * @return {!proto.file.ResizeMode}
+ */
+proto.file.ResizeOperation.prototype.getMode = function() {
+  return /** @type {!proto.file.ResizeMode} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+};
+
+
+/** @param {!proto.file.ResizeMode} value */
+proto.file.ResizeOperation.prototype.setMode = function(value) {
+  jspb.Message.setProto3EnumField(this, 3, value);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.file.CropOperation = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.file.CropOperation, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.file.CropOperation.displayName = 'proto.file.CropOperation';
+}
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.file.CropOperation.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.CropOperation.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.file.CropOperation} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.CropOperation.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    x: jspb.Message.getFieldWithDefault(msg, 1, 0),
+    y: jspb.Message.getFieldWithDefault(msg, 2, 0),
+    width: jspb.Message.getFieldWithDefault(msg, 3, 0),
+    height: jspb.Message.getFieldWithDefault(msg, 4, 0)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.file.CropOperation}
+ */
+proto.file.CropOperation.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.file.CropOperation;
+  return proto.file.CropOperation.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.file.CropOperation} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.file.CropOperation}
+ */
+proto.file.CropOperation.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setX(value);
+      break;
+    case 2:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setY(value);
+      break;
+    case 3:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setWidth(value);
+      break;
+    case 4:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setHeight(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.file.CropOperation.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.file.CropOperation.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.file.CropOperation} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.file.CropOperation.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getX();
+  if (f !== 0) {
+    writer.writeInt32(
+      1,
+      f
+    );
+  }
+  f = message.getY();
+  if (f !== 0) {
+    writer.writeInt32(
+      2,
+      f
+    );
+  }
+  f = message.getWidth();
+  if (f !== 0) {
+    writer.writeInt32(
+      3,
+      f
+    );
+  }
+  f = message.getHeight();
+  if (f !== 0) {
+    writer.writeInt32(
+      4,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional int32 x = 1;
+ * @return {number}
+ */
+proto.file.CropOperation.prototype.getX = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 1, 0));
+};
+
+
+/** @param {number} value */
+proto.file.CropOperation.prototype.setX = function(value) {
+  jspb.Message.setProto3IntField(this, 1, value);
+};
+
+
+/**
+ * optional int32 y = 2;
+ * @return {number}
+ */
+proto.file.CropOperation.prototype.getY = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 2, 0));
+};
+
+
+/** @param {number} value */
+proto.file.CropOperation.prototype.setY = function(value) {
+  jspb.Message.setProto3IntField(this, 2, value);
+};
+
+
+/**
+ * optional int32 width = 3;
+ * @return {number}
+ */
+proto.file.CropOperation.prototype.getWidth = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+};
+
+
+/** @param {number} value */
+proto.file.CropOperation.prototype.setWidth = function(value) {
+  jspb.Message.setProto3IntField(this, 3, value);
+};
+
+
+/**
+ * optional int32 height = 4;
+ * @return {number}
+ */
+proto.file.CropOperation.prototype.getHeight = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 4, 0));
+};
+
+
+/** @param {number} value */
+proto.file.CropOperation.prototype.setHeight = function(value) {
+  jspb.Message.setProto3IntField(this, 4, value);
+};
+
+
+
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.file.ImageOperation = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, proto.file.ImageOperation.oneofGroups_);
+};
+goog.inherits(proto.file.ImageOperation, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  proto.file.ImageOperation.displayName = 'proto.file.ImageOperation';
+}
+/**
+ * Oneof group definitions for this message. Each group defines the field
+ * numbers belonging to that group. When of these fields' value is set, all
+ * other fields in the group are cleared. During deserialization, if multiple
+ * fields are encountered for a group, only the last value seen will be kept.
+ * @private {!Array<!Array<number>>}
+ * @const
+ */
+proto.file.ImageOperation.oneofGroups_ = [[1,2,3,4,5]];
+
+/**
+ * @enum {number}
+ */
+proto.file.ImageOperation.OperationCase = {
+  OPERATION_NOT_SET: 0,
+  RESIZE: 1,
+  CROP: 2,
+  ROTATE: 3,
+  FLIP: 4,
+  GRAYSCALE: 5
+};
 
 /**
- * Generated by JsPbCodeGenerator.
- * @param {Array=} opt_data Optional initial data array, typically from a
- * server response, or constructed directly in Javascript. The array is used
- * in place and becomes part of the constructed object. It is not cloned.
- * If no data is provided, the constructed object will be empty, but still
- * valid.
- * @extends {jspb.Message}
- * @constructor
+ * @return {proto.file.ImageOperation.OperationCase}
  */
-proto.file.CreateDirResponse = function(opt_data) {
-  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+proto.file.ImageOperation.prototype.getOperationCase = function() {
+  return /** @type {proto.file.ImageOperation.OperationCase} */(jspb.Message.computeOneofCase(this, proto.file.ImageOperation.oneofGroups_[0]));
 };
-goog.inherits(proto.file.CreateDirResponse, jspb.Message);
-if (goog.DEBUG && !COMPILED) {
-  proto.file.CreateDirResponse.displayName = 'proto.file.CreateDirResponse';
-}
+
 
 
 if (jspb.Message.GENERATE_TO_OBJECT) {
@@ -735,8 +6970,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
  *     for transitional soy proto support: http://goto/soy-param-migration
  * @return {!Object}
  */
-proto.file.CreateDirResponse.prototype.toObject = function(opt_includeInstance) {
-  return proto.file.CreateDirResponse.toObject(opt_includeInstance, this);
+proto.file.ImageOperation.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.ImageOperation.toObject(opt_includeInstance, this);
 };
 
 
@@ -745,13 +6980,17 @@ proto.file.CreateDirResponse.prototype.toObject = function(opt_includeInstance)
  * @param {boolean|undefined} includeInstance Whether to include the JSPB
  *     instance for transitional soy proto support:
  *     http://goto/soy-param-migration
- * @param {!proto.file.CreateDirResponse} msg The msg instance to transform.
+ * @param {!proto.file.ImageOperation} msg The msg instance to transform.
  * @return {!Object}
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.file.CreateDirResponse.toObject = function(includeInstance, msg) {
+proto.file.ImageOperation.toObject = function(includeInstance, msg) {
   var f, obj = {
-    result: jspb.Message.getFieldWithDefault(msg, 1, false)
+    resize: (f = msg.getResize()) && proto.file.ResizeOperation.toObject(includeInstance, f),
+    crop: (f = msg.getCrop()) && proto.file.CropOperation.toObject(includeInstance, f),
+    rotate: jspb.Message.getFieldWithDefault(msg, 3, 0),
+    flip: jspb.Message.getFieldWithDefault(msg, 4, 0),
+    grayscale: jspb.Message.getFieldWithDefault(msg, 5, false)
   };
 
   if (includeInstance) {
@@ -765,23 +7004,23 @@ proto.file.CreateDirResponse.toObject = function(includeInstance, msg) {
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.file.CreateDirResponse}
+ * @return {!proto.file.ImageOperation}
  */
-proto.file.CreateDirResponse.deserializeBinary = function(bytes) {
+proto.file.ImageOperation.deserializeBinary = function(bytes) {
   var reader = new jspb.BinaryReader(bytes);
-  var msg = new proto.file.CreateDirResponse;
-  return proto.file.CreateDirResponse.deserializeBinaryFromReader(msg, reader);
+  var msg = new proto.file.ImageOperation;
+  return proto.file.ImageOperation.deserializeBinaryFromReader(msg, reader);
 };
 
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.file.CreateDirResponse} msg The message object to deserialize into.
+ * @param {!proto.file.ImageOperation} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.file.CreateDirResponse}
+ * @return {!proto.file.ImageOperation}
  */
-proto.file.CreateDirResponse.deserializeBinaryFromReader = function(msg, reader) {
+proto.file.ImageOperation.deserializeBinaryFromReader = function(msg, reader) {
   while (reader.nextField()) {
     if (reader.isEndGroup()) {
       break;
@@ -789,8 +7028,26 @@ proto.file.CreateDirResponse.deserializeBinaryFromReader = function(msg, reader)
     var field = reader.getFieldNumber();
     switch (field) {
     case 1:
+      var value = new proto.file.ResizeOperation;
+      reader.readMessage(value,proto.file.ResizeOperation.deserializeBinaryFromReader);
+      msg.setResize(value);
+      break;
+    case 2:
+      var value = new proto.file.CropOperation;
+      reader.readMessage(value,proto.file.CropOperation.deserializeBinaryFromReader);
+      msg.setCrop(value);
+      break;
+    case 3:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setRotate(value);
+      break;
+    case 4:
+      var value = /** @type {!proto.file.FlipDirection} */ (reader.readEnum());
+      msg.setFlip(value);
+      break;
+    case 5:
       var value = /** @type {boolean} */ (reader.readBool());
-      msg.setResult(value);
+      msg.setGrayscale(value);
       break;
     default:
       reader.skipField();
@@ -805,9 +7062,9 @@ proto.file.CreateDirResponse.deserializeBinaryFromReader = function(msg, reader)
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.file.CreateDirResponse.prototype.serializeBinary = function() {
+proto.file.ImageOperation.prototype.serializeBinary = function() {
   var writer = new jspb.BinaryWriter();
-  proto.file.CreateDirResponse.serializeBinaryToWriter(this, writer);
+  proto.file.ImageOperation.serializeBinaryToWriter(this, writer);
   return writer.getResultBuffer();
 };
 
@@ -815,16 +7072,46 @@ proto.file.CreateDirResponse.prototype.serializeBinary = function() {
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.file.CreateDirResponse} message
+ * @param {!proto.file.ImageOperation} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.file.CreateDirResponse.serializeBinaryToWriter = function(message, writer) {
+proto.file.ImageOperation.serializeBinaryToWriter = function(message, writer) {
   var f = undefined;
-  f = message.getResult();
-  if (f) {
-    writer.writeBool(
+  f = message.getResize();
+  if (f != null) {
+    writer.writeMessage(
       1,
+      f,
+      proto.file.ResizeOperation.serializeBinaryToWriter
+    );
+  }
+  f = message.getCrop();
+  if (f != null) {
+    writer.writeMessage(
+      2,
+      f,
+      proto.file.CropOperation.serializeBinaryToWriter
+    );
+  }
+  f = /** @type {number} */ (jspb.Message.getField(message, 3));
+  if (f != null) {
+    writer.writeInt32(
+      3,
+      f
+    );
+  }
+  f = /** @type {!proto.file.FlipDirection} */ (jspb.Message.getField(message, 4));
+  if (f != null) {
+    writer.writeEnum(
+      4,
+      f
+    );
+  }
+  f = /** @type {boolean} */ (jspb.Message.getField(message, 5));
+  if (f != null) {
+    writer.writeBool(
+      5,
       f
     );
   }
@@ -832,161 +7119,151 @@ proto.file.CreateDirResponse.serializeBinaryToWriter = function(message, writer)
 
 
 /**
- * optional bool result = 1;
- * Note that Boolean fields may be set to 0/1 when serialized from a Java server.
- * You should avoid comparisons like {@code val === true/false} in those cases.
- * @return {boolean}
+ * optional ResizeOperation resize = 1;
+ * @return {?proto.file.ResizeOperation}
  */
-proto.file.CreateDirResponse.prototype.getResult = function() {
-  return /** @type {boolean} */ (jspb.Message.getFieldWithDefault(this, 1, false));
+proto.file.ImageOperation.prototype.getResize = function() {
+  return /** @type{?proto.file.ResizeOperation} */ (
+    jspb.Message.getWrapperField(this, proto.file.ResizeOperation, 1));
 };
 
 
-/** @param {boolean} value */
-proto.file.CreateDirResponse.prototype.setResult = function(value) {
-  jspb.Message.setProto3BooleanField(this, 1, value);
+/** @param {?proto.file.ResizeOperation|undefined} value */
+proto.file.ImageOperation.prototype.setResize = function(value) {
+  jspb.Message.setOneofWrapperField(this, 1, proto.file.ImageOperation.oneofGroups_[0], value);
 };
 
 
+proto.file.ImageOperation.prototype.clearResize = function() {
+  this.setResize(undefined);
+};
+
 
 /**
- * Generated by JsPbCodeGenerator.
- * @param {Array=} opt_data Optional initial data array, typically from a
- * server response, or constructed directly in Javascript. The array is used
- * in place and becomes part of the constructed object. It is not cloned.
- * If no data is provided, the constructed object will be empty, but still
- * valid.
- * @extends {jspb.Message}
- * @constructor
+ * Returns whether this field is set.
+ * @return {!boolean}
  */
-proto.file.DeleteDirRequest = function(opt_data) {
-  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+proto.file.ImageOperation.prototype.hasResize = function() {
+  return jspb.Message.getField(this, 1) != null;
 };
-goog.inherits(proto.file.DeleteDirRequest, jspb.Message);
-if (goog.DEBUG && !COMPILED) {
-  proto.file.DeleteDirRequest.displayName = 'proto.file.DeleteDirRequest';
-}
 
 
-if (jspb.Message.GENERATE_TO_OBJECT) {
 /**
- * Creates an object representation of this proto suitable for use in Soy templates.
- * Field names that are reserved in JavaScript and will be renamed to pb_name.
- * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
- * For the list of reserved names please see:
- *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
- * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
- *     for transitional soy proto support: http://goto/soy-param-migration
- * @return {!Object}
+ * optional CropOperation crop = 2;
+ * @return {?proto.file.CropOperation}
  */
-proto.file.DeleteDirRequest.prototype.toObject = function(opt_includeInstance) {
-  return proto.file.DeleteDirRequest.toObject(opt_includeInstance, this);
+proto.file.ImageOperation.prototype.getCrop = function() {
+  return /** @type{?proto.file.CropOperation} */ (
+    jspb.Message.getWrapperField(this, proto.file.CropOperation, 2));
+};
+
+
+/** @param {?proto.file.CropOperation|undefined} value */
+proto.file.ImageOperation.prototype.setCrop = function(value) {
+  jspb.Message.setOneofWrapperField(this, 2, proto.file.ImageOperation.oneofGroups_[0], value);
+};
+
+
+proto.file.ImageOperation.prototype.clearCrop = function() {
+  this.setCrop(undefined);
 };
 
 
 /**
- * Static version of the {@see toObject} method.
- * @param {boolean|undefined} includeInstance Whether to include the JSPB
- *     instance for transitional soy proto support:
- *     http://goto/soy-param-migration
- * @param {!proto.file.DeleteDirRequest} msg The msg instance to transform.
- * @return {!Object}
- * @suppress {unusedLocalVariables} f is only used for nested messages
+ * Returns whether this field is set.
+ * @return {!boolean}
  */
-proto.file.DeleteDirRequest.toObject = function(includeInstance, msg) {
-  var f, obj = {
-    path: jspb.Message.getFieldWithDefault(msg, 1, "")
-  };
-
-  if (includeInstance) {
-    obj.$jspbMessageInstance = msg;
-  }
-  return obj;
+proto.file.ImageOperation.prototype.hasCrop = function() {
+  return jspb.Message.getField(this, 2) != null;
 };
-}
 
 
 /**
- * Deserializes binary data (in protobuf wire format).
- * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.file.DeleteDirRequest}
+ * optional int32 rotate = 3;
+ * @return {number}
  */
-proto.file.DeleteDirRequest.deserializeBinary = function(bytes) {
-  var reader = new jspb.BinaryReader(bytes);
-  var msg = new proto.file.DeleteDirRequest;
-  return proto.file.DeleteDirRequest.deserializeBinaryFromReader(msg, reader);
+proto.file.ImageOperation.prototype.getRotate = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+};
+
+
+/** @param {number} value */
+proto.file.ImageOperation.prototype.setRotate = function(value) {
+  jspb.Message.setOneofField(this, 3, proto.file.ImageOperation.oneofGroups_[0], value);
+};
+
+
+proto.file.ImageOperation.prototype.clearRotate = function() {
+  jspb.Message.setOneofField(this, 3, proto.file.ImageOperation.oneofGroups_[0], undefined);
 };
 
 
 /**
- * Deserializes binary data (in protobuf wire format) from the
- * given reader into the given message object.
- * @param {!proto.file.DeleteDirRequest} msg The message object to deserialize into.
- * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.file.DeleteDirRequest}
+ * Returns whether this field is set.
+ * @return {!boolean}
  */
-proto.file.DeleteDirRequest.deserializeBinaryFromReader = function(msg, reader) {
-  while (reader.nextField()) {
-    if (reader.isEndGroup()) {
-      break;
-    }
-    var field = reader.getFieldNumber();
-    switch (field) {
-    case 1:
-      var value = /** @type {string} */ (reader.readString());
-      msg.setPath(value);
-      break;
-    default:
-      reader.skipField();
-      break;
-    }
-  }
-  return msg;
+proto.file.ImageOperation.prototype.hasRotate = function() {
+  return jspb.Message.getField(this, 3) != null;
 };
 
 
 /**
- * Serializes the message to binary data (in protobuf wire format).
- * @return {!Uint8Array}
+ * optional FlipDirection flip = 4;
+ * @return {!proto.file.FlipDirection}
  */
-proto.file.DeleteDirRequest.prototype.serializeBinary = function() {
-  var writer = new jspb.BinaryWriter();
-  proto.file.DeleteDirRequest.serializeBinaryToWriter(this, writer);
-  return writer.getResultBuffer();
+proto.file.ImageOperation.prototype.getFlip = function() {
+  return /** @type {!proto.file.FlipDirection} */ (jspb.Message.getFieldWithDefault(this, 4, 0));
+};
+
+
+/** @param {!proto.file.FlipDirection} value */
+proto.file.ImageOperation.prototype.setFlip = function(value) {
+  jspb.Message.setOneofField(this, 4, proto.file.ImageOperation.oneofGroups_[0], value);
+};
+
+
+proto.file.ImageOperation.prototype.clearFlip = function() {
+  jspb.Message.setOneofField(this, 4, proto.file.ImageOperation.oneofGroups_[0], undefined);
 };
 
 
 /**
- * Serializes the given message to binary data (in protobuf wire
- * format), writing to the given BinaryWriter.
- * @param {!proto.file.DeleteDirRequest} message
- * @param {!jspb.BinaryWriter} writer
- * @suppress {unusedLocalVariables} f is only used for nested messages
+ * Returns whether this field is set.
+ * @return {!boolean}
  */
-proto.file.DeleteDirRequest.serializeBinaryToWriter = function(message, writer) {
-  var f = undefined;
-  f = message.getPath();
-  if (f.length > 0) {
-    writer.writeString(
-      1,
-      f
-    );
-  }
+proto.file.ImageOperation.prototype.hasFlip = function() {
+  return jspb.Message.getField(this, 4) != null;
 };
 
 
 /**
- * optional string path = 1;
- * @return {string}
+ * optional bool grayscale = 5;
+ * Note that Boolean fields may be set to 0/1 when serialized from a Java server.
+ * You should avoid comparisons like {@code val === true/false} in those cases.
+ * @return {boolean}
  */
-proto.file.DeleteDirRequest.prototype.getPath = function() {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+proto.file.ImageOperation.prototype.getGrayscale = function() {
+  return /** @type {boolean} */ (jspb.Message.getFieldWithDefault(this, 5, false));
 };
 
 
-/** @param {string} value */
-proto.file.DeleteDirRequest.prototype.setPath = function(value) {
-  jspb.Message.setProto3StringField(this, 1, value);
+/** @param {boolean} value */
+proto.file.ImageOperation.prototype.setGrayscale = function(value) {
+  jspb.Message.setOneofField(this, 5, proto.file.ImageOperation.oneofGroups_[0], value);
+};
+
+
+proto.file.ImageOperation.prototype.clearGrayscale = function() {
+  jspb.Message.setOneofField(this, 5, proto.file.ImageOperation.oneofGroups_[0], undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {!boolean}
+ */
+proto.file.ImageOperation.prototype.hasGrayscale = function() {
+  return jspb.Message.getField(this, 5) != null;
 };
 
 
@@ -1001,13 +7278,20 @@ proto.file.DeleteDirRequest.prototype.setPath = function(value) {
  * @extends {jspb.Message}
  * @constructor
  */
-proto.file.DeleteDirResponse = function(opt_data) {
-  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+proto.file.ImageTransformRequest = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.file.ImageTransformRequest.repeatedFields_, null);
 };
-goog.inherits(proto.file.DeleteDirResponse, jspb.Message);
+goog.inherits(proto.file.ImageTransformRequest, jspb.Message);
 if (goog.DEBUG && !COMPILED) {
-  proto.file.DeleteDirResponse.displayName = 'proto.file.DeleteDirResponse';
+  proto.file.ImageTransformRequest.displayName = 'proto.file.ImageTransformRequest';
 }
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.file.ImageTransformRequest.repeatedFields_ = [2];
+
 
 
 if (jspb.Message.GENERATE_TO_OBJECT) {
@@ -1021,8 +7305,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
  *     for transitional soy proto support: http://goto/soy-param-migration
  * @return {!Object}
  */
-proto.file.DeleteDirResponse.prototype.toObject = function(opt_includeInstance) {
-  return proto.file.DeleteDirResponse.toObject(opt_includeInstance, this);
+proto.file.ImageTransformRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.ImageTransformRequest.toObject(opt_includeInstance, this);
 };
 
 
@@ -1031,13 +7315,19 @@ proto.file.DeleteDirResponse.prototype.toObject = function(opt_includeInstance)
  * @param {boolean|undefined} includeInstance Whether to include the JSPB
  *     instance for transitional soy proto support:
  *     http://goto/soy-param-migration
- * @param {!proto.file.DeleteDirResponse} msg The msg instance to transform.
+ * @param {!proto.file.ImageTransformRequest} msg The msg instance to transform.
  * @return {!Object}
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.file.DeleteDirResponse.toObject = function(includeInstance, msg) {
+proto.file.ImageTransformRequest.toObject = function(includeInstance, msg) {
   var f, obj = {
-    result: jspb.Message.getFieldWithDefault(msg, 1, false)
+    path: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    operationsList: jspb.Message.toObjectList(msg.getOperationsList(),
+    proto.file.ImageOperation.toObject, includeInstance),
+    format: jspb.Message.getFieldWithDefault(msg, 3, 0),
+    quality: jspb.Message.getFieldWithDefault(msg, 4, 0),
+    destination: jspb.Message.getFieldWithDefault(msg, 5, ""),
+    conflict: jspb.Message.getFieldWithDefault(msg, 6, 0)
   };
 
   if (includeInstance) {
@@ -1051,32 +7341,53 @@ proto.file.DeleteDirResponse.toObject = function(includeInstance, msg) {
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.file.DeleteDirResponse}
+ * @return {!proto.file.ImageTransformRequest}
  */
-proto.file.DeleteDirResponse.deserializeBinary = function(bytes) {
+proto.file.ImageTransformRequest.deserializeBinary = function(bytes) {
   var reader = new jspb.BinaryReader(bytes);
-  var msg = new proto.file.DeleteDirResponse;
-  return proto.file.DeleteDirResponse.deserializeBinaryFromReader(msg, reader);
+  var msg = new proto.file.ImageTransformRequest;
+  return proto.file.ImageTransformRequest.deserializeBinaryFromReader(msg, reader);
 };
 
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.file.DeleteDirResponse} msg The message object to deserialize into.
+ * @param {!proto.file.ImageTransformRequest} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.file.DeleteDirResponse}
+ * @return {!proto.file.ImageTransformRequest}
  */
-proto.file.DeleteDirResponse.deserializeBinaryFromReader = function(msg, reader) {
+proto.file.ImageTransformRequest.deserializeBinaryFromReader = function(msg, reader) {
   while (reader.nextField()) {
     if (reader.isEndGroup()) {
       break;
-    }
-    var field = reader.getFieldNumber();
-    switch (field) {
-    case 1:
-      var value = /** @type {boolean} */ (reader.readBool());
-      msg.setResult(value);
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setPath(value);
+      break;
+    case 2:
+      var value = new proto.file.ImageOperation;
+      reader.readMessage(value,proto.file.ImageOperation.deserializeBinaryFromReader);
+      msg.addOperations(value);
+      break;
+    case 3:
+      var value = /** @type {!proto.file.ImageFormat} */ (reader.readEnum());
+      msg.setFormat(value);
+      break;
+    case 4:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setQuality(value);
+      break;
+    case 5:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setDestination(value);
+      break;
+    case 6:
+      var value = /** @type {!proto.file.ConflictPolicy} */ (reader.readEnum());
+      msg.setConflict(value);
       break;
     default:
       reader.skipField();
@@ -1091,9 +7402,9 @@ proto.file.DeleteDirResponse.deserializeBinaryFromReader = function(msg, reader)
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.file.DeleteDirResponse.prototype.serializeBinary = function() {
+proto.file.ImageTransformRequest.prototype.serializeBinary = function() {
   var writer = new jspb.BinaryWriter();
-  proto.file.DeleteDirResponse.serializeBinaryToWriter(this, writer);
+  proto.file.ImageTransformRequest.serializeBinaryToWriter(this, writer);
   return writer.getResultBuffer();
 };
 
@@ -1101,36 +7412,161 @@ proto.file.DeleteDirResponse.prototype.serializeBinary = function() {
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.file.DeleteDirResponse} message
+ * @param {!proto.file.ImageTransformRequest} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.file.DeleteDirResponse.serializeBinaryToWriter = function(message, writer) {
+proto.file.ImageTransformRequest.serializeBinaryToWriter = function(message, writer) {
   var f = undefined;
-  f = message.getResult();
-  if (f) {
-    writer.writeBool(
+  f = message.getPath();
+  if (f.length > 0) {
+    writer.writeString(
       1,
       f
     );
   }
+  f = message.getOperationsList();
+  if (f.length > 0) {
+    writer.writeRepeatedMessage(
+      2,
+      f,
+      proto.file.ImageOperation.serializeBinaryToWriter
+    );
+  }
+  f = message.getFormat();
+  if (f !== 0.0) {
+    writer.writeEnum(
+      3,
+      f
+    );
+  }
+  f = message.getQuality();
+  if (f !== 0) {
+    writer.writeInt32(
+      4,
+      f
+    );
+  }
+  f = message.getDestination();
+  if (f.length > 0) {
+    writer.writeString(
+      5,
+      f
+    );
+  }
+  f = message.getConflict();
+  if (f !== 0.0) {
+    writer.writeEnum(
+      6,
+      f
+    );
+  }
 };
 
 
 /**
- * optional bool result = 1;
- * Note that Boolean fields may be set to 0/1 when serialized from a Java server.
- * You should avoid comparisons like {@code val === true/false} in those cases.
- * @return {boolean}
+ * optional string path = 1;
+ * @return {string}
  */
-proto.file.DeleteDirResponse.prototype.getResult = function() {
-  return /** @type {boolean} */ (jspb.Message.getFieldWithDefault(this, 1, false));
+proto.file.ImageTransformRequest.prototype.getPath = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
 };
 
 
-/** @param {boolean} value */
-proto.file.DeleteDirResponse.prototype.setResult = function(value) {
-  jspb.Message.setProto3BooleanField(this, 1, value);
+/** @param {string} value */
+proto.file.ImageTransformRequest.prototype.setPath = function(value) {
+  jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * repeated ImageOperation operations = 2;
+ * @return {!Array<!proto.file.ImageOperation>}
+ */
+proto.file.ImageTransformRequest.prototype.getOperationsList = function() {
+  return /** @type{!Array<!proto.file.ImageOperation>} */ (
+    jspb.Message.getRepeatedWrapperField(this, proto.file.ImageOperation, 2));
+};
+
+
+/** @param {!Array<!proto.file.ImageOperation>} value */
+proto.file.ImageTransformRequest.prototype.setOperationsList = function(value) {
+  jspb.Message.setRepeatedWrapperField(this, 2, value);
+};
+
+
+/**
+ * @param {!proto.file.ImageOperation=} opt_value
+ * @param {number=} opt_index
+ * @return {!proto.file.ImageOperation}
+ */
+proto.file.ImageTransformRequest.prototype.addOperations = function(opt_value, opt_index) {
+  return jspb.Message.addToRepeatedWrapperField(this, 2, opt_value, proto.file.ImageOperation, opt_index);
+};
+
+
+proto.file.ImageTransformRequest.prototype.clearOperationsList = function() {
+  this.setOperationsList([]);
+};
+
+
+/**
+ * optional ImageFormat format = 3;
+ * @return {!proto.file.ImageFormat}
+ */
+proto.file.ImageTransformRequest.prototype.getFormat = function() {
+  return /** @type {!proto.file.ImageFormat} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+};
+
+
+/** @param {!proto.file.ImageFormat} value */
+proto.file.ImageTransformRequest.prototype.setFormat = function(value) {
+  jspb.Message.setProto3EnumField(this, 3, value);
+};
+
+
+/**
+ * optional int32 quality = 4;
+ * @return {number}
+ */
+proto.file.ImageTransformRequest.prototype.getQuality = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 4, 0));
+};
+
+
+/** @param {number} value */
+proto.file.ImageTransformRequest.prototype.setQuality = function(value) {
+  jspb.Message.setProto3IntField(this, 4, value);
+};
+
+
+/**
+ * optional string destination = 5;
+ * @return {string}
+ */
+proto.file.ImageTransformRequest.prototype.getDestination = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 5, ""));
+};
+
+
+/** @param {string} value */
+proto.file.ImageTransformRequest.prototype.setDestination = function(value) {
+  jspb.Message.setProto3StringField(this, 5, value);
+};
+
+
+/**
+ * optional ConflictPolicy conflict = 6;
+ * @return {!proto.file.ConflictPolicy}
+ */
+proto.file.ImageTransformRequest.prototype.getConflict = function() {
+  return /** @type {!proto.file.ConflictPolicy} */ (jspb.Message.getFieldWithDefault(this, 6, 0));
+};
+
+
+/** @param {!proto.file.ConflictPolicy} value */
+proto.file.ImageTransformRequest.prototype.setConflict = function(value) {
+  jspb.Message.setProto3EnumField(this, 6, value);
 };
 
 
@@ -1145,12 +7581,12 @@ proto.file.DeleteDirResponse.prototype.setResult = function(value) {
  * @extends {jspb.Message}
  * @constructor
  */
-proto.file.RenameRequest = function(opt_data) {
+proto.file.ImageTransformResponse = function(opt_data) {
   jspb.Message.initialize(this, opt_data, 0, -1, null, null);
 };
-goog.inherits(proto.file.RenameRequest, jspb.Message);
+goog.inherits(proto.file.ImageTransformResponse, jspb.Message);
 if (goog.DEBUG && !COMPILED) {
-  proto.file.RenameRequest.displayName = 'proto.file.RenameRequest';
+  proto.file.ImageTransformResponse.displayName = 'proto.file.ImageTransformResponse';
 }
 
 
@@ -1165,8 +7601,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
  *     for transitional soy proto support: http://goto/soy-param-migration
  * @return {!Object}
  */
-proto.file.RenameRequest.prototype.toObject = function(opt_includeInstance) {
-  return proto.file.RenameRequest.toObject(opt_includeInstance, this);
+proto.file.ImageTransformResponse.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.ImageTransformResponse.toObject(opt_includeInstance, this);
 };
 
 
@@ -1175,15 +7611,17 @@ proto.file.RenameRequest.prototype.toObject = function(opt_includeInstance) {
  * @param {boolean|undefined} includeInstance Whether to include the JSPB
  *     instance for transitional soy proto support:
  *     http://goto/soy-param-migration
- * @param {!proto.file.RenameRequest} msg The msg instance to transform.
+ * @param {!proto.file.ImageTransformResponse} msg The msg instance to transform.
  * @return {!Object}
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.file.RenameRequest.toObject = function(includeInstance, msg) {
+proto.file.ImageTransformResponse.toObject = function(includeInstance, msg) {
   var f, obj = {
-    path: jspb.Message.getFieldWithDefault(msg, 1, ""),
-    newName: jspb.Message.getFieldWithDefault(msg, 2, ""),
-    oldName: jspb.Message.getFieldWithDefault(msg, 3, "")
+    data: msg.getData_asB64(),
+    mime: jspb.Message.getFieldWithDefault(msg, 2, ""),
+    width: jspb.Message.getFieldWithDefault(msg, 3, 0),
+    height: jspb.Message.getFieldWithDefault(msg, 4, 0),
+    path: jspb.Message.getFieldWithDefault(msg, 5, "")
   };
 
   if (includeInstance) {
@@ -1197,23 +7635,23 @@ proto.file.RenameRequest.toObject = function(includeInstance, msg) {
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.file.RenameRequest}
+ * @return {!proto.file.ImageTransformResponse}
  */
-proto.file.RenameRequest.deserializeBinary = function(bytes) {
+proto.file.ImageTransformResponse.deserializeBinary = function(bytes) {
   var reader = new jspb.BinaryReader(bytes);
-  var msg = new proto.file.RenameRequest;
-  return proto.file.RenameRequest.deserializeBinaryFromReader(msg, reader);
+  var msg = new proto.file.ImageTransformResponse;
+  return proto.file.ImageTransformResponse.deserializeBinaryFromReader(msg, reader);
 };
 
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.file.RenameRequest} msg The message object to deserialize into.
+ * @param {!proto.file.ImageTransformResponse} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.file.RenameRequest}
+ * @return {!proto.file.ImageTransformResponse}
  */
-proto.file.RenameRequest.deserializeBinaryFromReader = function(msg, reader) {
+proto.file.ImageTransformResponse.deserializeBinaryFromReader = function(msg, reader) {
   while (reader.nextField()) {
     if (reader.isEndGroup()) {
       break;
@@ -1221,16 +7659,24 @@ proto.file.RenameRequest.deserializeBinaryFromReader = function(msg, reader) {
     var field = reader.getFieldNumber();
     switch (field) {
     case 1:
-      var value = /** @type {string} */ (reader.readString());
-      msg.setPath(value);
+      var value = /** @type {!Uint8Array} */ (reader.readBytes());
+      msg.setData(value);
       break;
     case 2:
       var value = /** @type {string} */ (reader.readString());
-      msg.setNewName(value);
+      msg.setMime(value);
       break;
     case 3:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setWidth(value);
+      break;
+    case 4:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setHeight(value);
+      break;
+    case 5:
       var value = /** @type {string} */ (reader.readString());
-      msg.setOldName(value);
+      msg.setPath(value);
       break;
     default:
       reader.skipField();
@@ -1245,9 +7691,9 @@ proto.file.RenameRequest.deserializeBinaryFromReader = function(msg, reader) {
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.file.RenameRequest.prototype.serializeBinary = function() {
+proto.file.ImageTransformResponse.prototype.serializeBinary = function() {
   var writer = new jspb.BinaryWriter();
-  proto.file.RenameRequest.serializeBinaryToWriter(this, writer);
+  proto.file.ImageTransformResponse.serializeBinaryToWriter(this, writer);
   return writer.getResultBuffer();
 };
 
@@ -1255,30 +7701,44 @@ proto.file.RenameRequest.prototype.serializeBinary = function() {
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.file.RenameRequest} message
+ * @param {!proto.file.ImageTransformResponse} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.file.RenameRequest.serializeBinaryToWriter = function(message, writer) {
+proto.file.ImageTransformResponse.serializeBinaryToWriter = function(message, writer) {
   var f = undefined;
-  f = message.getPath();
+  f = message.getData_asU8();
   if (f.length > 0) {
-    writer.writeString(
+    writer.writeBytes(
       1,
       f
     );
   }
-  f = message.getNewName();
+  f = message.getMime();
   if (f.length > 0) {
     writer.writeString(
       2,
       f
     );
   }
-  f = message.getOldName();
+  f = message.getWidth();
+  if (f !== 0) {
+    writer.writeInt32(
+      3,
+      f
+    );
+  }
+  f = message.getHeight();
+  if (f !== 0) {
+    writer.writeInt32(
+      4,
+      f
+    );
+  }
+  f = message.getPath();
   if (f.length > 0) {
     writer.writeString(
-      3,
+      5,
       f
     );
   }
@@ -1286,47 +7746,101 @@ proto.file.RenameRequest.serializeBinaryToWriter = function(message, writer) {
 
 
 /**
- * optional string path = 1;
+ * optional bytes data = 1;
  * @return {string}
  */
-proto.file.RenameRequest.prototype.getPath = function() {
+proto.file.ImageTransformResponse.prototype.getData = function() {
   return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
 };
 
 
-/** @param {string} value */
-proto.file.RenameRequest.prototype.setPath = function(value) {
-  jspb.Message.setProto3StringField(this, 1, value);
+/**
+ * optional bytes data = 1;
+ * This is a type-conversion wrapper around `getData()`
+ * @return {string}
+ */
+proto.file.ImageTransformResponse.prototype.getData_asB64 = function() {
+  return /** @type {string} */ (jspb.Message.bytesAsB64(
+      this.getData()));
 };
 
 
 /**
- * optional string new_name = 2;
+ * optional bytes data = 1;
+ * Note that Uint8Array is not supported on all browsers.
+ * @see http://caniuse.com/Uint8Array
+ * This is a type-conversion wrapper around `getData()`
+ * @return {!Uint8Array}
+ */
+proto.file.ImageTransformResponse.prototype.getData_asU8 = function() {
+  return /** @type {!Uint8Array} */ (jspb.Message.bytesAsU8(
+      this.getData()));
+};
+
+
+/** @param {!(string|Uint8Array)} value */
+proto.file.ImageTransformResponse.prototype.setData = function(value) {
+  jspb.Message.setProto3BytesField(this, 1, value);
+};
+
+
+/**
+ * optional string mime = 2;
  * @return {string}
  */
-proto.file.RenameRequest.prototype.getNewName = function() {
+proto.file.ImageTransformResponse.prototype.getMime = function() {
   return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
 };
 
 
 /** @param {string} value */
-proto.file.RenameRequest.prototype.setNewName = function(value) {
+proto.file.ImageTransformResponse.prototype.setMime = function(value) {
   jspb.Message.setProto3StringField(this, 2, value);
 };
 
 
 /**
- * optional string old_name = 3;
+ * optional int32 width = 3;
+ * @return {number}
+ */
+proto.file.ImageTransformResponse.prototype.getWidth = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+};
+
+
+/** @param {number} value */
+proto.file.ImageTransformResponse.prototype.setWidth = function(value) {
+  jspb.Message.setProto3IntField(this, 3, value);
+};
+
+
+/**
+ * optional int32 height = 4;
+ * @return {number}
+ */
+proto.file.ImageTransformResponse.prototype.getHeight = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 4, 0));
+};
+
+
+/** @param {number} value */
+proto.file.ImageTransformResponse.prototype.setHeight = function(value) {
+  jspb.Message.setProto3IntField(this, 4, value);
+};
+
+
+/**
+ * optional string path = 5;
  * @return {string}
  */
-proto.file.RenameRequest.prototype.getOldName = function() {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
+proto.file.ImageTransformResponse.prototype.getPath = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 5, ""));
 };
 
 
 /** @param {string} value */
-proto.file.RenameRequest.prototype.setOldName = function(value) {
-  jspb.Message.setProto3StringField(this, 3, value);
+proto.file.ImageTransformResponse.prototype.setPath = function(value) {
+  jspb.Message.setProto3StringField(this, 5, value);
 };
 
 
@@ -1341,13 +7855,20 @@ proto.file.RenameRequest.prototype.setOldName = function(value) {
  * @extends {jspb.Message}
  * @constructor
  */
-proto.file.RenameResponse = function(opt_data) {
-  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+proto.file.CreateArchiveRequest = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.file.CreateArchiveRequest.repeatedFields_, null);
 };
-goog.inherits(proto.file.RenameResponse, jspb.Message);
+goog.inherits(proto.file.CreateArchiveRequest, jspb.Message);
 if (goog.DEBUG && !COMPILED) {
-  proto.file.RenameResponse.displayName = 'proto.file.RenameResponse';
+  proto.file.CreateArchiveRequest.displayName = 'proto.file.CreateArchiveRequest';
 }
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.file.CreateArchiveRequest.repeatedFields_ = [1];
+
 
 
 if (jspb.Message.GENERATE_TO_OBJECT) {
@@ -1361,8 +7882,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
  *     for transitional soy proto support: http://goto/soy-param-migration
  * @return {!Object}
  */
-proto.file.RenameResponse.prototype.toObject = function(opt_includeInstance) {
-  return proto.file.RenameResponse.toObject(opt_includeInstance, this);
+proto.file.CreateArchiveRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.CreateArchiveRequest.toObject(opt_includeInstance, this);
 };
 
 
@@ -1371,13 +7892,16 @@ proto.file.RenameResponse.prototype.toObject = function(opt_includeInstance) {
  * @param {boolean|undefined} includeInstance Whether to include the JSPB
  *     instance for transitional soy proto support:
  *     http://goto/soy-param-migration
- * @param {!proto.file.RenameResponse} msg The msg instance to transform.
+ * @param {!proto.file.CreateArchiveRequest} msg The msg instance to transform.
  * @return {!Object}
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.file.RenameResponse.toObject = function(includeInstance, msg) {
+proto.file.CreateArchiveRequest.toObject = function(includeInstance, msg) {
   var f, obj = {
-    result: jspb.Message.getFieldWithDefault(msg, 1, false)
+    pathsList: (f = jspb.Message.getRepeatedField(msg, 1)) == null ? undefined : f,
+    format: jspb.Message.getFieldWithDefault(msg, 2, 0),
+    destination: jspb.Message.getFieldWithDefault(msg, 3, ""),
+    conflict: jspb.Message.getFieldWithDefault(msg, 4, 0)
   };
 
   if (includeInstance) {
@@ -1391,23 +7915,23 @@ proto.file.RenameResponse.toObject = function(includeInstance, msg) {
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.file.RenameResponse}
+ * @return {!proto.file.CreateArchiveRequest}
  */
-proto.file.RenameResponse.deserializeBinary = function(bytes) {
+proto.file.CreateArchiveRequest.deserializeBinary = function(bytes) {
   var reader = new jspb.BinaryReader(bytes);
-  var msg = new proto.file.RenameResponse;
-  return proto.file.RenameResponse.deserializeBinaryFromReader(msg, reader);
+  var msg = new proto.file.CreateArchiveRequest;
+  return proto.file.CreateArchiveRequest.deserializeBinaryFromReader(msg, reader);
 };
 
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.file.RenameResponse} msg The message object to deserialize into.
+ * @param {!proto.file.CreateArchiveRequest} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.file.RenameResponse}
+ * @return {!proto.file.CreateArchiveRequest}
  */
-proto.file.RenameResponse.deserializeBinaryFromReader = function(msg, reader) {
+proto.file.CreateArchiveRequest.deserializeBinaryFromReader = function(msg, reader) {
   while (reader.nextField()) {
     if (reader.isEndGroup()) {
       break;
@@ -1415,8 +7939,20 @@ proto.file.RenameResponse.deserializeBinaryFromReader = function(msg, reader) {
     var field = reader.getFieldNumber();
     switch (field) {
     case 1:
-      var value = /** @type {boolean} */ (reader.readBool());
-      msg.setResult(value);
+      var value = /** @type {string} */ (reader.readString());
+      msg.addPaths(value);
+      break;
+    case 2:
+      var value = /** @type {!proto.file.ArchiveFormat} */ (reader.readEnum());
+      msg.setFormat(value);
+      break;
+    case 3:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setDestination(value);
+      break;
+    case 4:
+      var value = /** @type {!proto.file.ConflictPolicy} */ (reader.readEnum());
+      msg.setConflict(value);
       break;
     default:
       reader.skipField();
@@ -1431,9 +7967,9 @@ proto.file.RenameResponse.deserializeBinaryFromReader = function(msg, reader) {
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.file.RenameResponse.prototype.serializeBinary = function() {
+proto.file.CreateArchiveRequest.prototype.serializeBinary = function() {
   var writer = new jspb.BinaryWriter();
-  proto.file.RenameResponse.serializeBinaryToWriter(this, writer);
+  proto.file.CreateArchiveRequest.serializeBinaryToWriter(this, writer);
   return writer.getResultBuffer();
 };
 
@@ -1441,36 +7977,114 @@ proto.file.RenameResponse.prototype.serializeBinary = function() {
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.file.RenameResponse} message
+ * @param {!proto.file.CreateArchiveRequest} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.file.RenameResponse.serializeBinaryToWriter = function(message, writer) {
+proto.file.CreateArchiveRequest.serializeBinaryToWriter = function(message, writer) {
   var f = undefined;
-  f = message.getResult();
-  if (f) {
-    writer.writeBool(
+  f = message.getPathsList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
       1,
       f
     );
   }
+  f = message.getFormat();
+  if (f !== 0.0) {
+    writer.writeEnum(
+      2,
+      f
+    );
+  }
+  f = message.getDestination();
+  if (f.length > 0) {
+    writer.writeString(
+      3,
+      f
+    );
+  }
+  f = message.getConflict();
+  if (f !== 0.0) {
+    writer.writeEnum(
+      4,
+      f
+    );
+  }
 };
 
 
 /**
- * optional bool result = 1;
- * Note that Boolean fields may be set to 0/1 when serialized from a Java server.
- * You should avoid comparisons like {@code val === true/false} in those cases.
- * @return {boolean}
+ * repeated string paths = 1;
+ * @return {!Array<string>}
  */
-proto.file.RenameResponse.prototype.getResult = function() {
-  return /** @type {boolean} */ (jspb.Message.getFieldWithDefault(this, 1, false));
+proto.file.CreateArchiveRequest.prototype.getPathsList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 1));
 };
 
 
-/** @param {boolean} value */
-proto.file.RenameResponse.prototype.setResult = function(value) {
-  jspb.Message.setProto3BooleanField(this, 1, value);
+/** @param {!Array<string>} value */
+proto.file.CreateArchiveRequest.prototype.setPathsList = function(value) {
+  jspb.Message.setField(this, 1, value || []);
+};
+
+
+/**
+ * @param {string} value
+ * @param {number=} opt_index
+ */
+proto.file.CreateArchiveRequest.prototype.addPaths = function(value, opt_index) {
+  jspb.Message.addToRepeatedField(this, 1, value, opt_index);
+};
+
+
+proto.file.CreateArchiveRequest.prototype.clearPathsList = function() {
+  this.setPathsList([]);
+};
+
+
+/**
+ * optional ArchiveFormat format = 2;
+ * @return {!proto.file.ArchiveFormat}
+ */
+proto.file.CreateArchiveRequest.prototype.getFormat = function() {
+  return /** @type {!proto.file.ArchiveFormat} */ (jspb.Message.getFieldWithDefault(this, 2, 0));
+};
+
+
+/** @param {!proto.file.ArchiveFormat} value */
+proto.file.CreateArchiveRequest.prototype.setFormat = function(value) {
+  jspb.Message.setProto3EnumField(this, 2, value);
+};
+
+
+/**
+ * optional string destination = 3;
+ * @return {string}
+ */
+proto.file.CreateArchiveRequest.prototype.getDestination = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
+};
+
+
+/** @param {string} value */
+proto.file.CreateArchiveRequest.prototype.setDestination = function(value) {
+  jspb.Message.setProto3StringField(this, 3, value);
+};
+
+
+/**
+ * optional ConflictPolicy conflict = 4;
+ * @return {!proto.file.ConflictPolicy}
+ */
+proto.file.CreateArchiveRequest.prototype.getConflict = function() {
+  return /** @type {!proto.file.ConflictPolicy} */ (jspb.Message.getFieldWithDefault(this, 4, 0));
+};
+
+
+/** @param {!proto.file.ConflictPolicy} value */
+proto.file.CreateArchiveRequest.prototype.setConflict = function(value) {
+  jspb.Message.setProto3EnumField(this, 4, value);
 };
 
 
@@ -1485,12 +8099,12 @@ proto.file.RenameResponse.prototype.setResult = function(value) {
  * @extends {jspb.Message}
  * @constructor
  */
-proto.file.GetFileInfoRequest = function(opt_data) {
+proto.file.CreateArchiveResponse = function(opt_data) {
   jspb.Message.initialize(this, opt_data, 0, -1, null, null);
 };
-goog.inherits(proto.file.GetFileInfoRequest, jspb.Message);
+goog.inherits(proto.file.CreateArchiveResponse, jspb.Message);
 if (goog.DEBUG && !COMPILED) {
-  proto.file.GetFileInfoRequest.displayName = 'proto.file.GetFileInfoRequest';
+  proto.file.CreateArchiveResponse.displayName = 'proto.file.CreateArchiveResponse';
 }
 
 
@@ -1505,8 +8119,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
  *     for transitional soy proto support: http://goto/soy-param-migration
  * @return {!Object}
  */
-proto.file.GetFileInfoRequest.prototype.toObject = function(opt_includeInstance) {
-  return proto.file.GetFileInfoRequest.toObject(opt_includeInstance, this);
+proto.file.CreateArchiveResponse.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.CreateArchiveResponse.toObject(opt_includeInstance, this);
 };
 
 
@@ -1515,15 +8129,14 @@ proto.file.GetFileInfoRequest.prototype.toObject = function(opt_includeInstance)
  * @param {boolean|undefined} includeInstance Whether to include the JSPB
  *     instance for transitional soy proto support:
  *     http://goto/soy-param-migration
- * @param {!proto.file.GetFileInfoRequest} msg The msg instance to transform.
+ * @param {!proto.file.CreateArchiveResponse} msg The msg instance to transform.
  * @return {!Object}
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.file.GetFileInfoRequest.toObject = function(includeInstance, msg) {
+proto.file.CreateArchiveResponse.toObject = function(includeInstance, msg) {
   var f, obj = {
-    path: jspb.Message.getFieldWithDefault(msg, 1, ""),
-    thumnailwidth: jspb.Message.getFieldWithDefault(msg, 2, 0),
-    thumnailheight: jspb.Message.getFieldWithDefault(msg, 3, 0)
+    data: msg.getData_asB64(),
+    progress: (f = msg.getProgress()) && proto.file.TransferProgress.toObject(includeInstance, f)
   };
 
   if (includeInstance) {
@@ -1537,23 +8150,23 @@ proto.file.GetFileInfoRequest.toObject = function(includeInstance, msg) {
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.file.GetFileInfoRequest}
+ * @return {!proto.file.CreateArchiveResponse}
  */
-proto.file.GetFileInfoRequest.deserializeBinary = function(bytes) {
+proto.file.CreateArchiveResponse.deserializeBinary = function(bytes) {
   var reader = new jspb.BinaryReader(bytes);
-  var msg = new proto.file.GetFileInfoRequest;
-  return proto.file.GetFileInfoRequest.deserializeBinaryFromReader(msg, reader);
+  var msg = new proto.file.CreateArchiveResponse;
+  return proto.file.CreateArchiveResponse.deserializeBinaryFromReader(msg, reader);
 };
 
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.file.GetFileInfoRequest} msg The message object to deserialize into.
+ * @param {!proto.file.CreateArchiveResponse} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.file.GetFileInfoRequest}
+ * @return {!proto.file.CreateArchiveResponse}
  */
-proto.file.GetFileInfoRequest.deserializeBinaryFromReader = function(msg, reader) {
+proto.file.CreateArchiveResponse.deserializeBinaryFromReader = function(msg, reader) {
   while (reader.nextField()) {
     if (reader.isEndGroup()) {
       break;
@@ -1561,16 +8174,13 @@ proto.file.GetFileInfoRequest.deserializeBinaryFromReader = function(msg, reader
     var field = reader.getFieldNumber();
     switch (field) {
     case 1:
-      var value = /** @type {string} */ (reader.readString());
-      msg.setPath(value);
+      var value = /** @type {!Uint8Array} */ (reader.readBytes());
+      msg.setData(value);
       break;
     case 2:
-      var value = /** @type {number} */ (reader.readInt32());
-      msg.setThumnailwidth(value);
-      break;
-    case 3:
-      var value = /** @type {number} */ (reader.readInt32());
-      msg.setThumnailheight(value);
+      var value = new proto.file.TransferProgress;
+      reader.readMessage(value,proto.file.TransferProgress.deserializeBinaryFromReader);
+      msg.setProgress(value);
       break;
     default:
       reader.skipField();
@@ -1585,9 +8195,9 @@ proto.file.GetFileInfoRequest.deserializeBinaryFromReader = function(msg, reader
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.file.GetFileInfoRequest.prototype.serializeBinary = function() {
+proto.file.CreateArchiveResponse.prototype.serializeBinary = function() {
   var writer = new jspb.BinaryWriter();
-  proto.file.GetFileInfoRequest.serializeBinaryToWriter(this, writer);
+  proto.file.CreateArchiveResponse.serializeBinaryToWriter(this, writer);
   return writer.getResultBuffer();
 };
 
@@ -1595,78 +8205,96 @@ proto.file.GetFileInfoRequest.prototype.serializeBinary = function() {
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.file.GetFileInfoRequest} message
+ * @param {!proto.file.CreateArchiveResponse} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.file.GetFileInfoRequest.serializeBinaryToWriter = function(message, writer) {
+proto.file.CreateArchiveResponse.serializeBinaryToWriter = function(message, writer) {
   var f = undefined;
-  f = message.getPath();
+  f = message.getData_asU8();
   if (f.length > 0) {
-    writer.writeString(
+    writer.writeBytes(
       1,
       f
     );
   }
-  f = message.getThumnailwidth();
-  if (f !== 0) {
-    writer.writeInt32(
+  f = message.getProgress();
+  if (f != null) {
+    writer.writeMessage(
       2,
-      f
-    );
-  }
-  f = message.getThumnailheight();
-  if (f !== 0) {
-    writer.writeInt32(
-      3,
-      f
+      f,
+      proto.file.TransferProgress.serializeBinaryToWriter
     );
   }
 };
 
 
 /**
- * optional string path = 1;
+ * optional bytes data = 1;
  * @return {string}
  */
-proto.file.GetFileInfoRequest.prototype.getPath = function() {
+proto.file.CreateArchiveResponse.prototype.getData = function() {
   return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
 };
 
 
-/** @param {string} value */
-proto.file.GetFileInfoRequest.prototype.setPath = function(value) {
-  jspb.Message.setProto3StringField(this, 1, value);
+/**
+ * optional bytes data = 1;
+ * This is a type-conversion wrapper around `getData()`
+ * @return {string}
+ */
+proto.file.CreateArchiveResponse.prototype.getData_asB64 = function() {
+  return /** @type {string} */ (jspb.Message.bytesAsB64(
+      this.getData()));
 };
 
 
 /**
- * optional int32 thumnailWidth = 2;
- * @return {number}
+ * optional bytes data = 1;
+ * Note that Uint8Array is not supported on all browsers.
+ * @see http://caniuse.com/Uint8Array
+ * This is a type-conversion wrapper around `getData()`
+ * @return {!Uint8Array}
  */
-proto.file.GetFileInfoRequest.prototype.getThumnailwidth = function() {
-  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 2, 0));
+proto.file.CreateArchiveResponse.prototype.getData_asU8 = function() {
+  return /** @type {!Uint8Array} */ (jspb.Message.bytesAsU8(
+      this.getData()));
 };
 
 
-/** @param {number} value */
-proto.file.GetFileInfoRequest.prototype.setThumnailwidth = function(value) {
-  jspb.Message.setProto3IntField(this, 2, value);
+/** @param {!(string|Uint8Array)} value */
+proto.file.CreateArchiveResponse.prototype.setData = function(value) {
+  jspb.Message.setProto3BytesField(this, 1, value);
 };
 
 
 /**
- * optional int32 thumnailHeight = 3;
- * @return {number}
+ * optional TransferProgress progress = 2;
+ * @return {?proto.file.TransferProgress}
  */
-proto.file.GetFileInfoRequest.prototype.getThumnailheight = function() {
-  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+proto.file.CreateArchiveResponse.prototype.getProgress = function() {
+  return /** @type{?proto.file.TransferProgress} */ (
+    jspb.Message.getWrapperField(this, proto.file.TransferProgress, 2));
 };
 
 
-/** @param {number} value */
-proto.file.GetFileInfoRequest.prototype.setThumnailheight = function(value) {
-  jspb.Message.setProto3IntField(this, 3, value);
+/** @param {?proto.file.TransferProgress|undefined} value */
+proto.file.CreateArchiveResponse.prototype.setProgress = function(value) {
+  jspb.Message.setWrapperField(this, 2, value);
+};
+
+
+proto.file.CreateArchiveResponse.prototype.clearProgress = function() {
+  this.setProgress(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {!boolean}
+ */
+proto.file.CreateArchiveResponse.prototype.hasProgress = function() {
+  return jspb.Message.getField(this, 2) != null;
 };
 
 
@@ -1681,12 +8309,12 @@ proto.file.GetFileInfoRequest.prototype.setThumnailheight = function(value) {
  * @extends {jspb.Message}
  * @constructor
  */
-proto.file.GetFileInfoResponse = function(opt_data) {
+proto.file.ExtractArchiveRequest = function(opt_data) {
   jspb.Message.initialize(this, opt_data, 0, -1, null, null);
 };
-goog.inherits(proto.file.GetFileInfoResponse, jspb.Message);
+goog.inherits(proto.file.ExtractArchiveRequest, jspb.Message);
 if (goog.DEBUG && !COMPILED) {
-  proto.file.GetFileInfoResponse.displayName = 'proto.file.GetFileInfoResponse';
+  proto.file.ExtractArchiveRequest.displayName = 'proto.file.ExtractArchiveRequest';
 }
 
 
@@ -1701,8 +8329,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
  *     for transitional soy proto support: http://goto/soy-param-migration
  * @return {!Object}
  */
-proto.file.GetFileInfoResponse.prototype.toObject = function(opt_includeInstance) {
-  return proto.file.GetFileInfoResponse.toObject(opt_includeInstance, this);
+proto.file.ExtractArchiveRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.ExtractArchiveRequest.toObject(opt_includeInstance, this);
 };
 
 
@@ -1711,13 +8339,17 @@ proto.file.GetFileInfoResponse.prototype.toObject = function(opt_includeInstance
  * @param {boolean|undefined} includeInstance Whether to include the JSPB
  *     instance for transitional soy proto support:
  *     http://goto/soy-param-migration
- * @param {!proto.file.GetFileInfoResponse} msg The msg instance to transform.
+ * @param {!proto.file.ExtractArchiveRequest} msg The msg instance to transform.
  * @return {!Object}
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.file.GetFileInfoResponse.toObject = function(includeInstance, msg) {
+proto.file.ExtractArchiveRequest.toObject = function(includeInstance, msg) {
   var f, obj = {
-    data: jspb.Message.getFieldWithDefault(msg, 1, "")
+    path: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    destination: jspb.Message.getFieldWithDefault(msg, 2, ""),
+    conflict: jspb.Message.getFieldWithDefault(msg, 3, 0),
+    maxsize: jspb.Message.getFieldWithDefault(msg, 4, 0),
+    maxfiles: jspb.Message.getFieldWithDefault(msg, 5, 0)
   };
 
   if (includeInstance) {
@@ -1731,23 +8363,23 @@ proto.file.GetFileInfoResponse.toObject = function(includeInstance, msg) {
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.file.GetFileInfoResponse}
+ * @return {!proto.file.ExtractArchiveRequest}
  */
-proto.file.GetFileInfoResponse.deserializeBinary = function(bytes) {
+proto.file.ExtractArchiveRequest.deserializeBinary = function(bytes) {
   var reader = new jspb.BinaryReader(bytes);
-  var msg = new proto.file.GetFileInfoResponse;
-  return proto.file.GetFileInfoResponse.deserializeBinaryFromReader(msg, reader);
+  var msg = new proto.file.ExtractArchiveRequest;
+  return proto.file.ExtractArchiveRequest.deserializeBinaryFromReader(msg, reader);
 };
 
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.file.GetFileInfoResponse} msg The message object to deserialize into.
+ * @param {!proto.file.ExtractArchiveRequest} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.file.GetFileInfoResponse}
+ * @return {!proto.file.ExtractArchiveRequest}
  */
-proto.file.GetFileInfoResponse.deserializeBinaryFromReader = function(msg, reader) {
+proto.file.ExtractArchiveRequest.deserializeBinaryFromReader = function(msg, reader) {
   while (reader.nextField()) {
     if (reader.isEndGroup()) {
       break;
@@ -1756,7 +8388,23 @@ proto.file.GetFileInfoResponse.deserializeBinaryFromReader = function(msg, reade
     switch (field) {
     case 1:
       var value = /** @type {string} */ (reader.readString());
-      msg.setData(value);
+      msg.setPath(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setDestination(value);
+      break;
+    case 3:
+      var value = /** @type {!proto.file.ConflictPolicy} */ (reader.readEnum());
+      msg.setConflict(value);
+      break;
+    case 4:
+      var value = /** @type {number} */ (reader.readInt64());
+      msg.setMaxsize(value);
+      break;
+    case 5:
+      var value = /** @type {number} */ (reader.readInt64());
+      msg.setMaxfiles(value);
       break;
     default:
       reader.skipField();
@@ -1771,9 +8419,9 @@ proto.file.GetFileInfoResponse.deserializeBinaryFromReader = function(msg, reade
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.file.GetFileInfoResponse.prototype.serializeBinary = function() {
+proto.file.ExtractArchiveRequest.prototype.serializeBinary = function() {
   var writer = new jspb.BinaryWriter();
-  proto.file.GetFileInfoResponse.serializeBinaryToWriter(this, writer);
+  proto.file.ExtractArchiveRequest.serializeBinaryToWriter(this, writer);
   return writer.getResultBuffer();
 };
 
@@ -1781,34 +8429,122 @@ proto.file.GetFileInfoResponse.prototype.serializeBinary = function() {
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.file.GetFileInfoResponse} message
+ * @param {!proto.file.ExtractArchiveRequest} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.file.GetFileInfoResponse.serializeBinaryToWriter = function(message, writer) {
+proto.file.ExtractArchiveRequest.serializeBinaryToWriter = function(message, writer) {
   var f = undefined;
-  f = message.getData();
+  f = message.getPath();
   if (f.length > 0) {
     writer.writeString(
       1,
       f
     );
   }
+  f = message.getDestination();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+  f = message.getConflict();
+  if (f !== 0.0) {
+    writer.writeEnum(
+      3,
+      f
+    );
+  }
+  f = message.getMaxsize();
+  if (f !== 0) {
+    writer.writeInt64(
+      4,
+      f
+    );
+  }
+  f = message.getMaxfiles();
+  if (f !== 0) {
+    writer.writeInt64(
+      5,
+      f
+    );
+  }
 };
 
 
 /**
- * optional string data = 1;
+ * optional string path = 1;
+ * @return {string}
+ */
+proto.file.ExtractArchiveRequest.prototype.getPath = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/** @param {string} value */
+proto.file.ExtractArchiveRequest.prototype.setPath = function(value) {
+  jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional string destination = 2;
  * @return {string}
  */
-proto.file.GetFileInfoResponse.prototype.getData = function() {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+proto.file.ExtractArchiveRequest.prototype.getDestination = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/** @param {string} value */
+proto.file.ExtractArchiveRequest.prototype.setDestination = function(value) {
+  jspb.Message.setProto3StringField(this, 2, value);
+};
+
+
+/**
+ * optional ConflictPolicy conflict = 3;
+ * @return {!proto.file.ConflictPolicy}
+ */
+proto.file.ExtractArchiveRequest.prototype.getConflict = function() {
+  return /** @type {!proto.file.ConflictPolicy} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+};
+
+
+/** @param {!proto.file.ConflictPolicy} value */
+proto.file.ExtractArchiveRequest.prototype.setConflict = function(value) {
+  jspb.Message.setProto3EnumField(this, 3, value);
+};
+
+
+/**
+ * optional int64 maxSize = 4;
+ * @return {number}
+ */
+proto.file.ExtractArchiveRequest.prototype.getMaxsize = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 4, 0));
+};
+
+
+/** @param {number} value */
+proto.file.ExtractArchiveRequest.prototype.setMaxsize = function(value) {
+  jspb.Message.setProto3IntField(this, 4, value);
+};
+
+
+/**
+ * optional int64 maxFiles = 5;
+ * @return {number}
+ */
+proto.file.ExtractArchiveRequest.prototype.getMaxfiles = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 5, 0));
 };
 
 
-/** @param {string} value */
-proto.file.GetFileInfoResponse.prototype.setData = function(value) {
-  jspb.Message.setProto3StringField(this, 1, value);
+/** @param {number} value */
+proto.file.ExtractArchiveRequest.prototype.setMaxfiles = function(value) {
+  jspb.Message.setProto3IntField(this, 5, value);
 };
 
 
@@ -1823,12 +8559,12 @@ proto.file.GetFileInfoResponse.prototype.setData = function(value) {
  * @extends {jspb.Message}
  * @constructor
  */
-proto.file.ReadFileRequest = function(opt_data) {
+proto.file.ExtractArchiveResponse = function(opt_data) {
   jspb.Message.initialize(this, opt_data, 0, -1, null, null);
 };
-goog.inherits(proto.file.ReadFileRequest, jspb.Message);
+goog.inherits(proto.file.ExtractArchiveResponse, jspb.Message);
 if (goog.DEBUG && !COMPILED) {
-  proto.file.ReadFileRequest.displayName = 'proto.file.ReadFileRequest';
+  proto.file.ExtractArchiveResponse.displayName = 'proto.file.ExtractArchiveResponse';
 }
 
 
@@ -1843,8 +8579,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
  *     for transitional soy proto support: http://goto/soy-param-migration
  * @return {!Object}
  */
-proto.file.ReadFileRequest.prototype.toObject = function(opt_includeInstance) {
-  return proto.file.ReadFileRequest.toObject(opt_includeInstance, this);
+proto.file.ExtractArchiveResponse.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.ExtractArchiveResponse.toObject(opt_includeInstance, this);
 };
 
 
@@ -1853,13 +8589,13 @@ proto.file.ReadFileRequest.prototype.toObject = function(opt_includeInstance) {
  * @param {boolean|undefined} includeInstance Whether to include the JSPB
  *     instance for transitional soy proto support:
  *     http://goto/soy-param-migration
- * @param {!proto.file.ReadFileRequest} msg The msg instance to transform.
+ * @param {!proto.file.ExtractArchiveResponse} msg The msg instance to transform.
  * @return {!Object}
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.file.ReadFileRequest.toObject = function(includeInstance, msg) {
+proto.file.ExtractArchiveResponse.toObject = function(includeInstance, msg) {
   var f, obj = {
-    path: jspb.Message.getFieldWithDefault(msg, 1, "")
+    progress: (f = msg.getProgress()) && proto.file.TransferProgress.toObject(includeInstance, f)
   };
 
   if (includeInstance) {
@@ -1873,23 +8609,23 @@ proto.file.ReadFileRequest.toObject = function(includeInstance, msg) {
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.file.ReadFileRequest}
+ * @return {!proto.file.ExtractArchiveResponse}
  */
-proto.file.ReadFileRequest.deserializeBinary = function(bytes) {
+proto.file.ExtractArchiveResponse.deserializeBinary = function(bytes) {
   var reader = new jspb.BinaryReader(bytes);
-  var msg = new proto.file.ReadFileRequest;
-  return proto.file.ReadFileRequest.deserializeBinaryFromReader(msg, reader);
+  var msg = new proto.file.ExtractArchiveResponse;
+  return proto.file.ExtractArchiveResponse.deserializeBinaryFromReader(msg, reader);
 };
 
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.file.ReadFileRequest} msg The message object to deserialize into.
+ * @param {!proto.file.ExtractArchiveResponse} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.file.ReadFileRequest}
+ * @return {!proto.file.ExtractArchiveResponse}
  */
-proto.file.ReadFileRequest.deserializeBinaryFromReader = function(msg, reader) {
+proto.file.ExtractArchiveResponse.deserializeBinaryFromReader = function(msg, reader) {
   while (reader.nextField()) {
     if (reader.isEndGroup()) {
       break;
@@ -1897,8 +8633,9 @@ proto.file.ReadFileRequest.deserializeBinaryFromReader = function(msg, reader) {
     var field = reader.getFieldNumber();
     switch (field) {
     case 1:
-      var value = /** @type {string} */ (reader.readString());
-      msg.setPath(value);
+      var value = new proto.file.TransferProgress;
+      reader.readMessage(value,proto.file.TransferProgress.deserializeBinaryFromReader);
+      msg.setProgress(value);
       break;
     default:
       reader.skipField();
@@ -1913,9 +8650,9 @@ proto.file.ReadFileRequest.deserializeBinaryFromReader = function(msg, reader) {
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.file.ReadFileRequest.prototype.serializeBinary = function() {
+proto.file.ExtractArchiveResponse.prototype.serializeBinary = function() {
   var writer = new jspb.BinaryWriter();
-  proto.file.ReadFileRequest.serializeBinaryToWriter(this, writer);
+  proto.file.ExtractArchiveResponse.serializeBinaryToWriter(this, writer);
   return writer.getResultBuffer();
 };
 
@@ -1923,34 +8660,50 @@ proto.file.ReadFileRequest.prototype.serializeBinary = function() {
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.file.ReadFileRequest} message
+ * @param {!proto.file.ExtractArchiveResponse} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.file.ReadFileRequest.serializeBinaryToWriter = function(message, writer) {
+proto.file.ExtractArchiveResponse.serializeBinaryToWriter = function(message, writer) {
   var f = undefined;
-  f = message.getPath();
-  if (f.length > 0) {
-    writer.writeString(
+  f = message.getProgress();
+  if (f != null) {
+    writer.writeMessage(
       1,
-      f
+      f,
+      proto.file.TransferProgress.serializeBinaryToWriter
     );
   }
 };
 
 
 /**
- * optional string path = 1;
- * @return {string}
+ * optional TransferProgress progress = 1;
+ * @return {?proto.file.TransferProgress}
  */
-proto.file.ReadFileRequest.prototype.getPath = function() {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+proto.file.ExtractArchiveResponse.prototype.getProgress = function() {
+  return /** @type{?proto.file.TransferProgress} */ (
+    jspb.Message.getWrapperField(this, proto.file.TransferProgress, 1));
 };
 
 
-/** @param {string} value */
-proto.file.ReadFileRequest.prototype.setPath = function(value) {
-  jspb.Message.setProto3StringField(this, 1, value);
+/** @param {?proto.file.TransferProgress|undefined} value */
+proto.file.ExtractArchiveResponse.prototype.setProgress = function(value) {
+  jspb.Message.setWrapperField(this, 1, value);
+};
+
+
+proto.file.ExtractArchiveResponse.prototype.clearProgress = function() {
+  this.setProgress(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {!boolean}
+ */
+proto.file.ExtractArchiveResponse.prototype.hasProgress = function() {
+  return jspb.Message.getField(this, 1) != null;
 };
 
 
@@ -1965,12 +8718,12 @@ proto.file.ReadFileRequest.prototype.setPath = function(value) {
  * @extends {jspb.Message}
  * @constructor
  */
-proto.file.ReadFileResponse = function(opt_data) {
+proto.file.ListArchiveRequest = function(opt_data) {
   jspb.Message.initialize(this, opt_data, 0, -1, null, null);
 };
-goog.inherits(proto.file.ReadFileResponse, jspb.Message);
+goog.inherits(proto.file.ListArchiveRequest, jspb.Message);
 if (goog.DEBUG && !COMPILED) {
-  proto.file.ReadFileResponse.displayName = 'proto.file.ReadFileResponse';
+  proto.file.ListArchiveRequest.displayName = 'proto.file.ListArchiveRequest';
 }
 
 
@@ -1985,8 +8738,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
  *     for transitional soy proto support: http://goto/soy-param-migration
  * @return {!Object}
  */
-proto.file.ReadFileResponse.prototype.toObject = function(opt_includeInstance) {
-  return proto.file.ReadFileResponse.toObject(opt_includeInstance, this);
+proto.file.ListArchiveRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.ListArchiveRequest.toObject(opt_includeInstance, this);
 };
 
 
@@ -1995,13 +8748,13 @@ proto.file.ReadFileResponse.prototype.toObject = function(opt_includeInstance) {
  * @param {boolean|undefined} includeInstance Whether to include the JSPB
  *     instance for transitional soy proto support:
  *     http://goto/soy-param-migration
- * @param {!proto.file.ReadFileResponse} msg The msg instance to transform.
+ * @param {!proto.file.ListArchiveRequest} msg The msg instance to transform.
  * @return {!Object}
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.file.ReadFileResponse.toObject = function(includeInstance, msg) {
+proto.file.ListArchiveRequest.toObject = function(includeInstance, msg) {
   var f, obj = {
-    data: msg.getData_asB64()
+    path: jspb.Message.getFieldWithDefault(msg, 1, "")
   };
 
   if (includeInstance) {
@@ -2015,23 +8768,23 @@ proto.file.ReadFileResponse.toObject = function(includeInstance, msg) {
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.file.ReadFileResponse}
+ * @return {!proto.file.ListArchiveRequest}
  */
-proto.file.ReadFileResponse.deserializeBinary = function(bytes) {
+proto.file.ListArchiveRequest.deserializeBinary = function(bytes) {
   var reader = new jspb.BinaryReader(bytes);
-  var msg = new proto.file.ReadFileResponse;
-  return proto.file.ReadFileResponse.deserializeBinaryFromReader(msg, reader);
+  var msg = new proto.file.ListArchiveRequest;
+  return proto.file.ListArchiveRequest.deserializeBinaryFromReader(msg, reader);
 };
 
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.file.ReadFileResponse} msg The message object to deserialize into.
+ * @param {!proto.file.ListArchiveRequest} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.file.ReadFileResponse}
+ * @return {!proto.file.ListArchiveRequest}
  */
-proto.file.ReadFileResponse.deserializeBinaryFromReader = function(msg, reader) {
+proto.file.ListArchiveRequest.deserializeBinaryFromReader = function(msg, reader) {
   while (reader.nextField()) {
     if (reader.isEndGroup()) {
       break;
@@ -2039,8 +8792,8 @@ proto.file.ReadFileResponse.deserializeBinaryFromReader = function(msg, reader)
     var field = reader.getFieldNumber();
     switch (field) {
     case 1:
-      var value = /** @type {!Uint8Array} */ (reader.readBytes());
-      msg.setData(value);
+      var value = /** @type {string} */ (reader.readString());
+      msg.setPath(value);
       break;
     default:
       reader.skipField();
@@ -2055,9 +8808,9 @@ proto.file.ReadFileResponse.deserializeBinaryFromReader = function(msg, reader)
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.file.ReadFileResponse.prototype.serializeBinary = function() {
+proto.file.ListArchiveRequest.prototype.serializeBinary = function() {
   var writer = new jspb.BinaryWriter();
-  proto.file.ReadFileResponse.serializeBinaryToWriter(this, writer);
+  proto.file.ListArchiveRequest.serializeBinaryToWriter(this, writer);
   return writer.getResultBuffer();
 };
 
@@ -2065,15 +8818,15 @@ proto.file.ReadFileResponse.prototype.serializeBinary = function() {
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.file.ReadFileResponse} message
+ * @param {!proto.file.ListArchiveRequest} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.file.ReadFileResponse.serializeBinaryToWriter = function(message, writer) {
+proto.file.ListArchiveRequest.serializeBinaryToWriter = function(message, writer) {
   var f = undefined;
-  f = message.getData_asU8();
+  f = message.getPath();
   if (f.length > 0) {
-    writer.writeBytes(
+    writer.writeString(
       1,
       f
     );
@@ -2082,41 +8835,17 @@ proto.file.ReadFileResponse.serializeBinaryToWriter = function(message, writer)
 
 
 /**
- * optional bytes data = 1;
+ * optional string path = 1;
  * @return {string}
  */
-proto.file.ReadFileResponse.prototype.getData = function() {
+proto.file.ListArchiveRequest.prototype.getPath = function() {
   return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
 };
 
 
-/**
- * optional bytes data = 1;
- * This is a type-conversion wrapper around `getData()`
- * @return {string}
- */
-proto.file.ReadFileResponse.prototype.getData_asB64 = function() {
-  return /** @type {string} */ (jspb.Message.bytesAsB64(
-      this.getData()));
-};
-
-
-/**
- * optional bytes data = 1;
- * Note that Uint8Array is not supported on all browsers.
- * @see http://caniuse.com/Uint8Array
- * This is a type-conversion wrapper around `getData()`
- * @return {!Uint8Array}
- */
-proto.file.ReadFileResponse.prototype.getData_asU8 = function() {
-  return /** @type {!Uint8Array} */ (jspb.Message.bytesAsU8(
-      this.getData()));
-};
-
-
-/** @param {!(string|Uint8Array)} value */
-proto.file.ReadFileResponse.prototype.setData = function(value) {
-  jspb.Message.setProto3BytesField(this, 1, value);
+/** @param {string} value */
+proto.file.ListArchiveRequest.prototype.setPath = function(value) {
+  jspb.Message.setProto3StringField(this, 1, value);
 };
 
 
@@ -2131,39 +8860,13 @@ proto.file.ReadFileResponse.prototype.setData = function(value) {
  * @extends {jspb.Message}
  * @constructor
  */
-proto.file.SaveFileRequest = function(opt_data) {
-  jspb.Message.initialize(this, opt_data, 0, -1, null, proto.file.SaveFileRequest.oneofGroups_);
+proto.file.ArchiveEntry = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
 };
-goog.inherits(proto.file.SaveFileRequest, jspb.Message);
+goog.inherits(proto.file.ArchiveEntry, jspb.Message);
 if (goog.DEBUG && !COMPILED) {
-  proto.file.SaveFileRequest.displayName = 'proto.file.SaveFileRequest';
+  proto.file.ArchiveEntry.displayName = 'proto.file.ArchiveEntry';
 }
-/**
- * Oneof group definitions for this message. Each group defines the field
- * numbers belonging to that group. When of these fields' value is set, all
- * other fields in the group are cleared. During deserialization, if multiple
- * fields are encountered for a group, only the last value seen will be kept.
- * @private {!Array<!Array<number>>}
- * @const
- */
-proto.file.SaveFileRequest.oneofGroups_ = [[1,2]];
-
-/**
- * @enum {number}
- */
-proto.file.SaveFileRequest.FileCase = {
-  FILE_NOT_SET: 0,
-  PATH: 1,
-  DATA: 2
-};
-
-/**
- * @return {proto.file.SaveFileRequest.FileCase}
- */
-proto.file.SaveFileRequest.prototype.getFileCase = function() {
-  return /** @type {proto.file.SaveFileRequest.FileCase} */(jspb.Message.computeOneofCase(this, proto.file.SaveFileRequest.oneofGroups_[0]));
-};
-
 
 
 if (jspb.Message.GENERATE_TO_OBJECT) {
@@ -2177,8 +8880,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
  *     for transitional soy proto support: http://goto/soy-param-migration
  * @return {!Object}
  */
-proto.file.SaveFileRequest.prototype.toObject = function(opt_includeInstance) {
-  return proto.file.SaveFileRequest.toObject(opt_includeInstance, this);
+proto.file.ArchiveEntry.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.ArchiveEntry.toObject(opt_includeInstance, this);
 };
 
 
@@ -2187,14 +8890,19 @@ proto.file.SaveFileRequest.prototype.toObject = function(opt_includeInstance) {
  * @param {boolean|undefined} includeInstance Whether to include the JSPB
  *     instance for transitional soy proto support:
  *     http://goto/soy-param-migration
- * @param {!proto.file.SaveFileRequest} msg The msg instance to transform.
+ * @param {!proto.file.ArchiveEntry} msg The msg instance to transform.
  * @return {!Object}
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.file.SaveFileRequest.toObject = function(includeInstance, msg) {
+proto.file.ArchiveEntry.toObject = function(includeInstance, msg) {
   var f, obj = {
-    path: jspb.Message.getFieldWithDefault(msg, 1, ""),
-    data: msg.getData_asB64()
+    name: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    size: jspb.Message.getFieldWithDefault(msg, 2, 0),
+    compressedsize: jspb.Message.getFieldWithDefault(msg, 3, 0),
+    mode: jspb.Message.getFieldWithDefault(msg, 4, 0),
+    modtime: jspb.Message.getFieldWithDefault(msg, 5, 0),
+    isdir: jspb.Message.getFieldWithDefault(msg, 6, false),
+    link: jspb.Message.getFieldWithDefault(msg, 7, "")
   };
 
   if (includeInstance) {
@@ -2208,23 +8916,23 @@ proto.file.SaveFileRequest.toObject = function(includeInstance, msg) {
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.file.SaveFileRequest}
+ * @return {!proto.file.ArchiveEntry}
  */
-proto.file.SaveFileRequest.deserializeBinary = function(bytes) {
+proto.file.ArchiveEntry.deserializeBinary = function(bytes) {
   var reader = new jspb.BinaryReader(bytes);
-  var msg = new proto.file.SaveFileRequest;
-  return proto.file.SaveFileRequest.deserializeBinaryFromReader(msg, reader);
+  var msg = new proto.file.ArchiveEntry;
+  return proto.file.ArchiveEntry.deserializeBinaryFromReader(msg, reader);
 };
 
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.file.SaveFileRequest} msg The message object to deserialize into.
+ * @param {!proto.file.ArchiveEntry} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.file.SaveFileRequest}
+ * @return {!proto.file.ArchiveEntry}
  */
-proto.file.SaveFileRequest.deserializeBinaryFromReader = function(msg, reader) {
+proto.file.ArchiveEntry.deserializeBinaryFromReader = function(msg, reader) {
   while (reader.nextField()) {
     if (reader.isEndGroup()) {
       break;
@@ -2233,11 +8941,31 @@ proto.file.SaveFileRequest.deserializeBinaryFromReader = function(msg, reader) {
     switch (field) {
     case 1:
       var value = /** @type {string} */ (reader.readString());
-      msg.setPath(value);
+      msg.setName(value);
       break;
     case 2:
-      var value = /** @type {!Uint8Array} */ (reader.readBytes());
-      msg.setData(value);
+      var value = /** @type {number} */ (reader.readInt64());
+      msg.setSize(value);
+      break;
+    case 3:
+      var value = /** @type {number} */ (reader.readInt64());
+      msg.setCompressedsize(value);
+      break;
+    case 4:
+      var value = /** @type {number} */ (reader.readUint32());
+      msg.setMode(value);
+      break;
+    case 5:
+      var value = /** @type {number} */ (reader.readInt64());
+      msg.setModtime(value);
+      break;
+    case 6:
+      var value = /** @type {boolean} */ (reader.readBool());
+      msg.setIsdir(value);
+      break;
+    case 7:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setLink(value);
       break;
     default:
       reader.skipField();
@@ -2252,9 +8980,9 @@ proto.file.SaveFileRequest.deserializeBinaryFromReader = function(msg, reader) {
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.file.SaveFileRequest.prototype.serializeBinary = function() {
+proto.file.ArchiveEntry.prototype.serializeBinary = function() {
   var writer = new jspb.BinaryWriter();
-  proto.file.SaveFileRequest.serializeBinaryToWriter(this, writer);
+  proto.file.ArchiveEntry.serializeBinaryToWriter(this, writer);
   return writer.getResultBuffer();
 };
 
@@ -2262,108 +8990,168 @@ proto.file.SaveFileRequest.prototype.serializeBinary = function() {
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.file.SaveFileRequest} message
+ * @param {!proto.file.ArchiveEntry} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.file.SaveFileRequest.serializeBinaryToWriter = function(message, writer) {
+proto.file.ArchiveEntry.serializeBinaryToWriter = function(message, writer) {
   var f = undefined;
-  f = /** @type {string} */ (jspb.Message.getField(message, 1));
-  if (f != null) {
+  f = message.getName();
+  if (f.length > 0) {
     writer.writeString(
       1,
       f
     );
   }
-  f = /** @type {!(string|Uint8Array)} */ (jspb.Message.getField(message, 2));
-  if (f != null) {
-    writer.writeBytes(
+  f = message.getSize();
+  if (f !== 0) {
+    writer.writeInt64(
       2,
       f
     );
   }
+  f = message.getCompressedsize();
+  if (f !== 0) {
+    writer.writeInt64(
+      3,
+      f
+    );
+  }
+  f = message.getMode();
+  if (f !== 0) {
+    writer.writeUint32(
+      4,
+      f
+    );
+  }
+  f = message.getModtime();
+  if (f !== 0) {
+    writer.writeInt64(
+      5,
+      f
+    );
+  }
+  f = message.getIsdir();
+  if (f) {
+    writer.writeBool(
+      6,
+      f
+    );
+  }
+  f = message.getLink();
+  if (f.length > 0) {
+    writer.writeString(
+      7,
+      f
+    );
+  }
 };
 
 
 /**
- * optional string path = 1;
+ * optional string name = 1;
  * @return {string}
  */
-proto.file.SaveFileRequest.prototype.getPath = function() {
+proto.file.ArchiveEntry.prototype.getName = function() {
   return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
 };
 
 
 /** @param {string} value */
-proto.file.SaveFileRequest.prototype.setPath = function(value) {
-  jspb.Message.setOneofField(this, 1, proto.file.SaveFileRequest.oneofGroups_[0], value);
+proto.file.ArchiveEntry.prototype.setName = function(value) {
+  jspb.Message.setProto3StringField(this, 1, value);
 };
 
 
-proto.file.SaveFileRequest.prototype.clearPath = function() {
-  jspb.Message.setOneofField(this, 1, proto.file.SaveFileRequest.oneofGroups_[0], undefined);
+/**
+ * optional int64 size = 2;
+ * @return {number}
+ */
+proto.file.ArchiveEntry.prototype.getSize = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 2, 0));
 };
 
 
-/**
- * Returns whether this field is set.
- * @return {!boolean}
- */
-proto.file.SaveFileRequest.prototype.hasPath = function() {
-  return jspb.Message.getField(this, 1) != null;
+/** @param {number} value */
+proto.file.ArchiveEntry.prototype.setSize = function(value) {
+  jspb.Message.setProto3IntField(this, 2, value);
 };
 
 
 /**
- * optional bytes data = 2;
- * @return {string}
+ * optional int64 compressedSize = 3;
+ * @return {number}
  */
-proto.file.SaveFileRequest.prototype.getData = function() {
-  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+proto.file.ArchiveEntry.prototype.getCompressedsize = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+};
+
+
+/** @param {number} value */
+proto.file.ArchiveEntry.prototype.setCompressedsize = function(value) {
+  jspb.Message.setProto3IntField(this, 3, value);
 };
 
 
 /**
- * optional bytes data = 2;
- * This is a type-conversion wrapper around `getData()`
- * @return {string}
+ * optional uint32 mode = 4;
+ * @return {number}
  */
-proto.file.SaveFileRequest.prototype.getData_asB64 = function() {
-  return /** @type {string} */ (jspb.Message.bytesAsB64(
-      this.getData()));
+proto.file.ArchiveEntry.prototype.getMode = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 4, 0));
+};
+
+
+/** @param {number} value */
+proto.file.ArchiveEntry.prototype.setMode = function(value) {
+  jspb.Message.setProto3IntField(this, 4, value);
 };
 
 
 /**
- * optional bytes data = 2;
- * Note that Uint8Array is not supported on all browsers.
- * @see http://caniuse.com/Uint8Array
- * This is a type-conversion wrapper around `getData()`
- * @return {!Uint8Array}
+ * optional int64 modTime = 5;
+ * @return {number}
  */
-proto.file.SaveFileRequest.prototype.getData_asU8 = function() {
-  return /** @type {!Uint8Array} */ (jspb.Message.bytesAsU8(
-      this.getData()));
+proto.file.ArchiveEntry.prototype.getModtime = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 5, 0));
+};
+
+
+/** @param {number} value */
+proto.file.ArchiveEntry.prototype.setModtime = function(value) {
+  jspb.Message.setProto3IntField(this, 5, value);
 };
 
 
-/** @param {!(string|Uint8Array)} value */
-proto.file.SaveFileRequest.prototype.setData = function(value) {
-  jspb.Message.setOneofField(this, 2, proto.file.SaveFileRequest.oneofGroups_[0], value);
+/**
+ * optional bool isDir = 6;
+ * Note that Boolean fields may be set to 0/1 when serialized from a Java server.
+ * You should avoid comparisons like {@code val === true/false} in those cases.
+ * @return {boolean}
+ */
+proto.file.ArchiveEntry.prototype.getIsdir = function() {
+  return /** @type {boolean} */ (jspb.Message.getFieldWithDefault(this, 6, false));
 };
 
 
-proto.file.SaveFileRequest.prototype.clearData = function() {
-  jspb.Message.setOneofField(this, 2, proto.file.SaveFileRequest.oneofGroups_[0], undefined);
+/** @param {boolean} value */
+proto.file.ArchiveEntry.prototype.setIsdir = function(value) {
+  jspb.Message.setProto3BooleanField(this, 6, value);
 };
 
 
 /**
- * Returns whether this field is set.
- * @return {!boolean}
+ * optional string link = 7;
+ * @return {string}
  */
-proto.file.SaveFileRequest.prototype.hasData = function() {
-  return jspb.Message.getField(this, 2) != null;
+proto.file.ArchiveEntry.prototype.getLink = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 7, ""));
+};
+
+
+/** @param {string} value */
+proto.file.ArchiveEntry.prototype.setLink = function(value) {
+  jspb.Message.setProto3StringField(this, 7, value);
 };
 
 
@@ -2378,12 +9166,12 @@ proto.file.SaveFileRequest.prototype.hasData = function() {
  * @extends {jspb.Message}
  * @constructor
  */
-proto.file.SaveFileResponse = function(opt_data) {
+proto.file.ListArchiveResponse = function(opt_data) {
   jspb.Message.initialize(this, opt_data, 0, -1, null, null);
 };
-goog.inherits(proto.file.SaveFileResponse, jspb.Message);
+goog.inherits(proto.file.ListArchiveResponse, jspb.Message);
 if (goog.DEBUG && !COMPILED) {
-  proto.file.SaveFileResponse.displayName = 'proto.file.SaveFileResponse';
+  proto.file.ListArchiveResponse.displayName = 'proto.file.ListArchiveResponse';
 }
 
 
@@ -2398,8 +9186,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
  *     for transitional soy proto support: http://goto/soy-param-migration
  * @return {!Object}
  */
-proto.file.SaveFileResponse.prototype.toObject = function(opt_includeInstance) {
-  return proto.file.SaveFileResponse.toObject(opt_includeInstance, this);
+proto.file.ListArchiveResponse.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.ListArchiveResponse.toObject(opt_includeInstance, this);
 };
 
 
@@ -2408,13 +9196,13 @@ proto.file.SaveFileResponse.prototype.toObject = function(opt_includeInstance) {
  * @param {boolean|undefined} includeInstance Whether to include the JSPB
  *     instance for transitional soy proto support:
  *     http://goto/soy-param-migration
- * @param {!proto.file.SaveFileResponse} msg The msg instance to transform.
+ * @param {!proto.file.ListArchiveResponse} msg The msg instance to transform.
  * @return {!Object}
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.file.SaveFileResponse.toObject = function(includeInstance, msg) {
+proto.file.ListArchiveResponse.toObject = function(includeInstance, msg) {
   var f, obj = {
-    result: jspb.Message.getFieldWithDefault(msg, 1, false)
+    entry: (f = msg.getEntry()) && proto.file.ArchiveEntry.toObject(includeInstance, f)
   };
 
   if (includeInstance) {
@@ -2428,23 +9216,23 @@ proto.file.SaveFileResponse.toObject = function(includeInstance, msg) {
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.file.SaveFileResponse}
+ * @return {!proto.file.ListArchiveResponse}
  */
-proto.file.SaveFileResponse.deserializeBinary = function(bytes) {
+proto.file.ListArchiveResponse.deserializeBinary = function(bytes) {
   var reader = new jspb.BinaryReader(bytes);
-  var msg = new proto.file.SaveFileResponse;
-  return proto.file.SaveFileResponse.deserializeBinaryFromReader(msg, reader);
+  var msg = new proto.file.ListArchiveResponse;
+  return proto.file.ListArchiveResponse.deserializeBinaryFromReader(msg, reader);
 };
 
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.file.SaveFileResponse} msg The message object to deserialize into.
+ * @param {!proto.file.ListArchiveResponse} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.file.SaveFileResponse}
+ * @return {!proto.file.ListArchiveResponse}
  */
-proto.file.SaveFileResponse.deserializeBinaryFromReader = function(msg, reader) {
+proto.file.ListArchiveResponse.deserializeBinaryFromReader = function(msg, reader) {
   while (reader.nextField()) {
     if (reader.isEndGroup()) {
       break;
@@ -2452,8 +9240,9 @@ proto.file.SaveFileResponse.deserializeBinaryFromReader = function(msg, reader)
     var field = reader.getFieldNumber();
     switch (field) {
     case 1:
-      var value = /** @type {boolean} */ (reader.readBool());
-      msg.setResult(value);
+      var value = new proto.file.ArchiveEntry;
+      reader.readMessage(value,proto.file.ArchiveEntry.deserializeBinaryFromReader);
+      msg.setEntry(value);
       break;
     default:
       reader.skipField();
@@ -2468,9 +9257,9 @@ proto.file.SaveFileResponse.deserializeBinaryFromReader = function(msg, reader)
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.file.SaveFileResponse.prototype.serializeBinary = function() {
+proto.file.ListArchiveResponse.prototype.serializeBinary = function() {
   var writer = new jspb.BinaryWriter();
-  proto.file.SaveFileResponse.serializeBinaryToWriter(this, writer);
+  proto.file.ListArchiveResponse.serializeBinaryToWriter(this, writer);
   return writer.getResultBuffer();
 };
 
@@ -2478,36 +9267,50 @@ proto.file.SaveFileResponse.prototype.serializeBinary = function() {
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.file.SaveFileResponse} message
+ * @param {!proto.file.ListArchiveResponse} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.file.SaveFileResponse.serializeBinaryToWriter = function(message, writer) {
+proto.file.ListArchiveResponse.serializeBinaryToWriter = function(message, writer) {
   var f = undefined;
-  f = message.getResult();
-  if (f) {
-    writer.writeBool(
+  f = message.getEntry();
+  if (f != null) {
+    writer.writeMessage(
       1,
-      f
+      f,
+      proto.file.ArchiveEntry.serializeBinaryToWriter
     );
   }
 };
 
 
 /**
- * optional bool result = 1;
- * Note that Boolean fields may be set to 0/1 when serialized from a Java server.
- * You should avoid comparisons like {@code val === true/false} in those cases.
- * @return {boolean}
+ * optional ArchiveEntry entry = 1;
+ * @return {?proto.file.ArchiveEntry}
  */
-proto.file.SaveFileResponse.prototype.getResult = function() {
-  return /** @type {boolean} */ (jspb.Message.getFieldWithDefault(this, 1, false));
+proto.file.ListArchiveResponse.prototype.getEntry = function() {
+  return /** @type{?proto.file.ArchiveEntry} */ (
+    jspb.Message.getWrapperField(this, proto.file.ArchiveEntry, 1));
 };
 
 
-/** @param {boolean} value */
-proto.file.SaveFileResponse.prototype.setResult = function(value) {
-  jspb.Message.setProto3BooleanField(this, 1, value);
+/** @param {?proto.file.ArchiveEntry|undefined} value */
+proto.file.ListArchiveResponse.prototype.setEntry = function(value) {
+  jspb.Message.setWrapperField(this, 1, value);
+};
+
+
+proto.file.ListArchiveResponse.prototype.clearEntry = function() {
+  this.setEntry(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {!boolean}
+ */
+proto.file.ListArchiveResponse.prototype.hasEntry = function() {
+  return jspb.Message.getField(this, 1) != null;
 };
 
 
@@ -2522,12 +9325,12 @@ proto.file.SaveFileResponse.prototype.setResult = function(value) {
  * @extends {jspb.Message}
  * @constructor
  */
-proto.file.DeleteFileRequest = function(opt_data) {
+proto.file.GetThumbnailsRequest = function(opt_data) {
   jspb.Message.initialize(this, opt_data, 0, -1, null, null);
 };
-goog.inherits(proto.file.DeleteFileRequest, jspb.Message);
+goog.inherits(proto.file.GetThumbnailsRequest, jspb.Message);
 if (goog.DEBUG && !COMPILED) {
-  proto.file.DeleteFileRequest.displayName = 'proto.file.DeleteFileRequest';
+  proto.file.GetThumbnailsRequest.displayName = 'proto.file.GetThumbnailsRequest';
 }
 
 
@@ -2542,8 +9345,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
  *     for transitional soy proto support: http://goto/soy-param-migration
  * @return {!Object}
  */
-proto.file.DeleteFileRequest.prototype.toObject = function(opt_includeInstance) {
-  return proto.file.DeleteFileRequest.toObject(opt_includeInstance, this);
+proto.file.GetThumbnailsRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.GetThumbnailsRequest.toObject(opt_includeInstance, this);
 };
 
 
@@ -2552,13 +9355,16 @@ proto.file.DeleteFileRequest.prototype.toObject = function(opt_includeInstance)
  * @param {boolean|undefined} includeInstance Whether to include the JSPB
  *     instance for transitional soy proto support:
  *     http://goto/soy-param-migration
- * @param {!proto.file.DeleteFileRequest} msg The msg instance to transform.
+ * @param {!proto.file.GetThumbnailsRequest} msg The msg instance to transform.
  * @return {!Object}
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.file.DeleteFileRequest.toObject = function(includeInstance, msg) {
+proto.file.GetThumbnailsRequest.toObject = function(includeInstance, msg) {
   var f, obj = {
-    path: jspb.Message.getFieldWithDefault(msg, 1, "")
+    path: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    recursive: jspb.Message.getFieldWithDefault(msg, 2, false),
+    thumnailwidth: jspb.Message.getFieldWithDefault(msg, 3, 0),
+    thumnailheight: jspb.Message.getFieldWithDefault(msg, 4, 0)
   };
 
   if (includeInstance) {
@@ -2572,23 +9378,23 @@ proto.file.DeleteFileRequest.toObject = function(includeInstance, msg) {
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.file.DeleteFileRequest}
+ * @return {!proto.file.GetThumbnailsRequest}
  */
-proto.file.DeleteFileRequest.deserializeBinary = function(bytes) {
+proto.file.GetThumbnailsRequest.deserializeBinary = function(bytes) {
   var reader = new jspb.BinaryReader(bytes);
-  var msg = new proto.file.DeleteFileRequest;
-  return proto.file.DeleteFileRequest.deserializeBinaryFromReader(msg, reader);
+  var msg = new proto.file.GetThumbnailsRequest;
+  return proto.file.GetThumbnailsRequest.deserializeBinaryFromReader(msg, reader);
 };
 
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.file.DeleteFileRequest} msg The message object to deserialize into.
+ * @param {!proto.file.GetThumbnailsRequest} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.file.DeleteFileRequest}
+ * @return {!proto.file.GetThumbnailsRequest}
  */
-proto.file.DeleteFileRequest.deserializeBinaryFromReader = function(msg, reader) {
+proto.file.GetThumbnailsRequest.deserializeBinaryFromReader = function(msg, reader) {
   while (reader.nextField()) {
     if (reader.isEndGroup()) {
       break;
@@ -2599,6 +9405,18 @@ proto.file.DeleteFileRequest.deserializeBinaryFromReader = function(msg, reader)
       var value = /** @type {string} */ (reader.readString());
       msg.setPath(value);
       break;
+    case 2:
+      var value = /** @type {boolean} */ (reader.readBool());
+      msg.setRecursive(value);
+      break;
+    case 3:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setThumnailwidth(value);
+      break;
+    case 4:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setThumnailheight(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -2612,9 +9430,9 @@ proto.file.DeleteFileRequest.deserializeBinaryFromReader = function(msg, reader)
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.file.DeleteFileRequest.prototype.serializeBinary = function() {
+proto.file.GetThumbnailsRequest.prototype.serializeBinary = function() {
   var writer = new jspb.BinaryWriter();
-  proto.file.DeleteFileRequest.serializeBinaryToWriter(this, writer);
+  proto.file.GetThumbnailsRequest.serializeBinaryToWriter(this, writer);
   return writer.getResultBuffer();
 };
 
@@ -2622,11 +9440,11 @@ proto.file.DeleteFileRequest.prototype.serializeBinary = function() {
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.file.DeleteFileRequest} message
+ * @param {!proto.file.GetThumbnailsRequest} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.file.DeleteFileRequest.serializeBinaryToWriter = function(message, writer) {
+proto.file.GetThumbnailsRequest.serializeBinaryToWriter = function(message, writer) {
   var f = undefined;
   f = message.getPath();
   if (f.length > 0) {
@@ -2635,6 +9453,27 @@ proto.file.DeleteFileRequest.serializeBinaryToWriter = function(message, writer)
       f
     );
   }
+  f = message.getRecursive();
+  if (f) {
+    writer.writeBool(
+      2,
+      f
+    );
+  }
+  f = message.getThumnailwidth();
+  if (f !== 0) {
+    writer.writeInt32(
+      3,
+      f
+    );
+  }
+  f = message.getThumnailheight();
+  if (f !== 0) {
+    writer.writeInt32(
+      4,
+      f
+    );
+  }
 };
 
 
@@ -2642,17 +9481,64 @@ proto.file.DeleteFileRequest.serializeBinaryToWriter = function(message, writer)
  * optional string path = 1;
  * @return {string}
  */
-proto.file.DeleteFileRequest.prototype.getPath = function() {
+proto.file.GetThumbnailsRequest.prototype.getPath = function() {
   return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
 };
 
 
 /** @param {string} value */
-proto.file.DeleteFileRequest.prototype.setPath = function(value) {
+proto.file.GetThumbnailsRequest.prototype.setPath = function(value) {
   jspb.Message.setProto3StringField(this, 1, value);
 };
 
 
+/**
+ * optional bool recursive = 2;
+ * Note that Boolean fields may be set to 0/1 when serialized from a Java server.
+ * You should avoid comparisons like {@code val === true/false} in those cases.
+ * @return {boolean}
+ */
+proto.file.GetThumbnailsRequest.prototype.getRecursive = function() {
+  return /** @type {boolean} */ (jspb.Message.getFieldWithDefault(this, 2, false));
+};
+
+
+/** @param {boolean} value */
+proto.file.GetThumbnailsRequest.prototype.setRecursive = function(value) {
+  jspb.Message.setProto3BooleanField(this, 2, value);
+};
+
+
+/**
+ * optional int32 thumnailWidth = 3;
+ * @return {number}
+ */
+proto.file.GetThumbnailsRequest.prototype.getThumnailwidth = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+};
+
+
+/** @param {number} value */
+proto.file.GetThumbnailsRequest.prototype.setThumnailwidth = function(value) {
+  jspb.Message.setProto3IntField(this, 3, value);
+};
+
+
+/**
+ * optional int32 thumnailHeight = 4;
+ * @return {number}
+ */
+proto.file.GetThumbnailsRequest.prototype.getThumnailheight = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 4, 0));
+};
+
+
+/** @param {number} value */
+proto.file.GetThumbnailsRequest.prototype.setThumnailheight = function(value) {
+  jspb.Message.setProto3IntField(this, 4, value);
+};
+
+
 
 /**
  * Generated by JsPbCodeGenerator.
@@ -2664,12 +9550,12 @@ proto.file.DeleteFileRequest.prototype.setPath = function(value) {
  * @extends {jspb.Message}
  * @constructor
  */
-proto.file.DeleteFileResponse = function(opt_data) {
+proto.file.GetThumbnailsResponse = function(opt_data) {
   jspb.Message.initialize(this, opt_data, 0, -1, null, null);
 };
-goog.inherits(proto.file.DeleteFileResponse, jspb.Message);
+goog.inherits(proto.file.GetThumbnailsResponse, jspb.Message);
 if (goog.DEBUG && !COMPILED) {
-  proto.file.DeleteFileResponse.displayName = 'proto.file.DeleteFileResponse';
+  proto.file.GetThumbnailsResponse.displayName = 'proto.file.GetThumbnailsResponse';
 }
 
 
@@ -2684,8 +9570,8 @@ if (jspb.Message.GENERATE_TO_OBJECT) {
  *     for transitional soy proto support: http://goto/soy-param-migration
  * @return {!Object}
  */
-proto.file.DeleteFileResponse.prototype.toObject = function(opt_includeInstance) {
-  return proto.file.DeleteFileResponse.toObject(opt_includeInstance, this);
+proto.file.GetThumbnailsResponse.prototype.toObject = function(opt_includeInstance) {
+  return proto.file.GetThumbnailsResponse.toObject(opt_includeInstance, this);
 };
 
 
@@ -2694,13 +9580,13 @@ proto.file.DeleteFileResponse.prototype.toObject = function(opt_includeInstance)
  * @param {boolean|undefined} includeInstance Whether to include the JSPB
  *     instance for transitional soy proto support:
  *     http://goto/soy-param-migration
- * @param {!proto.file.DeleteFileResponse} msg The msg instance to transform.
+ * @param {!proto.file.GetThumbnailsResponse} msg The msg instance to transform.
  * @return {!Object}
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.file.DeleteFileResponse.toObject = function(includeInstance, msg) {
+proto.file.GetThumbnailsResponse.toObject = function(includeInstance, msg) {
   var f, obj = {
-    result: jspb.Message.getFieldWithDefault(msg, 1, false)
+    info: (f = msg.getInfo()) && proto.file.FileInfo.toObject(includeInstance, f)
   };
 
   if (includeInstance) {
@@ -2714,32 +9600,33 @@ proto.file.DeleteFileResponse.toObject = function(includeInstance, msg) {
 /**
  * Deserializes binary data (in protobuf wire format).
  * @param {jspb.ByteSource} bytes The bytes to deserialize.
- * @return {!proto.file.DeleteFileResponse}
+ * @return {!proto.file.GetThumbnailsResponse}
  */
-proto.file.DeleteFileResponse.deserializeBinary = function(bytes) {
+proto.file.GetThumbnailsResponse.deserializeBinary = function(bytes) {
   var reader = new jspb.BinaryReader(bytes);
-  var msg = new proto.file.DeleteFileResponse;
-  return proto.file.DeleteFileResponse.deserializeBinaryFromReader(msg, reader);
+  var msg = new proto.file.GetThumbnailsResponse;
+  return proto.file.GetThumbnailsResponse.deserializeBinaryFromReader(msg, reader);
 };
 
 
 /**
  * Deserializes binary data (in protobuf wire format) from the
  * given reader into the given message object.
- * @param {!proto.file.DeleteFileResponse} msg The message object to deserialize into.
+ * @param {!proto.file.GetThumbnailsResponse} msg The message object to deserialize into.
  * @param {!jspb.BinaryReader} reader The BinaryReader to use.
- * @return {!proto.file.DeleteFileResponse}
+ * @return {!proto.file.GetThumbnailsResponse}
  */
-proto.file.DeleteFileResponse.deserializeBinaryFromReader = function(msg, reader) {
+proto.file.GetThumbnailsResponse.deserializeBinaryFromReader = function(msg, reader) {
   while (reader.nextField()) {
     if (reader.isEndGroup()) {
       break;
     }
     var field = reader.getFieldNumber();
     switch (field) {
-    case 1:
-      var value = /** @type {boolean} */ (reader.readBool());
-      msg.setResult(value);
+    case 2:
+      var value = new proto.file.FileInfo;
+      reader.readMessage(value,proto.file.FileInfo.deserializeBinaryFromReader);
+      msg.setInfo(value);
       break;
     default:
       reader.skipField();
@@ -2754,9 +9641,9 @@ proto.file.DeleteFileResponse.deserializeBinaryFromReader = function(msg, reader
  * Serializes the message to binary data (in protobuf wire format).
  * @return {!Uint8Array}
  */
-proto.file.DeleteFileResponse.prototype.serializeBinary = function() {
+proto.file.GetThumbnailsResponse.prototype.serializeBinary = function() {
   var writer = new jspb.BinaryWriter();
-  proto.file.DeleteFileResponse.serializeBinaryToWriter(this, writer);
+  proto.file.GetThumbnailsResponse.serializeBinaryToWriter(this, writer);
   return writer.getResultBuffer();
 };
 
@@ -2764,37 +9651,125 @@ proto.file.DeleteFileResponse.prototype.serializeBinary = function() {
 /**
  * Serializes the given message to binary data (in protobuf wire
  * format), writing to the given BinaryWriter.
- * @param {!proto.file.DeleteFileResponse} message
+ * @param {!proto.file.GetThumbnailsResponse} message
  * @param {!jspb.BinaryWriter} writer
  * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.file.DeleteFileResponse.serializeBinaryToWriter = function(message, writer) {
+proto.file.GetThumbnailsResponse.serializeBinaryToWriter = function(message, writer) {
   var f = undefined;
-  f = message.getResult();
-  if (f) {
-    writer.writeBool(
-      1,
-      f
+  f = message.getInfo();
+  if (f != null) {
+    writer.writeMessage(
+      2,
+      f,
+      proto.file.FileInfo.serializeBinaryToWriter
     );
   }
 };
 
 
 /**
- * optional bool result = 1;
- * Note that Boolean fields may be set to 0/1 when serialized from a Java server.
- * You should avoid comparisons like {@code val === true/false} in those cases.
- * @return {boolean}
+ * optional FileInfo info = 2;
+ * @return {?proto.file.FileInfo}
  */
-proto.file.DeleteFileResponse.prototype.getResult = function() {
-  return /** @type {boolean} */ (jspb.Message.getFieldWithDefault(this, 1, false));
+proto.file.GetThumbnailsResponse.prototype.getInfo = function() {
+  return /** @type{?proto.file.FileInfo} */ (
+    jspb.Message.getWrapperField(this, proto.file.FileInfo, 2));
 };
 
 
-/** @param {boolean} value */
-proto.file.DeleteFileResponse.prototype.setResult = function(value) {
-  jspb.Message.setProto3BooleanField(this, 1, value);
+/** @param {?proto.file.FileInfo|undefined} value */
+proto.file.GetThumbnailsResponse.prototype.setInfo = function(value) {
+  jspb.Message.setWrapperField(this, 2, value);
+};
+
+
+proto.file.GetThumbnailsResponse.prototype.clearInfo = function() {
+  this.setInfo(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {!boolean}
+ */
+proto.file.GetThumbnailsResponse.prototype.hasInfo = function() {
+  return jspb.Message.getField(this, 2) != null;
+};
+
+
+/**
+ * @enum {number}
+ */
+proto.file.SortOrder = {
+  SORT_BY_NAME: 0,
+  SORT_BY_SIZE: 1,
+  SORT_BY_TIME: 2,
+  SORT_BY_TYPE: 3
+};
+
+/**
+ * @enum {number}
+ */
+proto.file.ConflictPolicy = {
+  FAIL: 0,
+  REPLACE: 1,
+  SKIP: 2,
+  RENAME: 3
+};
+
+/**
+ * @enum {number}
+ */
+proto.file.FileChangeType = {
+  FILE_CREATED: 0,
+  FILE_MODIFIED: 1,
+  FILE_DELETED: 2,
+  FILE_RENAMED: 3
+};
+
+/**
+ * @enum {number}
+ */
+proto.file.SaveMode = {
+  OVERWRITE: 0,
+  CREATE: 1,
+  APPEND: 2
+};
+
+/**
+ * @enum {number}
+ */
+proto.file.ResizeMode = {
+  RESIZE_FIT: 0,
+  RESIZE_FILL: 1,
+  RESIZE_STRETCH: 2
+};
+
+/**
+ * @enum {number}
+ */
+proto.file.FlipDirection = {
+  FLIP_HORIZONTAL: 0,
+  FLIP_VERTICAL: 1
+};
+
+/**
+ * @enum {number}
+ */
+proto.file.ImageFormat = {
+  ORIGINAL_FORMAT: 0,
+  JPEG: 1,
+  PNG: 2,
+  GIF: 3
 };
 
+/**
+ * @enum {number}
+ */
+proto.file.ArchiveFormat = {
+  ZIP: 0,
+  TAR_GZ: 1
+};
 
 goog.object.extend(exports, proto.file);
@@ -121,6 +121,98 @@ class Globular {
         console.log("services are all initialysed!")
     }
 
+    /**
+     * Call listener with the events of a topic, ex: client.chat.* or file.*
+     * if it is in the EventTopics of the Globule. The events come from the
+     * Globule /events WebSocket, it is open again if it close.
+     */
+    subscribe(name, listener) {
+        if (this.listeners == undefined) {
+            this.listeners = {}
+        }
+
+        if (this.listeners[name] == undefined) {
+            this.listeners[name] = []
+            this.sendEventAction({ action: "subscribe", name: name })
+        }
+        this.listeners[name].push(listener)
+    }
+
+    /**
+     * Remove a listener of a topic.
+     */
+    unsubscribe(name, listener) {
+        if (this.listeners == undefined || this.listeners[name] == undefined) {
+            return
+        }
+
+        this.listeners[name] = this.listeners[name].filter(l => l != listener)
+        if (this.listeners[name].length == 0) {
+            delete this.listeners[name]
+            this.sendEventAction({ action: "unsubscribe", name: name })
+        }
+    }
+
+    /**
+     * Publish an event of a topic that start with client., data is any JSON
+     * value.
+     */
+    publish(name, data) {
+        this.sendEventAction({ action: "publish", name: name, data: data })
+    }
+
+    sendEventAction(action) {
+        if (this.events != undefined && this.events.readyState == WebSocket.OPEN) {
+            this.events.send(JSON.stringify(action))
+            return
+        }
+
+        // The actions are sent when the WebSocket is open.
+        if (this.pendingActions == undefined) {
+            this.pendingActions = []
+        }
+        this.pendingActions.push(action)
+
+        if (this.events == undefined) {
+            this.openEvents()
+        }
+    }
+
+    openEvents() {
+        let protocol = window.location.protocol == "https:" ? "wss://" : "ws://"
+        this.events = new WebSocket(protocol + window.location.host + "/events")
+
+        this.events.onopen = () => {
+            // The subscriptions are done again after a reconnection.
+            let actions = Object.keys(this.listeners || {}).map(name => ({ action: "subscribe", name: name }))
+            actions = actions.concat((this.pendingActions || []).filter(a => a.action == "publish"))
+            this.pendingActions = []
+            actions.forEach(a => this.events.send(JSON.stringify(a)))
+        }
+
+        this.events.onmessage = (e) => {
+            let evt = JSON.parse(e.data)
+            if (evt.error != undefined) {
+                console.log("event error: ", evt.error)
+                return
+            }
+
+            for (let name in this.listeners) {
+                let match = name.endsWith("*") ? evt.name.startsWith(name.slice(0, -1)) : evt.name == name
+                if (match) {
+                    this.listeners[name].forEach(l => l(evt))
+                }
+            }
+        }
+
+        this.events.onclose = () => {
+            this.events = undefined
+            if (Object.keys(this.listeners || {}).length > 0) {
+                setTimeout(() => { if (this.events == undefined) { this.openEvents() } }, 3000)
+            }
+        }
+    }
+
 }
 
 // export the class Globular.
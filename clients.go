@@ -6,8 +6,11 @@ import (
 
 	"io"
 	"os"
+	"time"
 
+	"github.com/davecourtois/Globular/admin/adminpb"
 	"github.com/davecourtois/Globular/echo/echopb"
+	"github.com/davecourtois/Globular/event/eventpb"
 	"github.com/davecourtois/Globular/file/filepb"
 
 	/*"github.com/davecourtois/Globular/ldap/ldappb"*/
@@ -16,12 +19,14 @@ import (
 	"encoding/json"
 
 	"github.com/davecourtois/Globular/persistence/persistencepb"
+	"github.com/davecourtois/Globular/resolver"
 	"github.com/davecourtois/Globular/smtp/smtppb"
 	"github.com/davecourtois/Globular/spc/spcpb"
 	"github.com/davecourtois/Globular/sql/sqlpb"
 	"github.com/davecourtois/Globular/storage/storagepb"
 	"github.com/davecourtois/Utility"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 // The client service interface.
@@ -37,11 +42,14 @@ type Client interface {
 func getClientConnection(addresse string) *grpc.ClientConn {
 	var err error
 	var cc *grpc.ClientConn
-	if cc == nil {
+	if strings.HasPrefix(addresse, resolver.Scheme+":///") {
+		// The service is address by it name.
+		cc, err = resolver.Dial(strings.TrimPrefix(addresse, resolver.Scheme+":///"), grpc.WithInsecure())
+	} else {
 		cc, err = grpc.Dial(addresse, grpc.WithInsecure())
-		if err != nil {
-			log.Fatalf("could not connect: %v", err)
-		}
+	}
+	if err != nil {
+		log.Fatalf("could not connect: %v", err)
 	}
 	return cc
 }
@@ -69,8 +77,9 @@ func (self *File_Client) Close() {
 	self.cc.Close()
 }
 
-// Read the content of a dir and return it info.
-func (self *File_Client) ReadDir(path interface{}, recursive interface{}, thumbnailHeight interface{}, thumbnailWidth interface{}) (string, error) {
+// Read the content of a dir and return it entries, the entries of the
+// sub-directories follow them.
+func (self *File_Client) ReadDir(path interface{}, recursive interface{}, thumbnailHeight interface{}, thumbnailWidth interface{}) ([]*filepb.FileInfo, error) {
 
 	// Create a new client service...
 	rqst := &filepb.ReadDirRequest{
@@ -82,25 +91,24 @@ func (self *File_Client) ReadDir(path interface{}, recursive interface{}, thumbn
 
 	stream, err := self.c.ReadDir(context.Background(), rqst)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	// Here I will create the final array
-	data := make([]byte, 0)
+	infos := make([]*filepb.FileInfo, 0)
 	for {
 		msg, err := stream.Recv()
 		if err == io.EOF {
 			// end of stream...
 			break
+		} else if err != nil {
+			return nil, err
 		}
 
-		data = append(data, msg.Data...)
-		if err != nil {
-			return "", err
-		}
+		infos = append(infos, msg.Info)
 	}
 
-	return string(data), nil
+	return infos, nil
 }
 
 /**
@@ -169,6 +177,58 @@ func (self *File_Client) RenameDir(path interface{}, oldname interface{}, newnam
 	return err
 }
 
+/**
+ * Copy a file or a directory of the service, dest is the path of the copy.
+ */
+func (self *File_Client) Copy(path string, dest string, conflict filepb.ConflictPolicy) error {
+	rqst := &filepb.CopyRequest{
+		Source:      path,
+		Destination: dest,
+		Conflict:    conflict,
+	}
+
+	stream, err := self.c.Copy(context.Background(), rqst)
+	if err != nil {
+		return err
+	}
+
+	// Wait until the copy is done.
+	for {
+		_, err = stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+/**
+ * Move a file or a directory of the service, dest is it new path.
+ */
+func (self *File_Client) Move(path string, dest string, conflict filepb.ConflictPolicy) error {
+	rqst := &filepb.MoveRequest{
+		Source:      path,
+		Destination: dest,
+		Conflict:    conflict,
+	}
+
+	stream, err := self.c.Move(context.Background(), rqst)
+	if err != nil {
+		return err
+	}
+
+	// Wait until the move is done.
+	for {
+		_, err = stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
 /**
  * Delete a directory
  */
@@ -201,10 +261,71 @@ func (self *File_Client) GetFileInfo(path interface{}, recursive interface{}, th
 }
 
 /**
- * That function move a file from a directory to another... (mv) in unix.
+ * Give the changes of the files of a directory to fct until ctx is done.
+ */
+func (self *File_Client) WatchDir(ctx context.Context, path string, recursive bool, fct func(changes []*filepb.FileChange)) error {
+	stream, err := self.c.WatchDir(ctx, &filepb.WatchDirRequest{Path: path, Recursive: recursive})
+	if err != nil {
+		return err
+	}
+
+	for {
+		rsp, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		// The first message has no change.
+		if len(rsp.Changes) > 0 {
+			fct(rsp.Changes)
+		}
+	}
+}
+
+/**
+ * Return the files of a directory tree that match the criteria of rqst.
+ */
+func (self *File_Client) Search(rqst *filepb.SearchRequest) ([]*filepb.SearchResponse, error) {
+	stream, err := self.c.Search(context.Background(), rqst)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*filepb.SearchResponse, 0)
+	for {
+		rsp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		results = append(results, rsp)
+	}
+
+	return results, nil
+}
+
+/**
+ * Save a local file in the file service at dest. The files of the service
+ * are moved with Move.
  */
 func (self *File_Client) MoveFile(path interface{}, dest interface{}) error {
 
+	// Where the file is read from.
+	file, err := os.Open(Utility.ToString(path))
+	if err != nil {
+		return err
+	}
+
+	// close the file when done.
+	defer file.Close()
+
+	// The size is verified by the service.
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
 	// Open the stream...
 	stream, err := self.c.SaveFile(context.Background())
 	if err != nil {
@@ -215,21 +336,13 @@ func (self *File_Client) MoveFile(path interface{}, dest interface{}) error {
 		File: &filepb.SaveFileRequest_Path{
 			Path: Utility.ToString(dest), // Where the file will be save...
 		},
+		Size: info.Size(),
 	})
 
 	if err != nil {
 		return err
 	}
 
-	// Where the file is read from.
-	file, err := os.Open(Utility.ToString(path))
-	if err != nil {
-		return err
-	}
-
-	// close the file when done.
-	defer file.Close()
-
 	const BufferSize = 1024 * 5 // the chunck size.
 	buffer := make([]byte, BufferSize)
 	for {
@@ -277,7 +390,7 @@ func (self *File_Client) DeleteFile(path string) error {
 }
 
 // Read the content of a dir and return all images as thumbnails.
-func (self *File_Client) GetThumbnails(path interface{}, recursive interface{}, thumbnailHeight interface{}, thumbnailWidth interface{}) (string, error) {
+func (self *File_Client) GetThumbnails(path interface{}, recursive interface{}, thumbnailHeight interface{}, thumbnailWidth interface{}) ([]*filepb.FileInfo, error) {
 
 	// Create a new client service...
 	rqst := &filepb.GetThumbnailsRequest{
@@ -289,25 +402,149 @@ func (self *File_Client) GetThumbnails(path interface{}, recursive interface{},
 
 	stream, err := self.c.GetThumbnails(context.Background(), rqst)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	// Here I will create the final array
-	data := make([]byte, 0)
+	infos := make([]*filepb.FileInfo, 0)
 	for {
 		msg, err := stream.Recv()
 		if err == io.EOF {
 			// end of stream...
 			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		infos = append(infos, msg.Info)
+	}
+
+	return infos, nil
+}
+
+// Return the JPEG thumbnail of an image, it fit in width and height.
+func (self *File_Client) GetThumbnail(path interface{}, width interface{}, height interface{}) ([]byte, error) {
+	rqst := &filepb.GetThumbnailRequest{
+		Path:   Utility.ToString(path),
+		Width:  int32(Utility.ToInt(width)),
+		Height: int32(Utility.ToInt(height)),
+	}
+
+	rsp, err := self.c.GetThumbnail(context.Background(), rqst)
+	if err != nil {
+		return nil, err
+	}
+
+	return rsp.Data, nil
+}
+
+// Transform an image of the service and return it, nothing is returned if
+// it is saved at the destination of the request.
+func (self *File_Client) ImageTransform(rqst *filepb.ImageTransformRequest) ([]byte, error) {
+	stream, err := self.c.ImageTransform(context.Background(), rqst)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, 0)
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
 		}
 
 		data = append(data, msg.Data...)
-		if err != nil {
-			return "", err
+	}
+
+	return data, nil
+}
+
+/**
+ * Create a zip or tar.gz archive of files and directories of the service. The
+ * archive is returned if dest is empty, it is saved at dest otherwise.
+ */
+func (self *File_Client) CreateArchive(paths []string, format filepb.ArchiveFormat, dest string, conflict filepb.ConflictPolicy) ([]byte, error) {
+	rqst := &filepb.CreateArchiveRequest{
+		Paths:       paths,
+		Format:      format,
+		Destination: dest,
+		Conflict:    conflict,
+	}
+
+	stream, err := self.c.CreateArchive(context.Background(), rqst)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, 0)
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
 		}
+
+		data = append(data, msg.Data...)
 	}
 
-	return string(data), nil
+	return data, nil
+}
+
+/**
+ * Extract an archive of the service in the directory dest.
+ */
+func (self *File_Client) ExtractArchive(path string, dest string, conflict filepb.ConflictPolicy) error {
+	rqst := &filepb.ExtractArchiveRequest{
+		Path:        path,
+		Destination: dest,
+		Conflict:    conflict,
+	}
+
+	stream, err := self.c.ExtractArchive(context.Background(), rqst)
+	if err != nil {
+		return err
+	}
+
+	// Wait until the extraction is done.
+	for {
+		_, err = stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+/**
+ * Return the entries of an archive of the service.
+ */
+func (self *File_Client) ListArchive(path string) ([]*filepb.ArchiveEntry, error) {
+	rqst := &filepb.ListArchiveRequest{
+		Path: path,
+	}
+
+	stream, err := self.c.ListArchive(context.Background(), rqst)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*filepb.ArchiveEntry, 0)
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, msg.Entry)
+	}
+
+	return entries, nil
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -576,3 +813,358 @@ func (self *Echo_Client) Echo(msg interface{}) (string, error) {
 
 	return rsp.Message, nil
 }
+
+////////////////////////////////////////////////////////////////////////////////
+// Event Client Service
+////////////////////////////////////////////////////////////////////////////////
+
+type Event_Client struct {
+	cc *grpc.ClientConn
+	c  eventpb.EventServiceClient
+}
+
+// Create a connection to the service.
+func NewEvent_Client(addresse string) *Event_Client {
+	client := new(Event_Client)
+	client.cc = getClientConnection(addresse)
+	client.c = eventpb.NewEventServiceClient(client.cc)
+	return client
+}
+
+// must be close when no more needed.
+func (self *Event_Client) Close() {
+	self.cc.Close()
+}
+
+/**
+ * Publish an event, data is it JSON value. A value that is not JSON is send
+ * as a JSON string.
+ */
+func (self *Event_Client) Publish(name interface{}, data interface{}) error {
+	value := []byte(Utility.ToString(data))
+	if !json.Valid(value) {
+		value, _ = json.Marshal(Utility.ToString(data))
+	}
+
+	rqst := &eventpb.PublishRequest{
+		Evt: &eventpb.Event{
+			Name: Utility.ToString(name),
+			Data: value,
+		},
+	}
+
+	_, err := self.c.Publish(context.Background(), rqst)
+	return err
+}
+
+/**
+ * Receive the events of a topic until the context is cancel or the stream
+ * is broken, fct is call with each event.
+ */
+func (self *Event_Client) Subscribe(ctx context.Context, name string, fct func(evt *eventpb.Event)) error {
+	stream, err := self.c.Subscribe(ctx, &eventpb.SubscribeRequest{Name: name})
+	if err != nil {
+		return err
+	}
+
+	for {
+		rsp, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		fct(rsp.Evt)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// Admin Client Service
+////////////////////////////////////////////////////////////////////////////////
+
+type Admin_Client struct {
+	cc *grpc.ClientConn
+	c  adminpb.AdminServiceClient
+
+	// The token given to the admin service of another host.
+	token string
+}
+
+/**
+ * Create a connection to the service, the token of GLOBULAR_ADMIN_TOKEN is
+ * given with the calls.
+ */
+func NewAdmin_Client(addresse string) *Admin_Client {
+	client := new(Admin_Client)
+	client.cc = getClientConnection(addresse)
+	client.c = adminpb.NewAdminServiceClient(client.cc)
+	client.token = os.Getenv("GLOBULAR_ADMIN_TOKEN")
+	return client
+}
+
+// Return the context of a call, with the token if there is one.
+func (self *Admin_Client) getContext() context.Context {
+	if len(self.token) == 0 {
+		return context.Background()
+	}
+
+	return metadata.AppendToOutgoingContext(context.Background(), adminTokenKey, self.token)
+}
+
+// must be close when no more needed.
+func (self *Admin_Client) Close() {
+	self.cc.Close()
+}
+
+// Return the Globule information and it services states.
+func (self *Admin_Client) GetStatus() (*adminpb.GetStatusResponse, error) {
+	return self.c.GetStatus(self.getContext(), &adminpb.GetStatusRequest{})
+}
+
+// Return the list of services.
+func (self *Admin_Client) ListServices() ([]*adminpb.ServiceInfo, error) {
+	rsp, err := self.c.ListServices(self.getContext(), &adminpb.ListServicesRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return rsp.Services, nil
+}
+
+// Start a service.
+func (self *Admin_Client) StartService(name string) (*adminpb.ServiceInfo, error) {
+	rsp, err := self.c.StartService(self.getContext(), &adminpb.StartServiceRequest{Name: name})
+	if err != nil {
+		return nil, err
+	}
+
+	return rsp.Service, nil
+}
+
+// Stop a service.
+func (self *Admin_Client) StopService(name string) (*adminpb.ServiceInfo, error) {
+	rsp, err := self.c.StopService(self.getContext(), &adminpb.StopServiceRequest{Name: name})
+	if err != nil {
+		return nil, err
+	}
+
+	return rsp.Service, nil
+}
+
+// Restart a service.
+func (self *Admin_Client) RestartService(name string) (*adminpb.ServiceInfo, error) {
+	rsp, err := self.c.RestartService(self.getContext(), &adminpb.RestartServiceRequest{Name: name})
+	if err != nil {
+		return nil, err
+	}
+
+	return rsp.Service, nil
+}
+
+/**
+ * Call fct for each line of the service output. If follow is true the
+ * function return only when the connection is closed.
+ */
+func (self *Admin_Client) GetLogs(name string, tail int, follow bool, fct func(line string)) error {
+	rqst := &adminpb.GetLogsRequest{
+		Name:   name,
+		Tail:   int32(tail),
+		Follow: follow,
+	}
+
+	stream, err := self.c.GetLogs(self.getContext(), rqst)
+	if err != nil {
+		return err
+	}
+
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			// end of stream...
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		fct(msg.Line)
+	}
+
+	return nil
+}
+
+// Return a configuration value as json string.
+func (self *Admin_Client) GetConfig(key string) (string, error) {
+	rsp, err := self.c.GetConfig(self.getContext(), &adminpb.GetConfigRequest{Key: key})
+	if err != nil {
+		return "", err
+	}
+
+	return rsp.Value, nil
+}
+
+// Set a configuration value.
+func (self *Admin_Client) SetConfig(key string, value string) error {
+	_, err := self.c.SetConfig(self.getContext(), &adminpb.SetConfigRequest{Key: key, Value: value})
+	return err
+}
+
+/**
+ * Install or upgrade a service from a package file.
+ */
+func (self *Admin_Client) InstallService(path string, checksum string, signature string) (*adminpb.InstallServiceResponse, error) {
+
+	// Open the stream...
+	stream, err := self.c.InstallService(self.getContext())
+	if err != nil {
+		return nil, err
+	}
+
+	err = stream.Send(&adminpb.InstallServiceRequest{
+		Package: &adminpb.InstallServiceRequest_Signature{
+			Signature: &adminpb.PackageSignature{
+				Checksum:  checksum,
+				Signature: signature,
+			},
+		},
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Where the package is read from.
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// close the file when done.
+	defer file.Close()
+
+	const BufferSize = 1024 * 5 // the chunck size.
+	buffer := make([]byte, BufferSize)
+	for {
+		bytesread, err := file.Read(buffer)
+		if bytesread > 0 {
+			rqst := &adminpb.InstallServiceRequest{
+				Package: &adminpb.InstallServiceRequest_Data{
+					Data: buffer[:bytesread],
+				},
+			}
+			err = stream.Send(rqst)
+		}
+
+		if err != nil {
+			if err != io.EOF {
+				return nil, err
+			}
+			break
+		}
+	}
+
+	return stream.CloseAndRecv()
+}
+
+// Stop a service and remove it files.
+func (self *Admin_Client) UninstallService(name string) error {
+	_, err := self.c.UninstallService(self.getContext(), &adminpb.UninstallServiceRequest{Name: name})
+	return err
+}
+
+/**
+ * Replace the process of a service without interruption, drainTimeout is the
+ * number of seconds given to the old process to finish it requests.
+ */
+func (self *Admin_Client) UpgradeService(name string, drainTimeout int) (*adminpb.ServiceInfo, error) {
+	rsp, err := self.c.UpgradeService(self.getContext(), &adminpb.UpgradeServiceRequest{Name: name, DrainTimeout: int32(drainTimeout)})
+	if err != nil {
+		return nil, err
+	}
+
+	return rsp.Service, nil
+}
+
+/**
+ * Send the known nodes to a Globule and return the ones it know.
+ */
+func (self *Admin_Client) Gossip(nodes []*adminpb.NodeInfo) ([]*adminpb.NodeInfo, error) {
+	ctx, cancel := context.WithTimeout(self.getContext(), time.Second)
+	defer cancel()
+
+	rsp, err := self.c.Gossip(ctx, &adminpb.GossipRequest{Nodes: nodes})
+	if err != nil {
+		return nil, err
+	}
+
+	return rsp.Nodes, nil
+}
+
+/**
+ * Return the nodes of the cluster known by the Globule.
+ */
+func (self *Admin_Client) GetCluster() ([]*adminpb.NodeInfo, error) {
+	rsp, err := self.c.GetCluster(self.getContext(), &adminpb.GetClusterRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return rsp.Nodes, nil
+}
+
+/**
+ * Return the web applications of the Globule.
+ */
+func (self *Admin_Client) ListApplications() ([]*adminpb.ApplicationInfo, error) {
+	rsp, err := self.c.ListApplications(self.getContext(), &adminpb.ListApplicationsRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return rsp.Applications, nil
+}
+
+/**
+ * Add or replace a web application.
+ */
+func (self *Admin_Client) SetApplication(application *adminpb.ApplicationInfo) error {
+	_, err := self.c.SetApplication(self.getContext(), &adminpb.SetApplicationRequest{Application: application})
+	return err
+}
+
+/**
+ * Remove a web application.
+ */
+func (self *Admin_Client) RemoveApplication(name string) error {
+	_, err := self.c.RemoveApplication(self.getContext(), &adminpb.RemoveApplicationRequest{Name: name})
+	return err
+}
+
+/**
+ * Create a backup of the Globule.
+ */
+func (self *Admin_Client) Backup() (*adminpb.BackupInfo, error) {
+	rsp, err := self.c.Backup(self.getContext(), &adminpb.BackupRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return rsp.Backup, nil
+}
+
+/**
+ * Return the backups of the Globule, the newest first.
+ */
+func (self *Admin_Client) ListBackups() ([]*adminpb.BackupInfo, error) {
+	rsp, err := self.c.ListBackups(self.getContext(), &adminpb.ListBackupsRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return rsp.Backups, nil
+}
+
+/**
+ * Restore a backup, or only check it.
+ */
+func (self *Admin_Client) Restore(name string, check bool) (*adminpb.RestoreResponse, error) {
+	return self.c.Restore(self.getContext(), &adminpb.RestoreRequest{Name: name, Check: check})
+}
@@ -0,0 +1,477 @@
+package main
+
+import (
+	"log"
+	"math/rand"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/davecourtois/Globular/admin/adminpb"
+	"github.com/davecourtois/Utility"
+)
+
+const (
+	// The delay between two gossip rounds.
+	gossipInterval = time.Second
+
+	// The number of nodes contacted at each round.
+	gossipFanout = 3
+
+	// A node whose heartbeat does not change for that delay is dead, it
+	// services are no more used.
+	nodeFailTimeout = 5 * time.Second
+
+	// A dead node is forgotten after that delay, a peer is contacted anyway
+	// and join again once it answer.
+	nodeRemoveTimeout = time.Minute
+)
+
+/**
+ * A Globule of the cluster as known by this one.
+ */
+type clusterNode struct {
+	info       *adminpb.NodeInfo
+	lastUpdate time.Time // The last time the heartbeat changed.
+}
+
+func (self *clusterNode) isAlive() bool {
+	return time.Since(self.lastUpdate) < nodeFailTimeout
+}
+
+/**
+ * A service that is not installed on this Globule but run on other nodes.
+ * The Globule listen it ports and forward the connections to the nodes.
+ */
+type remoteService struct {
+	port        int
+	proxy       int
+	router      *serviceRouter
+	proxyRouter *serviceRouter
+	nodes       []string // The addresses of the service on the other nodes.
+}
+
+/**
+ * Return the id of the Globule in the cluster.
+ */
+func (self *Globule) getNodeId() string {
+	return self.IP + ":" + strconv.Itoa(self.AdminPort)
+}
+
+/**
+ * Return the information of this Globule that is sent to the other nodes.
+ */
+func (self *Globule) getNodeInfo() *adminpb.NodeInfo {
+	self.mutex.Lock()
+	heartbeat := self.heartbeat
+	self.mutex.Unlock()
+
+	return &adminpb.NodeInfo{
+		Id:         self.getNodeId(),
+		Name:       self.Name,
+		Ip:         self.IP,
+		Port:       int32(self.Port),
+		AdminPort:  int32(self.AdminPort),
+		Generation: self.startTime.UnixNano(),
+		Heartbeat:  heartbeat,
+		Services:   self.getServicesInfo(),
+		Alive:      true,
+		LastUpdate: time.Now().Unix(),
+	}
+}
+
+/**
+ * Return the nodes known by the Globule, itself included.
+ */
+func (self *Globule) getNodes() []*adminpb.NodeInfo {
+	nodes := []*adminpb.NodeInfo{self.getNodeInfo()}
+
+	self.mutex.Lock()
+	for _, node := range self.nodes {
+		info := *node.info
+		info.Alive = node.isAlive()
+		info.LastUpdate = node.lastUpdate.Unix()
+		nodes = append(nodes, &info)
+	}
+	self.mutex.Unlock()
+
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Id < nodes[j].Id })
+
+	return nodes
+}
+
+/**
+ * Keep the nodes informations that are newer than the known ones, a node that
+ * restart has a new generation and it heartbeat start again at 0.
+ */
+func (self *Globule) mergeNodes(nodes []*adminpb.NodeInfo) {
+	id := self.getNodeId()
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	for _, info := range nodes {
+		if info.Id == id {
+			continue
+		}
+
+		node := self.nodes[info.Id]
+		if node == nil && !info.Alive {
+			// A node already forgotten here that other nodes still keep.
+			continue
+		}
+		if node == nil || info.Generation > node.info.Generation || (info.Generation == node.info.Generation && info.Heartbeat > node.info.Heartbeat) {
+			if node == nil {
+				log.Println("Node ", info.Id, " join the cluster")
+			} else if info.Generation != node.info.Generation {
+				log.Println("Node ", info.Id, " has restarted")
+			} else if !node.isAlive() {
+				log.Println("Node ", info.Id, " is back")
+			}
+			self.nodes[info.Id] = &clusterNode{info: info, lastUpdate: time.Now()}
+		}
+	}
+}
+
+/**
+ * Exchange the nodes informations with the other nodes at each round.
+ */
+func (self *Globule) gossip() {
+	for {
+		time.Sleep(gossipInterval)
+		self.gossipRound()
+	}
+}
+
+/**
+ * Exchange the nodes informations with the peers and a few known nodes, then
+ * update the routes to the remote services.
+ */
+func (self *Globule) gossipRound() {
+	self.mutex.Lock()
+	self.heartbeat++
+
+	// The peers are always contacted, so a node can come back after it was
+	// forgotten.
+	addresses := make([]string, 0)
+	for _, peer := range self.Peers {
+		addresses = append(addresses, peer)
+	}
+	self.mutex.Unlock()
+
+	known := self.removeExpiredNodes()
+	rand.Shuffle(len(known), func(i, j int) { known[i], known[j] = known[j], known[i] })
+	for i := 0; i < len(known) && i < gossipFanout; i++ {
+		if !contains(addresses, known[i]) {
+			addresses = append(addresses, known[i])
+		}
+	}
+
+	nodes := self.getNodes()
+	for _, address := range addresses {
+		if address == self.getNodeId() {
+			continue
+		}
+
+		nodes_, err := self.getPeerClient(address).Gossip(nodes)
+		if err == nil {
+			self.mergeNodes(nodes_)
+		}
+	}
+
+	self.updateRemoteServices()
+
+	// The nodes states can change without news from them.
+	self.notifyRegistry()
+}
+
+/**
+ * Forget the nodes without news since nodeRemoveTimeout and return the admin
+ * addresses of the others.
+ */
+func (self *Globule) removeExpiredNodes() []string {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	addresses := make([]string, 0)
+	for id, node := range self.nodes {
+		if time.Since(node.lastUpdate) > nodeRemoveTimeout {
+			log.Println("Node ", id, " is removed from the cluster")
+			delete(self.nodes, id)
+			continue
+		}
+		addresses = append(addresses, node.info.Ip+":"+strconv.Itoa(int(node.info.AdminPort)))
+	}
+
+	return addresses
+}
+
+/**
+ * Return the admin client of a node, the connections are kept between the
+ * rounds.
+ */
+func (self *Globule) getPeerClient(address string) *Admin_Client {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	client := self.peerClients[address]
+	if client == nil {
+		client = NewAdmin_Client(address)
+		client.token = self.adminToken
+		self.peerClients[address] = client
+	}
+
+	return client
+}
+
+/**
+ * Route the services that are not installed here to the alive nodes that
+ * run them and remove the routes of the services that are no more available.
+ */
+func (self *Globule) updateRemoteServices() {
+	// The services addresses and proxies addresses on the other nodes.
+	addresses := make(map[string][]string, 0)
+	proxies := make(map[string][]string, 0)
+
+	self.mutex.Lock()
+	ids := make([]string, 0)
+	for id, node := range self.nodes {
+		if node.isAlive() {
+			ids = append(ids, id)
+		}
+	}
+
+	// The same order each time, to know if the addresses change.
+	sort.Strings(ids)
+	for _, id := range ids {
+		node := self.nodes[id]
+		for _, s := range node.info.Services {
+			// The http services are only reach on their own node.
+			if self.services[s.Name] == nil && s.Running && s.Protocol != "http" {
+				addresses[s.Name] = append(addresses[s.Name], node.info.Ip+":"+strconv.Itoa(int(s.Port)))
+				if s.Proxy > 0 {
+					proxies[s.Name] = append(proxies[s.Name], node.info.Ip+":"+strconv.Itoa(int(s.Proxy)))
+				}
+			}
+		}
+	}
+	self.mutex.Unlock()
+
+	changed := false
+	for name, addresses_ := range addresses {
+		self.mutex.Lock()
+		remote := self.remoteServices[name]
+		self.mutex.Unlock()
+
+		if remote != nil && strings.Join(remote.nodes, ",") == strings.Join(addresses_, ",") {
+			continue
+		}
+
+		if remote == nil {
+			proxyAddress := ""
+			if len(proxies[name]) > 0 {
+				proxyAddress = proxies[name][0]
+			}
+
+			var err error
+			remote, err = self.routeRemoteService(name, addresses_[0], proxyAddress)
+			if err != nil {
+				log.Println("Fail to route remote service ", name, " with error ", err)
+				continue
+			}
+		}
+
+		remote.router.updateBackends(addresses_)
+		if remote.proxyRouter != nil {
+			remote.proxyRouter.updateBackends(proxies[name])
+		}
+
+		self.mutex.Lock()
+		remote.nodes = addresses_
+		self.mutex.Unlock()
+
+		// The web api use the service as a local one.
+		self.initClient(strings.TrimSuffix(name, "_server"))
+
+		log.Println("Service ", name, " is routed to ", strings.Join(addresses_, ", "))
+		changed = true
+	}
+
+	// Remove the routes of the services that are no more available, and the
+	// one saved by a previous run.
+	self.mutex.Lock()
+	removed := make([]string, 0)
+	for name, s := range self.Services {
+		if self.services[name] == nil && addresses[name] == nil && s.(map[string]interface{})["Remote"] == true {
+			removed = append(removed, name)
+		}
+	}
+	self.mutex.Unlock()
+
+	for _, name := range removed {
+		log.Println("Service ", name, " is no more available")
+		self.closeRemoteService(name)
+
+		self.mutex.Lock()
+		delete(self.Services, name)
+		client := self.clients[strings.TrimSuffix(name, "_server")+"_service"]
+		delete(self.clients, strings.TrimSuffix(name, "_server")+"_service")
+		self.mutex.Unlock()
+
+		if client != nil {
+			client.Close()
+		}
+		changed = true
+	}
+
+	if changed {
+		self.saveConfig()
+	}
+}
+
+/**
+ * Listen the ports of a remote service, the same ports as on the other nodes
+ * if they are free here. proxyAddress is empty if the service has no proxy
+ * port.
+ */
+func (self *Globule) routeRemoteService(name string, address string, proxyAddress string) (*remoteService, error) {
+	router, err := self.listenRemotePort(name, address)
+	if err != nil {
+		return nil, err
+	}
+
+	var proxyRouter *serviceRouter
+	if len(proxyAddress) > 0 {
+		proxyRouter, err = self.listenRemotePort(name, proxyAddress)
+		if err != nil {
+			router.close()
+			return nil, err
+		}
+	}
+
+	remote := new(remoteService)
+	remote.router = router
+	remote.proxyRouter = proxyRouter
+	remote.port = router.listener.Addr().(*net.TCPAddr).Port
+	remote.nodes = make([]string, 0)
+
+	// The services are exported as the local ones so the web clients use
+	// them the same way.
+	s_ := make(map[string]interface{})
+	s_["Port"] = float64(remote.port)
+	s_["Remote"] = true
+	if proxyRouter != nil {
+		remote.proxy = proxyRouter.listener.Addr().(*net.TCPAddr).Port
+		s_["Proxy"] = float64(remote.proxy)
+	}
+
+	self.mutex.Lock()
+	self.remoteServices[name] = remote
+	self.Services[name] = s_
+	self.mutex.Unlock()
+
+	return remote, nil
+}
+
+/**
+ * Listen the port of a remote service address, a free port is taken if it
+ * is use.
+ */
+func (self *Globule) listenRemotePort(name string, address string) (*serviceRouter, error) {
+	port := Utility.ToInt(address[strings.LastIndex(address, ":")+1:])
+	if !self.isPortUsed(name, port) {
+		router, err := newServiceRouter(port)
+		if err == nil {
+			return router, nil
+		}
+	}
+
+	return newServiceRouter(0)
+}
+
+/**
+ * Return the addresses of the processes of a service, the local ones if it
+ * is installed here or the ones of the alive nodes that run it.
+ */
+func (self *Globule) getServiceAddresses(name string) []string {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	addresses := make([]string, 0)
+	if s, ok := self.services[name].(map[string]interface{}); ok {
+		for _, instance := range getInstances(s) {
+			if instance.isRunning() {
+				addresses = append(addresses, instance.address())
+			}
+		}
+		return addresses
+	}
+
+	for _, node := range self.nodes {
+		if !node.isAlive() {
+			continue
+		}
+
+		for _, s := range node.info.Services {
+			if s.Name == name && s.Running && s.Protocol != "http" {
+				addresses = append(addresses, node.info.Ip+":"+strconv.Itoa(int(s.Port)))
+			}
+		}
+	}
+
+	sort.Strings(addresses)
+
+	return addresses
+}
+
+/**
+ * Return the channel closed at the next change of the services addresses.
+ */
+func (self *Globule) getRegistryChanged() chan bool {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	return self.registryChanged
+}
+
+/**
+ * Wake up the services watchers, call when the addresses of a service can
+ * have change.
+ */
+func (self *Globule) notifyRegistry() {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	close(self.registryChanged)
+	self.registryChanged = make(chan bool)
+}
+
+/**
+ * Close the routes of a remote service, also use when the service is
+ * installed on this Globule.
+ */
+func (self *Globule) closeRemoteService(name string) {
+	self.mutex.Lock()
+	remote := self.remoteServices[name]
+	delete(self.remoteServices, name)
+	self.mutex.Unlock()
+
+	if remote != nil {
+		remote.router.close()
+		if remote.proxyRouter != nil {
+			remote.proxyRouter.close()
+		}
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, value_ := range values {
+		if value_ == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/davecourtois/Globular/admin/adminpb"
+)
+
+/**
+ * Return a Globule of the cluster tests, it configuration is written in a new
+ * directory.
+ */
+func newTestGlobule(t *testing.T, adminPort int) *Globule {
+	dir, err := ioutil.TempDir("", "cluster_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g := new(Globule)
+	g.Name = "globular_" + strconv.Itoa(adminPort)
+	g.IP = "127.0.0.1"
+	g.AdminAddress = "127.0.0.1"
+	g.AdminPort = adminPort
+	g.path = dir
+	g.adminToken = "cluster_test"
+	g.startTime = time.Now()
+	g.services = make(map[string]interface{}, 0)
+	g.Services = make(map[string]interface{}, 0)
+	g.clients = make(map[string]Client, 0)
+	g.Peers = make([]string, 0)
+	g.nodes = make(map[string]*clusterNode, 0)
+	g.peerClients = make(map[string]*Admin_Client, 0)
+	g.remoteServices = make(map[string]*remoteService, 0)
+	g.registryChanged = make(chan bool)
+
+	return g
+}
+
+// The newer informations of a node are kept, a restarted node has a new
+// generation and it heartbeat start again.
+func TestMergeNodes(t *testing.T) {
+	known := &adminpb.NodeInfo{Id: "10.0.0.2:10015", Generation: 10, Heartbeat: 5, Alive: true}
+
+	tests := []struct {
+		name     string
+		known    *adminpb.NodeInfo
+		info     *adminpb.NodeInfo
+		accepted bool
+	}{
+		{"next heartbeat", known, &adminpb.NodeInfo{Id: known.Id, Generation: 10, Heartbeat: 6, Alive: true}, true},
+		{"same heartbeat", known, &adminpb.NodeInfo{Id: known.Id, Generation: 10, Heartbeat: 5, Alive: true}, false},
+		{"older heartbeat", known, &adminpb.NodeInfo{Id: known.Id, Generation: 10, Heartbeat: 4, Alive: true}, false},
+		{"restarted", known, &adminpb.NodeInfo{Id: known.Id, Generation: 11, Heartbeat: 0, Alive: true}, true},
+		{"previous generation", known, &adminpb.NodeInfo{Id: known.Id, Generation: 9, Heartbeat: 100, Alive: true}, false},
+		{"dead elsewhere", known, &adminpb.NodeInfo{Id: known.Id, Generation: 10, Heartbeat: 6, Alive: false}, true},
+		{"join", nil, &adminpb.NodeInfo{Id: "10.0.0.3:10015", Generation: 1, Heartbeat: 1, Alive: true}, true},
+		{"forgotten", nil, &adminpb.NodeInfo{Id: "10.0.0.3:10015", Generation: 1, Heartbeat: 1, Alive: false}, false},
+		{"itself", nil, &adminpb.NodeInfo{Id: "127.0.0.1:10015", Generation: 1, Heartbeat: 1, Alive: true}, false},
+	}
+
+	for _, test := range tests {
+		g := newTestGlobule(t, 10015)
+		if test.known != nil {
+			g.nodes[test.known.Id] = &clusterNode{info: test.known, lastUpdate: time.Now().Add(-time.Second)}
+		}
+
+		g.mergeNodes([]*adminpb.NodeInfo{test.info})
+
+		node := g.nodes[test.info.Id]
+		accepted := node != nil && node.info == test.info
+		if accepted != test.accepted {
+			t.Errorf("%s: the node information is accepted %v, %v is expected", test.name, accepted, test.accepted)
+		}
+		os.RemoveAll(g.path)
+	}
+}
+
+// A node without news is dead after nodeFailTimeout and forgotten after
+// nodeRemoveTimeout.
+func TestNodesExpiration(t *testing.T) {
+	g := newTestGlobule(t, 10015)
+	defer os.RemoveAll(g.path)
+
+	g.nodes["10.0.0.2:10015"] = &clusterNode{info: &adminpb.NodeInfo{Id: "10.0.0.2:10015", Ip: "10.0.0.2", AdminPort: 10015}, lastUpdate: time.Now()}
+	g.nodes["10.0.0.3:10015"] = &clusterNode{info: &adminpb.NodeInfo{Id: "10.0.0.3:10015", Ip: "10.0.0.3", AdminPort: 10015}, lastUpdate: time.Now().Add(-2 * nodeFailTimeout)}
+	g.nodes["10.0.0.4:10015"] = &clusterNode{info: &adminpb.NodeInfo{Id: "10.0.0.4:10015", Ip: "10.0.0.4", AdminPort: 10015}, lastUpdate: time.Now().Add(-2 * nodeRemoveTimeout)}
+
+	alive := make(map[string]bool, 0)
+	for _, node := range g.getNodes() {
+		alive[node.Id] = node.Alive
+	}
+
+	if !alive["127.0.0.1:10015"] || !alive["10.0.0.2:10015"] || alive["10.0.0.3:10015"] || alive["10.0.0.4:10015"] {
+		t.Fatalf("unexpected nodes states %v", alive)
+	}
+
+	addresses := g.removeExpiredNodes()
+	if len(addresses) != 2 || !contains(addresses, "10.0.0.2:10015") || !contains(addresses, "10.0.0.3:10015") {
+		t.Fatalf("unexpected nodes addresses %v", addresses)
+	}
+
+	if g.nodes["10.0.0.4:10015"] != nil {
+		t.Fatal("the expired node is not removed")
+	}
+}
+
+// The grpc services of the alive nodes that are not installed here are
+// routed, their routes are removed when the node die.
+func TestUpdateRemoteServices(t *testing.T) {
+	g := newTestGlobule(t, 10015)
+	defer os.RemoveAll(g.path)
+
+	// The echo service of the other node.
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lis.Close()
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go io.Copy(conn, conn)
+		}
+	}()
+
+	port := int32(lis.Addr().(*net.TCPAddr).Port)
+	g.services["local_server"] = map[string]interface{}{"Name": "local_server", "Port": float64(port + 1)}
+	g.nodes["127.0.0.2:10015"] = &clusterNode{
+		info: &adminpb.NodeInfo{Id: "127.0.0.2:10015", Ip: "127.0.0.1", AdminPort: 10015, Services: []*adminpb.ServiceInfo{
+			{Name: "echo_server", Protocol: "grpc", Running: true, Port: port},
+			{Name: "local_server", Protocol: "grpc", Running: true, Port: port},
+			{Name: "stopped_server", Protocol: "grpc", Running: false, Port: port},
+			{Name: "http_server", Protocol: "http", Running: true, Port: port},
+		}},
+		lastUpdate: time.Now(),
+	}
+
+	g.updateRemoteServices()
+	defer g.closeRemoteService("echo_server")
+
+	if len(g.remoteServices) != 1 || g.remoteServices["echo_server"] == nil {
+		t.Fatalf("unexpected remote services %v", g.remoteServices)
+	}
+
+	if s, ok := g.Services["echo_server"].(map[string]interface{}); !ok || s["Remote"] != true {
+		t.Fatalf("the remote service is not exported %v", g.Services["echo_server"])
+	}
+
+	// The connections are forwarded to the other node.
+	conn, err := net.Dial("tcp", "127.0.0.1:"+strconv.Itoa(g.remoteServices["echo_server"].port))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+	conn.Write([]byte("hello"))
+	data := make([]byte, 5)
+	_, err = io.ReadFull(conn, data)
+	if err != nil || string(data) != "hello" {
+		t.Fatalf("the connection is not forwarded %s %v", data, err)
+	}
+
+	g.nodes["127.0.0.2:10015"].lastUpdate = time.Now().Add(-2 * nodeFailTimeout)
+	g.updateRemoteServices()
+
+	if len(g.remoteServices) != 0 || g.Services["echo_server"] != nil {
+		t.Fatalf("the routes of the dead node are kept %v", g.remoteServices)
+	}
+}
+
+// Two Globules of the host that know each other from one peer address, the
+// restart of a node is seen by the other.
+func TestGossip(t *testing.T) {
+	ports := make([]int, 2)
+	for i := 0; i < len(ports); i++ {
+		port, err := getFreePort()
+		if err != nil {
+			t.Fatal(err)
+		}
+		ports[i] = port
+	}
+
+	a := newTestGlobule(t, ports[0])
+	defer os.RemoveAll(a.path)
+	b := newTestGlobule(t, ports[1])
+	defer os.RemoveAll(b.path)
+
+	for _, g := range []*Globule{a, b} {
+		err := g.startAdminService()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	a.Peers = []string{b.getNodeId()}
+
+	a.gossipRound()
+	b.gossipRound()
+
+	if a.nodes[b.getNodeId()] == nil || b.nodes[a.getNodeId()] == nil {
+		t.Fatal("the Globules do not know each other")
+	}
+
+	heartbeat := a.nodes[b.getNodeId()].info.Heartbeat
+	if heartbeat != 1 {
+		t.Fatalf("unexpected heartbeat %d", heartbeat)
+	}
+
+	// b restart, it heartbeat start again.
+	b.mutex.Lock()
+	b.startTime = time.Now()
+	b.heartbeat = 0
+	b.mutex.Unlock()
+
+	b.gossipRound()
+	a.gossipRound()
+
+	node := a.nodes[b.getNodeId()]
+	if node.info.Generation != b.startTime.UnixNano() || node.info.Heartbeat != 1 {
+		t.Fatalf("the restart of the node is not seen %v", node.info)
+	}
+
+	// A client without the token is refused.
+	client := NewAdmin_Client(b.getNodeId())
+	defer client.Close()
+	_, err := client.Gossip(a.getNodes())
+	if err == nil {
+		t.Fatal("the gossip without token is accepted")
+	}
+}
@@ -0,0 +1,423 @@
+package config
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+/**
+ * The configuration files of the Globule and of the services carry the
+ * version of their schema in ConfigVersion. A file of an older version is
+ * upgraded by the migrations registered for the versions after it, the
+ * previous file is kept as config.json.v<version>. The file is then checked
+ * against the fields of the struct it is read in so a renamed or mistyped
+ * field is reported instead of being silently ignored. Each program keep the
+ * version it expect in a configVersion variable, incremented with each
+ * migration it register:
+ *
+ *	func init() {
+ *		// Version 2 rename Root to FilesRoot.
+ *		config.RegisterMigration(2, func(values map[string]interface{}) error {
+ *			values["FilesRoot"] = values["Root"]
+ *			delete(values, "Root")
+ *			return nil
+ *		})
+ *	}
+ *
+ *	err := config.Load(dir+"/config.json", self, 2)
+ */
+
+// The field of the schema version in the configuration files.
+const VersionKey = "ConfigVersion"
+
+/**
+ * A migration change the values of a configuration of the version before it
+ * to it version.
+ */
+type Migration func(values map[string]interface{}) error
+
+var (
+	mutex      sync.Mutex
+	migrations = make(map[int]Migration, 0)
+)
+
+/**
+ * Register the migration that upgrade the configurations to a version.
+ */
+func RegisterMigration(version int, migration Migration) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	migrations[version] = migration
+}
+
+/**
+ * The errors found in a configuration file.
+ */
+type Error struct {
+	Path   string   // The configuration file.
+	Errors []string // The errors, ex: Connections.main.Port: a number is expected, not a string
+}
+
+func (self *Error) Error() string {
+	if len(self.Path) == 0 {
+		return "the configuration is not valid: " + strings.Join(self.Errors, "; ")
+	}
+
+	return "the configuration " + self.Path + " is not valid: " + strings.Join(self.Errors, "; ")
+}
+
+/**
+ * Read a configuration file in v, a pointer to a struct. The file is upgraded
+ * to version and checked before v is set. The error of a file that does not
+ * exist satisfy os.IsNotExist, the errors of a file that does not match v are
+ * an *Error.
+ */
+func Load(path string, v interface{}, version int) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	values := make(map[string]interface{}, 0)
+	err = json.Unmarshal(data, &values)
+	if err != nil {
+		return &Error{Path: path, Errors: []string{getSyntaxError(data, err)}}
+	}
+
+	current, err := getVersion(values)
+	if err != nil {
+		return &Error{Path: path, Errors: []string{err.Error()}}
+	}
+
+	if current > version {
+		return &Error{Path: path, Errors: []string{"the version " + strconv.Itoa(current) + " is newer than the version " + strconv.Itoa(version) + " supported"}}
+	}
+
+	upgrade := current < version
+	if upgrade {
+		err = migrate(values, current, version)
+		if err != nil {
+			return &Error{Path: path, Errors: []string{err.Error()}}
+		}
+	}
+
+	err = Validate(values, v)
+	if err != nil {
+		err.(*Error).Path = path
+		return err
+	}
+
+	if upgrade {
+		// The previous file is kept.
+		err = ioutil.WriteFile(path+".v"+strconv.Itoa(current), data, 0644)
+		if err != nil {
+			return err
+		}
+
+		data, err = json.MarshalIndent(values, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		err = writeFile(path, data)
+		if err != nil {
+			return err
+		}
+
+		log.Println("The configuration ", path, " is upgraded from version ", current, " to ", version)
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+/**
+ * Return the schema version of a configuration, 0 for the files written
+ * before the versions.
+ */
+func getVersion(values map[string]interface{}) (int, error) {
+	value, ok := values[VersionKey]
+	if !ok {
+		return 0, nil
+	}
+
+	number, ok := value.(float64)
+	if !ok || number != math.Trunc(number) || number < 0 {
+		return 0, errors.New(VersionKey + ": a positive integer is expected")
+	}
+
+	return int(number), nil
+}
+
+/**
+ * Apply the migrations after current up to version.
+ */
+func migrate(values map[string]interface{}, current int, version int) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	for version_ := current + 1; version_ <= version; version_++ {
+		if migration := migrations[version_]; migration != nil {
+			err := migration(values)
+			if err != nil {
+				return fmt.Errorf("the migration to version %d fail: %v", version_, err)
+			}
+		}
+	}
+
+	values[VersionKey] = float64(version) // as it is read from json.
+
+	return nil
+}
+
+/**
+ * Write a file aside and rename it so a crash does not leave it half written.
+ */
+func writeFile(path string, data []byte) error {
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	err := ioutil.WriteFile(path+".tmp", data, mode)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(path+".tmp", path)
+}
+
+/**
+ * Return a json syntax error with it line and column.
+ */
+func getSyntaxError(data []byte, err error) string {
+	var offset int64
+	switch err_ := err.(type) {
+	case *json.SyntaxError:
+		offset = err_.Offset
+	case *json.UnmarshalTypeError:
+		offset = err_.Offset
+	default:
+		return err.Error()
+	}
+
+	// The offset is after the invalid character.
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	if offset > 0 {
+		offset--
+	}
+
+	line := bytes.Count(data[:offset], []byte("\n")) + 1
+	column := int(offset) - bytes.LastIndex(data[:offset], []byte("\n"))
+
+	return "line " + strconv.Itoa(line) + " column " + strconv.Itoa(column) + ": " + err.Error()
+}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+/**
+ * Check the json values of a configuration against v, the struct they are
+ * read in. Return an *Error with all the errors found.
+ */
+func Validate(values map[string]interface{}, v interface{}) error {
+	errs := make([]string, 0)
+	validate(values, reflect.TypeOf(v), "", &errs)
+	if len(errs) > 0 {
+		return &Error{Errors: errs}
+	}
+
+	return nil
+}
+
+/**
+ * Check a json value against the type it is read in, the errors are added
+ * to errs with the path of the value. The unknown fields of the structs are
+ * errors, the types that read themself are not checked.
+ */
+func validate(value interface{}, t reflect.Type, path string, errs *[]string) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if value == nil || t.Kind() == reflect.Interface || reflect.PtrTo(t).Implements(jsonUnmarshalerType) {
+		return
+	}
+
+	// The time and the other text values.
+	if reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		if _, ok := value.(string); !ok {
+			*errs = append(*errs, getErrorPath(path)+"a string is expected, not "+getJsonType(value))
+		}
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			*errs = append(*errs, getErrorPath(path)+"an object is expected, not "+getJsonType(value))
+			return
+		}
+
+		fields := getFields(t)
+		for _, name := range getSortedKeys(object) {
+			field, ok := fields[strings.ToLower(name)]
+			if !ok {
+				*errs = append(*errs, getErrorPath(joinPath(path, name))+"unknown field")
+				continue
+			}
+			validate(object[name], field.Type, joinPath(path, name), errs)
+		}
+	case reflect.Map:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			*errs = append(*errs, getErrorPath(path)+"an object is expected, not "+getJsonType(value))
+			return
+		}
+
+		for _, name := range getSortedKeys(object) {
+			validate(object[name], t.Elem(), joinPath(path, name), errs)
+		}
+	case reflect.Slice, reflect.Array:
+		// The bytes are base64 encoded.
+		if t.Elem().Kind() == reflect.Uint8 {
+			if _, ok := value.(string); ok {
+				return
+			}
+		}
+
+		array, ok := value.([]interface{})
+		if !ok {
+			*errs = append(*errs, getErrorPath(path)+"an array is expected, not "+getJsonType(value))
+			return
+		}
+
+		for i, value_ := range array {
+			validate(value_, t.Elem(), path+"["+strconv.Itoa(i)+"]", errs)
+		}
+	case reflect.String:
+		if _, ok := value.(string); !ok {
+			*errs = append(*errs, getErrorPath(path)+"a string is expected, not "+getJsonType(value))
+		}
+	case reflect.Bool:
+		if _, ok := value.(bool); !ok {
+			*errs = append(*errs, getErrorPath(path)+"a boolean is expected, not "+getJsonType(value))
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		number, ok := value.(float64)
+		if !ok {
+			*errs = append(*errs, getErrorPath(path)+"a number is expected, not "+getJsonType(value))
+		} else if number != math.Trunc(number) {
+			*errs = append(*errs, getErrorPath(path)+"an integer is expected, not "+strconv.FormatFloat(number, 'f', -1, 64))
+		} else if number < 0 && t.Kind() >= reflect.Uint {
+			*errs = append(*errs, getErrorPath(path)+"a positive integer is expected, not "+strconv.FormatFloat(number, 'f', -1, 64))
+		}
+	case reflect.Float32, reflect.Float64:
+		if _, ok := value.(float64); !ok {
+			*errs = append(*errs, getErrorPath(path)+"a number is expected, not "+getJsonType(value))
+		}
+	}
+}
+
+/**
+ * Return the fields of a struct read from json by lower case name, with the
+ * fields of the embedded structs.
+ */
+func getFields(t reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField, 0)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := strings.Split(field.Tag.Get("json"), ",")[0]
+		if tag == "-" {
+			continue
+		}
+
+		if field.Anonymous && len(tag) == 0 {
+			t_ := field.Type
+			if t_.Kind() == reflect.Ptr {
+				t_ = t_.Elem()
+			}
+			if t_.Kind() == reflect.Struct {
+				for name, field_ := range getFields(t_) {
+					if _, ok := fields[name]; !ok {
+						fields[name] = field_
+					}
+				}
+				continue
+			}
+		}
+
+		if len(field.PkgPath) > 0 {
+			continue // not exported
+		}
+
+		name := field.Name
+		if len(tag) > 0 {
+			name = tag
+		}
+		fields[strings.ToLower(name)] = field
+	}
+
+	return fields
+}
+
+func getSortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key, _ := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func joinPath(path string, name string) string {
+	if len(path) == 0 {
+		return name
+	}
+
+	return path + "." + name
+}
+
+func getErrorPath(path string) string {
+	if len(path) == 0 {
+		return ""
+	}
+
+	return path + ": "
+}
+
+/**
+ * Return the name of the type of a json value for the errors.
+ */
+func getJsonType(value interface{}) string {
+	switch value.(type) {
+	case string:
+		return "a string"
+	case float64:
+		return "a number"
+	case bool:
+		return "a boolean"
+	case map[string]interface{}:
+		return "an object"
+	case []interface{}:
+		return "an array"
+	}
+
+	return "null"
+}
@@ -0,0 +1,131 @@
+package Globular
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/davecourtois/Globular/config"
+)
+
+type testConfig struct {
+	Name          string
+	Port          int
+	FilesRoot     string
+	Connections   map[string]testConnection
+	ConfigVersion int
+}
+
+type testConnection struct {
+	Host string
+	Port int32
+}
+
+func init() {
+	// Version 2 rename Root to FilesRoot.
+	config.RegisterMigration(2, func(values map[string]interface{}) error {
+		values["FilesRoot"] = values["Root"]
+		delete(values, "Root")
+		return nil
+	})
+}
+
+/**
+ * Write a configuration file in a new directory.
+ */
+func writeConfig(t *testing.T, content string) string {
+	dir, err := ioutil.TempDir("", "config_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(dir, "config.json")
+	err = ioutil.WriteFile(path, []byte(content), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+// A file without version is upgraded and the previous one is kept.
+func TestLoadMigration(t *testing.T) {
+	content := `{"Name": "file_server", "Port": 10011, "Root": "/var/www"}`
+	path := writeConfig(t, content)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	c := new(testConfig)
+	err := config.Load(path, c, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.FilesRoot != "/var/www" || c.ConfigVersion != 2 || c.Port != 10011 {
+		t.Fatalf("unexpected configuration %+v", c)
+	}
+
+	previous, err := ioutil.ReadFile(path + ".v0")
+	if err != nil || string(previous) != content {
+		t.Fatalf("the previous file is not kept: %v", err)
+	}
+
+	data, _ := ioutil.ReadFile(path)
+	values := make(map[string]interface{}, 0)
+	json.Unmarshal(data, &values)
+	if values["ConfigVersion"] != 2.0 || values["Root"] != nil {
+		t.Fatalf("the file is not upgraded: %s", data)
+	}
+
+	// Loaded again nothing change.
+	err = config.Load(path, new(testConfig), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// The unknown fields and the wrong types are reported with their path.
+func TestLoadInvalid(t *testing.T) {
+	path := writeConfig(t, `{"ConfigVersion": 2, "Name": "file_server", "Prot": 10011, "Connections": {"main": {"Host": "localhost", "Port": "3306"}}}`)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	err := config.Load(path, new(testConfig), 2)
+	err_, ok := err.(*config.Error)
+	if !ok {
+		t.Fatalf("expected a configuration error, got %v", err)
+	}
+
+	expected := []string{"Connections.main.Port: a number is expected, not a string", "Prot: unknown field"}
+	if strings.Join(err_.Errors, "|") != strings.Join(expected, "|") {
+		t.Fatalf("unexpected errors %q", err_.Errors)
+	}
+}
+
+// A file of a newer version is refused and not changed.
+func TestLoadNewerVersion(t *testing.T) {
+	path := writeConfig(t, `{"ConfigVersion": 3, "Name": "file_server"}`)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	err := config.Load(path, new(testConfig), 2)
+	if err == nil || !strings.Contains(err.Error(), "is newer than the version 2") {
+		t.Fatalf("expected a version error, got %v", err)
+	}
+}
+
+// The syntax errors give their line and column.
+func TestLoadSyntaxError(t *testing.T) {
+	path := writeConfig(t, "{\n  \"Name\": \"file_server\",\n  \"Port\": 10011,,\n}")
+	defer os.RemoveAll(filepath.Dir(path))
+
+	err := config.Load(path, new(testConfig), 2)
+	if err == nil || !strings.Contains(err.Error(), "line 3 column 17") {
+		t.Fatalf("expected a syntax error at line 3, got %v", err)
+	}
+
+	_, err = os.Stat(path + ".v0")
+	if !os.IsNotExist(err) {
+		t.Fatal("an invalid file must not be upgraded")
+	}
+}
@@ -22,7 +22,7 @@ cp WebRoot/js/services.js dist/globular/WebRoot/js
 cp WebRoot/js/test.js dist/globular/WebRoot/js
 cp WebRoot/css/styles.css dist/globular/WebRoot/css
 cp WebRoot/index.html dist/globular/WebRoot
-cp WebRoot/config.json dist/globular/WebRoot
+cp globular.json dist/globular
 #echo service
 mkdir dist/globular/echo
 cp echo/echo_server/echo_server dist/globular/echo
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/davecourtois/Globular/file/filepb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+/**
+ * The files of the file service are downloaded at /downloads/, the path after
+ * it is the path of the file in the file service Root:
+ *
+ *	GET /downloads/archives/a.zip Range: bytes=1048576- If-Range: "..."
+ *		-> 206 Content-Range: bytes 1048576-4194303/4194304
+ *
+ * The ranges, If-Range and the conditional requests are answered like the
+ * files of the web root, so an interrupted download can be resumed as long as
+ * the file does not change.
+ */
+
+// The size of the data read by message from the file service.
+const downloadChunkSize = 1024 * 64
+
+/**
+ * A file of the file service read as an io.ReadSeeker. The data are read by a
+ * stream from the offset to the end of the file, open again after a seek.
+ */
+type remoteFile struct {
+	ctx     context.Context
+	client  *File_Client
+	path    string
+	size    int64
+	modTime time.Time
+
+	// The position of the reader and of the stream.
+	offset   int64
+	position int64
+	data     []byte // received and not read yet
+	stream   filepb.FileService_ReadFileClient
+	cancel   context.CancelFunc
+}
+
+/**
+ * Open a file of the file service, it is read until ctx is done.
+ */
+func openRemoteFile(ctx context.Context, client *File_Client, path string) (*remoteFile, error) {
+	f := &remoteFile{ctx: ctx, client: client, path: path}
+	err := f.open()
+	if err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+/**
+ * Open the stream at the offset. The size and time of the file are set by the
+ * first stream, the next ones fail if the file has changed.
+ */
+func (f *remoteFile) open() error {
+	f.Close()
+
+	ctx, cancel := context.WithCancel(f.ctx)
+	stream, err := f.client.c.ReadFile(ctx, &filepb.ReadFileRequest{Path: f.path, Offset: f.offset, ChunkSize: downloadChunkSize})
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	rsp, err := stream.Recv()
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	modTime := time.Unix(0, rsp.ModTimeNano)
+	if f.modTime.IsZero() {
+		f.size = rsp.Size
+		f.modTime = modTime
+	} else if f.size != rsp.Size || !f.modTime.Equal(modTime) {
+		cancel()
+		return errors.New("the file " + f.path + " has changed")
+	}
+
+	f.stream = stream
+	f.cancel = cancel
+	f.data = rsp.Data
+	f.position = f.offset
+
+	return nil
+}
+
+func (f *remoteFile) Read(p []byte) (int, error) {
+	if f.offset >= f.size {
+		return 0, io.EOF
+	}
+
+	if f.stream == nil || f.position != f.offset {
+		err := f.open()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	for len(f.data) == 0 {
+		rsp, err := f.stream.Recv()
+		if err == io.EOF {
+			return 0, io.ErrUnexpectedEOF // the file is shorter than it size.
+		} else if err != nil {
+			return 0, err
+		}
+		f.data = rsp.Data
+	}
+
+	n := copy(p, f.data)
+	f.data = f.data[n:]
+	f.offset += int64(n)
+	f.position = f.offset
+
+	return n, nil
+}
+
+/**
+ * Move the offset, the stream is open again by the next read if it is not at
+ * the offset.
+ */
+func (f *remoteFile) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		offset += f.size
+	}
+
+	if offset < 0 {
+		return 0, errors.New("negative offset")
+	}
+
+	f.offset = offset
+
+	return offset, nil
+}
+
+func (f *remoteFile) Close() error {
+	if f.cancel != nil {
+		f.cancel()
+	}
+
+	f.stream = nil
+	f.cancel = nil
+	f.data = nil
+
+	return nil
+}
+
+/**
+ * The /downloads/ endpoint.
+ */
+func (self *Globule) DownloadsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "the method "+r.Method+" is not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	self.mutex.Lock()
+	client, _ := self.clients["file_service"].(*File_Client)
+	self.mutex.Unlock()
+
+	if client == nil {
+		http.Error(w, "the file service is not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, "/downloads")
+	f, err := openRemoteFile(r.Context(), client, name)
+	if err != nil {
+		http.Error(w, status.Convert(err).Message(), getDownloadStatus(err))
+		return
+	}
+	defer f.Close()
+
+	// The ETag change with the file, for If-Range.
+	w.Header().Set("ETag", `"`+strconv.FormatInt(f.size, 16)+"-"+strconv.FormatInt(f.modTime.UnixNano(), 16)+`"`)
+	if contentType := mime.TypeByExtension(path.Ext(name)); len(contentType) > 0 {
+		w.Header().Set("Content-Type", contentType)
+	}
+
+	http.ServeContent(w, r, path.Base(name), f.modTime, f)
+}
+
+/**
+ * Return the http status of an error of the file service.
+ */
+func getDownloadStatus(err error) int {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	}
+
+	return http.StatusBadGateway
+}
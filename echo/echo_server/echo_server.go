@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
@@ -12,6 +11,7 @@ import (
 	"path/filepath"
 	"strconv"
 
+	"github.com/davecourtois/Globular/config"
 	"github.com/davecourtois/Globular/echo/echopb"
 	"github.com/davecourtois/Utility"
 	"google.golang.org/grpc"
@@ -30,6 +30,9 @@ var (
 
 	// comma separeated values.
 	allowed_origins string = ""
+
+	// The configuration schema version, see package config.
+	configVersion = 1
 )
 
 // Value need by Globular to start the services...
@@ -41,17 +44,23 @@ type server struct {
 	AllowAllOrigins bool
 	AllowedOrigins  string // comma separated string.
 	Protocol        string
+
+	// The number of processes run by the Globule and the version of the
+	// configuration schema.
+	Replicas      int
+	ConfigVersion int
 }
 
 // Create the configuration file if is not already exist.
 func (self *server) init() {
 	// Here I will retreive the list of connections from file if there are some...
 	dir, _ := filepath.Abs(filepath.Dir(os.Args[0]))
-	file, err := ioutil.ReadFile(dir + "/config.json")
-	if err == nil {
-		json.Unmarshal([]byte(file), self)
-	} else {
+	self.ConfigVersion = configVersion
+	err := config.Load(dir+"/config.json", self, configVersion)
+	if os.IsNotExist(err) {
 		self.save()
+	} else if err != nil {
+		log.Fatalln(err)
 	}
 }
 
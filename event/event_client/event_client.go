@@ -0,0 +1,127 @@
+/**
+ * The services publish their events with that package, ex:
+ *
+ *	event_client.Publish(event_client.FileSaved, map[string]interface{}{"path": path})
+ *
+ * The events are sent to the event service in order by a goroutine so the
+ * publisher is never blocked, they are lost if the event service is not
+ * running.
+ */
+package event_client
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/davecourtois/Globular/event/eventpb"
+	"github.com/davecourtois/Globular/resolver"
+	"google.golang.org/grpc"
+)
+
+// The well known topics, the data is a JSON object with the given values.
+const (
+	ServiceStarted   = "service.started"   // name, replicas
+	ServiceStopped   = "service.stopped"   // name
+	ServiceExited    = "service.exited"    // name, pid, error
+	ServiceRestarted = "service.restarted" // name, pid
+	ServiceUpgraded  = "service.upgraded"  // name, replicas
+
+	FileSaved     = "file.saved"     // path
+	FileDeleted   = "file.deleted"   // path
+	FileRenamed   = "file.renamed"   // path, old, new (a file or a directory)
+	FileCopied    = "file.copied"    // source, destination (a file or a directory)
+	FileMoved     = "file.moved"     // source, destination (a file or a directory)
+	FileExtracted = "file.extracted" // source, destination (an archive and a directory)
+	DirCreated    = "dir.created"    // path
+	DirDeleted    = "dir.deleted"    // path
+
+	EmailSent = "email.sent" // id, from, to, subject
+
+	DatabaseCreated   = "persistence.database.created"   // connection, database
+	DatabaseDeleted   = "persistence.database.deleted"   // connection, database
+	CollectionCreated = "persistence.collection.created" // connection, database, collection
+	CollectionDeleted = "persistence.collection.deleted" // connection, database, collection
+	EntityInserted    = "persistence.inserted"           // connection, database, collection, id or count
+	EntityUpdated     = "persistence.updated"            // connection, database, collection, query
+	EntityReplaced    = "persistence.replaced"           // connection, database, collection, query
+	EntityDeleted     = "persistence.deleted"            // connection, database, collection, query
+
+	BackupCreated  = "backup.created"  // name, size
+	BackupRestored = "backup.restored" // name
+)
+
+const (
+	// The events waiting to be sent, the next ones are lost.
+	queueSize = 1000
+
+	// The time to send an event.
+	publishTimeout = time.Second
+)
+
+var (
+	mutex  sync.Mutex
+	queue  chan *eventpb.Event
+	client eventpb.EventServiceClient
+)
+
+/**
+ * Publish an event, data is marshal in JSON.
+ */
+func Publish(name string, data interface{}) {
+	value, err := json.Marshal(data)
+	if err != nil {
+		log.Println("Fail to publish event ", name, " with error ", err)
+		return
+	}
+
+	mutex.Lock()
+	if queue == nil {
+		queue = make(chan *eventpb.Event, queueSize)
+		go send()
+	}
+	mutex.Unlock()
+
+	evt := &eventpb.Event{
+		Name: name,
+		Data: value,
+		Time: time.Now().UnixNano() / int64(time.Millisecond),
+	}
+
+	select {
+	case queue <- evt:
+	default:
+		log.Println("Event ", name, " is lost, the event service is too slow")
+	}
+}
+
+/**
+ * Send the events of the queue to the event service.
+ */
+func send() {
+	failing := false
+	for evt := range queue {
+		if client == nil {
+			cc, err := resolver.Dial("event_server", grpc.WithInsecure())
+			if err != nil {
+				log.Println("Fail to connect to the event service with error ", err)
+				continue
+			}
+			client = eventpb.NewEventServiceClient(cc)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+		_, err := client.Publish(ctx, &eventpb.PublishRequest{Evt: evt})
+		cancel()
+
+		// The errors are log once until the event service is back.
+		if err != nil && !failing {
+			log.Println("Fail to publish event ", evt.Name, " with error ", err)
+		} else if err == nil && failing {
+			log.Println("The events are published again")
+		}
+		failing = err != nil
+	}
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/davecourtois/Globular/config"
+	"github.com/davecourtois/Globular/event/eventpb"
+	"github.com/davecourtois/Utility"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/grpclog"
+	"google.golang.org/grpc/status"
+)
+
+// TODO take care of TLS/https
+var (
+	defaultPort = 10016
+
+	// The configuration schema version (see package config), 2 removed the grpc-web proxy.
+	configVersion = 2
+)
+
+func init() {
+	// Version 2 remove the grpc-web proxy, the browsers reach the events at
+	// the Globule /events only.
+	config.RegisterMigration(2, func(values map[string]interface{}) error {
+		delete(values, "Proxy")
+		delete(values, "AllowAllOrigins")
+		delete(values, "AllowedOrigins")
+		return nil
+	})
+}
+
+// Value need by Globular to start the services...
+type server struct {
+	// The global attribute of the services.
+	Name     string
+	Port     int
+	Protocol string
+
+	// The number of processes run by the Globule and the version of the
+	// configuration schema.
+	Replicas      int
+	ConfigVersion int
+
+	// The number of events kept for a subscriber that does not read them,
+	// the next ones are lost.
+	QueueSize int
+
+	// The subscribers by topic.
+	mutex       sync.Mutex
+	subscribers map[string]map[*subscriber]bool
+}
+
+/**
+ * A Subscribe stream, it receive the events from it queue.
+ */
+type subscriber struct {
+	name   string
+	events chan *eventpb.Event
+	lost   int
+}
+
+// Create the configuration file if is not already exist.
+func (self *server) init() {
+	// Here I will retreive the list of connections from file if there are some...
+	dir, _ := filepath.Abs(filepath.Dir(os.Args[0]))
+	self.ConfigVersion = configVersion
+	err := config.Load(dir+"/config.json", self, configVersion)
+	if os.IsNotExist(err) {
+		self.save()
+	} else if err != nil {
+		log.Fatalln(err)
+	}
+}
+
+// Save the configuration values.
+func (self *server) save() error {
+	// Create the file...
+	str, err := Utility.ToJson(self)
+	if err != nil {
+		return err
+	}
+
+	dir, err := filepath.Abs(filepath.Dir(os.Args[0]))
+	if err != nil {
+		return err
+	}
+
+	ioutil.WriteFile(dir+"/config.json", []byte(str), 0644)
+	return nil
+}
+
+/**
+ * Return true if an event name match a subscription name, the name itself or
+ * a prefix that end with *.
+ */
+func match(name string, topic string) bool {
+	if strings.HasSuffix(topic, "*") {
+		return strings.HasPrefix(name, strings.TrimSuffix(topic, "*"))
+	}
+
+	return name == topic
+}
+
+// Send an event to the subscribers of it topic.
+func (self *server) Publish(ctx context.Context, rqst *eventpb.PublishRequest) (*eventpb.PublishResponse, error) {
+	evt := rqst.GetEvt()
+	if evt == nil || len(evt.Name) == 0 || strings.Contains(evt.Name, "*") {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the event must have a name without *")))
+	}
+
+	if evt.Time == 0 {
+		evt.Time = time.Now().UnixNano() / int64(time.Millisecond)
+	}
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	for topic, subscribers := range self.subscribers {
+		if !match(evt.Name, topic) {
+			continue
+		}
+
+		for s, _ := range subscribers {
+			// A slow subscriber does not slow the publishers.
+			select {
+			case s.events <- evt:
+			default:
+				s.lost++
+			}
+		}
+	}
+
+	return &eventpb.PublishResponse{
+		Result: true,
+	}, nil
+}
+
+// Receive the events of a topic until the stream is cancel.
+func (self *server) Subscribe(rqst *eventpb.SubscribeRequest, stream eventpb.EventService_SubscribeServer) error {
+	name := rqst.GetName()
+	if len(name) == 0 || strings.Contains(strings.TrimSuffix(name, "*"), "*") {
+		return status.Errorf(
+			codes.InvalidArgument,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the topic must be a name, a name that end with * or *")))
+	}
+
+	s := &subscriber{name: name, events: make(chan *eventpb.Event, self.QueueSize)}
+
+	self.mutex.Lock()
+	if self.subscribers[name] == nil {
+		self.subscribers[name] = make(map[*subscriber]bool, 0)
+	}
+	self.subscribers[name][s] = true
+	self.mutex.Unlock()
+
+	defer func() {
+		self.mutex.Lock()
+		delete(self.subscribers[name], s)
+		if len(self.subscribers[name]) == 0 {
+			delete(self.subscribers, name)
+		}
+		self.mutex.Unlock()
+	}()
+
+	for {
+		select {
+		case evt := <-s.events:
+			self.mutex.Lock()
+			if s.lost > 0 {
+				log.Println("Subscriber of ", name, " lost ", s.lost, " events")
+				s.lost = 0
+			}
+			self.mutex.Unlock()
+
+			err := stream.Send(&eventpb.SubscribeResponse{
+				Evt: evt,
+			})
+			if err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// The event service.
+// port number must be pass as argument.
+func main() {
+
+	// set the logger.
+	grpclog.SetLogger(log.New(os.Stdout, "event_service: ", log.LstdFlags))
+
+	// Set the log information in case of crash...
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+
+	// The first argument must be the port number to listen to.
+	port := defaultPort // the default value.
+
+	if len(os.Args) > 1 {
+		port, _ = strconv.Atoi(os.Args[1]) // The second argument must be the port number
+	}
+
+	// First of all I will creat a listener.
+	lis, err := net.Listen("tcp", "0.0.0.0:"+strconv.Itoa(port))
+	if err != nil {
+		log.Fatalf("Failed to listen: %v", err)
+	}
+
+	// The actual server implementation.
+	s_impl := new(server)
+	s_impl.Name = Utility.GetExecName(os.Args[0])
+	s_impl.Port = port
+	s_impl.Protocol = "grpc"
+	s_impl.QueueSize = 1000
+	s_impl.subscribers = make(map[string]map[*subscriber]bool, 0)
+
+	// Here I will retreive the list of connections from file if there are some...
+	s_impl.init()
+
+	grpcServer := grpc.NewServer()
+	eventpb.RegisterEventServiceServer(grpcServer, s_impl)
+
+	// Here I will make a signal hook to interrupt to exit cleanly.
+	go func() {
+		log.Println(s_impl.Name + " grpc service is starting")
+		// no web-rpc server.
+		if err := grpcServer.Serve(lis); err != nil {
+			log.Fatalf("failed to serve: %v", err)
+		}
+		log.Println(s_impl.Name + " grpc service is closed")
+	}()
+
+	// Wait for signal to stop.
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, os.Interrupt)
+	<-ch
+
+}
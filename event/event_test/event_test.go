@@ -0,0 +1,100 @@
+package Globular
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/davecourtois/Globular/event/eventpb"
+	"google.golang.org/grpc"
+
+	"testing"
+)
+
+// Set the correct addresse here as needed.
+var (
+	addresse = "localhost:10016"
+)
+
+/**
+ * Get the client connection.
+ */
+func getClientConnection() *grpc.ClientConn {
+	var err error
+	var cc *grpc.ClientConn
+	if cc == nil {
+		cc, err = grpc.Dial(addresse, grpc.WithInsecure())
+		if err != nil {
+			log.Fatalf("could not connect: %v", err)
+		}
+
+	}
+	return cc
+}
+
+// Publish an event and receive it with a subscription to it topic.
+func TestPublishSubscribe(t *testing.T) {
+	fmt.Println("Publish/Subscribe test.")
+
+	cc := getClientConnection()
+
+	// when done the connection will be close.
+	defer cc.Close()
+
+	// Create a new client service...
+	c := eventpb.NewEventServiceClient(cc)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := c.Subscribe(ctx, &eventpb.SubscribeRequest{Name: "test.*"})
+	if err != nil {
+		log.Fatalf("error while Subscribe: %v", err)
+	}
+
+	// The subscription is register when the stream start.
+	time.Sleep(100 * time.Millisecond)
+
+	_, err = c.Publish(context.Background(), &eventpb.PublishRequest{
+		Evt: &eventpb.Event{Name: "other.topic", Data: []byte(`"not for me"`)},
+	})
+	if err != nil {
+		log.Fatalf("error while Publish: %v", err)
+	}
+
+	_, err = c.Publish(context.Background(), &eventpb.PublishRequest{
+		Evt: &eventpb.Event{Name: "test.hello", Data: []byte(`"Hello Globular"`)},
+	})
+	if err != nil {
+		log.Fatalf("error while Publish: %v", err)
+	}
+
+	rsp, err := stream.Recv()
+	if err != nil {
+		log.Fatalf("error while Recv: %v", err)
+	}
+
+	if rsp.Evt.Name != "test.hello" || string(rsp.Evt.Data) != `"Hello Globular"` {
+		t.Fatalf("receive the wrong event %v", rsp.Evt)
+	}
+
+	log.Println("Response form Subscribe:", rsp.Evt.Name, string(rsp.Evt.Data))
+}
+
+// The events without name are refused.
+func TestPublishWithoutName(t *testing.T) {
+	fmt.Println("Publish without name test.")
+
+	cc := getClientConnection()
+	defer cc.Close()
+
+	c := eventpb.NewEventServiceClient(cc)
+
+	_, err := c.Publish(context.Background(), &eventpb.PublishRequest{
+		Evt: &eventpb.Event{Data: []byte(`{}`)},
+	})
+	if err == nil {
+		t.Fatal("an event without name was published")
+	}
+}
@@ -0,0 +1,419 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: event/eventpb/event.proto
+
+package eventpb
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type Event struct {
+	// The topic of the event, ex: file.saved
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The event value, JSON by convention.
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// The time of the publication, in milliseconds since the epoch.
+	Time                 int64    `protobuf:"varint,3,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Event) Reset()         { *m = Event{} }
+func (m *Event) String() string { return proto.CompactTextString(m) }
+func (*Event) ProtoMessage()    {}
+func (*Event) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7c88e0126701e74a, []int{0}
+}
+
+func (m *Event) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Event.Unmarshal(m, b)
+}
+func (m *Event) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Event.Marshal(b, m, deterministic)
+}
+func (m *Event) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Event.Merge(m, src)
+}
+func (m *Event) XXX_Size() int {
+	return xxx_messageInfo_Event.Size(m)
+}
+func (m *Event) XXX_DiscardUnknown() {
+	xxx_messageInfo_Event.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Event proto.InternalMessageInfo
+
+func (m *Event) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Event) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *Event) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+type PublishRequest struct {
+	Evt                  *Event   `protobuf:"bytes,1,opt,name=evt,proto3" json:"evt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PublishRequest) Reset()         { *m = PublishRequest{} }
+func (m *PublishRequest) String() string { return proto.CompactTextString(m) }
+func (*PublishRequest) ProtoMessage()    {}
+func (*PublishRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7c88e0126701e74a, []int{1}
+}
+
+func (m *PublishRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PublishRequest.Unmarshal(m, b)
+}
+func (m *PublishRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PublishRequest.Marshal(b, m, deterministic)
+}
+func (m *PublishRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublishRequest.Merge(m, src)
+}
+func (m *PublishRequest) XXX_Size() int {
+	return xxx_messageInfo_PublishRequest.Size(m)
+}
+func (m *PublishRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublishRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PublishRequest proto.InternalMessageInfo
+
+func (m *PublishRequest) GetEvt() *Event {
+	if m != nil {
+		return m.Evt
+	}
+	return nil
+}
+
+type PublishResponse struct {
+	Result               bool     `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PublishResponse) Reset()         { *m = PublishResponse{} }
+func (m *PublishResponse) String() string { return proto.CompactTextString(m) }
+func (*PublishResponse) ProtoMessage()    {}
+func (*PublishResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7c88e0126701e74a, []int{2}
+}
+
+func (m *PublishResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PublishResponse.Unmarshal(m, b)
+}
+func (m *PublishResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PublishResponse.Marshal(b, m, deterministic)
+}
+func (m *PublishResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublishResponse.Merge(m, src)
+}
+func (m *PublishResponse) XXX_Size() int {
+	return xxx_messageInfo_PublishResponse.Size(m)
+}
+func (m *PublishResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublishResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PublishResponse proto.InternalMessageInfo
+
+func (m *PublishResponse) GetResult() bool {
+	if m != nil {
+		return m.Result
+	}
+	return false
+}
+
+type SubscribeRequest struct {
+	// The topic, a name that end with * receive the topics that start with
+	// the name, * alone receive them all.
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscribeRequest) Reset()         { *m = SubscribeRequest{} }
+func (m *SubscribeRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeRequest) ProtoMessage()    {}
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7c88e0126701e74a, []int{3}
+}
+
+func (m *SubscribeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeRequest.Unmarshal(m, b)
+}
+func (m *SubscribeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeRequest.Marshal(b, m, deterministic)
+}
+func (m *SubscribeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeRequest.Merge(m, src)
+}
+func (m *SubscribeRequest) XXX_Size() int {
+	return xxx_messageInfo_SubscribeRequest.Size(m)
+}
+func (m *SubscribeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeRequest proto.InternalMessageInfo
+
+func (m *SubscribeRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type SubscribeResponse struct {
+	Evt                  *Event   `protobuf:"bytes,1,opt,name=evt,proto3" json:"evt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscribeResponse) Reset()         { *m = SubscribeResponse{} }
+func (m *SubscribeResponse) String() string { return proto.CompactTextString(m) }
+func (*SubscribeResponse) ProtoMessage()    {}
+func (*SubscribeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7c88e0126701e74a, []int{4}
+}
+
+func (m *SubscribeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeResponse.Unmarshal(m, b)
+}
+func (m *SubscribeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeResponse.Marshal(b, m, deterministic)
+}
+func (m *SubscribeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeResponse.Merge(m, src)
+}
+func (m *SubscribeResponse) XXX_Size() int {
+	return xxx_messageInfo_SubscribeResponse.Size(m)
+}
+func (m *SubscribeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeResponse proto.InternalMessageInfo
+
+func (m *SubscribeResponse) GetEvt() *Event {
+	if m != nil {
+		return m.Evt
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Event)(nil), "event.Event")
+	proto.RegisterType((*PublishRequest)(nil), "event.PublishRequest")
+	proto.RegisterType((*PublishResponse)(nil), "event.PublishResponse")
+	proto.RegisterType((*SubscribeRequest)(nil), "event.SubscribeRequest")
+	proto.RegisterType((*SubscribeResponse)(nil), "event.SubscribeResponse")
+}
+
+func init() { proto.RegisterFile("event/eventpb/event.proto", fileDescriptor_7c88e0126701e74a) }
+
+var fileDescriptor_7c88e0126701e74a = []byte{
+	// 256 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x51, 0x4d, 0x4f, 0x83, 0x40,
+	0x10, 0x75, 0xc5, 0xb6, 0x32, 0x12, 0x3f, 0x26, 0xb1, 0x62, 0x0f, 0x86, 0xec, 0xc1, 0xe0, 0xa5,
+	0x36, 0xed, 0xcd, 0x63, 0x8d, 0x77, 0xb3, 0xbd, 0x79, 0x63, 0xeb, 0x24, 0x92, 0xb4, 0x80, 0xec,
+	0xc2, 0xcf, 0xf0, 0x37, 0x1b, 0x66, 0xb7, 0x8d, 0xa2, 0x49, 0x2f, 0xf0, 0x66, 0xe6, 0xcd, 0x7b,
+	0x6f, 0x33, 0x70, 0x4b, 0x2d, 0x15, 0xf6, 0x91, 0xbf, 0x95, 0x76, 0xff, 0x69, 0x55, 0x97, 0xb6,
+	0xc4, 0x01, 0x17, 0xf2, 0x19, 0x06, 0x2f, 0x1d, 0x40, 0x84, 0x93, 0x22, 0xdb, 0x52, 0x2c, 0x12,
+	0x91, 0x86, 0x8a, 0x71, 0xd7, 0x7b, 0xcf, 0x6c, 0x16, 0x1f, 0x27, 0x22, 0x8d, 0x14, 0xe3, 0xae,
+	0x67, 0xf3, 0x2d, 0xc5, 0x41, 0x22, 0xd2, 0x40, 0x31, 0x96, 0x33, 0x38, 0x7f, 0x6d, 0xf4, 0x26,
+	0x37, 0x1f, 0x8a, 0x3e, 0x1b, 0x32, 0x16, 0xef, 0x20, 0xa0, 0xd6, 0xb2, 0xd8, 0xd9, 0x3c, 0x9a,
+	0x3a, 0x63, 0x36, 0x52, 0xdd, 0x40, 0x3e, 0xc0, 0xc5, 0x7e, 0xc3, 0x54, 0x65, 0x61, 0x08, 0xc7,
+	0x30, 0xac, 0xc9, 0x34, 0x1b, 0xb7, 0x75, 0xaa, 0x7c, 0x25, 0xef, 0xe1, 0x72, 0xd5, 0x68, 0xb3,
+	0xae, 0x73, 0x4d, 0x3b, 0xf9, 0x7f, 0xc2, 0xca, 0x05, 0x5c, 0xfd, 0xe0, 0x79, 0xd1, 0x03, 0x39,
+	0xe6, 0x5f, 0x02, 0x22, 0x2e, 0x57, 0x54, 0xb7, 0xf9, 0x9a, 0xf0, 0x09, 0x46, 0x3e, 0x18, 0x5e,
+	0x7b, 0xfa, 0xef, 0xa7, 0x4d, 0xc6, 0xfd, 0xb6, 0xb3, 0x92, 0x47, 0xb8, 0x84, 0x70, 0x9f, 0x00,
+	0x6f, 0x3c, 0xad, 0x9f, 0x7d, 0x12, 0xff, 0x1d, 0xec, 0x14, 0x66, 0x62, 0x19, 0xbe, 0x8d, 0xfc,
+	0xb5, 0xf4, 0x90, 0x0f, 0xb5, 0xf8, 0x1e, 0x00, 0x60, 0xe8, 0x3f, 0x8d, 0xc5, 0x01, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// EventServiceClient is the client API for EventService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type EventServiceClient interface {
+	// Send an event to the subscribers of it topic.
+	Publish(ctx context.Context, in *PublishRequest, opts ...grpc.CallOption) (*PublishResponse, error)
+	// Receive the events of a topic until the stream is cancel.
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (EventService_SubscribeClient, error)
+}
+
+type eventServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewEventServiceClient(cc *grpc.ClientConn) EventServiceClient {
+	return &eventServiceClient{cc}
+}
+
+func (c *eventServiceClient) Publish(ctx context.Context, in *PublishRequest, opts ...grpc.CallOption) (*PublishResponse, error) {
+	out := new(PublishResponse)
+	err := c.cc.Invoke(ctx, "/event.EventService/Publish", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (EventService_SubscribeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_EventService_serviceDesc.Streams[0], "/event.EventService/Subscribe", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &eventServiceSubscribeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type EventService_SubscribeClient interface {
+	Recv() (*SubscribeResponse, error)
+	grpc.ClientStream
+}
+
+type eventServiceSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *eventServiceSubscribeClient) Recv() (*SubscribeResponse, error) {
+	m := new(SubscribeResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// EventServiceServer is the server API for EventService service.
+type EventServiceServer interface {
+	// Send an event to the subscribers of it topic.
+	Publish(context.Context, *PublishRequest) (*PublishResponse, error)
+	// Receive the events of a topic until the stream is cancel.
+	Subscribe(*SubscribeRequest, EventService_SubscribeServer) error
+}
+
+// UnimplementedEventServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedEventServiceServer struct {
+}
+
+func (*UnimplementedEventServiceServer) Publish(ctx context.Context, req *PublishRequest) (*PublishResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Publish not implemented")
+}
+func (*UnimplementedEventServiceServer) Subscribe(req *SubscribeRequest, srv EventService_SubscribeServer) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+
+func RegisterEventServiceServer(s *grpc.Server, srv EventServiceServer) {
+	s.RegisterService(&_EventService_serviceDesc, srv)
+}
+
+func _EventService_Publish_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).Publish(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/event.EventService/Publish",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).Publish(ctx, req.(*PublishRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EventServiceServer).Subscribe(m, &eventServiceSubscribeServer{stream})
+}
+
+type EventService_SubscribeServer interface {
+	Send(*SubscribeResponse) error
+	grpc.ServerStream
+}
+
+type eventServiceSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *eventServiceSubscribeServer) Send(m *SubscribeResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _EventService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "event.EventService",
+	HandlerType: (*EventServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Publish",
+			Handler:    _EventService_Publish_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _EventService_Subscribe_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "event/eventpb/event.proto",
+}
@@ -0,0 +1,394 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/davecourtois/Globular/event/eventpb"
+	"golang.org/x/net/websocket"
+)
+
+/**
+ * The browsers receive the events of the event service at /events, with a
+ * WebSocket they can also publish:
+ *
+ *	ws = new WebSocket("ws://localhost:8080/events")
+ *	ws.send(JSON.stringify({"action": "subscribe", "name": "file.*"}))
+ *	ws.send(JSON.stringify({"action": "publish", "name": "client.chat.message", "data": {"text": "hello"}}))
+ *	ws.onmessage = (e) => { const evt = JSON.parse(e.data) } // {name, data, time}
+ *
+ * or with the server-sent events, that only receive:
+ *
+ *	new EventSource("/events?name=file.*&name=service.*")
+ *
+ * The browsers are not authenticated, they publish only the client.* topics
+ * and listen to them and to the topics of the services given in EventTopics.
+ * The Globule open one subscription to the event service by topic whatever
+ * the number of browsers that listen to it.
+ */
+
+const (
+	// The topics the browsers can publish.
+	browserTopicPrefix = "client."
+
+	// The events waiting to be sent to a browser, the next ones are lost.
+	eventQueueSize = 256
+
+	// The comment sent to keep the server-sent events connections open.
+	eventKeepAliveDelay = 30 * time.Second
+)
+
+/**
+ * An event as it is sent to the browsers, the errors of the WebSocket
+ * actions are sent with the error only.
+ */
+type browserEvent struct {
+	Name  string          `json:"name,omitempty"`
+	Data  json.RawMessage `json:"data,omitempty"`
+	Time  int64           `json:"time,omitempty"`
+	Error string          `json:"error,omitempty"`
+}
+
+/**
+ * A browser connection.
+ */
+type eventListener struct {
+	events chan *browserEvent
+}
+
+/**
+ * The listeners of a topic and the subscription that feed them.
+ */
+type eventTopic struct {
+	listeners map[*eventListener]bool
+	cancel    context.CancelFunc
+}
+
+/**
+ * Add a listener to a topic, the subscription to the event service is open
+ * with the first listener.
+ */
+func (self *Globule) subscribeEvents(name string, l *eventListener) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	topic := self.topics[name]
+	if topic == nil {
+		var ctx context.Context
+		topic = &eventTopic{listeners: make(map[*eventListener]bool, 0)}
+		ctx, topic.cancel = context.WithCancel(context.Background())
+		self.topics[name] = topic
+		go self.listenEvents(ctx, name)
+	}
+
+	topic.listeners[l] = true
+}
+
+/**
+ * Remove a listener from a topic, the subscription is close with the last
+ * listener.
+ */
+func (self *Globule) unsubscribeEvents(name string, l *eventListener) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	topic := self.topics[name]
+	if topic == nil {
+		return
+	}
+
+	delete(topic.listeners, l)
+	if len(topic.listeners) == 0 {
+		topic.cancel()
+		delete(self.topics, name)
+	}
+}
+
+/**
+ * Receive the events of a topic until ctx is cancel. The subscription is
+ * open again when the event service restart.
+ */
+func (self *Globule) listenEvents(ctx context.Context, name string) {
+	delay := time.Second
+	for {
+		self.mutex.Lock()
+		client, _ := self.clients["event_service"].(*Event_Client)
+		self.mutex.Unlock()
+
+		err := errors.New("the event service is not available")
+		if client != nil {
+			err = client.Subscribe(ctx, name, func(evt *eventpb.Event) {
+				delay = time.Second
+				self.dispatchEvent(name, evt)
+			})
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		log.Println("Subscription to ", name, " is interrupted with error ", err)
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return
+		}
+
+		if delay < 30*time.Second {
+			delay *= 2
+		}
+	}
+}
+
+/**
+ * Give an event to the listeners of a topic, the event is lost for the
+ * listeners that does not read them.
+ */
+func (self *Globule) dispatchEvent(name string, evt *eventpb.Event) {
+	e := &browserEvent{Name: evt.Name, Data: evt.Data, Time: evt.Time}
+	if !json.Valid(e.Data) {
+		e.Data, _ = json.Marshal(string(evt.Data))
+	}
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	topic := self.topics[name]
+	if topic == nil {
+		return
+	}
+
+	for l, _ := range topic.listeners {
+		select {
+		case l.events <- e:
+		default:
+		}
+	}
+}
+
+/**
+ * The /events endpoint, a WebSocket or the server-sent events of the topics
+ * given by the name parameters.
+ */
+func (self *Globule) EventsHandler(w http.ResponseWriter, r *http.Request) {
+	if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		server := websocket.Server{
+			Handshake: checkEventsOrigin,
+			Handler:   self.serveEventsWebSocket,
+		}
+		server.ServeHTTP(w, r)
+		return
+	}
+
+	self.serveServerSentEvents(w, r)
+}
+
+/**
+ * Refuse the WebSocket opened by the pages of other sites.
+ */
+func checkEventsOrigin(config *websocket.Config, r *http.Request) error {
+	origin := r.Header.Get("Origin")
+	if len(origin) == 0 {
+		return nil
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil || !strings.EqualFold(u.Host, r.Host) {
+		return errors.New("the origin " + origin + " is not allowed")
+	}
+
+	return nil
+}
+
+/**
+ * Return nil if the browsers can listen to a topic, all the topics it match
+ * must be client ones or match a topic of EventTopics.
+ */
+func (self *Globule) checkBrowserTopic(name string) error {
+	self.mutex.Lock()
+	topics := append([]string{browserTopicPrefix + "*"}, self.EventTopics...)
+	self.mutex.Unlock()
+
+	for _, topic := range topics {
+		if name == topic || (strings.HasSuffix(topic, "*") && strings.HasPrefix(strings.TrimSuffix(name, "*"), strings.TrimSuffix(topic, "*"))) {
+			return nil
+		}
+	}
+
+	return errors.New("the topic " + name + " can not be listen by the browsers, it must start with " + browserTopicPrefix + " or be given in EventTopics")
+}
+
+/**
+ * Send the events of the topics given by the name parameters until the
+ * browser close the connection.
+ */
+func (self *Globule) serveServerSentEvents(w http.ResponseWriter, r *http.Request) {
+	names := r.URL.Query()["name"]
+	if len(names) == 0 {
+		http.Error(w, "no topic given, use /events?name=topic", http.StatusBadRequest)
+		return
+	}
+
+	for _, name := range names {
+		err := self.checkBrowserTopic(name)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "the server-sent events are not supported", http.StatusInternalServerError)
+		return
+	}
+
+	l := &eventListener{events: make(chan *browserEvent, eventQueueSize)}
+	for _, name := range names {
+		self.subscribeEvents(name, l)
+		defer self.unsubscribeEvents(name, l)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("retry: 3000\n\n"))
+	flusher.Flush()
+
+	ticker := time.NewTicker(eventKeepAliveDelay)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case e := <-l.events:
+			data, err := json.Marshal(e)
+			if err != nil {
+				continue
+			}
+			_, err = w.Write([]byte("data: " + string(data) + "\n\n"))
+			if err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-ticker.C:
+			_, err := w.Write([]byte(": keep-alive\n\n"))
+			if err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+/**
+ * Execute the actions of a WebSocket and send it the events of the topics it
+ * subscribe to.
+ */
+func (self *Globule) serveEventsWebSocket(ws *websocket.Conn) {
+	l := &eventListener{events: make(chan *browserEvent, eventQueueSize)}
+	names := make(map[string]bool, 0)
+	done := make(chan bool)
+
+	// The subscriptions are remove once the actions are no more read.
+	defer func() {
+		ws.Close()
+		<-done
+		for name, _ := range names {
+			self.unsubscribeEvents(name, l)
+		}
+	}()
+
+	// The actions are read here, the events are written by the loop below.
+	go func() {
+		defer close(done)
+		for {
+			var action struct {
+				Action string
+				Name   string
+				Data   json.RawMessage
+			}
+
+			err := websocket.JSON.Receive(ws, &action)
+			if err != nil {
+				return
+			}
+
+			err = self.executeEventAction(l, names, action.Action, action.Name, action.Data)
+			if err != nil {
+				select {
+				case l.events <- &browserEvent{Name: action.Name, Error: err.Error()}:
+				default:
+				}
+			}
+		}
+	}()
+
+	for {
+		select {
+		case e := <-l.events:
+			err := websocket.JSON.Send(ws, e)
+			if err != nil {
+				return
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
+/**
+ * Subscribe, unsubscribe or publish for a WebSocket.
+ */
+func (self *Globule) executeEventAction(l *eventListener, names map[string]bool, action string, name string, data json.RawMessage) error {
+	if len(name) == 0 {
+		return errors.New("no topic name was given")
+	}
+
+	switch action {
+	case "subscribe":
+		err := self.checkBrowserTopic(name)
+		if err != nil {
+			return err
+		}
+		if !names[name] {
+			self.subscribeEvents(name, l)
+		}
+		names[name] = true
+	case "unsubscribe":
+		if names[name] {
+			self.unsubscribeEvents(name, l)
+		}
+		delete(names, name)
+	case "publish":
+		if !strings.HasPrefix(name, browserTopicPrefix) || strings.Contains(name, "*") {
+			return errors.New("the browsers can publish only the topics that start with " + browserTopicPrefix)
+		}
+
+		self.mutex.Lock()
+		client, _ := self.clients["event_service"].(*Event_Client)
+		self.mutex.Unlock()
+
+		if client == nil {
+			return errors.New("the event service is not available")
+		}
+
+		if len(data) == 0 {
+			data = json.RawMessage("null")
+		}
+		return client.Publish(name, string(data))
+	default:
+		return errors.New("unknown action " + action + ", use subscribe, unsubscribe or publish")
+	}
+
+	return nil
+}
@@ -0,0 +1,902 @@
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/davecourtois/Globular/event/event_client"
+	"github.com/davecourtois/Globular/file/filepb"
+	"github.com/davecourtois/Utility"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// The size of the files extracted from an archive at most.
+	maxExtractSize = 10 * 1024 * 1024 * 1024
+
+	// The number of files extracted from an archive at most.
+	maxExtractFiles = 100000
+
+	// The size of the data of the messages of the archives sent.
+	archiveChunkSize = 64 * 1024
+
+	// The size of the target of a symbolic link in a zip at most.
+	maxLinkSize = 4096
+)
+
+var errNotArchive = errors.New("the file is not a zip, tar or tar.gz archive")
+
+/**
+ * The writing of an archive, the progress is the one of a transfer.
+ */
+type archiver struct {
+	*transfer
+	zip *zip.Writer
+	tar *tar.Writer
+}
+
+/**
+ * Create a zip or tar.gz archive of files and directories, each one at the top
+ * of it by it name.
+ */
+func (self *server) CreateArchive(rqst *filepb.CreateArchiveRequest, stream filepb.FileService_CreateArchiveServer) error {
+	if len(rqst.GetPaths()) == 0 {
+		return status.Errorf(
+			codes.InvalidArgument,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("no path to archive is given")))
+	}
+
+	format := rqst.GetFormat()
+	if format != filepb.ArchiveFormat_ZIP && format != filepb.ArchiveFormat_TAR_GZ {
+		return status.Errorf(
+			codes.InvalidArgument,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the archive format "+format.String()+" is not supported")))
+	}
+
+	// The paths are in the Root specefied by the server.
+	paths := make([]string, 0, len(rqst.GetPaths()))
+	names := make(map[string]bool, 0)
+	for _, path := range rqst.GetPaths() {
+		path_, err := self.getPath(path)
+		if err != nil {
+			return err
+		}
+
+		if path_ == self.Root {
+			return status.Errorf(
+				codes.PermissionDenied,
+				Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the root can not be archived, give the files in it")))
+		}
+
+		_, err = os.Lstat(path_)
+		if os.IsNotExist(err) {
+			return status.Errorf(
+				codes.NotFound,
+				Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the file "+path+" does not exist")))
+		} else if err != nil {
+			return status.Errorf(
+				codes.Internal,
+				Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+		}
+
+		name := filepath.Base(path_)
+		if names[name] {
+			return status.Errorf(
+				codes.InvalidArgument,
+				Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("two paths are named "+name)))
+		}
+		names[name] = true
+		paths = append(paths, path_)
+	}
+
+	dst := ""
+	if len(rqst.GetDestination()) > 0 {
+		var err error
+		dst, err = self.getPath(rqst.GetDestination())
+		if err != nil {
+			return err
+		}
+	}
+
+	// The progress is sent only when the archive is saved.
+	a := &archiver{transfer: &transfer{server: self, ctx: stream.Context()}}
+	a.send = func(progress *filepb.TransferProgress) error {
+		if len(dst) == 0 {
+			return nil
+		}
+		return stream.Send(&filepb.CreateArchiveResponse{Progress: progress})
+	}
+
+	for _, path := range paths {
+		files, size := countFiles(path)
+		a.progress.TotalFiles += files
+		a.progress.TotalSize += size
+	}
+
+	if len(dst) == 0 {
+		w := bufio.NewWriterSize(chunkWriter(func(data []byte) error {
+			return stream.Send(&filepb.CreateArchiveResponse{Data: data})
+		}), archiveChunkSize)
+
+		err := a.write(w, format, paths)
+		if err == nil {
+			err = w.Flush()
+		}
+		if err != nil {
+			return getArchiveError(err)
+		}
+
+		return nil
+	}
+
+	var written bool
+	var err error
+	a.progress.Destination, written, err = self.writeFile(dst, rqst.GetConflict(), func(file *os.File) error {
+		w := bufio.NewWriterSize(file, archiveChunkSize)
+		err := a.write(w, format, paths)
+		if err == nil {
+			err = w.Flush()
+		}
+		if err != nil {
+			return getArchiveError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if written {
+		event_client.Publish(event_client.FileSaved, map[string]interface{}{"path": a.progress.Destination})
+	} else {
+		a.progress.Files = a.progress.TotalFiles
+		a.progress.Skipped = a.progress.TotalFiles
+		a.progress.Size = a.progress.TotalSize
+	}
+
+	return a.sendProgress(true)
+}
+
+/**
+ * Write the archive of files and directories.
+ */
+func (a *archiver) write(w io.Writer, format filepb.ArchiveFormat, paths []string) error {
+	var gz *gzip.Writer
+	if format == filepb.ArchiveFormat_ZIP {
+		a.zip = zip.NewWriter(w)
+	} else {
+		gz = gzip.NewWriter(w)
+		a.tar = tar.NewWriter(gz)
+	}
+
+	for _, path := range paths {
+		err := a.add(path, filepath.Base(path))
+		if err != nil {
+			return err
+		}
+	}
+
+	if a.zip != nil {
+		return a.zip.Close()
+	}
+
+	err := a.tar.Close()
+	if err == nil {
+		err = gz.Close()
+	}
+
+	return err
+}
+
+/**
+ * Add a file or a directory tree to the archive with a name. The links that
+ * lead out of Root, the devices, sockets... are skipped.
+ */
+func (a *archiver) add(path string, name string) error {
+	return filepath.Walk(path, func(path_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		err = a.ctx.Err()
+		if err != nil {
+			return err
+		}
+
+		if path_ != path && isTempFile(info.Name()) {
+			return nil
+		}
+
+		name_ := name
+		if path_ != path {
+			rel, err := filepath.Rel(path, path_)
+			if err != nil {
+				return err
+			}
+			name_ += "/" + filepath.ToSlash(rel)
+		}
+
+		link := ""
+		switch {
+		case info.IsDir():
+			return a.writeEntry(path_, name_+"/", info, "")
+		case info.Mode()&os.ModeSymlink != 0:
+			if a.server.checkLinks(path_) == nil {
+				link, err = os.Readlink(path_)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		a.progress.Files++
+		if info.Mode().IsRegular() || len(link) > 0 {
+			err = a.writeEntry(path_, name_, info, link)
+			if err != nil {
+				return err
+			}
+			a.progress.Path = a.server.getRelativePath(path_)
+		} else {
+			a.progress.Skipped++
+		}
+
+		return a.sendProgress(false)
+	})
+}
+
+/**
+ * Write an entry of the archive, with the data of a file or the target of a
+ * link.
+ */
+func (a *archiver) writeEntry(path string, name string, info os.FileInfo, link string) error {
+	var w io.Writer
+	if a.zip != nil {
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = name
+		if info.Mode().IsRegular() {
+			header.Method = zip.Deflate
+		}
+
+		w, err = a.zip.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+
+		// The target of a link is the data of it entry.
+		if len(link) > 0 {
+			_, err = io.WriteString(w, link)
+			return err
+		}
+	} else {
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		header.Name = name
+
+		err = a.tar.WriteHeader(header)
+		if err != nil {
+			return err
+		}
+		w = a.tar
+	}
+
+	if !info.Mode().IsRegular() {
+		return nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return a.copyData(w, file)
+}
+
+/**
+ * A writer that send the data in messages of archiveChunkSize at most.
+ */
+type chunkWriter func([]byte) error
+
+func (w chunkWriter) Write(data []byte) (int, error) {
+	for i := 0; i < len(data); i += archiveChunkSize {
+		end := i + archiveChunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		err := w(data[i:end])
+		if err != nil {
+			return i, err
+		}
+	}
+
+	return len(data), nil
+}
+
+/**
+ * Read the entries of a zip, tar or tar.gz archive, the format is found by it
+ * content. The function given to visit return the data of the entry.
+ */
+func readArchive(path string, visit func(*filepb.ArchiveEntry, func() (io.Reader, error)) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	header := make([]byte, 512)
+	n, err := io.ReadFull(file, header)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return err
+	}
+	header = header[:n]
+
+	_, err = file.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case bytes.HasPrefix(header, []byte("PK\x03\x04")) || bytes.HasPrefix(header, []byte("PK\x05\x06")):
+		info, err := file.Stat()
+		if err != nil {
+			return err
+		}
+		r, err := zip.NewReader(file, info.Size())
+		if err != nil {
+			return err
+		}
+		return readZip(r, visit)
+
+	case bytes.HasPrefix(header, []byte{0x1f, 0x8b}):
+		gz, err := gzip.NewReader(bufio.NewReader(file))
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		return readTar(tar.NewReader(gz), visit)
+
+	case len(header) >= 262 && string(header[257:262]) == "ustar":
+		return readTar(tar.NewReader(bufio.NewReader(file)), visit)
+	}
+
+	return errNotArchive
+}
+
+func readZip(r *zip.Reader, visit func(*filepb.ArchiveEntry, func() (io.Reader, error)) error) error {
+	for _, f := range r.File {
+		info := f.FileInfo()
+		entry := &filepb.ArchiveEntry{
+			Name:           f.Name,
+			Size:           int64(f.UncompressedSize64),
+			CompressedSize: int64(f.CompressedSize64),
+			Mode:           uint32(info.Mode()),
+			ModTime:        f.Modified.Unix(),
+			IsDir:          info.IsDir(),
+		}
+
+		if info.Mode()&os.ModeSymlink != 0 {
+			rc, err := f.Open()
+			if err != nil {
+				return err
+			}
+			target, err := ioutil.ReadAll(io.LimitReader(rc, maxLinkSize))
+			rc.Close()
+			if err != nil {
+				return err
+			}
+			entry.Link = string(target)
+		}
+
+		var rc io.ReadCloser
+		err := visit(entry, func() (io.Reader, error) {
+			var err error
+			rc, err = f.Open()
+			return rc, err
+		})
+		if rc != nil {
+			rc.Close()
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func readTar(r *tar.Reader, visit func(*filepb.ArchiveEntry, func() (io.Reader, error)) error) error {
+	for {
+		header, err := r.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if header.Typeflag == tar.TypeXGlobalHeader {
+			continue
+		}
+
+		info := header.FileInfo()
+		entry := &filepb.ArchiveEntry{
+			Name:    header.Name,
+			Size:    header.Size,
+			Mode:    uint32(info.Mode()),
+			ModTime: header.ModTime.Unix(),
+			IsDir:   info.IsDir(),
+		}
+
+		// The hard links are given with their target, they are not
+		// extracted.
+		if header.Typeflag == tar.TypeSymlink || header.Typeflag == tar.TypeLink {
+			entry.Link = header.Linkname
+		}
+
+		err = visit(entry, func() (io.Reader, error) {
+			return r, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+/**
+ * Return the error of an archive as a status.
+ */
+func getArchiveError(err error) error {
+	var corrupt flate.CorruptInputError
+	if errors.As(err, &corrupt) || errors.Is(err, errNotArchive) || errors.Is(err, zip.ErrFormat) || errors.Is(err, zip.ErrChecksum) || errors.Is(err, zip.ErrAlgorithm) ||
+		errors.Is(err, tar.ErrHeader) || errors.Is(err, gzip.ErrHeader) || errors.Is(err, gzip.ErrChecksum) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return status.Errorf(
+			codes.InvalidArgument,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the archive is not valid: "+err.Error())))
+	} else if err == nil {
+		return nil
+	}
+
+	return getListError(err)
+}
+
+/**
+ * The extraction of an archive, the progress is the one of a transfer.
+ */
+type extractor struct {
+	*transfer
+	dst      string
+	dir      string // The destination, with it links resolved.
+	maxSize  int64
+	maxFiles int64
+
+	// The files and the directories created, removed if the extraction fail.
+	created []string
+}
+
+/**
+ * Extract an archive in a directory, the progress is sent until it is done. The
+ * archive is read twice: the entries are checked first, so an entry that lead
+ * out of the destination, an archive bigger than the limits or a file that
+ * exist with the FAIL policy stop it before anything is written. The size is
+ * checked again on the data extracted as the one of a zip entry can be false,
+ * and the files and the directories created are removed if the extraction fail.
+ */
+func (self *server) ExtractArchive(rqst *filepb.ExtractArchiveRequest, stream filepb.FileService_ExtractArchiveServer) error {
+	// The paths are in the Root specefied by the server.
+	src, err := self.getPath(rqst.GetPath())
+	if err != nil {
+		return err
+	}
+
+	dst, err := self.getPath(rqst.GetDestination())
+	if err != nil {
+		return err
+	}
+
+	err = self.checkArchive(src, rqst.GetPath())
+	if err != nil {
+		return err
+	}
+
+	if info, err := os.Stat(dst); err == nil && !info.IsDir() {
+		return status.Errorf(
+			codes.InvalidArgument,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New(rqst.GetDestination()+" is not a directory")))
+	} else if err != nil && !os.IsNotExist(err) {
+		return status.Errorf(
+			codes.Internal,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+
+	// The limits of the request can be lower than the ones of the service.
+	e := &extractor{
+		transfer: &transfer{server: self, ctx: stream.Context(), conflict: rqst.GetConflict()},
+		maxSize:  maxExtractSize,
+		maxFiles: maxExtractFiles,
+	}
+	if rqst.GetMaxSize() > 0 && rqst.GetMaxSize() < e.maxSize {
+		e.maxSize = rqst.GetMaxSize()
+	}
+	if rqst.GetMaxFiles() > 0 && rqst.GetMaxFiles() < e.maxFiles {
+		e.maxFiles = rqst.GetMaxFiles()
+	}
+	e.send = func(progress *filepb.TransferProgress) error {
+		return stream.Send(&filepb.ExtractArchiveResponse{Progress: progress})
+	}
+	e.progress.Destination = self.getRelativePath(dst)
+
+	err = e.check(src, dst)
+	if err == nil {
+		err = e.extract(src, dst)
+	}
+	if err != nil {
+		e.remove()
+		return getArchiveError(err)
+	}
+
+	event_client.Publish(event_client.FileExtracted, map[string]interface{}{"source": rqst.GetPath(), "destination": e.progress.Destination})
+
+	return e.sendProgress(true)
+}
+
+/**
+ * Check the entries of an archive before it is extracted.
+ */
+func (e *extractor) check(src string, dst string) error {
+	entries := int64(0)
+	return readArchive(src, func(entry *filepb.ArchiveEntry, open func() (io.Reader, error)) error {
+		path, err := getEntryPath(dst, entry.Name)
+		if err != nil || len(path) == 0 {
+			return err
+		}
+
+		// The directories are counted with the files.
+		entries++
+		if entries > e.maxFiles {
+			return status.Errorf(
+				codes.ResourceExhausted,
+				Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the archive contain more than "+strconv.FormatInt(e.maxFiles, 10)+" entries")))
+		} else if entry.IsDir {
+			return nil
+		}
+
+		e.progress.TotalFiles++
+
+		if isExtractedFile(entry) {
+			e.progress.TotalSize += entry.Size
+			if entry.Size < 0 || e.progress.TotalSize > e.maxSize {
+				return status.Errorf(
+					codes.ResourceExhausted,
+					Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the files of the archive are bigger than "+strconv.FormatInt(e.maxSize, 10)+" bytes")))
+			}
+		}
+
+		if e.conflict == filepb.ConflictPolicy_FAIL {
+			if _, err := os.Lstat(path); err == nil {
+				return status.Errorf(
+					codes.AlreadyExists,
+					Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New(e.server.getRelativePath(path)+" already exist")))
+			}
+		}
+
+		return nil
+	})
+}
+
+/**
+ * Extract the entries of an archive in the destination, created if needed.
+ */
+func (e *extractor) extract(src string, dst string) error {
+	err := e.mkdirAll(dst)
+	if err != nil {
+		return err
+	}
+
+	e.dst = dst
+	e.dir, err = filepath.EvalSymlinks(dst)
+	if err != nil {
+		return err
+	}
+
+	return readArchive(src, func(entry *filepb.ArchiveEntry, open func() (io.Reader, error)) error {
+		err := e.ctx.Err()
+		if err != nil {
+			return err
+		}
+
+		path, err := getEntryPath(dst, entry.Name)
+		if err != nil || len(path) == 0 {
+			return err
+		}
+
+		if entry.IsDir {
+			err = e.checkLinks(path)
+			if err == nil {
+				err = e.mkdirAll(path)
+			}
+			return err
+		}
+
+		err = e.checkLinks(filepath.Dir(path))
+		if err == nil {
+			err = e.mkdirAll(filepath.Dir(path))
+		}
+		if err != nil {
+			return err
+		}
+
+		e.progress.Files++
+		if e.progress.Files > e.maxFiles {
+			return status.Errorf(
+				codes.ResourceExhausted,
+				Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the archive contain more than "+strconv.FormatInt(e.maxFiles, 10)+" files")))
+		}
+
+		switch {
+		case os.FileMode(entry.Mode)&os.ModeSymlink != 0:
+			err = e.extractLink(path, entry.Link)
+		case isExtractedFile(entry):
+			var r io.Reader
+			r, err = open()
+			if err == nil {
+				err = e.extractFile(path, entry, r)
+			}
+		default:
+			// The hard links, devices... are not extracted.
+			e.progress.Skipped++
+		}
+		if err != nil {
+			return err
+		}
+
+		return e.sendProgress(false)
+	})
+}
+
+/**
+ * Extract a file with it permissions and modification time. The size of the
+ * data is checked as the one of the entry can be false.
+ */
+func (e *extractor) extractFile(path string, entry *filepb.ArchiveEntry, r io.Reader) error {
+	_, err := os.Lstat(path)
+	existed := err == nil
+
+	rel, written, err := e.server.writeFile(path, e.conflict, func(file *os.File) error {
+		err := e.copyData(file, io.LimitReader(r, e.maxSize-e.progress.Size+1))
+		if err == nil && e.progress.Size > e.maxSize {
+			return status.Errorf(
+				codes.ResourceExhausted,
+				Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the files of the archive are bigger than "+strconv.FormatInt(e.maxSize, 10)+" bytes")))
+		}
+
+		// The files stay readable and writable by the service.
+		if err == nil {
+			err = file.Chmod(os.FileMode(entry.Mode).Perm() | 0600)
+		}
+		if err == nil {
+			modTime := time.Unix(entry.ModTime, 0)
+			err = os.Chtimes(file.Name(), modTime, modTime)
+		}
+
+		return getArchiveError(err)
+	})
+	if err != nil {
+		return err
+	}
+
+	if !written {
+		e.progress.Skipped++
+		e.progress.Size += entry.Size
+		return nil
+	}
+
+	path_ := filepath.Join(e.server.Root, filepath.FromSlash(rel))
+	if !existed || path_ != path {
+		e.created = append(e.created, path_)
+	}
+	e.progress.Path = rel
+
+	return nil
+}
+
+/**
+ * Extract a symbolic link, it is skipped if it lead out of Root or is broken
+ * once extracted as by Copy.
+ */
+func (e *extractor) extractLink(path string, target string) error {
+	if _, err := os.Lstat(path); err == nil {
+		switch e.conflict {
+		case filepb.ConflictPolicy_SKIP:
+			e.progress.Skipped++
+			return nil
+		case filepb.ConflictPolicy_RENAME:
+			path = getFreePath(path, false)
+		default:
+			err = os.Remove(path)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err := os.Symlink(target, path)
+	if err != nil {
+		return err
+	}
+
+	if e.server.checkLinks(path) != nil {
+		e.progress.Skipped++
+		return os.Remove(path)
+	}
+
+	e.created = append(e.created, path)
+	e.progress.Path = e.server.getRelativePath(path)
+
+	return nil
+}
+
+/**
+ * Create a directory and it parents, the ones created are removed if the
+ * extraction fail.
+ */
+func (e *extractor) mkdirAll(path string) error {
+	info, err := os.Stat(path)
+	if err == nil {
+		if !info.IsDir() {
+			return status.Errorf(
+				codes.InvalidArgument,
+				Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New(e.server.getRelativePath(path)+" is not a directory")))
+		}
+		return nil
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	err = e.mkdirAll(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+
+	err = os.Mkdir(path, 0755)
+	if err != nil {
+		return err
+	}
+	e.created = append(e.created, path)
+
+	return nil
+}
+
+/**
+ * Return an error if the existing part of a path lead out of the destination
+ * by a symbolic link, as one extracted before.
+ */
+func (e *extractor) checkLinks(path string) error {
+	existing := path
+	for existing != e.dst {
+		_, err := os.Lstat(existing)
+		if err == nil {
+			break
+		} else if !os.IsNotExist(err) {
+			return err
+		}
+		existing = filepath.Dir(existing)
+	}
+
+	resolved, err := filepath.EvalSymlinks(existing)
+	if err != nil {
+		return err
+	}
+
+	rel, err := filepath.Rel(e.dir, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(os.PathSeparator)) {
+		return status.Errorf(
+			codes.PermissionDenied,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the link "+e.server.getRelativePath(existing)+" lead out of the destination")))
+	}
+
+	return nil
+}
+
+/**
+ * Remove the files and the directories created by a failed extraction, the
+ * files replaced are not restored.
+ */
+func (e *extractor) remove() {
+	for i := len(e.created) - 1; i >= 0; i-- {
+		os.Remove(e.created[i])
+	}
+}
+
+/**
+ * Return the path of an entry of an archive in a directory, an error if it
+ * lead out of it. The path is empty for the directory itself.
+ */
+func getEntryPath(dir string, name string) (string, error) {
+	name_ := strings.Replace(name, "\\", "/", -1)
+	invalid := strings.IndexByte(name_, 0) != -1 || strings.HasPrefix(name_, "/") || (len(name_) > 1 && name_[1] == ':')
+	for _, part := range strings.Split(name_, "/") {
+		invalid = invalid || part == ".."
+	}
+	if invalid {
+		return "", status.Errorf(
+			codes.InvalidArgument,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the entry "+name+" lead out of the destination")))
+	}
+
+	rel := filepath.Clean(filepath.FromSlash(name_))
+	if rel == "." {
+		return "", nil
+	}
+
+	return filepath.Join(dir, rel), nil
+}
+
+/**
+ * Return true for the entries extracted as files.
+ */
+func isExtractedFile(entry *filepb.ArchiveEntry) bool {
+	return os.FileMode(entry.Mode).IsRegular() && len(entry.Link) == 0
+}
+
+/**
+ * Return an error if the archive of a request is not a file.
+ */
+func (self *server) checkArchive(path string, name string) error {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return status.Errorf(
+			codes.NotFound,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the archive "+name+" does not exist")))
+	} else if err != nil {
+		return status.Errorf(
+			codes.Internal,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	} else if info.IsDir() {
+		return status.Errorf(
+			codes.InvalidArgument,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New(name+" is a directory")))
+	}
+
+	return nil
+}
+
+// Return the entries of an archive without extracting them.
+func (self *server) ListArchive(rqst *filepb.ListArchiveRequest, stream filepb.FileService_ListArchiveServer) error {
+	// The path is in the Root specefied by the server.
+	path, err := self.getPath(rqst.GetPath())
+	if err != nil {
+		return err
+	}
+
+	err = self.checkArchive(path, rqst.GetPath())
+	if err != nil {
+		return err
+	}
+
+	err = readArchive(path, func(entry *filepb.ArchiveEntry, open func() (io.Reader, error)) error {
+		err := stream.Context().Err()
+		if err != nil {
+			return err
+		}
+		return stream.Send(&filepb.ListArchiveResponse{Entry: entry})
+	})
+
+	return getArchiveError(err)
+}
@@ -3,28 +3,24 @@ package main
 import (
 	"bytes"
 	"context"
-	"encoding/json"
+	"crypto/sha256"
 	"errors"
-	"image"
-	"image/gif"
-	"image/jpeg"
-	"image/png"
 	"io"
 	"io/ioutil"
 	"log"
-	"math"
 	"net"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/davecourtois/Globular/config"
+	"github.com/davecourtois/Globular/event/event_client"
 	"github.com/davecourtois/Globular/file/filepb"
 	"github.com/davecourtois/Utility"
-	"github.com/nfnt/resize"
-	"github.com/polds/imgbase64"
 	"google.golang.org/grpc"
 
 	"google.golang.org/grpc/codes"
@@ -43,9 +39,21 @@ var (
 	// comma separeated values.
 	allowed_origins string = ""
 
+	// The configuration schema version, see package config.
+	configVersion = 1
+
 	s *server
 )
 
+const (
+	// The size of the data sent by message.
+	defaultChunkSize = 1024 * 5
+	maxChunkSize     = 1024 * 1024 * 2
+
+	// The number of files with a SHA-256 kept.
+	maxChecksums = 1024
+)
+
 // Value need by Globular to start the services...
 type server struct {
 	// The global attribute of the services.
@@ -56,17 +64,49 @@ type server struct {
 	AllowedOrigins  string // comma separated string.
 	Protocol        string
 	Root            string
+
+	// The number of processes run by the Globule and the version of the
+	// configuration schema.
+	Replicas      int
+	ConfigVersion int
+
+	// Keep an index of the files of Root to search them without reading the
+	// directories.
+	Index bool
+	index *fileIndex
+
+	// The SHA-256 of the files read, and the files replaced by a temporary
+	// file for the watchers, by path.
+	checksums map[string]*fileChecksum
+	replaced  map[string]*replacedFile
+	mutex     sync.Mutex
+
+	// The directory of the thumbnails, the thumbnails being computed and the
+	// workers that compute them.
+	thumbnails       string
+	thumbnailJobs    map[string]*thumbnailJob
+	thumbnailWorkers chan bool
+}
+
+/**
+ * The SHA-256 of a file as it was when it was computed.
+ */
+type fileChecksum struct {
+	size    int64
+	modTime time.Time
+	sum     []byte
 }
 
 // Create the configuration file if is not already exist.
 func (self *server) init() {
 	// Here I will retreive the list of connections from file if there are some...
 	dir, _ := filepath.Abs(filepath.Dir(os.Args[0]))
-	file, err := ioutil.ReadFile(dir + "/config.json")
-	if err == nil {
-		json.Unmarshal([]byte(file), self)
-	} else {
+	self.ConfigVersion = configVersion
+	err := config.Load(dir+"/config.json", self, configVersion)
+	if os.IsNotExist(err) {
 		self.save()
+	} else if err != nil {
+		log.Fatalln(err)
 	}
 }
 
@@ -88,65 +128,85 @@ func (self *server) save() error {
 }
 
 /**
- * Create a thumbnail...
+ * Return the file of a path given to the service. The paths are relative to
+ * Root, with / or \ as separator, and can not lead out of it: a path that go
+ * up from Root or a symbolic link to a file outside of it are refused with
+ * PermissionDenied.
  */
-func createThumbnail(file *os.File, thumbnailMaxHeight int, thumbnailMaxWidth int) string {
-	// Set the buffer pointer back to the begening of the file...
-	file.Seek(0, 0)
-	var originalImg image.Image
-	var format string
-	var err error
-
-	if strings.HasSuffix(file.Name(), ".png") || strings.HasSuffix(file.Name(), ".PNG") {
-		originalImg, err = png.Decode(file)
-	} else if strings.HasSuffix(file.Name(), ".jpeg") || strings.HasSuffix(file.Name(), ".jpg") || strings.HasSuffix(file.Name(), ".JPEG") || strings.HasSuffix(file.Name(), ".JPG") {
-		originalImg, err = jpeg.Decode(file)
-	} else if strings.HasSuffix(file.Name(), ".gif") || strings.HasSuffix(file.Name(), ".GIF") {
-		originalImg, err = gif.Decode(file)
-	} else {
-		return ""
+func (self *server) getPath(path string) (string, error) {
+	if strings.IndexByte(path, 0) != -1 {
+		return "", status.Errorf(
+			codes.InvalidArgument,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the path contain a null character")))
 	}
 
+	rel := filepath.Clean(filepath.FromSlash(strings.TrimLeft(strings.Replace(path, "\\", "/", -1), "/")))
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(os.PathSeparator)) || filepath.IsAbs(rel) || len(filepath.VolumeName(rel)) > 0 {
+		return "", status.Errorf(
+			codes.PermissionDenied,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the path "+path+" is outside of the root")))
+	}
+
+	path_ := filepath.Join(self.Root, rel)
+	err := self.checkLinks(path_)
 	if err != nil {
-		log.Println("File ", file.Name(), " Format is ", format, " error: ", err)
-		return ""
+		return "", status.Errorf(
+			codes.PermissionDenied,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the path "+path+" is outside of the root: "+err.Error())))
 	}
 
-	// I will get the ratio for the new image size to respect the scale.
-	hRatio := thumbnailMaxHeight / originalImg.Bounds().Size().Y
-	wRatio := thumbnailMaxWidth / originalImg.Bounds().Size().X
+	return path_, nil
+}
 
-	var h int
-	var w int
+/**
+ * Return an error if the existing part of a path in Root lead out of it by
+ * a symbolic link, or end with a broken one.
+ */
+func (self *server) checkLinks(path string) error {
+	existing := path
+	for existing != self.Root {
+		_, err := os.Lstat(existing)
+		if err == nil {
+			break
+		} else if !os.IsNotExist(err) {
+			return err
+		}
+		existing = filepath.Dir(existing)
+	}
 
-	// First I will try with the height
-	if hRatio*originalImg.Bounds().Size().Y < thumbnailMaxWidth {
-		h = thumbnailMaxHeight
-		w = hRatio * originalImg.Bounds().Size().Y
-	} else {
-		// So here i will use it width
-		h = wRatio * thumbnailMaxHeight
-		w = thumbnailMaxWidth
+	resolved, err := filepath.EvalSymlinks(existing)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return errors.New("the link " + existing + " is broken")
+		}
+		return err
 	}
 
-	// do not zoom...
-	if hRatio > 1 {
-		h = originalImg.Bounds().Size().Y
+	if !self.isInRoot(resolved) {
+		return errors.New("the link " + existing + " lead to " + resolved)
 	}
 
-	if wRatio > 1 {
-		w = originalImg.Bounds().Size().X
+	return nil
+}
+
+/**
+ * Return the path given to the service of a file in Root, ex: /photos/a.jpg
+ */
+func (self *server) getRelativePath(path string) string {
+	rel, err := filepath.Rel(self.Root, path)
+	if err != nil || rel == "." {
+		return "/"
 	}
 
-	// Now I will calculate the image size...
-	img := resize.Resize(uint(h), uint(w), originalImg, resize.Lanczos3)
-	var buf bytes.Buffer
-	jpeg.Encode(&buf, img, &jpeg.Options{jpeg.DefaultQuality})
+	return "/" + filepath.ToSlash(rel)
+}
 
-	// Now I will save the buffer containt to the thumbnail...
-	thumbnail := imgbase64.FromBuffer(buf)
-	file.Seek(0, 0) // Set the reader back to the begenin of the file...
-	return thumbnail
+/**
+ * Return true if a path without links is Root or a path in it.
+ */
+func (self *server) isInRoot(path string) bool {
+	rel, err := filepath.Rel(self.Root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(os.PathSeparator))
 }
 
 type fileInfo struct {
@@ -188,139 +248,18 @@ func getFileInfo(path string) (*fileInfo, error) {
 	return info, nil
 }
 
-func getThumbnails(info *fileInfo) []interface{} {
-	// The array of thumbnail
-	thumbnails := make([]interface{}, 0)
-
-	// Now from the info i will extract the thumbnail
-	for i := 0; i < len(info.Files); i++ {
-		if !info.Files[i].IsDir {
-			thumbnail := make(map[string]string)
-			thumbnail["path"] = info.Files[i].Path
-			thumbnail["thumbnail"] = info.Files[i].Thumbnail
-			thumbnails = append(thumbnails, thumbnail)
-			log.Println("--> file path ", thumbnail["path"], ":", len(thumbnail["thumbnail"]))
-		} else {
-			thumbnails = append(thumbnails, getThumbnails(info.Files[i])...)
-		}
-		///if info
-	}
-
-	return thumbnails
-}
-
-/**
- * Read the directory and return the file info.
- */
-func readDir(path string, recursive bool, thumbnailMaxWidth int32, thumbnailMaxHeight int32) (*fileInfo, error) {
-
-	// get the file info
-	info, err := getFileInfo(path)
-	if err != nil {
-		return nil, err
-	}
-	if info.IsDir == false {
-		return nil, errors.New(path + " is a directory")
-	}
-
-	files, err := ioutil.ReadDir(path)
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	for _, f := range files {
-		if f.IsDir() {
-			if recursive {
-				info_, err := readDir(path+string(os.PathSeparator)+f.Name(), recursive, thumbnailMaxWidth, thumbnailMaxHeight)
-				if err != nil {
-					return nil, err
-				}
-				info.Files = append(info.Files, info_)
-			}
-		} else {
-			info_, err := getFileInfo(path + string(os.PathSeparator) + f.Name())
-
-			f_, err := os.Open(path + string(os.PathSeparator) + f.Name())
-			defer f_.Close()
-
-			if err != nil {
-				return nil, err
-			}
-
-			info_.Mime, err = Utility.GetFileContentType(f_)
-
-			// in case of image...
-			if strings.HasPrefix(info_.Mime, "image/") {
-				if thumbnailMaxHeight > 0 && thumbnailMaxWidth > 0 {
-					info_.Thumbnail = createThumbnail(f_, int(thumbnailMaxHeight), int(thumbnailMaxWidth))
-				}
-			}
-
-			if err != nil {
-				return nil, err
-			}
-			info.Files = append(info.Files, info_)
-		}
-
-	}
-
-	return info, err
-}
-
 ////////////////////////////////////////////////////////////////////////////////
 // Directory operations
 ////////////////////////////////////////////////////////////////////////////////
-func (self *server) ReadDir(rqst *filepb.ReadDirRequest, stream filepb.FileService_ReadDirServer) error {
-	path := rqst.GetPath()
-
-	// The roo will be the Root specefied by the server.
-	if strings.HasPrefix(path, "/") {
-		path = self.Root + path
-		// Set the path separator...
-		path = strings.Replace(path, "/", string(os.PathSeparator), -1)
-	}
-
-	info, err := readDir(path, rqst.GetRecursive(), rqst.GetThumnailWidth(), rqst.GetThumnailHeight())
-	if err != nil {
-		return err
-	}
-
-	// Here I will serialyse the data into JSON.
-	jsonStr, err := json.Marshal(info)
-	if err != nil {
-		return err
-	}
-
-	maxSize := 1024 * 5
-	size := int(math.Ceil(float64(len(jsonStr)) / float64(maxSize)))
-	for i := 0; i < size; i++ {
-		start := i * maxSize
-		end := start + maxSize
-		var data []byte
-		if end > len(jsonStr) {
-			data = jsonStr[start:]
-		} else {
-			data = jsonStr[start:end]
-		}
-		stream.Send(&filepb.ReadDirResponse{
-			Data: data,
-		})
-	}
-
-	return nil
-}
-
 // Create a new directory
 func (self *server) CreateDir(ctx context.Context, rqst *filepb.CreateDirRequest) (*filepb.CreateDirResponse, error) {
-	path := rqst.GetPath()
-
-	// The roo will be the Root specefied by the server.
-	if strings.HasPrefix(path, "/") {
-		path = self.Root + path
-		path = strings.Replace(path, "/", string(os.PathSeparator), -1)
+	// The path is in the Root specefied by the server.
+	path, err := self.getPath(rqst.GetPath() + "/" + rqst.GetName())
+	if err != nil {
+		return nil, err
 	}
 
-	err := Utility.CreateDirIfNotExist(path + string(os.PathSeparator) + rqst.GetName())
+	err = Utility.CreateDirIfNotExist(path)
 	if err != nil {
 		return nil, status.Errorf(
 			codes.Internal,
@@ -328,6 +267,8 @@ func (self *server) CreateDir(ctx context.Context, rqst *filepb.CreateDirRequest
 	}
 
 	// The directory was successfuly created.
+	event_client.Publish(event_client.DirCreated, map[string]interface{}{"path": rqst.GetPath() + "/" + rqst.GetName()})
+
 	return &filepb.CreateDirResponse{
 		Result: true,
 	}, nil
@@ -335,19 +276,32 @@ func (self *server) CreateDir(ctx context.Context, rqst *filepb.CreateDirRequest
 
 // Rename a file or a directory.
 func (self *server) Rename(ctx context.Context, rqst *filepb.RenameRequest) (*filepb.RenameResponse, error) {
-	path := rqst.GetPath()
+	// The both names are in the Root specefied by the server.
+	oldPath, err := self.getPath(rqst.GetPath() + "/" + rqst.OldName)
+	if err != nil {
+		return nil, err
+	}
+
+	newPath, err := self.getPath(rqst.GetPath() + "/" + rqst.NewName)
+	if err != nil {
+		return nil, err
+	}
 
-	if strings.HasPrefix(path, "/") {
-		path = self.Root + path
+	if oldPath == self.Root || newPath == self.Root {
+		return nil, status.Errorf(
+			codes.PermissionDenied,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the root can not be renamed")))
 	}
 
-	err := os.Rename(path+string(os.PathSeparator)+rqst.OldName, path+string(os.PathSeparator)+rqst.NewName)
+	err = os.Rename(oldPath, newPath)
 	if err != nil {
 		return nil, status.Errorf(
 			codes.Internal,
 			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
 	}
 
+	event_client.Publish(event_client.FileRenamed, map[string]interface{}{"path": rqst.GetPath(), "old": rqst.OldName, "new": rqst.NewName})
+
 	return &filepb.RenameResponse{
 		Result: true,
 	}, nil
@@ -355,21 +309,27 @@ func (self *server) Rename(ctx context.Context, rqst *filepb.RenameRequest) (*fi
 
 // Delete a directory
 func (self *server) DeleteDir(ctx context.Context, rqst *filepb.DeleteDirRequest) (*filepb.DeleteDirResponse, error) {
-	path := rqst.GetPath()
+	// The path is in the Root specefied by the server.
+	path, err := self.getPath(rqst.GetPath())
+	if err != nil {
+		return nil, err
+	}
 
-	// The roo will be the Root specefied by the server.
-	if strings.HasPrefix(path, "/") {
-		path = self.Root + path
-		path = strings.Replace(path, "/", string(os.PathSeparator), -1)
+	if path == self.Root {
+		return nil, status.Errorf(
+			codes.PermissionDenied,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the root can not be deleted")))
 	}
 
-	err := os.RemoveAll(path)
+	err = os.RemoveAll(path)
 	if err != nil {
 		return nil, status.Errorf(
 			codes.Internal,
 			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
 	}
 
+	event_client.Publish(event_client.DirDeleted, map[string]interface{}{"path": rqst.GetPath()})
+
 	return &filepb.DeleteDirResponse{
 		Result: true,
 	}, nil
@@ -382,43 +342,42 @@ func (self *server) DeleteDir(ctx context.Context, rqst *filepb.DeleteDirRequest
 // Get file info, can be use to get file thumbnail or knowing that a file exist
 // or not.
 func (self *server) GetFileInfo(ctx context.Context, rqst *filepb.GetFileInfoRequest) (*filepb.GetFileInfoResponse, error) {
-	path := rqst.GetPath()
-
-	// The roo will be the Root specefied by the server.
-	if strings.HasPrefix(path, "/") {
-		path = self.Root + path
-		path = strings.Replace(path, "/", string(os.PathSeparator), -1)
+	// The path is in the Root specefied by the server.
+	path, err := self.getPath(rqst.GetPath())
+	if err != nil {
+		return nil, err
 	}
 
 	info, err := getFileInfo(path)
-
-	// the file
-	f_, err := os.Open(path)
-	defer f_.Close()
-
-	if err != nil {
+	if os.IsNotExist(err) {
+		return nil, status.Errorf(
+			codes.NotFound,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the file "+rqst.GetPath()+" does not exist")))
+	} else if err != nil {
 		return nil, status.Errorf(
 			codes.Internal,
 			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
 	}
 
-	info.Mime, err = Utility.GetFileContentType(f_)
+	if !info.IsDir {
+		info.Mime, err = readMime(path)
+		if err != nil {
+			return nil, status.Errorf(
+				codes.Internal,
+				Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+		}
+	}
+
 	thumbnailMaxHeight := rqst.GetThumnailHeight()
 	thumbnailMaxWidth := rqst.GetThumnailWidth()
 
 	// in case of image...
 	if strings.HasPrefix(info.Mime, "image/") {
 		if thumbnailMaxHeight > 0 && thumbnailMaxWidth > 0 {
-			info.Thumbnail = createThumbnail(f_, int(thumbnailMaxHeight), int(thumbnailMaxWidth))
+			info.Thumbnail = self.getThumbnailUrl(ctx, path, int(thumbnailMaxWidth), int(thumbnailMaxHeight))
 		}
 	}
 
-	if err != nil {
-		return nil, status.Errorf(
-			codes.Internal,
-			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
-	}
-
 	var jsonStr string
 	jsonStr, err = Utility.ToJson(info)
 	if err != nil {
@@ -432,100 +391,423 @@ func (self *server) GetFileInfo(ctx context.Context, rqst *filepb.GetFileInfoReq
 	}, nil
 }
 
-// Read file, can be use for small to medium file...
+// Read a file, or a part of it with offset and length. The first message give
+// the size and the time of the last modification of the file, and it SHA-256
+// if asked, so an interrupted read can be resume on the same file.
 func (self *server) ReadFile(rqst *filepb.ReadFileRequest, stream filepb.FileService_ReadFileServer) error {
-	path := rqst.GetPath()
+	// The path is in the Root specefied by the server.
+	path, err := self.getPath(rqst.GetPath())
+	if err != nil {
+		return err
+	}
+
+	offset := rqst.GetOffset()
+	length := rqst.GetLength()
+	chunkSize := int(rqst.GetChunkSize())
+	if offset < 0 || length < 0 || chunkSize < 0 {
+		return status.Errorf(
+			codes.InvalidArgument,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the offset, the length and the chunk size can not be negative")))
+	}
 
-	// The roo will be the Root specefied by the server.
-	if strings.HasPrefix(path, "/") {
-		path = self.Root + path
-		path = strings.Replace(path, "/", string(os.PathSeparator), -1)
+	if chunkSize == 0 {
+		chunkSize = defaultChunkSize
+	} else if chunkSize > maxChunkSize {
+		chunkSize = maxChunkSize
 	}
 
 	file, err := os.Open(path)
-	if err != nil {
-		return err
+	if os.IsNotExist(err) {
+		return status.Errorf(
+			codes.NotFound,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the file "+rqst.GetPath()+" does not exist")))
+	} else if err != nil {
+		return status.Errorf(
+			codes.Internal,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
 	}
 
 	// close the file when done.
 	defer file.Close()
-	const BufferSize = 1024 * 5 // the chunck size.
-	buffer := make([]byte, BufferSize)
 
-	for {
-		bytesread, err := file.Read(buffer)
-		if bytesread > 0 {
-			stream.Send(&filepb.ReadFileResponse{
-				Data: buffer[:bytesread],
-			})
-		}
+	info, err := file.Stat()
+	if err != nil {
+		return status.Errorf(
+			codes.Internal,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	} else if info.IsDir() {
+		return status.Errorf(
+			codes.InvalidArgument,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New(rqst.GetPath()+" is a directory")))
+	} else if offset > info.Size() {
+		return status.Errorf(
+			codes.OutOfRange,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the offset is after the end of the file")))
+	}
+
+	rsp := &filepb.ReadFileResponse{
+		Size:        info.Size(),
+		ModTimeNano: info.ModTime().UnixNano(),
+	}
+
+	if rqst.GetChecksum() {
+		rsp.Sha256, err = self.getChecksum(path, file, info)
 		if err != nil {
-			if err != io.EOF {
-				return err
+			return status.Errorf(
+				codes.Internal,
+				Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+		}
+	}
+
+	var r io.Reader = io.NewSectionReader(file, offset, info.Size()-offset)
+	if length > 0 {
+		r = io.LimitReader(r, length)
+	}
+
+	// The first message is sent even if there is no data.
+	buffer := make([]byte, chunkSize)
+	first := true
+	for {
+		n, err := io.ReadFull(r, buffer)
+		if n > 0 || first {
+			rsp.Data = buffer[:n]
+			err_ := stream.Send(rsp)
+			if err_ != nil {
+				return err_
 			}
+			rsp = new(filepb.ReadFileResponse)
+			first = false
+		}
+
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
 			break
+		} else if err != nil {
+			return status.Errorf(
+				codes.Internal,
+				Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
 		}
 	}
+
 	return nil
 }
 
-// Save a file on the server...
+/**
+ * Return the SHA-256 of a file, it is kept until the file change.
+ */
+func (self *server) getChecksum(path string, file *os.File, info os.FileInfo) ([]byte, error) {
+	self.mutex.Lock()
+	checksum := self.checksums[path]
+	self.mutex.Unlock()
+
+	if checksum != nil && checksum.size == info.Size() && checksum.modTime.Equal(info.ModTime()) {
+		return checksum.sum, nil
+	}
+
+	hash := sha256.New()
+	_, err := io.Copy(hash, io.NewSectionReader(file, 0, info.Size()))
+	if err != nil {
+		return nil, err
+	}
+
+	checksum = &fileChecksum{size: info.Size(), modTime: info.ModTime(), sum: hash.Sum(nil)}
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if self.checksums == nil || len(self.checksums) >= maxChecksums {
+		self.checksums = make(map[string]*fileChecksum, 0)
+	}
+	self.checksums[path] = checksum
+
+	return checksum.sum, nil
+}
+
+// Save a file on the server. The data are write in a temporary file, beside
+// the file, that replace it once they are all receive and verified so a failed
+// save leave the file as it was. The path, the expected size and SHA-256 of the
+// data and the save mode are given in the first message.
 func (self *server) SaveFile(stream filepb.FileService_SaveFileServer) error {
-	// Here I will receive the file
-	data := make([]byte, 0)
-	var path string
+	rqst, err := stream.Recv()
+	if err == io.EOF {
+		return status.Errorf(
+			codes.InvalidArgument,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("no path was given")))
+	} else if err != nil {
+		return err
+	}
+
+	msg, ok := rqst.File.(*filepb.SaveFileRequest_Path)
+	if !ok {
+		return status.Errorf(
+			codes.InvalidArgument,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the path must be given in the first message")))
+	}
+
+	// The path is in the Root specefied by the server.
+	path, err := self.getPath(msg.Path)
+	if err != nil {
+		return err
+	}
+
+	mode := rqst.GetMode()
+	expectedSize := rqst.GetSize()
+	expectedChecksum := rqst.GetSha256()
+
+	info, err := os.Stat(path)
+	existed := err == nil
+	if existed && info.IsDir() {
+		return status.Errorf(
+			codes.InvalidArgument,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New(msg.Path+" is a directory")))
+	} else if existed && mode == filepb.SaveMode_CREATE {
+		return status.Errorf(
+			codes.AlreadyExists,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the file "+msg.Path+" already exist")))
+	}
+
+	file, err := createTempFile(path, mode == filepb.SaveMode_APPEND)
+	if err != nil {
+		return status.Errorf(
+			codes.Internal,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+
+	// The temporary file is remove if the save fail.
+	tmp := file.Name()
+	defer func() {
+		file.Close()
+		os.Remove(tmp)
+	}()
+
+	checksum := sha256.New()
+	w := io.MultiWriter(file, checksum)
+	var size int64
 	for {
 		rqst, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+
+		data, ok := rqst.File.(*filepb.SaveFileRequest_Data)
+		if !ok {
+			return status.Errorf(
+				codes.InvalidArgument,
+				Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the path must be given once")))
+		}
+
+		size += int64(len(data.Data))
+		if expectedSize > 0 && size > expectedSize {
+			return status.Errorf(
+				codes.InvalidArgument,
+				Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("more data than the size "+strconv.FormatInt(expectedSize, 10)+" was sent")))
+		}
+
+		_, err = w.Write(data.Data)
 		if err != nil {
-			if err == io.EOF {
-				// Here all data is read...
-				err := ioutil.WriteFile(path, data, 0644)
-
-				if err != nil {
-					return status.Errorf(
-						codes.Internal,
-						Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
-				}
-
-				// Close the stream...
-				stream.SendAndClose(&filepb.SaveFileResponse{
-					Result: true,
-				})
-
-				return nil
-			} else {
-				return err
-			}
+			return status.Errorf(
+				codes.Internal,
+				Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
 		}
+	}
 
-		// Receive message informations.
-		switch msg := rqst.File.(type) {
-		case *filepb.SaveFileRequest_Path:
-			// The roo will be the Root specefied by the server.
-			path = msg.Path
-			if strings.HasPrefix(path, "/") {
-				path = self.Root + string(os.PathSeparator) + path
-			}
+	if expectedSize > 0 && size != expectedSize {
+		return status.Errorf(
+			codes.InvalidArgument,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New(strconv.FormatInt(size, 10)+" bytes was sent instead of "+strconv.FormatInt(expectedSize, 10))))
+	}
+
+	if len(expectedChecksum) > 0 && !bytes.Equal(checksum.Sum(nil), expectedChecksum) {
+		return status.Errorf(
+			codes.DataLoss,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the SHA-256 of the data does not match")))
+	}
+
+	err = file.Sync()
+	if err == nil {
+		err = file.Close()
+	}
+	if err == nil {
+		self.setReplaced(path, existed)
+		err = replaceFile(tmp, path, mode == filepb.SaveMode_CREATE)
+	}
+	if os.IsExist(err) {
+		return status.Errorf(
+			codes.AlreadyExists,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the file "+msg.Path+" already exist")))
+	} else if err != nil {
+		return status.Errorf(
+			codes.Internal,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+
+	info, err = os.Stat(path)
+	if err != nil {
+		return status.Errorf(
+			codes.Internal,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+
+	event_client.Publish(event_client.FileSaved, map[string]interface{}{"path": msg.Path})
+
+	return stream.SendAndClose(&filepb.SaveFileResponse{
+		Result: true,
+		Size:   info.Size(),
+	})
+}
+
+/**
+ * Create the temporary file of a save in the directory of the file, with the
+ * permissions of the file if it exist. To append, the data of the file are
+ * copied in it first. Two appends at the same time to a file keep the data of
+ * the last one only.
+ */
+func createTempFile(path string, appending bool) (*os.File, error) {
+	file, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return nil, err
+	}
 
-		case *filepb.SaveFileRequest_Data:
-			data = append(data, msg.Data...)
+	perm := os.FileMode(0644)
+	existing, err := os.Open(path)
+	if err == nil {
+		defer existing.Close()
+		info, err := existing.Stat()
+		if err == nil {
+			perm = info.Mode().Perm()
 		}
+		if appending {
+			_, err = io.Copy(file, existing)
+		}
+	} else if os.IsNotExist(err) {
+		err = nil
 	}
 
-	return nil
+	if err == nil {
+		err = file.Chmod(perm)
+	}
+
+	if err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, err
+	}
+
+	return file, nil
+}
+
+/**
+ * Move the temporary file of a save to the file. To create, the file must not
+ * exist and the error satisfy os.IsExist if it does.
+ */
+func replaceFile(tmp string, path string, create bool) error {
+	if !create {
+		return os.Rename(tmp, path)
+	}
+
+	// A link fail if the file was created since the save start.
+	err := os.Link(tmp, path)
+	if err != nil {
+		return err
+	}
+
+	return os.Remove(tmp)
+}
+
+/**
+ * Write a file in a temporary file renamed once it is complete, an existing
+ * file is kept, replaced or the file renamed as the conflict policy give.
+ * Return the path in Root of the file and false if it is skipped, the error is
+ * a status.
+ */
+func (self *server) writeFile(path string, conflict filepb.ConflictPolicy, write func(*os.File) error) (string, bool, error) {
+	if path == self.Root {
+		return "", false, status.Errorf(
+			codes.PermissionDenied,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the root can not be replaced")))
+	}
+
+	if info, err := os.Stat(filepath.Dir(path)); err != nil || !info.IsDir() {
+		return "", false, status.Errorf(
+			codes.NotFound,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the directory of "+self.getRelativePath(path)+" does not exist")))
+	}
+
+	info, err := os.Lstat(path)
+	existed := err == nil
+	if existed && info.IsDir() {
+		return "", false, status.Errorf(
+			codes.InvalidArgument,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New(self.getRelativePath(path)+" is a directory")))
+	}
+
+	create := true
+	if existed {
+		switch conflict {
+		case filepb.ConflictPolicy_SKIP:
+			return self.getRelativePath(path), false, nil
+		case filepb.ConflictPolicy_REPLACE:
+			create = false
+		case filepb.ConflictPolicy_RENAME:
+			path = getFreePath(path, false)
+			existed = false
+		}
+	}
+
+	file, err := createTempFile(path, false)
+	if err != nil {
+		return "", false, status.Errorf(
+			codes.Internal,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+	tmp := file.Name()
+	defer os.Remove(tmp)
+
+	err = write(file)
+	if err == nil {
+		err = file.Sync()
+	}
+	if err == nil {
+		err = file.Close()
+	} else {
+		file.Close()
+	}
+	if err == nil {
+		self.setReplaced(path, existed)
+		err = replaceFile(tmp, path, create)
+	}
+
+	if os.IsExist(err) {
+		return "", false, status.Errorf(
+			codes.AlreadyExists,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New(self.getRelativePath(path)+" already exist")))
+	} else if _, ok := status.FromError(err); err != nil && !ok {
+		return "", false, status.Errorf(
+			codes.Internal,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	} else if err != nil {
+		return "", false, err
+	}
+
+	return self.getRelativePath(path), true, nil
 }
 
 // Delete file
 func (self *server) DeleteFile(ctx context.Context, rqst *filepb.DeleteFileRequest) (*filepb.DeleteFileResponse, error) {
-	path := rqst.GetPath()
+	// The path is in the Root specefied by the server.
+	path, err := self.getPath(rqst.GetPath())
+	if err != nil {
+		return nil, err
+	}
 
-	// The roo will be the Root specefied by the server.
-	if strings.HasPrefix(path, "/") {
-		path = self.Root + path
-		path = strings.Replace(path, "/", string(os.PathSeparator), -1)
+	if path == self.Root {
+		return nil, status.Errorf(
+			codes.PermissionDenied,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the root can not be deleted")))
 	}
 
-	err := os.Remove(path)
+	err = os.Remove(path)
 
 	if err != nil {
 		return nil, status.Errorf(
@@ -533,6 +815,8 @@ func (self *server) DeleteFile(ctx context.Context, rqst *filepb.DeleteFileReque
 			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
 	}
 
+	event_client.Publish(event_client.FileDeleted, map[string]interface{}{"path": rqst.GetPath()})
+
 	return &filepb.DeleteFileResponse{
 		Result: true,
 	}, nil
@@ -581,8 +865,28 @@ func main() {
 		s_impl.Root = os.Args[2]
 	}
 
+	// The paths are resolved from the absolute Root without links.
+	if len(s_impl.Root) == 0 {
+		log.Fatalln("No root is set for the file service.")
+	}
+	root, err := filepath.Abs(s_impl.Root)
+	if err == nil {
+		root, err = filepath.EvalSymlinks(root)
+	}
+	if err != nil {
+		log.Fatalln("The root ", s_impl.Root, " of the file service is not valid: ", err)
+	}
+	s_impl.Root = root
+
 	s = s_impl // keep ref...
 
+	dir, _ := filepath.Abs(filepath.Dir(os.Args[0]))
+	s_impl.startThumbnails(dir + "/thumbnails")
+
+	if s_impl.Index {
+		s_impl.startIndex(dir + "/index.json")
+	}
+
 	grpcServer := grpc.NewServer()
 	filepb.RegisterFileServiceServer(grpcServer, s_impl)
 
@@ -601,50 +905,8 @@ func main() {
 	signal.Notify(ch, os.Interrupt)
 	<-ch
 
-}
-
-////////////////////////////////////////////////////////////////////////////////
-// Utility functions
-////////////////////////////////////////////////////////////////////////////////
-// Return the list of thumbnail for a given directory...
-func (self *server) GetThumbnails(rqst *filepb.GetThumbnailsRequest, stream filepb.FileService_GetThumbnailsServer) error {
-	path := rqst.GetPath()
-
-	// The roo will be the Root specefied by the server.
-	if strings.HasPrefix(path, "/") {
-		path = self.Root + path
-		// Set the path separator...
-		path = strings.Replace(path, "/", string(os.PathSeparator), -1)
-	}
-
-	info, err := readDir(path, rqst.GetRecursive(), rqst.GetThumnailHeight(), rqst.GetThumnailWidth())
-	thumbnails := getThumbnails(info)
-
-	if err != nil {
-		return err
-	}
-
-	// Here I will serialyse the data into JSON.
-	jsonStr, err := json.Marshal(thumbnails)
-	if err != nil {
-		return err
+	if s_impl.index != nil {
+		s_impl.index.save()
 	}
 
-	maxSize := 1024 * 5
-	size := int(math.Ceil(float64(len(jsonStr)) / float64(maxSize)))
-	for i := 0; i < size; i++ {
-		start := i * maxSize
-		end := start + maxSize
-		var data []byte
-		if end > len(jsonStr) {
-			data = jsonStr[start:]
-		} else {
-			data = jsonStr[start:end]
-		}
-		stream.Send(&filepb.GetThumbnailsResponse{
-			Data: data,
-		})
-	}
-
-	return nil
 }
@@ -0,0 +1,283 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/davecourtois/Globular/event/event_client"
+	"github.com/davecourtois/Globular/file/filepb"
+	"github.com/davecourtois/Utility"
+	"github.com/nfnt/resize"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// The size of the images made at most.
+	maxImageSize = 16384
+
+	defaultImageQuality = 85
+
+	// The size of the data of the messages of the images sent.
+	imageChunkSize = 64 * 1024
+)
+
+/**
+ * Resize, crop, rotate... an image and convert it. The operations of the
+ * request are applied after it EXIF orientation, the result is sent or saved
+ * in Root. Only the first image of the animated GIF is kept.
+ */
+func (self *server) ImageTransform(rqst *filepb.ImageTransformRequest, stream filepb.FileService_ImageTransformServer) error {
+	// The path is in the Root specefied by the server.
+	path, err := self.getPath(rqst.GetPath())
+	if err != nil {
+		return err
+	}
+
+	dst := ""
+	if len(rqst.GetDestination()) > 0 {
+		dst, err = self.getPath(rqst.GetDestination())
+		if err != nil {
+			return err
+		}
+	}
+
+	quality := int(rqst.GetQuality())
+	if quality == 0 {
+		quality = defaultImageQuality
+	} else if quality < 1 || quality > 100 {
+		return status.Errorf(
+			codes.InvalidArgument,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the quality must be from 1 to 100")))
+	}
+
+	img, format, orientation, err := openImage(path)
+	if os.IsNotExist(err) {
+		return status.Errorf(
+			codes.NotFound,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the file "+rqst.GetPath()+" does not exist")))
+	} else if err == errNotImage {
+		return status.Errorf(
+			codes.InvalidArgument,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New(rqst.GetPath()+" is not an image")))
+	} else if err == errImageTooLarge {
+		return status.Errorf(
+			codes.InvalidArgument,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	} else if err != nil {
+		return status.Errorf(
+			codes.Internal,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+
+	img = orient(img, orientation)
+	for i, operation := range rqst.GetOperations() {
+		img, err = transformImage(img, operation)
+		if err != nil {
+			return status.Errorf(
+				codes.InvalidArgument,
+				Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("operation "+strconv.Itoa(i+1)+": "+err.Error())))
+		}
+	}
+
+	// The format of the destination, or of the image.
+	imageFormat := rqst.GetFormat()
+	if imageFormat == filepb.ImageFormat_ORIGINAL_FORMAT {
+		imageFormat = getImageFormat(filepath.Ext(dst))
+	}
+	if imageFormat == filepb.ImageFormat_ORIGINAL_FORMAT {
+		imageFormat = getImageFormat("." + format)
+	}
+
+	var buf bytes.Buffer
+	mime := ""
+	switch imageFormat {
+	case filepb.ImageFormat_PNG:
+		mime = "image/png"
+		err = png.Encode(&buf, img)
+	case filepb.ImageFormat_GIF:
+		mime = "image/gif"
+		err = gif.Encode(&buf, img, &gif.Options{NumColors: 256})
+	default:
+		mime = "image/jpeg"
+		err = jpeg.Encode(&buf, flatten(img), &jpeg.Options{Quality: quality})
+	}
+	if err != nil {
+		return status.Errorf(
+			codes.Internal,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+
+	rsp := &filepb.ImageTransformResponse{
+		Mime:   mime,
+		Width:  int32(img.Bounds().Dx()),
+		Height: int32(img.Bounds().Dy()),
+	}
+
+	if len(dst) > 0 {
+		var written bool
+		rsp.Path, written, err = self.writeFile(dst, rqst.GetConflict(), func(file *os.File) error {
+			_, err := file.Write(buf.Bytes())
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		if written {
+			event_client.Publish(event_client.FileSaved, map[string]interface{}{"path": rsp.Path})
+		}
+		return stream.Send(rsp)
+	}
+
+	err = stream.Send(rsp)
+	if err != nil {
+		return err
+	}
+
+	data := buf.Bytes()
+	for len(data) > 0 {
+		size := imageChunkSize
+		if size > len(data) {
+			size = len(data)
+		}
+		err = stream.Send(&filepb.ImageTransformResponse{Data: data[:size]})
+		if err != nil {
+			return err
+		}
+		data = data[size:]
+	}
+
+	return nil
+}
+
+/**
+ * Return the format of an image by the extension of it file.
+ */
+func getImageFormat(ext string) filepb.ImageFormat {
+	switch strings.ToLower(ext) {
+	case ".jpg", ".jpeg":
+		return filepb.ImageFormat_JPEG
+	case ".png":
+		return filepb.ImageFormat_PNG
+	case ".gif":
+		return filepb.ImageFormat_GIF
+	}
+
+	return filepb.ImageFormat_ORIGINAL_FORMAT
+}
+
+/**
+ * Apply an operation to an image.
+ */
+func transformImage(img image.Image, operation *filepb.ImageOperation) (image.Image, error) {
+	switch op := operation.GetOperation().(type) {
+	case *filepb.ImageOperation_Resize:
+		return resizeImage(img, op.Resize)
+
+	case *filepb.ImageOperation_Crop:
+		crop := op.Crop
+		rect := image.Rect(int(crop.X), int(crop.Y), int(crop.X)+int(crop.Width), int(crop.Y)+int(crop.Height)).Add(img.Bounds().Min)
+		if crop.Width <= 0 || crop.Height <= 0 || !rect.In(img.Bounds()) {
+			return nil, errors.New("the crop is not in the image")
+		}
+		dst := image.NewRGBA(image.Rect(0, 0, rect.Dx(), rect.Dy()))
+		draw.Draw(dst, dst.Bounds(), img, rect.Min, draw.Src)
+		return dst, nil
+
+	case *filepb.ImageOperation_Rotate:
+		if op.Rotate%90 != 0 {
+			return nil, errors.New("the rotation must be a multiple of 90 degrees")
+		}
+		// As the EXIF orientations.
+		switch (op.Rotate%360 + 360) % 360 {
+		case 90:
+			return orient(img, 6), nil
+		case 180:
+			return orient(img, 3), nil
+		case 270:
+			return orient(img, 8), nil
+		}
+		return img, nil
+
+	case *filepb.ImageOperation_Flip:
+		if op.Flip == filepb.FlipDirection_FLIP_VERTICAL {
+			return orient(img, 4), nil
+		}
+		return orient(img, 2), nil
+
+	case *filepb.ImageOperation_Grayscale:
+		if !op.Grayscale {
+			return img, nil
+		}
+		dst := image.NewGray(image.Rect(0, 0, img.Bounds().Dx(), img.Bounds().Dy()))
+		draw.Draw(dst, dst.Bounds(), img, img.Bounds().Min, draw.Src)
+		return dst, nil
+	}
+
+	return nil, errors.New("no operation is given")
+}
+
+/**
+ * Resize an image as the mode of the operation give.
+ */
+func resizeImage(img image.Image, op *filepb.ResizeOperation) (image.Image, error) {
+	width, height := float64(op.Width), float64(op.Height)
+	w, h := float64(img.Bounds().Dx()), float64(img.Bounds().Dy())
+	if width < 0 || height < 0 || (width == 0 && height == 0) {
+		return nil, errors.New("the size of the image is not valid")
+	}
+
+	var fill bool
+	switch op.Mode {
+	case filepb.ResizeMode_RESIZE_FIT:
+		scale := math.Min(width/w, height/h)
+		if width == 0 {
+			scale = height / h
+		} else if height == 0 {
+			scale = width / w
+		}
+		width, height = w*scale, h*scale
+	case filepb.ResizeMode_RESIZE_FILL:
+		if width == 0 || height == 0 {
+			return nil, errors.New("the width and the height are needed to fill")
+		}
+		fill = true
+	case filepb.ResizeMode_RESIZE_STRETCH:
+		if width == 0 {
+			width = w
+		}
+		if height == 0 {
+			height = h
+		}
+	}
+
+	width, height = math.Max(1, math.Round(width)), math.Max(1, math.Round(height))
+	if width > maxImageSize || height > maxImageSize || width*height > maxImagePixels {
+		return nil, errImageTooLarge
+	}
+
+	if !fill {
+		return resize.Resize(uint(width), uint(height), img, resize.Lanczos3), nil
+	}
+
+	// The image cover the size, then it is cropped at the center.
+	scale := math.Max(width/w, height/h)
+	w, h = math.Max(width, math.Round(w*scale)), math.Max(height, math.Round(h*scale))
+	img = resize.Resize(uint(w), uint(h), img, resize.Lanczos3)
+
+	x, y := (int(w)-int(width))/2, (int(h)-int(height))/2
+	dst := image.NewRGBA(image.Rect(0, 0, int(width), int(height)))
+	draw.Draw(dst, dst.Bounds(), img, img.Bounds().Min.Add(image.Pt(x, y)), draw.Src)
+
+	return dst, nil
+}
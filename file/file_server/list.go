@@ -0,0 +1,475 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/davecourtois/Globular/file/filepb"
+	"github.com/davecourtois/Utility"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+/**
+ * The position of a listing after a page.
+ */
+type pageToken struct {
+	Path       string
+	Sort       filepb.SortOrder
+	Descending bool
+	Depth      int32
+	Position   []*pagePosition // The last entry sent, after it parent directories.
+}
+
+/**
+ * The values of an entry that give it place in it directory.
+ */
+type pagePosition struct {
+	Name    string
+	Size    int64
+	ModTime int64
+	IsDir   bool
+	Mime    string
+}
+
+func getPagePosition(info *filepb.FileInfo) *pagePosition {
+	return &pagePosition{Name: info.Name, Size: info.Size, ModTime: info.ModTime, IsDir: info.IsDir, Mime: info.Mime}
+}
+
+func (position *pagePosition) getFileInfo() *filepb.FileInfo {
+	return &filepb.FileInfo{Name: position.Name, Size: position.Size, ModTime: position.ModTime, IsDir: position.IsDir, Mime: position.Mime}
+}
+
+func (token *pageToken) encode() string {
+	data, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodePageToken(str string) (*pageToken, error) {
+	data, err := base64.RawURLEncoding.DecodeString(str)
+	if err != nil {
+		return nil, errors.New("the page token is not valid")
+	}
+
+	token := new(pageToken)
+	err = json.Unmarshal(data, token)
+	if err != nil || len(token.Position) == 0 || (token.Depth > 0 && len(token.Position) > int(token.Depth)) {
+		return nil, errors.New("the page token is not valid")
+	}
+	for _, position := range token.Position {
+		if position == nil {
+			return nil, errors.New("the page token is not valid")
+		}
+	}
+
+	return token, nil
+}
+
+/**
+ * A listing of a directory tree.
+ */
+type lister struct {
+	server     *server
+	ctx        context.Context
+	sort       filepb.SortOrder
+	descending bool
+	depth      int32 // 0 for all the levels.
+
+	// The size of the thumbnails of the images, none if it is 0.
+	thumbnailWidth  int
+	thumbnailHeight int
+
+	// The entries are given to visit after the start position, until it
+	// return an error. The position of the entry given is set before.
+	start    []*pagePosition
+	position []*pagePosition
+	visit    func(*filepb.FileInfo) error
+}
+
+/**
+ * List the entries of a directory, the error of the directory itself is
+ * returned.
+ */
+func (l *lister) list(path string) error {
+	entries, err := ioutil.ReadDir(path)
+	if err != nil {
+		return err
+	}
+
+	return l.listEntries(path, entries, 1, nil, l.start)
+}
+
+/**
+ * List the entries of a directory at a level, the parents are the positions
+ * of the directory and it parents. The entries before the start position are
+ * skipped, the listing resume in the directory of it that was already sent.
+ */
+func (l *lister) listEntries(dir string, entries []os.FileInfo, level int32, parents []*pagePosition, start []*pagePosition) error {
+	infos := make([]*filepb.FileInfo, 0, len(entries))
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if isTempFile(entry.Name()) {
+			continue
+		}
+
+		// The links that lead out of Root are not given, the others are
+		// given as their target.
+		isLink := entry.Mode()&os.ModeSymlink != 0
+		if isLink {
+			if l.server.checkLinks(path) != nil {
+				continue
+			}
+			entry_, err := os.Stat(path)
+			if err != nil {
+				continue
+			}
+			entry = &namedFileInfo{FileInfo: entry_, name: entry.Name()}
+		}
+
+		info := &filepb.FileInfo{
+			Name:    entry.Name(),
+			Path:    l.server.getRelativePath(path),
+			Size:    entry.Size(),
+			Mode:    uint32(entry.Mode()),
+			ModTime: entry.ModTime().Unix(),
+			IsDir:   entry.IsDir(),
+			Depth:   level,
+		}
+		if info.IsDir {
+			info.Size = 0
+		} else if l.sort == filepb.SortOrder_SORT_BY_TYPE {
+			l.setMime(path, info)
+		}
+
+		// The links are marked as such, the linked directories are not read
+		// as they could contain their parent.
+		if isLink {
+			info.Mode |= uint32(os.ModeSymlink)
+		}
+
+		infos = append(infos, info)
+	}
+
+	l.sortEntries(infos)
+
+	for _, info := range infos {
+		err := l.ctx.Err()
+		if err != nil {
+			return err
+		}
+
+		path := filepath.Join(dir, info.Name)
+		position := append(parents[:len(parents):len(parents)], getPagePosition(info))
+
+		// The entry of the start position was sent, with it directories.
+		sent := false
+		if len(start) > 0 {
+			if info.Name == start[0].Name {
+				sent = true
+			} else if l.less(info, start[0].getFileInfo()) {
+				continue
+			} else {
+				start = nil
+			}
+		}
+
+		// The entries of a sub-directory are read before it is given, to
+		// give it error.
+		var entries []os.FileInfo
+		readEntries := info.IsDir && info.Mode&uint32(os.ModeSymlink) == 0 && (l.depth == 0 || level < l.depth)
+		if readEntries {
+			entries, err = ioutil.ReadDir(path)
+			if err != nil {
+				info.Error = err.Error()
+				readEntries = false
+			}
+		}
+
+		if sent {
+			if readEntries {
+				err = l.listEntries(path, entries, level+1, position, start[1:])
+				if err != nil {
+					return err
+				}
+			}
+			start = nil
+			continue
+		}
+
+		l.position = position
+		err = l.give(path, info)
+		if err != nil {
+			return err
+		}
+
+		if readEntries {
+			err = l.listEntries(path, entries, level+1, position, nil)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+/**
+ * Give an entry to visit, it mime type and it thumbnail are read only then.
+ */
+func (l *lister) give(path string, info *filepb.FileInfo) error {
+	if !info.IsDir && len(info.Mime) == 0 && len(info.Error) == 0 {
+		l.setMime(path, info)
+	}
+
+	if strings.HasPrefix(info.Mime, "image/") && l.thumbnailWidth > 0 && l.thumbnailHeight > 0 {
+		info.Thumbnail = l.server.getThumbnailUrl(l.ctx, path, l.thumbnailWidth, l.thumbnailHeight)
+	}
+
+	return l.visit(info)
+}
+
+func (l *lister) setMime(path string, info *filepb.FileInfo) {
+	var err error
+	info.Mime, err = readMime(path)
+	if err != nil {
+		info.Error = err.Error()
+	}
+}
+
+/**
+ * Sort the entries of a directory.
+ */
+func (l *lister) sortEntries(infos []*filepb.FileInfo) {
+	sort.Slice(infos, func(i, j int) bool {
+		return l.less(infos[i], infos[j])
+	})
+}
+
+/**
+ * Return true if an entry is before another one in the listing order, by name
+ * when they are equal.
+ */
+func (l *lister) less(a *filepb.FileInfo, b *filepb.FileInfo) bool {
+	if l.descending {
+		a, b = b, a
+	}
+
+	switch l.sort {
+	case filepb.SortOrder_SORT_BY_SIZE:
+		if a.Size != b.Size {
+			return a.Size < b.Size
+		}
+	case filepb.SortOrder_SORT_BY_TIME:
+		if a.ModTime != b.ModTime {
+			return a.ModTime < b.ModTime
+		}
+	case filepb.SortOrder_SORT_BY_TYPE:
+		if a.IsDir != b.IsDir {
+			return a.IsDir
+		}
+		if a.Mime != b.Mime {
+			return a.Mime < b.Mime
+		}
+	}
+	return a.Name < b.Name
+}
+
+/**
+ * The information of the target of a link with the name of the link.
+ */
+type namedFileInfo struct {
+	os.FileInfo
+	name string
+}
+
+func (info *namedFileInfo) Name() string {
+	return info.name
+}
+
+/**
+ * Return the mime type of a file from it content.
+ */
+func readMime(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	} else if info.Size() == 0 {
+		return "text/plain; charset=utf-8", nil
+	}
+
+	return Utility.GetFileContentType(f)
+}
+
+/**
+ * Return the directory of a listing, the error is a status.
+ */
+func (self *server) getListedDir(path string) (string, error) {
+	// The path is in the Root specefied by the server.
+	path_, err := self.getPath(path)
+	if err != nil {
+		return "", err
+	}
+
+	info, err := os.Stat(path_)
+	if os.IsNotExist(err) {
+		return "", status.Errorf(
+			codes.NotFound,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the directory "+path+" does not exist")))
+	} else if err != nil {
+		return "", status.Errorf(
+			codes.Internal,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	} else if !info.IsDir() {
+		return "", status.Errorf(
+			codes.InvalidArgument,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New(path+" is not a directory")))
+	}
+
+	return path_, nil
+}
+
+/**
+ * Return the error of a listing as a status.
+ */
+func getListError(err error) error {
+	if err == context.Canceled || err == context.DeadlineExceeded {
+		return status.Errorf(
+			codes.Canceled,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	} else if os.IsPermission(err) {
+		return status.Errorf(
+			codes.PermissionDenied,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	} else if _, ok := status.FromError(err); !ok {
+		return status.Errorf(
+			codes.Internal,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+
+	return err
+}
+
+/**
+ * Read the entries of a directory tree, by page. The entries are sent one by
+ * one, the entries of a sub-directory following it, and the entries that can
+ * not be read are sent with the error instead of stopping the listing. The
+ * token of the next page give the position of the last entry sent, so the next
+ * page read only the directories after it and the entries added or removed
+ * before it are not seen.
+ */
+func (self *server) ReadDir(rqst *filepb.ReadDirRequest, stream filepb.FileService_ReadDirServer) error {
+	path, err := self.getListedDir(rqst.GetPath())
+	if err != nil {
+		return err
+	}
+
+	depth := rqst.GetDepth()
+	if rqst.GetRecursive() {
+		depth = 0
+	} else if depth < 1 {
+		depth = 1
+	}
+
+	token := &pageToken{Path: self.getRelativePath(path), Sort: rqst.GetSort(), Descending: rqst.GetDescending(), Depth: depth}
+	if len(rqst.GetPageToken()) > 0 {
+		token_, err := decodePageToken(rqst.GetPageToken())
+		if err == nil && (token_.Path != token.Path || token_.Sort != token.Sort || token_.Descending != token.Descending || token_.Depth != token.Depth) {
+			err = errors.New("the page token is not the one of this listing")
+		}
+		if err != nil {
+			return status.Errorf(
+				codes.InvalidArgument,
+				Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+		}
+		token = token_
+	}
+
+	l := &lister{
+		server:          self,
+		ctx:             stream.Context(),
+		sort:            rqst.GetSort(),
+		descending:      rqst.GetDescending(),
+		depth:           depth,
+		thumbnailWidth:  int(rqst.GetThumnailWidth()),
+		thumbnailHeight: int(rqst.GetThumnailHeight()),
+		start:           token.Position,
+	}
+
+	// The page is full when an entry is found after it.
+	pageSize := int64(rqst.GetPageSize())
+	errPageDone := errors.New("the page is done")
+	sent := int64(0)
+	l.visit = func(info *filepb.FileInfo) error {
+		if pageSize > 0 && sent == pageSize {
+			return errPageDone
+		}
+
+		err := stream.Send(&filepb.ReadDirResponse{Info: info})
+		if err != nil {
+			return err
+		}
+
+		sent++
+		token.Position = l.position
+
+		return nil
+	}
+
+	err = l.list(path)
+	if err == errPageDone {
+		return stream.Send(&filepb.ReadDirResponse{NextPageToken: token.encode()})
+	} else if err != nil {
+		return getListError(err)
+	}
+
+	return nil
+}
+
+/**
+ * Send the images of a directory with their thumbnail, in the order of ReadDir.
+ */
+func (self *server) GetThumbnails(rqst *filepb.GetThumbnailsRequest, stream filepb.FileService_GetThumbnailsServer) error {
+	path, err := self.getListedDir(rqst.GetPath())
+	if err != nil {
+		return err
+	}
+
+	depth := int32(1)
+	if rqst.GetRecursive() {
+		depth = 0
+	}
+
+	l := &lister{
+		server:          self,
+		ctx:             stream.Context(),
+		depth:           depth,
+		thumbnailWidth:  int(rqst.GetThumnailWidth()),
+		thumbnailHeight: int(rqst.GetThumnailHeight()),
+		visit: func(info *filepb.FileInfo) error {
+			if !strings.HasPrefix(info.Mime, "image/") {
+				return nil
+			}
+			return stream.Send(&filepb.GetThumbnailsResponse{Info: info})
+		},
+	}
+
+	err = l.list(path)
+	if err != nil {
+		return getListError(err)
+	}
+
+	return nil
+}
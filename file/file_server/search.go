@@ -0,0 +1,650 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/davecourtois/Globular/file/filepb"
+	"github.com/davecourtois/Utility"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// The longest line read in the text files, the longer lines are not searched.
+	maxSearchLineSize = 1024 * 1024
+
+	// The length of the matching line sent at most.
+	maxMatchSize = 256
+
+	// The time between two saves of the index.
+	indexSaveInterval = time.Minute
+
+	// The time between two scans of Root if it can not be watched.
+	indexPollInterval = time.Minute
+)
+
+// Returned to stop the search once the limit is reached.
+var errSearchDone = errors.New("the search is done")
+
+/**
+ * The criteria of a search.
+ */
+type searchQuery struct {
+	rqst    *filepb.SearchRequest
+	name    string
+	regex   *regexp.Regexp
+	content string
+}
+
+func newSearchQuery(rqst *filepb.SearchRequest) (*searchQuery, error) {
+	q := &searchQuery{rqst: rqst, name: rqst.GetName(), content: rqst.GetContent()}
+	if rqst.GetIgnoreCase() {
+		q.name = strings.ToLower(q.name)
+		q.content = strings.ToLower(q.content)
+	}
+
+	if len(q.name) > 0 {
+		if _, err := filepath.Match(q.name, ""); err != nil {
+			return nil, errors.New("the pattern " + rqst.GetName() + " is not valid")
+		}
+	}
+
+	if len(rqst.GetRegex()) > 0 {
+		expr := rqst.GetRegex()
+		if rqst.GetIgnoreCase() {
+			expr = "(?i)" + expr
+		}
+
+		var err error
+		q.regex, err = regexp.Compile(expr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if rqst.GetMinSize() < 0 || rqst.GetMaxSize() < 0 || (rqst.GetMaxSize() > 0 && rqst.GetMaxSize() < rqst.GetMinSize()) {
+		return nil, errors.New("the size range is not valid")
+	}
+
+	return q, nil
+}
+
+/**
+ * Return true if only the files can match, the directories have no type, size
+ * or content.
+ */
+func (q *searchQuery) isFileOnly() bool {
+	return len(q.rqst.GetMime()) > 0 || q.rqst.GetMinSize() > 0 || q.rqst.GetMaxSize() > 0 || len(q.content) > 0
+}
+
+/**
+ * Return true if a file match the criteria other than it type and content.
+ */
+func (q *searchQuery) match(name string, entry *indexEntry) bool {
+	if entry.IsDir && q.isFileOnly() {
+		return false
+	}
+
+	if len(q.name) > 0 {
+		name_ := name
+		if q.rqst.GetIgnoreCase() {
+			name_ = strings.ToLower(name)
+		}
+		if ok, _ := filepath.Match(q.name, name_); !ok {
+			return false
+		}
+	}
+
+	if q.regex != nil && !q.regex.MatchString(name) {
+		return false
+	}
+
+	modTime := time.Unix(0, entry.ModTime).Unix()
+	if q.rqst.GetModifiedAfter() > 0 && modTime < q.rqst.GetModifiedAfter() {
+		return false
+	}
+	if q.rqst.GetModifiedBefore() > 0 && modTime >= q.rqst.GetModifiedBefore() {
+		return false
+	}
+
+	if entry.IsDir {
+		return true
+	}
+
+	return entry.Size >= q.rqst.GetMinSize() && (q.rqst.GetMaxSize() == 0 || entry.Size <= q.rqst.GetMaxSize())
+}
+
+func (q *searchQuery) matchMime(mime string) bool {
+	return strings.HasPrefix(mime, q.rqst.GetMime())
+}
+
+/**
+ * Return the first line of a text file that contain the searched text, from
+ * 1, or 0 if there is none.
+ */
+func (q *searchQuery) matchContent(ctx context.Context, path string, mime string) (int32, string, error) {
+	if !isTextMime(mime) {
+		return 0, "", nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, "", nil // the file is gone.
+	}
+	defer f.Close()
+
+	r := bufio.NewReaderSize(f, 64*1024)
+	for line := int32(1); ; line++ {
+		if line%1000 == 0 {
+			if err := ctx.Err(); err != nil {
+				return 0, "", err
+			}
+		}
+
+		text, err := readSearchLine(r)
+		if err != nil {
+			break // the end of the file, or it can not be read.
+		}
+
+		text_ := text
+		if q.rqst.GetIgnoreCase() {
+			text_ = strings.ToLower(text)
+		}
+
+		if strings.Contains(text_, q.content) {
+			if len(text) > maxMatchSize {
+				n := maxMatchSize
+				for n > 0 && !utf8.RuneStart(text[n]) {
+					n--
+				}
+				text = text[:n]
+			}
+			return line, text, nil
+		}
+	}
+
+	return 0, "", nil
+}
+
+/**
+ * Read a line of a text file without it end, the lines longer than
+ * maxSearchLineSize are read as empty lines.
+ */
+func readSearchLine(r *bufio.Reader) (string, error) {
+	var line []byte
+	size := 0
+	for {
+		data, err := r.ReadSlice('\n')
+		size += len(data)
+		if size <= maxSearchLineSize+1 {
+			line = append(line, data...)
+		} else {
+			line = nil
+		}
+
+		if err == bufio.ErrBufferFull {
+			continue
+		} else if err == io.EOF && size > 0 {
+			break
+		} else if err != nil {
+			return "", err
+		}
+		break
+	}
+
+	text := strings.TrimSuffix(string(line), "\n")
+	return strings.TrimSuffix(text, "\r"), nil
+}
+
+/**
+ * Return true for the types of the files that can be searched by content.
+ */
+func isTextMime(mime string) bool {
+	return strings.HasPrefix(mime, "text/") || strings.Contains(mime, "json") || strings.Contains(mime, "xml") || strings.Contains(mime, "javascript")
+}
+
+/**
+ * Return the mime type of a file from it content, empty if it can not be
+ * read.
+ */
+func getMime(path string) string {
+	mime, _ := readMime(path)
+	return mime
+}
+
+/**
+ * Search the files of a directory tree by their name, mime type, size, date
+ * and content. The tree is read at each search, except if Index is set in the
+ * configuration: the name, size, date and type of the files of Root are then
+ * kept in index.json beside the configuration and updated as the files change,
+ * only the content is read from the files.
+ */
+func (self *server) Search(rqst *filepb.SearchRequest, stream filepb.FileService_SearchServer) error {
+	// The path is in the Root specefied by the server.
+	path, err := self.getPath(rqst.GetPath())
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return status.Errorf(
+			codes.NotFound,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the directory "+rqst.GetPath()+" does not exist")))
+	} else if err != nil {
+		return status.Errorf(
+			codes.Internal,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	} else if !info.IsDir() {
+		return status.Errorf(
+			codes.InvalidArgument,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New(rqst.GetPath()+" is not a directory")))
+	}
+
+	query, err := newSearchQuery(rqst)
+	if err != nil {
+		return status.Errorf(
+			codes.InvalidArgument,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+
+	ctx := stream.Context()
+	found := int32(0)
+	send := func(path string, entry *indexEntry) error {
+		rsp := &filepb.SearchResponse{
+			Path:    self.getRelativePath(path),
+			Size:    entry.Size,
+			ModTime: time.Unix(0, entry.ModTime).Unix(),
+			IsDir:   entry.IsDir,
+			Mime:    entry.Mime,
+		}
+
+		if len(query.content) > 0 {
+			line, match, err := query.matchContent(ctx, path, entry.Mime)
+			if err != nil || line == 0 {
+				return err
+			}
+			rsp.Line = line
+			rsp.Match = match
+		}
+
+		err := stream.Send(rsp)
+		if err != nil {
+			return err
+		}
+
+		found++
+		if rqst.GetLimit() > 0 && found >= rqst.GetLimit() {
+			return errSearchDone
+		}
+
+		return ctx.Err()
+	}
+
+	if self.index != nil && self.index.isReady() {
+		err = self.index.search(ctx, path, query, send)
+	} else {
+		err = searchDir(ctx, path, query, send)
+	}
+
+	if err == errSearchDone {
+		return nil
+	} else if err == context.Canceled || err == context.DeadlineExceeded {
+		return status.Errorf(
+			codes.Canceled,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	} else if _, ok := status.FromError(err); !ok {
+		return status.Errorf(
+			codes.Internal,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+
+	return err
+}
+
+/**
+ * Search a directory tree by reading it. The links and the directories that
+ * can not be read are skipped.
+ */
+func searchDir(ctx context.Context, path string, query *searchQuery, send func(string, *indexEntry) error) error {
+	return filepath.Walk(path, func(path_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			if path_ == path {
+				return err
+			}
+			return nil
+		}
+
+		if path_ == path || info.Mode()&os.ModeSymlink != 0 || isTempFile(info.Name()) {
+			return nil
+		}
+
+		err = ctx.Err()
+		if err != nil {
+			return err
+		}
+
+		entry := newIndexEntry(info)
+		if !query.match(info.Name(), entry) {
+			return nil
+		}
+
+		if !entry.IsDir {
+			entry.Mime = getMime(path_)
+			if !query.matchMime(entry.Mime) {
+				return nil
+			}
+		}
+
+		return send(path_, entry)
+	})
+}
+
+/**
+ * A file of the index.
+ */
+type indexEntry struct {
+	Size    int64
+	ModTime int64 // unix time in nanoseconds.
+	IsDir   bool
+	Mime    string
+}
+
+func newIndexEntry(info os.FileInfo) *indexEntry {
+	entry := &indexEntry{ModTime: info.ModTime().UnixNano(), IsDir: info.IsDir()}
+	if !entry.IsDir {
+		entry.Size = info.Size()
+	}
+
+	return entry
+}
+
+/**
+ * The index of the files of Root, by path in Root. It is read from the disk
+ * at the start of the service, checked against the files and then updated by
+ * a watcher of Root. It is used once it is checked.
+ */
+type fileIndex struct {
+	Root  string
+	Files map[string]*indexEntry
+
+	path  string // The index file.
+	ready bool
+	dirty bool
+	mutex sync.RWMutex
+}
+
+/**
+ * Start the index of Root, it is saved in path.
+ */
+func (self *server) startIndex(path string) {
+	index := &fileIndex{path: path, Files: make(map[string]*indexEntry, 0)}
+	err := index.load(self.Root)
+	if err != nil && !os.IsNotExist(err) {
+		log.Println("Fail to read the index ", path, ": ", err)
+	}
+	self.index = index
+
+	// Root is watched before it is checked so no change is missed, the
+	// changes are kept until it is checked.
+	watcher, err := self.newDirWatcher(self.Root, true, indexPollInterval)
+	if err != nil {
+		log.Println("Fail to watch ", self.Root, ", the files are not indexed: ", err)
+		return
+	}
+
+	checked := make(chan bool)
+	go func() {
+		index.check(self.Root)
+		close(checked)
+	}()
+
+	go func() {
+		ticker := time.NewTicker(indexSaveInterval)
+		defer ticker.Stop()
+
+		pending := make([]*fileChange, 0)
+		for {
+			select {
+			case change, ok := <-watcher.Changes():
+				if !ok {
+					log.Println("The watch of ", self.Root, " is interrupted, the files are not indexed.")
+					index.setReady(false)
+					return
+				}
+				if pending != nil {
+					pending = append(pending, change)
+				} else {
+					index.update(self, change)
+				}
+
+			case <-checked:
+				checked = nil
+				for _, change := range pending {
+					index.update(self, change)
+				}
+				pending = nil
+				index.setReady(true)
+
+			case <-ticker.C:
+				err := index.save()
+				if err != nil {
+					log.Println("Fail to save the index ", index.path, ": ", err)
+				}
+			}
+		}
+	}()
+}
+
+/**
+ * Read the index saved for root.
+ */
+func (index *fileIndex) load(root string) error {
+	data, err := ioutil.ReadFile(index.path)
+	if err != nil {
+		return err
+	}
+
+	saved := new(fileIndex)
+	err = json.Unmarshal(data, saved)
+	if err != nil {
+		return err
+	}
+
+	// The index of another root is not used.
+	if saved.Root == root && saved.Files != nil {
+		index.Files = saved.Files
+	}
+
+	return nil
+}
+
+/**
+ * Save the index if it has changed, in a temporary file renamed once it is
+ * written.
+ */
+func (index *fileIndex) save() error {
+	index.mutex.Lock()
+	if !index.dirty || !index.ready {
+		index.mutex.Unlock()
+		return nil
+	}
+	data, err := json.Marshal(index)
+	index.dirty = false
+	index.mutex.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	tmp := index.path + ".tmp"
+	err = ioutil.WriteFile(tmp, data, 0600)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, index.path)
+}
+
+func (index *fileIndex) isReady() bool {
+	index.mutex.RLock()
+	defer index.mutex.RUnlock()
+
+	return index.ready
+}
+
+func (index *fileIndex) setReady(ready bool) {
+	index.mutex.Lock()
+	defer index.mutex.Unlock()
+
+	index.ready = ready
+}
+
+/**
+ * Check the index against the files of root. The type of the files with the
+ * same size and time is not read again.
+ */
+func (index *fileIndex) check(root string) {
+	files := make(map[string]*indexEntry, 0)
+	filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil || path == root || info.Mode()&os.ModeSymlink != 0 || isTempFile(info.Name()) {
+			return nil
+		}
+
+		rel, _ := filepath.Rel(root, path)
+		entry := newIndexEntry(info)
+		if !entry.IsDir {
+			index.mutex.RLock()
+			previous := index.Files[rel]
+			index.mutex.RUnlock()
+
+			if previous != nil && !previous.IsDir && previous.Size == entry.Size && previous.ModTime == entry.ModTime {
+				entry.Mime = previous.Mime
+			} else {
+				entry.Mime = getMime(path)
+			}
+		}
+		files[rel] = entry
+
+		return nil
+	})
+
+	index.mutex.Lock()
+	defer index.mutex.Unlock()
+
+	index.Root = root
+	index.Files = files
+	index.dirty = true
+}
+
+/**
+ * Update the index with a change of the files.
+ */
+func (index *fileIndex) update(server *server, change *fileChange) {
+	rel, err := filepath.Rel(server.Root, change.path)
+	if err != nil || rel == "." {
+		return
+	}
+
+	var entry *indexEntry
+	if change.kind != filepb.FileChangeType_FILE_DELETED {
+		info, err := os.Lstat(change.path)
+		if err == nil && info.Mode()&os.ModeSymlink == 0 {
+			entry = newIndexEntry(info)
+			if !entry.IsDir {
+				entry.Mime = getMime(change.path)
+			}
+		}
+	}
+
+	index.mutex.Lock()
+	defer index.mutex.Unlock()
+
+	index.dirty = true
+	if change.kind == filepb.FileChangeType_FILE_RENAMED {
+		oldRel, err := filepath.Rel(server.Root, change.oldPath)
+		if err == nil {
+			for path, entry_ := range index.Files {
+				if path == oldRel {
+					delete(index.Files, path)
+				} else if strings.HasPrefix(path, oldRel+string(os.PathSeparator)) {
+					delete(index.Files, path)
+					index.Files[rel+path[len(oldRel):]] = entry_
+				}
+			}
+		}
+	}
+
+	if entry == nil {
+		delete(index.Files, rel)
+		if change.isDir || change.kind == filepb.FileChangeType_FILE_DELETED {
+			for path := range index.Files {
+				if strings.HasPrefix(path, rel+string(os.PathSeparator)) {
+					delete(index.Files, path)
+				}
+			}
+		}
+		return
+	}
+
+	index.Files[rel] = entry
+}
+
+/**
+ * Search the files of a directory tree in the index, the files are sent by
+ * path.
+ */
+func (index *fileIndex) search(ctx context.Context, path string, query *searchQuery, send func(string, *indexEntry) error) error {
+	type result struct {
+		path  string
+		entry indexEntry
+	}
+
+	prefix, err := filepath.Rel(index.Root, path)
+	if err != nil {
+		return err
+	}
+
+	results := make([]*result, 0)
+	index.mutex.RLock()
+	for rel, entry := range index.Files {
+		if prefix != "." && !strings.HasPrefix(rel, prefix+string(os.PathSeparator)) {
+			continue
+		}
+		if query.match(filepath.Base(rel), entry) && (entry.IsDir || query.matchMime(entry.Mime)) {
+			results = append(results, &result{path: filepath.Join(index.Root, rel), entry: *entry})
+		}
+	}
+	index.mutex.RUnlock()
+
+	// In the order of a walk of the tree.
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].path < results[j].path
+	})
+
+	for _, result := range results {
+		err := ctx.Err()
+		if err == nil {
+			err = send(result.path, &result.entry)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
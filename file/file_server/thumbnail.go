@@ -0,0 +1,486 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"io/ioutil"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/davecourtois/Globular/file/filepb"
+	"github.com/davecourtois/Utility"
+	"github.com/nfnt/resize"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultThumbnailSize = 256
+	maxThumbnailSize     = 2048
+
+	// The images larger than that are not read, they would take too much
+	// memory.
+	maxImagePixels = 64 * 1024 * 1024
+
+	thumbnailQuality = 85
+
+	// The thumbnails not used for that time are removed.
+	thumbnailMaxAge        = 30 * 24 * time.Hour
+	thumbnailPruneInterval = 24 * time.Hour
+)
+
+var (
+	// The error of the files that are not images, or that can not be decoded.
+	errNotImage = errors.New("the file is not an image")
+
+	errImageTooLarge = errors.New("the image is too large to be read")
+)
+
+/**
+ * The thumbnails being computed, the other requests of the same thumbnail
+ * wait for it.
+ */
+type thumbnailJob struct {
+	done chan bool
+	data []byte
+	err  error
+}
+
+/**
+ * Start to keep the thumbnails in dir, the old ones are removed every
+ * thumbnailPruneInterval.
+ */
+func (self *server) startThumbnails(dir string) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		log.Println("Fail to create ", dir, ", the thumbnails are not kept: ", err)
+		return
+	}
+	self.thumbnails = dir
+
+	go func() {
+		for {
+			pruneThumbnails(dir)
+			time.Sleep(thumbnailPruneInterval)
+		}
+	}()
+}
+
+/**
+ * Remove the thumbnails not used for thumbnailMaxAge.
+ */
+func pruneThumbnails(dir string) {
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() && time.Since(info.ModTime()) > thumbnailMaxAge {
+			os.Remove(path)
+		}
+		return nil
+	})
+}
+
+/**
+ * Return the JPEG thumbnail of an image, from the kept thumbnails if the image
+ * has not changed. The thumbnails are scaled to fit in the size asked, keeping
+ * the ratio of the image, and turned as the EXIF orientation of the JPEG images
+ * give. They are kept in the thumbnails directory beside the configuration by
+ * path, time and size of the image, so an image is read again only when it
+ * change. At most one thumbnail by CPU is computed at time.
+ */
+func (self *server) getThumbnail(ctx context.Context, path string, width int, height int) ([]byte, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	} else if info.IsDir() {
+		return nil, errNotImage
+	}
+
+	key := getThumbnailKey(self.getRelativePath(path), info, width, height)
+	file := ""
+	if len(self.thumbnails) > 0 {
+		file = filepath.Join(self.thumbnails, key[:2], key+".jpg")
+		data, err := ioutil.ReadFile(file)
+		if err == nil {
+			// It is used.
+			now := time.Now()
+			os.Chtimes(file, now, now)
+			return data, nil
+		}
+	}
+
+	// The thumbnail is computed once for the requests that ask it together.
+	self.mutex.Lock()
+	if self.thumbnailJobs == nil {
+		self.thumbnailJobs = make(map[string]*thumbnailJob, 0)
+		self.thumbnailWorkers = make(chan bool, runtime.NumCPU())
+	}
+	job := self.thumbnailJobs[key]
+	if job == nil {
+		job = &thumbnailJob{done: make(chan bool)}
+		self.thumbnailJobs[key] = job
+		go self.runThumbnailJob(key, job, path, file, width, height)
+	}
+	self.mutex.Unlock()
+
+	select {
+	case <-job.done:
+		return job.data, job.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (self *server) runThumbnailJob(key string, job *thumbnailJob, path string, file string, width int, height int) {
+	self.thumbnailWorkers <- true
+	job.data, job.err = createThumbnail(path, width, height)
+	<-self.thumbnailWorkers
+
+	if job.err == nil && len(file) > 0 {
+		err := saveThumbnail(file, job.data)
+		if err != nil {
+			log.Println("Fail to keep the thumbnail of ", path, ": ", err)
+		}
+	}
+
+	self.mutex.Lock()
+	delete(self.thumbnailJobs, key)
+	self.mutex.Unlock()
+
+	close(job.done)
+}
+
+/**
+ * Return the name of the thumbnail of a file as it is, for a size.
+ */
+func getThumbnailKey(path string, info os.FileInfo, width int, height int) string {
+	hash := sha256.Sum256([]byte(path + "|" + strconv.FormatInt(info.ModTime().UnixNano(), 10) + "|" + strconv.FormatInt(info.Size(), 10) + "|" + strconv.Itoa(width) + "x" + strconv.Itoa(height)))
+	return hex.EncodeToString(hash[:])
+}
+
+/**
+ * Write a thumbnail in a temporary file renamed once it is complete.
+ */
+func saveThumbnail(file string, data []byte) error {
+	err := os.MkdirAll(filepath.Dir(file), 0755)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(file), "."+filepath.Base(file)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Close()
+	} else {
+		tmp.Close()
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), file)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+
+	return err
+}
+
+/**
+ * Return the thumbnail of an image as a data url, empty if it is not an image.
+ */
+func (self *server) getThumbnailUrl(ctx context.Context, path string, width int, height int) string {
+	data, err := self.getThumbnail(ctx, path, width, height)
+	if err != nil {
+		if err != errNotImage && err != errImageTooLarge && err != ctx.Err() {
+			log.Println("Fail to create the thumbnail of ", path, ": ", err)
+		}
+		return ""
+	}
+
+	return "data:image/jpeg;base64," + base64.StdEncoding.EncodeToString(data)
+}
+
+/**
+ * Create the JPEG thumbnail of a PNG, JPEG or GIF image. It fit in width and
+ * height, the images are not zoomed.
+ */
+func createThumbnail(path string, width int, height int) ([]byte, error) {
+	img, _, orientation, err := openImage(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// The size of the image as it is shown.
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+	if orientation >= 5 {
+		w, h = h, w
+	}
+
+	scale := math.Min(1, math.Min(float64(width)/float64(w), float64(height)/float64(h)))
+	w = int(math.Max(1, math.Round(float64(w)*scale)))
+	h = int(math.Max(1, math.Round(float64(h)*scale)))
+	if orientation >= 5 {
+		w, h = h, w
+	}
+
+	if w != img.Bounds().Dx() || h != img.Bounds().Dy() {
+		img = resize.Resize(uint(w), uint(h), img, resize.Lanczos3)
+	}
+	img = orient(img, orientation)
+
+	var buf bytes.Buffer
+	err = jpeg.Encode(&buf, flatten(img), &jpeg.Options{Quality: thumbnailQuality})
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+/**
+ * Decode a PNG, JPEG or GIF image, return it with it format and it EXIF
+ * orientation.
+ */
+func openImage(path string) (image.Image, string, int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	defer file.Close()
+
+	config, format, err := image.DecodeConfig(bufio.NewReader(file))
+	if err != nil {
+		return nil, "", 0, errNotImage
+	} else if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > maxImagePixels {
+		return nil, "", 0, errImageTooLarge
+	}
+
+	orientation := 1
+	if format == "jpeg" {
+		file.Seek(0, io.SeekStart)
+		orientation = getOrientation(bufio.NewReader(file))
+	}
+
+	file.Seek(0, io.SeekStart)
+	img, _, err := image.Decode(bufio.NewReader(file))
+	if err != nil {
+		return nil, "", 0, errNotImage
+	}
+
+	return img, format, orientation, nil
+}
+
+/**
+ * Return an image without transparency, the transparent pixels are white.
+ */
+func flatten(img image.Image) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, img.Bounds().Dx(), img.Bounds().Dy()))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), img, img.Bounds().Min, draw.Over)
+
+	return dst
+}
+
+/**
+ * Return the EXIF orientation of a JPEG image, from 1 to 8, 1 if it has none.
+ */
+func getOrientation(r io.Reader) int {
+	var marker [4]byte
+	if _, err := io.ReadFull(r, marker[:2]); err != nil || marker[0] != 0xFF || marker[1] != 0xD8 {
+		return 1
+	}
+
+	for {
+		if _, err := io.ReadFull(r, marker[:]); err != nil || marker[0] != 0xFF {
+			return 1
+		}
+
+		// The image data start after the SOS segment.
+		if marker[1] == 0xDA {
+			return 1
+		}
+
+		size := int(binary.BigEndian.Uint16(marker[2:])) - 2
+		if size < 0 {
+			return 1
+		}
+
+		if marker[1] != 0xE1 {
+			if _, err := io.CopyN(ioutil.Discard, r, int64(size)); err != nil {
+				return 1
+			}
+			continue
+		}
+
+		data := make([]byte, size)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return 1
+		}
+		if bytes.HasPrefix(data, []byte("Exif\x00\x00")) {
+			return getExifOrientation(data[6:])
+		}
+	}
+}
+
+/**
+ * Return the orientation of the first IFD of EXIF data.
+ */
+func getExifOrientation(data []byte) int {
+	if len(data) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(data[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	offset := int(order.Uint32(data[4:]))
+	if offset < 8 || offset+2 > len(data) {
+		return 1
+	}
+
+	count := int(order.Uint16(data[offset:]))
+	for i := 0; i < count; i++ {
+		entry := offset + 2 + i*12
+		if entry+12 > len(data) {
+			return 1
+		}
+
+		// The orientation is a short.
+		if order.Uint16(data[entry:]) == 0x0112 && order.Uint16(data[entry+2:]) == 3 {
+			orientation := int(order.Uint16(data[entry+8:]))
+			if orientation >= 1 && orientation <= 8 {
+				return orientation
+			}
+			return 1
+		}
+	}
+
+	return 1
+}
+
+/**
+ * Turn and flip an image as the EXIF orientation give.
+ */
+func orient(img image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2: // flipped horizontally
+				sx, sy = w-1-x, y
+			case 3: // turned 180°
+				sx, sy = w-1-x, h-1-y
+			case 4: // flipped vertically
+				sx, sy = x, h-1-y
+			case 5: // transposed
+				sx, sy = y, x
+			case 6: // turned 90° clockwise
+				sx, sy = y, h-1-x
+			case 7: // transversed
+				sx, sy = w-1-y, h-1-x
+			case 8: // turned 90° counterclockwise
+				sx, sy = w-1-y, x
+			}
+			dst.Set(x, y, img.At(bounds.Min.X+sx, bounds.Min.Y+sy))
+		}
+	}
+
+	return dst
+}
+
+// Return the thumbnail of an image.
+func (self *server) GetThumbnail(ctx context.Context, rqst *filepb.GetThumbnailRequest) (*filepb.GetThumbnailResponse, error) {
+	// The path is in the Root specefied by the server.
+	path, err := self.getPath(rqst.GetPath())
+	if err != nil {
+		return nil, err
+	}
+
+	width, height := int(rqst.GetWidth()), int(rqst.GetHeight())
+	if width == 0 {
+		width = defaultThumbnailSize
+	}
+	if height == 0 {
+		height = defaultThumbnailSize
+	}
+	if width < 0 || height < 0 || width > maxThumbnailSize || height > maxThumbnailSize {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the size of a thumbnail is at most "+strconv.Itoa(maxThumbnailSize)+"x"+strconv.Itoa(maxThumbnailSize))))
+	}
+
+	data, err := self.getThumbnail(ctx, path, width, height)
+	if os.IsNotExist(err) {
+		return nil, status.Errorf(
+			codes.NotFound,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the file "+rqst.GetPath()+" does not exist")))
+	} else if err == errNotImage {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New(rqst.GetPath()+" is not an image")))
+	} else if err == errImageTooLarge {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	} else if err == context.Canceled || err == context.DeadlineExceeded {
+		return nil, status.Errorf(
+			codes.Canceled,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	} else if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+
+	config, err := jpeg.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+
+	return &filepb.GetThumbnailResponse{
+		Data:   data,
+		Mime:   "image/jpeg",
+		Width:  int32(config.Width),
+		Height: int32(config.Height),
+	}, nil
+}
@@ -0,0 +1,413 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/davecourtois/Globular/event/event_client"
+	"github.com/davecourtois/Globular/file/filepb"
+	"github.com/davecourtois/Utility"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// The delay between two progress messages.
+	transferProgressDelay = 250 * time.Millisecond
+
+	// The size of the data copied at time.
+	copyBufferSize = 1024 * 1024
+)
+
+/**
+ * A copy or a move of a file or a directory tree in Root. The progress is
+ * sent at most every transferProgressDelay and once it is done.
+ */
+type transfer struct {
+	server   *server
+	ctx      context.Context
+	move     bool
+	conflict filepb.ConflictPolicy
+	progress filepb.TransferProgress
+	send     func(*filepb.TransferProgress) error
+	sent     time.Time
+}
+
+// Copy a file or a directory tree.
+func (self *server) Copy(rqst *filepb.CopyRequest, stream filepb.FileService_CopyServer) error {
+	t := &transfer{server: self, ctx: stream.Context(), conflict: rqst.GetConflict()}
+	t.send = func(progress *filepb.TransferProgress) error {
+		return stream.Send(&filepb.CopyResponse{Progress: progress})
+	}
+
+	err := t.run(rqst.GetSource(), rqst.GetDestination())
+	if err != nil {
+		return err
+	}
+
+	event_client.Publish(event_client.FileCopied, map[string]interface{}{"source": rqst.GetSource(), "destination": t.progress.Destination})
+
+	return nil
+}
+
+// Move a file or a directory tree. It is renamed if it can be, copied and
+// deleted if it is on another device.
+func (self *server) Move(rqst *filepb.MoveRequest, stream filepb.FileService_MoveServer) error {
+	t := &transfer{server: self, ctx: stream.Context(), move: true, conflict: rqst.GetConflict()}
+	t.send = func(progress *filepb.TransferProgress) error {
+		return stream.Send(&filepb.MoveResponse{Progress: progress})
+	}
+
+	err := t.run(rqst.GetSource(), rqst.GetDestination())
+	if err != nil {
+		return err
+	}
+
+	event_client.Publish(event_client.FileMoved, map[string]interface{}{"source": rqst.GetSource(), "destination": t.progress.Destination})
+
+	return nil
+}
+
+/**
+ * Copy or move a path given to the service to the destination, the conflict
+ * with an existing destination is resolved here for FAIL and RENAME.
+ */
+func (t *transfer) run(source string, destination string) error {
+	src, err := t.server.getPath(source)
+	if err != nil {
+		return err
+	}
+
+	dst, err := t.server.getPath(destination)
+	if err != nil {
+		return err
+	}
+
+	if src == t.server.Root || dst == t.server.Root {
+		return status.Errorf(
+			codes.PermissionDenied,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the root can not be copied or replaced")))
+	}
+
+	if rel, err := filepath.Rel(src, dst); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(os.PathSeparator)) {
+		return status.Errorf(
+			codes.InvalidArgument,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the destination "+destination+" is in the source "+source)))
+	}
+
+	info, err := os.Lstat(src)
+	if os.IsNotExist(err) {
+		return status.Errorf(
+			codes.NotFound,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the source "+source+" does not exist")))
+	} else if err != nil {
+		return status.Errorf(
+			codes.Internal,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+
+	if info, err := os.Stat(filepath.Dir(dst)); err != nil || !info.IsDir() {
+		return status.Errorf(
+			codes.NotFound,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the directory of the destination "+destination+" does not exist")))
+	}
+
+	if _, err := os.Lstat(dst); err == nil {
+		switch t.conflict {
+		case filepb.ConflictPolicy_FAIL:
+			return status.Errorf(
+				codes.AlreadyExists,
+				Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the destination "+destination+" already exist")))
+		case filepb.ConflictPolicy_RENAME:
+			dst = getFreePath(dst, info.IsDir())
+		}
+	}
+
+	t.progress.Destination = t.server.getRelativePath(dst)
+	t.progress.TotalFiles, t.progress.TotalSize = countFiles(src)
+
+	err = t.transfer(src, dst, info)
+	if err == nil {
+		err = t.sendProgress(true)
+	}
+
+	if err == context.Canceled || err == context.DeadlineExceeded {
+		return status.Errorf(
+			codes.Canceled,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	} else if _, ok := status.FromError(err); !ok {
+		return status.Errorf(
+			codes.Internal,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+
+	return err
+}
+
+/**
+ * Copy or move a file or a directory. An existing directory is merged, an
+ * existing file is replaced or skipped.
+ */
+func (t *transfer) transfer(src string, dst string, info os.FileInfo) error {
+	err := t.ctx.Err()
+	if err != nil {
+		return err
+	}
+
+	existing, err := os.Lstat(dst)
+	if err == nil {
+		if info.IsDir() && existing.IsDir() {
+			return t.transferDir(src, dst, info, true)
+		}
+
+		if t.conflict == filepb.ConflictPolicy_SKIP {
+			files, size := countFiles(src)
+			t.progress.Files += files
+			t.progress.Skipped += files
+			t.progress.Size += size
+			t.progress.Path = t.server.getRelativePath(dst)
+			return t.sendProgress(false)
+		}
+
+		// A file is replaced by the rename, a directory must be removed.
+		if info.IsDir() || existing.IsDir() {
+			err = os.RemoveAll(dst)
+			if err != nil {
+				return err
+			}
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	if t.move && os.Rename(src, dst) == nil {
+		files, size := countFiles(dst)
+		t.progress.Files += files
+		t.progress.Size += size
+		t.progress.Path = t.server.getRelativePath(dst)
+		return t.sendProgress(false)
+	}
+
+	switch {
+	case info.IsDir():
+		return t.transferDir(src, dst, info, false)
+	case info.Mode()&os.ModeSymlink != 0:
+		var copied bool
+		copied, err = t.copyLink(src, dst)
+		if err == nil && !copied {
+			return nil // the link is kept in the source.
+		}
+	case info.Mode().IsRegular():
+		err = t.copyFile(src, dst, info)
+	default:
+		// The devices, sockets... are not copied.
+		t.progress.Files++
+		t.progress.Skipped++
+		return t.sendProgress(false)
+	}
+
+	if err == nil && t.move {
+		err = os.Remove(src)
+	}
+
+	return err
+}
+
+/**
+ * Copy or move the files of a directory, in a new directory or merged in an
+ * existing one.
+ */
+func (t *transfer) transferDir(src string, dst string, info os.FileInfo, merge bool) error {
+	if !merge {
+		err := os.Mkdir(dst, info.Mode().Perm())
+		if err != nil {
+			return err
+		}
+	}
+
+	entries, err := ioutil.ReadDir(src)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		err = t.transfer(filepath.Join(src, entry.Name()), filepath.Join(dst, entry.Name()), entry)
+		if err != nil {
+			return err
+		}
+	}
+
+	if t.move {
+		// The directory is kept with the files skipped.
+		os.Remove(src)
+	}
+
+	return nil
+}
+
+/**
+ * Copy a file in a temporary file renamed once it is complete, with the
+ * permissions and the modification time of the file.
+ */
+func (t *transfer) copyFile(src string, dst string, info os.FileInfo) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := ioutil.TempFile(filepath.Dir(dst), "."+filepath.Base(dst)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	tmp := out.Name()
+	defer func() {
+		out.Close()
+		os.Remove(tmp)
+	}()
+
+	err = t.copyData(out, in)
+	if err != nil {
+		return err
+	}
+
+	err = out.Chmod(info.Mode().Perm())
+	if err == nil {
+		err = out.Close()
+	}
+	if err == nil {
+		err = os.Chtimes(tmp, info.ModTime(), info.ModTime())
+	}
+	if err == nil {
+		_, err = os.Lstat(dst)
+		t.server.setReplaced(dst, err == nil)
+		err = os.Rename(tmp, dst)
+	}
+	if err != nil {
+		return err
+	}
+
+	t.progress.Files++
+	t.progress.Path = t.server.getRelativePath(dst)
+
+	return t.sendProgress(false)
+}
+
+/**
+ * Copy data until the end of the reader, the size copied is added to the
+ * progress.
+ */
+func (t *transfer) copyData(w io.Writer, r io.Reader) error {
+	buffer := make([]byte, copyBufferSize)
+	for {
+		n, err := r.Read(buffer)
+		if n > 0 {
+			_, err := w.Write(buffer[:n])
+			if err != nil {
+				return err
+			}
+
+			t.progress.Size += int64(n)
+			err = t.sendProgress(false)
+			if err == nil {
+				err = t.ctx.Err()
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+/**
+ * Copy a symbolic link, it is skipped if it lead out of Root or is broken once
+ * copied. Return false if it is skipped.
+ */
+func (t *transfer) copyLink(src string, dst string) (bool, error) {
+	target, err := os.Readlink(src)
+	if err != nil {
+		return false, err
+	}
+
+	err = os.Symlink(target, dst)
+	if err != nil {
+		return false, err
+	}
+
+	t.progress.Files++
+	copied := t.server.checkLinks(dst) == nil
+	if copied {
+		t.progress.Path = t.server.getRelativePath(dst)
+	} else {
+		os.Remove(dst)
+		t.progress.Skipped++
+	}
+
+	return copied, t.sendProgress(false)
+}
+
+/**
+ * Send the progress, if the last one was sent more than transferProgressDelay
+ * ago or if the transfer is done.
+ */
+func (t *transfer) sendProgress(done bool) error {
+	if !done && time.Since(t.sent) < transferProgressDelay {
+		return nil
+	}
+
+	t.sent = time.Now()
+	progress := t.progress
+
+	return t.send(&progress)
+}
+
+/**
+ * Return the number of files and their size in a directory tree, the links
+ * are not followed.
+ */
+func countFiles(path string) (int64, int64) {
+	var files, size int64
+	filepath.Walk(path, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			files++
+			if info.Mode().IsRegular() {
+				size += info.Size()
+			}
+		}
+		return nil
+	})
+
+	return files, size
+}
+
+/**
+ * Return the first path that does not exist, from "a.txt": "a (1).txt",
+ * "a (2).txt"... The extension of a directory is not kept apart.
+ */
+func getFreePath(path string, dir bool) string {
+	ext := filepath.Ext(path)
+	if dir {
+		ext = ""
+	}
+	base := strings.TrimSuffix(path, ext)
+	for i := 1; ; i++ {
+		path_ := base + " (" + strconv.Itoa(i) + ")" + ext
+		if _, err := os.Lstat(path_); os.IsNotExist(err) {
+			return path_
+		}
+	}
+}
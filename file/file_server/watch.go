@@ -0,0 +1,377 @@
+package main
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/davecourtois/Globular/file/filepb"
+	"github.com/davecourtois/Utility"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// The time without change before the changes are sent.
+	defaultWatchDelay = 100 * time.Millisecond
+	maxWatchDelay     = 10 * time.Second
+
+	// The changes are sent after that number of delays even if the files
+	// keep changing.
+	maxWatchDelays = 10
+
+	// The time between two scans of a polled directory.
+	watchPollInterval = time.Second
+
+	// The time a replaced file is remembered.
+	replacedFileDelay = 10 * time.Second
+)
+
+/**
+ * A change seen by a watcher, the paths are the paths on the disk.
+ */
+type fileChange struct {
+	kind    filepb.FileChangeType
+	path    string
+	oldPath string
+	isDir   bool
+}
+
+/**
+ * A watcher of a directory, it changes are read until it is close.
+ */
+type dirWatcher interface {
+	Changes() <-chan *fileChange
+	Close()
+}
+
+/**
+ * A file replaced by a temporary file of the service, the watchers see it as
+ * a rename of the temporary file.
+ */
+type replacedFile struct {
+	existed bool
+	time    time.Time
+}
+
+/**
+ * Send the changes of the files of a directory until the client stop, seen by
+ * inotify on Linux or by polling the directory. The changes are sent once there
+ * is no new one for the delay of the request, the changes of a same file being
+ * merged: a file created then modified is created, created then deleted is not
+ * sent...
+ */
+func (self *server) WatchDir(rqst *filepb.WatchDirRequest, stream filepb.FileService_WatchDirServer) error {
+	// The path is in the Root specefied by the server.
+	path, err := self.getPath(rqst.GetPath())
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return status.Errorf(
+			codes.NotFound,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the directory "+rqst.GetPath()+" does not exist")))
+	} else if err != nil {
+		return status.Errorf(
+			codes.Internal,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	} else if !info.IsDir() {
+		return status.Errorf(
+			codes.InvalidArgument,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New(rqst.GetPath()+" is not a directory")))
+	}
+
+	delay := time.Duration(rqst.GetDelay()) * time.Millisecond
+	if delay <= 0 {
+		delay = defaultWatchDelay
+	} else if delay > maxWatchDelay {
+		delay = maxWatchDelay
+	}
+
+	watcher, err := self.newDirWatcher(path, rqst.GetRecursive(), watchPollInterval)
+	if err != nil {
+		return status.Errorf(
+			codes.Internal,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+	defer watcher.Close()
+
+	// The directory is watched.
+	err = stream.Send(&filepb.WatchDirResponse{})
+	if err != nil {
+		return err
+	}
+
+	changes := make([]*filepb.FileChange, 0)
+	var timer <-chan time.Time
+	var deadline time.Time
+	for {
+		select {
+		case change, ok := <-watcher.Changes():
+			if !ok {
+				return status.Errorf(
+					codes.Internal,
+					Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the watch of "+rqst.GetPath()+" is interrupted")))
+			}
+
+			if len(changes) == 0 {
+				deadline = time.Now().Add(maxWatchDelays * delay)
+			}
+			changes = mergeChange(changes, &filepb.FileChange{
+				Type:    change.kind,
+				Path:    self.getRelativePath(change.path),
+				OldPath: self.getOldPath(change.oldPath),
+				IsDir:   change.isDir,
+			})
+
+			wait := delay
+			if remaining := time.Until(deadline); remaining < wait {
+				wait = remaining
+			}
+			timer = time.After(wait)
+
+		case <-timer:
+			timer = nil
+			if len(changes) > 0 {
+				err := stream.Send(&filepb.WatchDirResponse{Changes: changes})
+				if err != nil {
+					return err
+				}
+				changes = make([]*filepb.FileChange, 0)
+			}
+
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+func (self *server) getOldPath(path string) string {
+	if len(path) == 0 {
+		return ""
+	}
+
+	return self.getRelativePath(path)
+}
+
+/**
+ * Add a change to the changes waiting to be sent. It is merged with the last
+ * change of the same file: created then deleted is nothing, deleted then
+ * created is modified...
+ */
+func mergeChange(changes []*filepb.FileChange, change *filepb.FileChange) []*filepb.FileChange {
+	for i := len(changes) - 1; i >= 0; i-- {
+		last := changes[i]
+		if last.Path != change.Path && last.OldPath != change.Path {
+			continue
+		}
+
+		if last.Type == filepb.FileChangeType_FILE_RENAMED || change.Type == filepb.FileChangeType_FILE_RENAMED {
+			if last.Type == change.Type && last.Path == change.Path && last.OldPath == change.OldPath {
+				return changes
+			}
+			break
+		}
+
+		switch {
+		case last.Type == change.Type:
+			return changes
+		case last.Type == filepb.FileChangeType_FILE_CREATED && change.Type == filepb.FileChangeType_FILE_MODIFIED:
+			return changes
+		case last.Type == filepb.FileChangeType_FILE_CREATED && change.Type == filepb.FileChangeType_FILE_DELETED:
+			return append(changes[:i], changes[i+1:]...)
+		case last.Type == filepb.FileChangeType_FILE_DELETED && change.Type == filepb.FileChangeType_FILE_CREATED:
+			last.Type = filepb.FileChangeType_FILE_MODIFIED
+			last.IsDir = change.IsDir
+			return changes
+		case last.Type == filepb.FileChangeType_FILE_MODIFIED && change.Type == filepb.FileChangeType_FILE_DELETED:
+			last.Type = filepb.FileChangeType_FILE_DELETED
+			return changes
+		}
+		break
+	}
+
+	return append(changes, change)
+}
+
+/**
+ * Return true for the temporary files of SaveFile and Copy, that are not
+ * reported by the watchers.
+ */
+func isTempFile(name string) bool {
+	return strings.HasPrefix(name, ".") && strings.HasSuffix(name, ".tmp")
+}
+
+/**
+ * Remember that a file is replaced by a temporary file, and if it existed.
+ */
+func (self *server) setReplaced(path string, existed bool) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if self.replaced == nil {
+		self.replaced = make(map[string]*replacedFile, 0)
+	}
+
+	for path_, replaced := range self.replaced {
+		if time.Since(replaced.time) > replacedFileDelay {
+			delete(self.replaced, path_)
+		}
+	}
+
+	self.replaced[path] = &replacedFile{existed: existed, time: time.Now()}
+}
+
+/**
+ * Return true if a file replaced by a temporary file existed before, the
+ * files replaced by other programs are considered as existing.
+ */
+func (self *server) existed(path string) bool {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	replaced := self.replaced[path]
+
+	return replaced == nil || replaced.existed
+}
+
+/**
+ * A watcher that scan a directory at interval, it does not see the renames.
+ */
+type pollWatcher struct {
+	path      string
+	recursive bool
+	files     map[string]os.FileInfo
+	changes   chan *fileChange
+	done      chan bool
+}
+
+func newPollWatcher(path string, recursive bool, interval time.Duration) (dirWatcher, error) {
+	files, err := scanDir(path, recursive)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &pollWatcher{
+		path:      path,
+		recursive: recursive,
+		files:     files,
+		changes:   make(chan *fileChange),
+		done:      make(chan bool),
+	}
+
+	go w.poll(interval)
+
+	return w, nil
+}
+
+func (w *pollWatcher) Changes() <-chan *fileChange {
+	return w.changes
+}
+
+func (w *pollWatcher) Close() {
+	close(w.done)
+}
+
+func (w *pollWatcher) poll(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-w.done:
+			return
+		}
+
+		files, err := scanDir(w.path, w.recursive)
+		if err != nil {
+			// The directory is deleted.
+			files = make(map[string]os.FileInfo, 0)
+		}
+
+		for _, change := range getChanges(w.files, files) {
+			select {
+			case w.changes <- change:
+			case <-w.done:
+				return
+			}
+		}
+		w.files = files
+	}
+}
+
+/**
+ * Return the files of a directory by path, without the temporary files.
+ */
+func scanDir(path string, recursive bool) (map[string]os.FileInfo, error) {
+	files := make(map[string]os.FileInfo, 0)
+	if !recursive {
+		infos, err := ioutil.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, info := range infos {
+			if !isTempFile(info.Name()) {
+				files[filepath.Join(path, info.Name())] = info
+			}
+		}
+
+		return files, nil
+	}
+
+	err := filepath.Walk(path, func(path_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			if path_ == path {
+				return err
+			}
+			return nil
+		}
+
+		if path_ != path && !isTempFile(info.Name()) {
+			files[path_] = info
+		}
+
+		return nil
+	})
+
+	return files, err
+}
+
+/**
+ * Return the changes between two scans of a directory.
+ */
+func getChanges(previous map[string]os.FileInfo, files map[string]os.FileInfo) []*fileChange {
+	changes := make([]*fileChange, 0)
+	for path, info := range files {
+		previous_, ok := previous[path]
+		if !ok {
+			changes = append(changes, &fileChange{kind: filepb.FileChangeType_FILE_CREATED, path: path, isDir: info.IsDir()})
+		} else if previous_.IsDir() != info.IsDir() {
+			changes = append(changes, &fileChange{kind: filepb.FileChangeType_FILE_DELETED, path: path, isDir: previous_.IsDir()})
+			changes = append(changes, &fileChange{kind: filepb.FileChangeType_FILE_CREATED, path: path, isDir: info.IsDir()})
+		} else if !info.IsDir() && (previous_.Size() != info.Size() || !previous_.ModTime().Equal(info.ModTime())) {
+			changes = append(changes, &fileChange{kind: filepb.FileChangeType_FILE_MODIFIED, path: path})
+		}
+	}
+
+	for path, info := range previous {
+		if _, ok := files[path]; !ok {
+			changes = append(changes, &fileChange{kind: filepb.FileChangeType_FILE_DELETED, path: path, isDir: info.IsDir()})
+		}
+	}
+
+	// The parents before their files.
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].path < changes[j].path
+	})
+
+	return changes
+}
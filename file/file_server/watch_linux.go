@@ -0,0 +1,267 @@
+package main
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"github.com/davecourtois/Globular/file/filepb"
+)
+
+// The events of the watched directories.
+const inotifyMask = syscall.IN_CREATE | syscall.IN_DELETE | syscall.IN_MODIFY | syscall.IN_MOVED_FROM | syscall.IN_MOVED_TO | syscall.IN_DELETE_SELF
+
+/**
+ * Watch a directory with inotify, it is polled at interval if inotify can not
+ * be use (the limit of watches is reached...).
+ */
+func (self *server) newDirWatcher(path string, recursive bool, interval time.Duration) (dirWatcher, error) {
+	w, err := self.newInotifyWatcher(path, recursive)
+	if err != nil {
+		log.Println("Fail to watch ", path, " with inotify, it is polled: ", err)
+		return newPollWatcher(path, recursive, interval)
+	}
+
+	return w, nil
+}
+
+/**
+ * A watcher of a directory, and of it sub-directories if it is recursive, by
+ * inotify. The watches are changed as the sub-directories are created, moved
+ * or deleted.
+ */
+type inotifyWatcher struct {
+	server    *server
+	fd        int
+	file      *os.File // to read the events until it is close.
+	path      string
+	recursive bool
+	dirs      map[int32]string // The watched directories by watch.
+	changes   chan *fileChange
+	done      chan bool
+}
+
+func (self *server) newInotifyWatcher(path string, recursive bool) (*inotifyWatcher, error) {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return nil, os.NewSyscallError("inotify_init1", err)
+	}
+
+	w := &inotifyWatcher{
+		server:    self,
+		fd:        fd,
+		file:      os.NewFile(uintptr(fd), "inotify"),
+		path:      path,
+		recursive: recursive,
+		dirs:      make(map[int32]string, 0),
+		changes:   make(chan *fileChange),
+		done:      make(chan bool),
+	}
+
+	if recursive {
+		err = filepath.Walk(path, func(path string, info os.FileInfo, err error) error {
+			if err != nil || !info.IsDir() {
+				return err
+			}
+			return w.addDir(path)
+		})
+	} else {
+		err = w.addDir(path)
+	}
+
+	if err != nil {
+		w.file.Close()
+		return nil, err
+	}
+
+	go w.read()
+
+	return w, nil
+}
+
+func (w *inotifyWatcher) Changes() <-chan *fileChange {
+	return w.changes
+}
+
+func (w *inotifyWatcher) Close() {
+	close(w.done)
+	w.file.Close()
+}
+
+func (w *inotifyWatcher) addDir(path string) error {
+	wd, err := syscall.InotifyAddWatch(w.fd, path, inotifyMask)
+	if err != nil {
+		return os.NewSyscallError("inotify_add_watch", err)
+	}
+
+	w.dirs[int32(wd)] = path
+
+	return nil
+}
+
+/**
+ * Watch a directory created or moved in a recursive watch, the files it
+ * already contain are created.
+ */
+func (w *inotifyWatcher) addTree(path string) {
+	filepath.Walk(path, func(path_ string, info os.FileInfo, err error) error {
+		if err != nil || isTempFile(info.Name()) {
+			return nil
+		}
+
+		if path_ != path {
+			w.send(&fileChange{kind: filepb.FileChangeType_FILE_CREATED, path: path_, isDir: info.IsDir()})
+		}
+
+		if info.IsDir() {
+			err = w.addDir(path_)
+			if err != nil {
+				log.Println("Fail to watch ", path_, ": ", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+/**
+ * Change the path of the watches of a directory tree moved in the watch.
+ */
+func (w *inotifyWatcher) moveTree(oldPath string, path string) {
+	for wd, path_ := range w.dirs {
+		if path_ == oldPath || strings.HasPrefix(path_, oldPath+string(os.PathSeparator)) {
+			w.dirs[wd] = path + path_[len(oldPath):]
+		}
+	}
+}
+
+/**
+ * Remove the watches of a directory tree moved out of the watch.
+ */
+func (w *inotifyWatcher) removeTree(path string) {
+	for wd, path_ := range w.dirs {
+		if path_ == path || strings.HasPrefix(path_, path+string(os.PathSeparator)) {
+			syscall.InotifyRmWatch(w.fd, uint32(wd))
+			delete(w.dirs, wd)
+		}
+	}
+}
+
+func (w *inotifyWatcher) send(change *fileChange) {
+	select {
+	case w.changes <- change:
+	case <-w.done:
+	}
+}
+
+/**
+ * Read the events until the watcher is close.
+ */
+func (w *inotifyWatcher) read() {
+	defer close(w.changes)
+
+	buffer := make([]byte, (syscall.SizeofInotifyEvent+syscall.NAME_MAX+1)*64)
+	for {
+		n, err := w.file.Read(buffer)
+		if err != nil {
+			return
+		}
+
+		// The files moved from a directory, by cookie, until they are moved
+		// to another one.
+		moves := make(map[uint32]*fileChange, 0)
+		for offset := 0; offset+syscall.SizeofInotifyEvent <= n; {
+			event := (*syscall.InotifyEvent)(unsafe.Pointer(&buffer[offset]))
+			offset += syscall.SizeofInotifyEvent
+
+			name := ""
+			if event.Len > 0 && offset+int(event.Len) <= n {
+				name = strings.TrimRight(string(buffer[offset:offset+int(event.Len)]), "\x00")
+			}
+			offset += int(event.Len)
+
+			w.handle(event.Wd, event.Mask, event.Cookie, name, moves)
+		}
+
+		// The files moved out of the watch are deleted.
+		for _, change := range moves {
+			if change.isDir && w.recursive {
+				w.removeTree(change.path)
+			}
+			if !isTempFile(filepath.Base(change.path)) {
+				w.send(change)
+			}
+		}
+	}
+}
+
+func (w *inotifyWatcher) handle(wd int32, mask uint32, cookie uint32, name string, moves map[uint32]*fileChange) {
+	dir, ok := w.dirs[wd]
+	if !ok {
+		return
+	}
+
+	if mask&syscall.IN_IGNORED != 0 {
+		delete(w.dirs, wd)
+		return
+	}
+
+	// The watched directory itself is deleted, the sub-directories are
+	// reported by their parent.
+	if mask&syscall.IN_DELETE_SELF != 0 {
+		if dir == w.path {
+			w.send(&fileChange{kind: filepb.FileChangeType_FILE_DELETED, path: dir, isDir: true})
+		}
+		return
+	}
+
+	path := filepath.Join(dir, name)
+	isDir := mask&syscall.IN_ISDIR != 0
+
+	switch {
+	case mask&syscall.IN_MOVED_FROM != 0:
+		moves[cookie] = &fileChange{kind: filepb.FileChangeType_FILE_DELETED, path: path, isDir: isDir}
+
+	case mask&syscall.IN_MOVED_TO != 0:
+		from := moves[cookie]
+		delete(moves, cookie)
+
+		if from != nil && isTempFile(filepath.Base(from.path)) {
+			// A file saved by the service.
+			kind := filepb.FileChangeType_FILE_CREATED
+			if w.server.existed(path) {
+				kind = filepb.FileChangeType_FILE_MODIFIED
+			}
+			w.send(&fileChange{kind: kind, path: path})
+		} else if from != nil {
+			w.send(&fileChange{kind: filepb.FileChangeType_FILE_RENAMED, path: path, oldPath: from.path, isDir: isDir})
+			if isDir && w.recursive {
+				w.moveTree(from.path, path)
+			}
+		} else if !isTempFile(name) {
+			// Moved from out of the watch.
+			w.send(&fileChange{kind: filepb.FileChangeType_FILE_CREATED, path: path, isDir: isDir})
+			if isDir && w.recursive {
+				w.addTree(path)
+			}
+		}
+
+	case isTempFile(name):
+
+	case mask&syscall.IN_CREATE != 0:
+		w.send(&fileChange{kind: filepb.FileChangeType_FILE_CREATED, path: path, isDir: isDir})
+		if isDir && w.recursive {
+			w.addTree(path)
+		}
+
+	case mask&syscall.IN_DELETE != 0:
+		w.send(&fileChange{kind: filepb.FileChangeType_FILE_DELETED, path: path, isDir: isDir})
+
+	case mask&syscall.IN_MODIFY != 0:
+		w.send(&fileChange{kind: filepb.FileChangeType_FILE_MODIFIED, path: path})
+	}
+}
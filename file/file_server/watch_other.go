@@ -0,0 +1,13 @@
+//go:build !linux
+// +build !linux
+
+package main
+
+import "time"
+
+/**
+ * Watch a directory by polling it at interval.
+ */
+func (self *server) newDirWatcher(path string, recursive bool, interval time.Duration) (dirWatcher, error) {
+	return newPollWatcher(path, recursive, interval)
+}
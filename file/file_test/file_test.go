@@ -1,15 +1,30 @@
 package Globular
 
 import (
-	//"bytes"
+	"archive/tar"
+	"archive/zip"
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
 	"io"
+	"io/ioutil"
 	"log"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/davecourtois/Globular/file/filepb"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"testing"
 )
@@ -17,6 +32,7 @@ import (
 // Set the correct addresse here as needed.
 var (
 	addresse = "localhost:10011"
+	root     = "" // The Root of the service on this computer, use by the links tests.
 )
 
 /**
@@ -58,20 +74,16 @@ func _TestReadDir(t *testing.T) {
 		log.Fatalf("Query error %v", err)
 	}
 
-	// Here I will create the final array
-	data := make([]byte, 0)
 	for {
 		msg, err := stream.Recv()
 		if err == io.EOF {
 			// end of stream...
-			log.Println("----> ", string(data))
 			break
-		}
-
-		data = append(data, msg.Data...)
-		if err != nil {
+		} else if err != nil {
 			log.Fatalf("error while TestReadDir: %v", err)
 		}
+
+		log.Println("----> ", msg.Info.Path, msg.Info.Size, msg.Info.Mime)
 	}
 
 	log.Println("TestReadDir successed!")
@@ -100,19 +112,16 @@ func TestGetThumbnails(t *testing.T) {
 		log.Fatalf("Query error %v", err)
 	}
 
-	// Here I will create the final array
-	data := make([]byte, 0)
 	for {
 		msg, err := stream.Recv()
 		if err == io.EOF {
 			// end of stream...
 			break
+		} else if err != nil {
+			log.Fatalf("error while TestGetThumbnails: %v", err)
 		}
 
-		data = append(data, msg.Data...)
-		if err != nil {
-			log.Fatalf("error while TestReadDir: %v", err)
-		}
+		log.Println("----> ", msg.Info.Path, len(msg.Info.Thumbnail))
 	}
 
 	log.Println("TestReadDir successed!")
@@ -327,6 +336,992 @@ func TestSaveFile(t *testing.T) {
 	log.Println("save file succeed ", rsp.Result)
 }
 
+/**
+ * Save data in a file of the service, return the size of the file.
+ */
+func saveFile(c filepb.FileServiceClient, path string, data string, size int64, checksum []byte, mode filepb.SaveMode) (int64, error) {
+	stream, err := c.SaveFile(context.Background())
+	if err != nil {
+		return 0, err
+	}
+
+	stream.Send(&filepb.SaveFileRequest{
+		File:   &filepb.SaveFileRequest_Path{Path: path},
+		Size:   size,
+		Sha256: checksum,
+		Mode:   mode,
+	})
+
+	// The data are sent in small chunks.
+	for i := 0; i < len(data); i += 3 {
+		end := i + 3
+		if end > len(data) {
+			end = len(data)
+		}
+		stream.Send(&filepb.SaveFileRequest{File: &filepb.SaveFileRequest_Data{Data: []byte(data[i:end])}})
+	}
+
+	rsp, err := stream.CloseAndRecv()
+	if err != nil {
+		return 0, err
+	}
+
+	return rsp.Size, nil
+}
+
+/**
+ * Read a file of the service.
+ */
+func readFile(c filepb.FileServiceClient, path string) (string, error) {
+	stream, err := c.ReadFile(context.Background(), &filepb.ReadFileRequest{Path: path})
+	if err != nil {
+		return "", err
+	}
+
+	data := make([]byte, 0)
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			return string(data), nil
+		} else if err != nil {
+			return "", err
+		}
+		data = append(data, msg.Data...)
+	}
+}
+
+// The modes of save and the verification of the data.
+func TestSaveFileModes(t *testing.T) {
+	cc := getClientConnection()
+	defer cc.Close()
+
+	c := filepb.NewFileServiceClient(cc)
+	path := "/save_test.txt"
+	defer c.DeleteFile(context.Background(), &filepb.DeleteFileRequest{Path: path})
+
+	checksum := sha256.Sum256([]byte("hello"))
+	size, err := saveFile(c, path, "hello", 5, checksum[:], filepb.SaveMode_OVERWRITE)
+	if err != nil || size != 5 {
+		t.Fatalf("save fail: %v", err)
+	}
+
+	_, err = saveFile(c, path, "other", 0, nil, filepb.SaveMode_CREATE)
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("expected AlreadyExists, got %v", err)
+	}
+
+	size, err = saveFile(c, path, " world", 6, nil, filepb.SaveMode_APPEND)
+	if err != nil || size != 11 {
+		t.Fatalf("append fail: %v", err)
+	}
+
+	// The file is not change by a failed save.
+	_, err = saveFile(c, path, "too long", 3, nil, filepb.SaveMode_OVERWRITE)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+
+	_, err = saveFile(c, path, "short", 10, nil, filepb.SaveMode_OVERWRITE)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+
+	_, err = saveFile(c, path, "corrupted", 0, checksum[:], filepb.SaveMode_OVERWRITE)
+	if status.Code(err) != codes.DataLoss {
+		t.Errorf("expected DataLoss, got %v", err)
+	}
+
+	data, err := readFile(c, path)
+	if err != nil || data != "hello world" {
+		t.Fatalf("unexpected content %q: %v", data, err)
+	}
+
+	size, err = saveFile(c, path, "new", 0, nil, filepb.SaveMode_OVERWRITE)
+	if err != nil || size != 3 {
+		t.Fatalf("overwrite fail: %v", err)
+	}
+}
+
+// A part of a file is read with it size, time and checksum.
+func TestReadFileRange(t *testing.T) {
+	cc := getClientConnection()
+	defer cc.Close()
+
+	c := filepb.NewFileServiceClient(cc)
+	path := "/read_test.txt"
+	defer c.DeleteFile(context.Background(), &filepb.DeleteFileRequest{Path: path})
+
+	_, err := saveFile(c, path, "0123456789", 0, nil, filepb.SaveMode_OVERWRITE)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stream, err := c.ReadFile(context.Background(), &filepb.ReadFileRequest{Path: path, Offset: 2, Length: 5, ChunkSize: 2, Checksum: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checksum := sha256.Sum256([]byte("0123456789"))
+	data := make([]byte, 0)
+	for i := 0; ; i++ {
+		rsp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+
+		if i == 0 && (rsp.Size != 10 || rsp.ModTimeNano == 0 || string(rsp.Sha256) != string(checksum[:])) {
+			t.Fatalf("unexpected first message %v", rsp)
+		} else if len(rsp.Data) > 2 {
+			t.Fatalf("the chunk %q is bigger than the chunk size", rsp.Data)
+		}
+		data = append(data, rsp.Data...)
+	}
+
+	if string(data) != "23456" {
+		t.Fatalf("unexpected data %q", data)
+	}
+
+	stream, err = c.ReadFile(context.Background(), &filepb.ReadFileRequest{Path: path, Offset: 11})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.OutOfRange {
+		t.Errorf("expected OutOfRange, got %v", err)
+	}
+}
+
+/**
+ * Copy or move a path of the service, return the last progress.
+ */
+func transfer(c filepb.FileServiceClient, move bool, source string, destination string, conflict filepb.ConflictPolicy) (*filepb.TransferProgress, error) {
+	var progress *filepb.TransferProgress
+	var recv func() (*filepb.TransferProgress, error)
+	if move {
+		stream, err := c.Move(context.Background(), &filepb.MoveRequest{Source: source, Destination: destination, Conflict: conflict})
+		if err != nil {
+			return nil, err
+		}
+		recv = func() (*filepb.TransferProgress, error) {
+			rsp, err := stream.Recv()
+			return rsp.GetProgress(), err
+		}
+	} else {
+		stream, err := c.Copy(context.Background(), &filepb.CopyRequest{Source: source, Destination: destination, Conflict: conflict})
+		if err != nil {
+			return nil, err
+		}
+		recv = func() (*filepb.TransferProgress, error) {
+			rsp, err := stream.Recv()
+			return rsp.GetProgress(), err
+		}
+	}
+
+	for {
+		progress_, err := recv()
+		if err == io.EOF {
+			return progress, nil
+		} else if err != nil {
+			return nil, err
+		}
+		progress = progress_
+	}
+}
+
+// The copy and the move of directory trees with the conflict policies.
+func TestCopyMove(t *testing.T) {
+	cc := getClientConnection()
+	defer cc.Close()
+
+	c := filepb.NewFileServiceClient(cc)
+	ctx := context.Background()
+	c.DeleteDir(ctx, &filepb.DeleteDirRequest{Path: "/copy_test"})
+	defer c.DeleteDir(ctx, &filepb.DeleteDirRequest{Path: "/copy_test"})
+
+	c.CreateDir(ctx, &filepb.CreateDirRequest{Path: "/", Name: "copy_test"})
+	c.CreateDir(ctx, &filepb.CreateDirRequest{Path: "/copy_test", Name: "a"})
+	c.CreateDir(ctx, &filepb.CreateDirRequest{Path: "/copy_test/a", Name: "c"})
+	saveFile(c, "/copy_test/a/b.txt", "first", 0, nil, filepb.SaveMode_OVERWRITE)
+	saveFile(c, "/copy_test/a/c/d.txt", "tree", 0, nil, filepb.SaveMode_OVERWRITE)
+
+	progress, err := transfer(c, false, "/copy_test/a", "/copy_test/x", filepb.ConflictPolicy_FAIL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if progress.Files != 2 || progress.TotalFiles != 2 || progress.Size != 9 || progress.TotalSize != 9 {
+		t.Fatalf("unexpected progress %v", progress)
+	}
+	if data, _ := readFile(c, "/copy_test/x/c/d.txt"); data != "tree" {
+		t.Fatalf("unexpected copy %q", data)
+	}
+
+	_, err = transfer(c, false, "/copy_test/a", "/copy_test/x", filepb.ConflictPolicy_FAIL)
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("expected AlreadyExists, got %v", err)
+	}
+
+	progress, err = transfer(c, false, "/copy_test/a", "/copy_test/x", filepb.ConflictPolicy_RENAME)
+	if err != nil || progress.Destination != "/copy_test/x (1)" {
+		t.Fatalf("unexpected rename %v: %v", progress, err)
+	}
+
+	// The existing files are kept or replaced in the merged directories.
+	saveFile(c, "/copy_test/a/b.txt", "second", 0, nil, filepb.SaveMode_OVERWRITE)
+	progress, err = transfer(c, false, "/copy_test/a", "/copy_test/x", filepb.ConflictPolicy_SKIP)
+	if err != nil || progress.Skipped != 2 {
+		t.Fatalf("unexpected skip %v: %v", progress, err)
+	}
+	if data, _ := readFile(c, "/copy_test/x/b.txt"); data != "first" {
+		t.Fatalf("the file is not kept %q", data)
+	}
+
+	_, err = transfer(c, false, "/copy_test/a", "/copy_test/x", filepb.ConflictPolicy_REPLACE)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := readFile(c, "/copy_test/x/b.txt"); data != "second" {
+		t.Fatalf("the file is not replaced %q", data)
+	}
+
+	progress, err = transfer(c, true, "/copy_test/x", "/copy_test/a/c/y", filepb.ConflictPolicy_FAIL)
+	if err != nil || progress.Files != 2 {
+		t.Fatalf("unexpected move %v: %v", progress, err)
+	}
+	if _, err = c.GetFileInfo(ctx, &filepb.GetFileInfoRequest{Path: "/copy_test/x"}); err == nil {
+		t.Error("the source of the move still exist")
+	}
+
+	_, err = transfer(c, false, "/copy_test/a", "/copy_test/a/c/z", filepb.ConflictPolicy_FAIL)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+
+	_, err = transfer(c, true, "/copy_test/a", "/../outside", filepb.ConflictPolicy_FAIL)
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied, got %v", err)
+	}
+}
+
+// The changes made by the service are seen by the watch.
+func TestWatchDir(t *testing.T) {
+	cc := getClientConnection()
+	defer cc.Close()
+
+	c := filepb.NewFileServiceClient(cc)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c.DeleteDir(ctx, &filepb.DeleteDirRequest{Path: "/watch_test"})
+	c.CreateDir(ctx, &filepb.CreateDirRequest{Path: "/", Name: "watch_test"})
+	defer c.DeleteDir(context.Background(), &filepb.DeleteDirRequest{Path: "/watch_test"})
+
+	stream, err := c.WatchDir(ctx, &filepb.WatchDirRequest{Path: "/watch_test", Recursive: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The directory is watched after the first message.
+	_, err = stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Return the changes as a string, ex: FILE_CREATED /watch_test/sub
+	next := func() string {
+		rsp, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		changes := ""
+		for _, change := range rsp.Changes {
+			changes += change.Type.String() + " " + change.OldPath + " " + change.Path + ";"
+		}
+		return changes
+	}
+
+	c.CreateDir(ctx, &filepb.CreateDirRequest{Path: "/watch_test", Name: "sub"})
+	if changes := next(); changes != "FILE_CREATED  /watch_test/sub;" {
+		t.Fatalf("unexpected changes %q", changes)
+	}
+
+	saveFile(c, "/watch_test/sub/a.txt", "first", 0, nil, filepb.SaveMode_OVERWRITE)
+	if changes := next(); changes != "FILE_CREATED  /watch_test/sub/a.txt;" {
+		t.Fatalf("unexpected changes %q", changes)
+	}
+
+	saveFile(c, "/watch_test/sub/a.txt", "second", 0, nil, filepb.SaveMode_OVERWRITE)
+	if changes := next(); changes != "FILE_MODIFIED  /watch_test/sub/a.txt;" {
+		t.Fatalf("unexpected changes %q", changes)
+	}
+
+	c.Rename(ctx, &filepb.RenameRequest{Path: "/watch_test/sub", OldName: "a.txt", NewName: "b.txt"})
+	if changes := next(); changes != "FILE_RENAMED /watch_test/sub/a.txt /watch_test/sub/b.txt;" {
+		t.Fatalf("unexpected changes %q", changes)
+	}
+
+	c.DeleteDir(ctx, &filepb.DeleteDirRequest{Path: "/watch_test/sub"})
+	if changes := next(); changes != "FILE_DELETED  /watch_test/sub/b.txt;FILE_DELETED  /watch_test/sub;" {
+		t.Fatalf("unexpected changes %q", changes)
+	}
+}
+
+// Return the paths found by a search, ex: /search_test/a.txt:1;
+func search(c filepb.FileServiceClient, rqst *filepb.SearchRequest) (string, error) {
+	stream, err := c.Search(context.Background(), rqst)
+	if err != nil {
+		return "", err
+	}
+
+	found := ""
+	for {
+		rsp, err := stream.Recv()
+		if err == io.EOF {
+			return found, nil
+		} else if err != nil {
+			return "", err
+		}
+		found += rsp.Path
+		if rsp.Line > 0 {
+			found += ":" + strconv.Itoa(int(rsp.Line))
+		}
+		found += ";"
+	}
+}
+
+func TestSearch(t *testing.T) {
+	cc := getClientConnection()
+	defer cc.Close()
+
+	c := filepb.NewFileServiceClient(cc)
+	ctx := context.Background()
+
+	c.DeleteDir(ctx, &filepb.DeleteDirRequest{Path: "/search_test"})
+	c.CreateDir(ctx, &filepb.CreateDirRequest{Path: "/", Name: "search_test"})
+	c.CreateDir(ctx, &filepb.CreateDirRequest{Path: "/search_test", Name: "docs"})
+	defer c.DeleteDir(ctx, &filepb.DeleteDirRequest{Path: "/search_test"})
+
+	saveFile(c, "/search_test/a.txt", "first line\nThe Needle is here\n", 0, nil, filepb.SaveMode_OVERWRITE)
+	saveFile(c, "/search_test/docs/b.TXT", "no needle", 0, nil, filepb.SaveMode_OVERWRITE)
+	saveFile(c, "/search_test/docs/c.json", `{"needle": true, "size": "a bit longer"}`, 0, nil, filepb.SaveMode_OVERWRITE)
+
+	tests := []struct {
+		rqst  *filepb.SearchRequest
+		found string
+	}{
+		{&filepb.SearchRequest{Path: "/search_test", Name: "*.txt"}, "/search_test/a.txt;"},
+		{&filepb.SearchRequest{Path: "/search_test", Name: "*.txt", IgnoreCase: true}, "/search_test/a.txt;/search_test/docs/b.TXT;"},
+		{&filepb.SearchRequest{Path: "/search_test", Regex: "^[bc]\\."}, "/search_test/docs/b.TXT;/search_test/docs/c.json;"},
+		{&filepb.SearchRequest{Path: "/search_test", Name: "docs"}, "/search_test/docs;"},
+		{&filepb.SearchRequest{Path: "/search_test/docs", Mime: "text/"}, "/search_test/docs/b.TXT;/search_test/docs/c.json;"},
+		{&filepb.SearchRequest{Path: "/search_test", MinSize: 20}, "/search_test/a.txt;/search_test/docs/c.json;"},
+		{&filepb.SearchRequest{Path: "/search_test", MinSize: 20, MaxSize: 30}, "/search_test/a.txt;"},
+		{&filepb.SearchRequest{Path: "/search_test", ModifiedAfter: time.Now().Add(time.Hour).Unix()}, ""},
+		{&filepb.SearchRequest{Path: "/search_test", ModifiedBefore: time.Now().Add(time.Hour).Unix(), Limit: 2}, "/search_test/a.txt;/search_test/docs;"},
+		{&filepb.SearchRequest{Path: "/search_test", Content: "Needle"}, "/search_test/a.txt:2;"},
+		{&filepb.SearchRequest{Path: "/search_test", Content: "needle", IgnoreCase: true}, "/search_test/a.txt:2;/search_test/docs/b.TXT:1;/search_test/docs/c.json:1;"},
+	}
+
+	for _, test := range tests {
+		found, err := search(c, test.rqst)
+		if err != nil {
+			t.Fatal(test.rqst, err)
+		}
+		if found != test.found {
+			t.Fatalf("search %v found %q, %q expected", test.rqst, found, test.found)
+		}
+	}
+
+	// A line too long to be searched do not stop the search of the file, the
+	// file is written in the root as it is too big to be sent quickly.
+	if len(root) > 0 {
+		data := strings.Repeat("x", 2*1024*1024) + "\nthe needle\n"
+		ioutil.WriteFile(filepath.Join(root, "search_test", "docs", "long.txt"), []byte(data), 0644)
+		found, err := search(c, &filepb.SearchRequest{Path: "/search_test", Name: "long.txt", Content: "needle"})
+		if err != nil || found != "/search_test/docs/long.txt:2;" {
+			t.Fatalf("search after a long line found %q %v, %q expected", found, err, "/search_test/docs/long.txt:2;")
+		}
+	}
+
+	for _, rqst := range []*filepb.SearchRequest{{Path: "/search_test", Name: "["}, {Path: "/search_test", Regex: "("}, {Path: "/search_test/a.txt"}} {
+		if _, err := search(c, rqst); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("search %v return %v, InvalidArgument expected", rqst, err)
+		}
+	}
+}
+
+// Return the paths of a page of a listing, ex: /list_test/a.txt;
+func listDir(c filepb.FileServiceClient, rqst *filepb.ReadDirRequest) (string, string, error) {
+	stream, err := c.ReadDir(context.Background(), rqst)
+	if err != nil {
+		return "", "", err
+	}
+
+	paths := ""
+	for {
+		rsp, err := stream.Recv()
+		if err == io.EOF {
+			return paths, "", nil
+		} else if err != nil {
+			return "", "", err
+		}
+
+		if len(rsp.NextPageToken) > 0 {
+			return paths, rsp.NextPageToken, nil
+		}
+		paths += rsp.Info.Path + ";"
+	}
+}
+
+func TestReadDirPages(t *testing.T) {
+	cc := getClientConnection()
+	defer cc.Close()
+
+	c := filepb.NewFileServiceClient(cc)
+	ctx := context.Background()
+
+	c.DeleteDir(ctx, &filepb.DeleteDirRequest{Path: "/list_test"})
+	c.CreateDir(ctx, &filepb.CreateDirRequest{Path: "/", Name: "list_test"})
+	c.CreateDir(ctx, &filepb.CreateDirRequest{Path: "/list_test", Name: "b_dir"})
+	c.CreateDir(ctx, &filepb.CreateDirRequest{Path: "/list_test/b_dir", Name: "deep"})
+	defer c.DeleteDir(ctx, &filepb.DeleteDirRequest{Path: "/list_test"})
+
+	saveFile(c, "/list_test/a.txt", "0123456789", 0, nil, filepb.SaveMode_OVERWRITE)
+	saveFile(c, "/list_test/c.txt", "0", 0, nil, filepb.SaveMode_OVERWRITE)
+	saveFile(c, "/list_test/b_dir/x.txt", "012", 0, nil, filepb.SaveMode_OVERWRITE)
+	saveFile(c, "/list_test/b_dir/deep/y.txt", "01", 0, nil, filepb.SaveMode_OVERWRITE)
+
+	tests := []struct {
+		rqst  *filepb.ReadDirRequest
+		paths string
+	}{
+		{&filepb.ReadDirRequest{Path: "/list_test"}, "/list_test/a.txt;/list_test/b_dir;/list_test/c.txt;"},
+		{&filepb.ReadDirRequest{Path: "/list_test", Depth: 2}, "/list_test/a.txt;/list_test/b_dir;/list_test/b_dir/deep;/list_test/b_dir/x.txt;/list_test/c.txt;"},
+		{&filepb.ReadDirRequest{Path: "/list_test", Recursive: true}, "/list_test/a.txt;/list_test/b_dir;/list_test/b_dir/deep;/list_test/b_dir/deep/y.txt;/list_test/b_dir/x.txt;/list_test/c.txt;"},
+		{&filepb.ReadDirRequest{Path: "/list_test", Sort: filepb.SortOrder_SORT_BY_SIZE}, "/list_test/b_dir;/list_test/c.txt;/list_test/a.txt;"},
+		{&filepb.ReadDirRequest{Path: "/list_test", Sort: filepb.SortOrder_SORT_BY_TYPE}, "/list_test/b_dir;/list_test/a.txt;/list_test/c.txt;"},
+		{&filepb.ReadDirRequest{Path: "/list_test", Descending: true}, "/list_test/c.txt;/list_test/b_dir;/list_test/a.txt;"},
+	}
+
+	for _, test := range tests {
+		paths, token, err := listDir(c, test.rqst)
+		if err != nil {
+			t.Fatal(test.rqst, err)
+		}
+		if paths != test.paths || len(token) > 0 {
+			t.Fatalf("listing %v give %q, %q expected", test.rqst, paths, test.paths)
+		}
+	}
+
+	// The pages of a listing follow each other.
+	rqst := &filepb.ReadDirRequest{Path: "/list_test", Recursive: true, PageSize: 2}
+	pages := ""
+	for i := 0; i < 5; i++ {
+		paths, token, err := listDir(c, rqst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pages += paths + "|"
+		if len(token) == 0 {
+			break
+		}
+		rqst.PageToken = token
+	}
+	if pages != "/list_test/a.txt;/list_test/b_dir;|/list_test/b_dir/deep;/list_test/b_dir/deep/y.txt;|/list_test/b_dir/x.txt;/list_test/c.txt;|" {
+		t.Fatalf("unexpected pages %q", pages)
+	}
+
+	// The token is the one of the same listing.
+	_, token, err := listDir(c, &filepb.ReadDirRequest{Path: "/list_test", PageSize: 1})
+	if err != nil || len(token) == 0 {
+		t.Fatal("no next page", err)
+	}
+	_, _, err = listDir(c, &filepb.ReadDirRequest{Path: "/list_test", PageSize: 1, PageToken: token, Descending: true})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("a token of another listing return %v, InvalidArgument expected", err)
+	}
+
+	// The next page follow the last entry sent, even if it was removed.
+	c.DeleteFile(ctx, &filepb.DeleteFileRequest{Path: "/list_test/a.txt"})
+	paths, _, err := listDir(c, &filepb.ReadDirRequest{Path: "/list_test", PageSize: 1, PageToken: token})
+	if err != nil || paths != "/list_test/b_dir;" {
+		t.Fatalf("the page after a removed entry give %q %v, %q expected", paths, err, "/list_test/b_dir;")
+	}
+
+	if _, _, err = listDir(c, &filepb.ReadDirRequest{Path: "/list_test/c.txt"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("the listing of a file return %v, InvalidArgument expected", err)
+	}
+	if _, _, err = listDir(c, &filepb.ReadDirRequest{Path: "/list_test/none"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("the listing of a missing directory return %v, NotFound expected", err)
+	}
+
+	// An unreadable sub-directory is given with it error.
+	if len(root) == 0 || os.Geteuid() == 0 {
+		return
+	}
+	os.Chmod(filepath.Join(root, "list_test", "b_dir"), 0)
+	defer os.Chmod(filepath.Join(root, "list_test", "b_dir"), 0755)
+
+	stream, err := c.ReadDir(ctx, &filepb.ReadDirRequest{Path: "/list_test", Recursive: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for {
+		rsp, err := stream.Recv()
+		if err == io.EOF {
+			t.Fatal("the unreadable directory is not given")
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		if rsp.Info.Path == "/list_test/b_dir" {
+			if len(rsp.Info.Error) == 0 {
+				t.Fatal("the unreadable directory is given without error")
+			}
+			break
+		}
+	}
+}
+
+// Return a JPEG image of w x h with an EXIF orientation.
+func createJpeg(w int, h int, orientation byte) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for x := 0; x < w; x++ {
+		for y := 0; y < h; y++ {
+			img.Set(x, y, color.RGBA{uint8(x), uint8(y), 128, 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	jpeg.Encode(&buf, img, nil)
+	data := buf.Bytes()
+
+	// An APP1 segment with an IFD of one entry, the orientation, after SOI.
+	exif := []byte("Exif\x00\x00MM\x00\x2a\x00\x00\x00\x08\x00\x01\x01\x12\x00\x03\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x00")
+	exif[6+8+2+8+1] = orientation
+	app1 := append([]byte{0xFF, 0xE1, 0, byte(len(exif) + 2)}, exif...)
+
+	return append(append(data[:2:2], app1...), data[2:]...)
+}
+
+func TestGetThumbnail(t *testing.T) {
+	cc := getClientConnection()
+	defer cc.Close()
+
+	c := filepb.NewFileServiceClient(cc)
+	ctx := context.Background()
+
+	c.DeleteDir(ctx, &filepb.DeleteDirRequest{Path: "/thumbnail_test"})
+	c.CreateDir(ctx, &filepb.CreateDirRequest{Path: "/", Name: "thumbnail_test"})
+	defer c.DeleteDir(ctx, &filepb.DeleteDirRequest{Path: "/thumbnail_test"})
+
+	img := image.NewNRGBA(image.Rect(0, 0, 64, 32))
+	var buf bytes.Buffer
+	png.Encode(&buf, img)
+	saveFile(c, "/thumbnail_test/a.png", buf.String(), 0, nil, filepb.SaveMode_OVERWRITE)
+	saveFile(c, "/thumbnail_test/b.jpg", string(createJpeg(40, 20, 6)), 0, nil, filepb.SaveMode_OVERWRITE)
+	saveFile(c, "/thumbnail_test/c.txt", "not an image", 0, nil, filepb.SaveMode_OVERWRITE)
+
+	tests := []struct {
+		rqst          *filepb.GetThumbnailRequest
+		width, height int
+	}{
+		{&filepb.GetThumbnailRequest{Path: "/thumbnail_test/a.png", Width: 16, Height: 16}, 16, 8},
+		{&filepb.GetThumbnailRequest{Path: "/thumbnail_test/a.png", Width: 32, Height: 4}, 8, 4},
+		{&filepb.GetThumbnailRequest{Path: "/thumbnail_test/a.png"}, 64, 32}, // not zoomed
+		{&filepb.GetThumbnailRequest{Path: "/thumbnail_test/b.jpg", Width: 16, Height: 16}, 8, 16},
+	}
+
+	for _, test := range tests {
+		rsp, err := c.GetThumbnail(ctx, test.rqst)
+		if err != nil {
+			t.Fatal(test.rqst, err)
+		}
+
+		config, err := jpeg.DecodeConfig(bytes.NewReader(rsp.Data))
+		if err != nil {
+			t.Fatal(test.rqst, err)
+		}
+		if config.Width != test.width || config.Height != test.height || rsp.Width != int32(test.width) || rsp.Height != int32(test.height) {
+			t.Fatalf("the thumbnail of %v is %dx%d, %dx%d expected", test.rqst, config.Width, config.Height, test.width, test.height)
+		}
+
+		// The same thumbnail is given again.
+		rsp_, err := c.GetThumbnail(ctx, test.rqst)
+		if err != nil || !bytes.Equal(rsp.Data, rsp_.Data) {
+			t.Fatal("the thumbnail has changed", err)
+		}
+	}
+
+	if _, err := c.GetThumbnail(ctx, &filepb.GetThumbnailRequest{Path: "/thumbnail_test/c.txt"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("the thumbnail of a text return %v, InvalidArgument expected", err)
+	}
+	if _, err := c.GetThumbnail(ctx, &filepb.GetThumbnailRequest{Path: "/thumbnail_test/none.png"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("the thumbnail of a missing file return %v, NotFound expected", err)
+	}
+	if _, err := c.GetThumbnail(ctx, &filepb.GetThumbnailRequest{Path: "/thumbnail_test/a.png", Width: 100000}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("a too large thumbnail return %v, InvalidArgument expected", err)
+	}
+
+	// The thumbnail of a changed image is computed again.
+	saveFile(c, "/thumbnail_test/a.png", string(createJpeg(20, 40, 1)), 0, nil, filepb.SaveMode_OVERWRITE)
+	rsp, err := c.GetThumbnail(ctx, &filepb.GetThumbnailRequest{Path: "/thumbnail_test/a.png", Width: 16, Height: 16})
+	if err != nil || rsp.Width != 8 || rsp.Height != 16 {
+		t.Fatal("the thumbnail of the changed image is not computed again", rsp, err)
+	}
+}
+
+// Return the first message of a transform and the image received.
+func transformImage(c filepb.FileServiceClient, rqst *filepb.ImageTransformRequest) (*filepb.ImageTransformResponse, image.Image, error) {
+	stream, err := c.ImageTransform(context.Background(), rqst)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rsp, err := stream.Recv()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var buf bytes.Buffer
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, nil, err
+		}
+		buf.Write(msg.Data)
+	}
+
+	if buf.Len() == 0 {
+		return rsp, nil, nil
+	}
+
+	img, _, err := image.Decode(&buf)
+	return rsp, img, err
+}
+
+func TestImageTransform(t *testing.T) {
+	cc := getClientConnection()
+	defer cc.Close()
+
+	c := filepb.NewFileServiceClient(cc)
+	ctx := context.Background()
+
+	c.DeleteDir(ctx, &filepb.DeleteDirRequest{Path: "/image_test"})
+	c.CreateDir(ctx, &filepb.CreateDirRequest{Path: "/", Name: "image_test"})
+	defer c.DeleteDir(ctx, &filepb.DeleteDirRequest{Path: "/image_test"})
+
+	// Red at the left, blue at the right.
+	img := image.NewRGBA(image.Rect(0, 0, 64, 32))
+	for x := 0; x < 64; x++ {
+		for y := 0; y < 32; y++ {
+			if x < 32 {
+				img.Set(x, y, color.RGBA{255, 0, 0, 255})
+			} else {
+				img.Set(x, y, color.RGBA{0, 0, 255, 255})
+			}
+		}
+	}
+	var buf bytes.Buffer
+	png.Encode(&buf, img)
+	saveFile(c, "/image_test/a.png", buf.String(), 0, nil, filepb.SaveMode_OVERWRITE)
+	saveFile(c, "/image_test/b.txt", "not an image", 0, nil, filepb.SaveMode_OVERWRITE)
+
+	isRed := func(img image.Image, x int, y int) bool {
+		r, _, b, _ := img.At(x, y).RGBA()
+		return r > 0xc000 && b < 0x4000
+	}
+
+	resize := func(width int32, height int32, mode filepb.ResizeMode) []*filepb.ImageOperation {
+		return []*filepb.ImageOperation{{Operation: &filepb.ImageOperation_Resize{Resize: &filepb.ResizeOperation{Width: width, Height: height, Mode: mode}}}}
+	}
+
+	tests := []struct {
+		rqst          *filepb.ImageTransformRequest
+		mime          string
+		width, height int
+	}{
+		{&filepb.ImageTransformRequest{Path: "/image_test/a.png", Operations: resize(16, 16, filepb.ResizeMode_RESIZE_FIT)}, "image/png", 16, 8},
+		{&filepb.ImageTransformRequest{Path: "/image_test/a.png", Operations: resize(32, 0, filepb.ResizeMode_RESIZE_FIT)}, "image/png", 32, 16},
+		{&filepb.ImageTransformRequest{Path: "/image_test/a.png", Operations: resize(16, 16, filepb.ResizeMode_RESIZE_FILL)}, "image/png", 16, 16},
+		{&filepb.ImageTransformRequest{Path: "/image_test/a.png", Operations: resize(10, 40, filepb.ResizeMode_RESIZE_STRETCH)}, "image/png", 10, 40},
+		{&filepb.ImageTransformRequest{Path: "/image_test/a.png", Format: filepb.ImageFormat_JPEG, Quality: 50}, "image/jpeg", 64, 32},
+		{&filepb.ImageTransformRequest{Path: "/image_test/a.png", Format: filepb.ImageFormat_GIF}, "image/gif", 64, 32},
+	}
+
+	for _, test := range tests {
+		rsp, img, err := transformImage(c, test.rqst)
+		if err != nil {
+			t.Fatal(test.rqst, err)
+		}
+		if rsp.Mime != test.mime || img.Bounds().Dx() != test.width || img.Bounds().Dy() != test.height || rsp.Width != int32(test.width) || rsp.Height != int32(test.height) {
+			t.Fatalf("%v give a %s of %dx%d, a %s of %dx%d expected", test.rqst, rsp.Mime, img.Bounds().Dx(), img.Bounds().Dy(), test.mime, test.width, test.height)
+		}
+	}
+
+	// The operations are done in order.
+	_, img_, err := transformImage(c, &filepb.ImageTransformRequest{Path: "/image_test/a.png", Operations: []*filepb.ImageOperation{
+		{Operation: &filepb.ImageOperation_Crop{Crop: &filepb.CropOperation{X: 16, Y: 0, Width: 32, Height: 16}}},
+		{Operation: &filepb.ImageOperation_Rotate{Rotate: 90}},
+		{Operation: &filepb.ImageOperation_Flip{Flip: filepb.FlipDirection_FLIP_VERTICAL}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img_.Bounds().Dx() != 16 || img_.Bounds().Dy() != 32 || isRed(img_, 8, 4) || !isRed(img_, 8, 28) {
+		t.Fatal("the crop, rotation and flip are not done in order")
+	}
+
+	_, img_, err = transformImage(c, &filepb.ImageTransformRequest{Path: "/image_test/a.png", Operations: []*filepb.ImageOperation{{Operation: &filepb.ImageOperation_Grayscale{Grayscale: true}}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, g, b, _ := img_.At(10, 10).RGBA(); r != g || g != b {
+		t.Fatal("the image is not gray")
+	}
+
+	// The image is saved at the destination.
+	rsp, _, err := transformImage(c, &filepb.ImageTransformRequest{Path: "/image_test/a.png", Operations: resize(16, 16, filepb.ResizeMode_RESIZE_FIT), Destination: "/image_test/small.jpg"})
+	if err != nil || rsp.Path != "/image_test/small.jpg" || rsp.Mime != "image/jpeg" {
+		t.Fatal("the image is not saved", rsp, err)
+	}
+	data, err := readFile(c, "/image_test/small.jpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config, err := jpeg.DecodeConfig(bytes.NewReader([]byte(data))); err != nil || config.Width != 16 || config.Height != 8 {
+		t.Fatal("the saved image is not valid", err)
+	}
+
+	_, _, err = transformImage(c, &filepb.ImageTransformRequest{Path: "/image_test/a.png", Destination: "/image_test/small.jpg"})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("the save over an image return %v, AlreadyExists expected", err)
+	}
+	rsp, _, err = transformImage(c, &filepb.ImageTransformRequest{Path: "/image_test/a.png", Destination: "/image_test/small.jpg", Conflict: filepb.ConflictPolicy_RENAME})
+	if err != nil || rsp.Path != "/image_test/small (1).jpg" {
+		t.Fatal("the image is not renamed", rsp, err)
+	}
+
+	invalids := []*filepb.ImageTransformRequest{
+		{Path: "/image_test/b.txt"},
+		{Path: "/image_test/a.png", Quality: 101},
+		{Path: "/image_test/a.png", Operations: []*filepb.ImageOperation{{Operation: &filepb.ImageOperation_Rotate{Rotate: 45}}}},
+		{Path: "/image_test/a.png", Operations: []*filepb.ImageOperation{{Operation: &filepb.ImageOperation_Crop{Crop: &filepb.CropOperation{X: 60, Y: 0, Width: 10, Height: 10}}}}},
+		{Path: "/image_test/a.png", Operations: resize(0, 0, filepb.ResizeMode_RESIZE_FIT)},
+		{Path: "/image_test/a.png", Operations: resize(100000, 100000, filepb.ResizeMode_RESIZE_STRETCH)},
+	}
+	for _, rqst := range invalids {
+		if _, _, err := transformImage(c, rqst); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("%v return %v, InvalidArgument expected", rqst, err)
+		}
+	}
+}
+
+/**
+ * Create an archive of the service, it data or the progress if it is saved.
+ */
+func createArchive(c filepb.FileServiceClient, rqst *filepb.CreateArchiveRequest) ([]byte, *filepb.TransferProgress, error) {
+	stream, err := c.CreateArchive(context.Background(), rqst)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var data []byte
+	var progress *filepb.TransferProgress
+	for {
+		rsp, err := stream.Recv()
+		if err == io.EOF {
+			return data, progress, nil
+		} else if err != nil {
+			return nil, nil, err
+		}
+		data = append(data, rsp.Data...)
+		if rsp.Progress != nil {
+			progress = rsp.Progress
+		}
+	}
+}
+
+/**
+ * Extract an archive of the service, return the last progress.
+ */
+func extractArchive(c filepb.FileServiceClient, rqst *filepb.ExtractArchiveRequest) (*filepb.TransferProgress, error) {
+	stream, err := c.ExtractArchive(context.Background(), rqst)
+	if err != nil {
+		return nil, err
+	}
+
+	var progress *filepb.TransferProgress
+	for {
+		rsp, err := stream.Recv()
+		if err == io.EOF {
+			return progress, nil
+		} else if err != nil {
+			return nil, err
+		}
+		progress = rsp.Progress
+	}
+}
+
+/**
+ * Return the names and the sizes of the entries of an archive of the service.
+ */
+func listArchive(c filepb.FileServiceClient, path string) (string, error) {
+	stream, err := c.ListArchive(context.Background(), &filepb.ListArchiveRequest{Path: path})
+	if err != nil {
+		return "", err
+	}
+
+	list := ""
+	for {
+		rsp, err := stream.Recv()
+		if err == io.EOF {
+			return list, nil
+		} else if err != nil {
+			return "", err
+		}
+		list += rsp.Entry.Name + ":" + strconv.FormatInt(rsp.Entry.Size, 10) + " "
+	}
+}
+
+/**
+ * Create a tar archive, the entries with a target are symbolic links.
+ */
+func createTar(entries ...[2]string) string {
+	var buf bytes.Buffer
+	w := tar.NewWriter(&buf)
+	for _, entry := range entries {
+		if len(entry[1]) > 0 {
+			w.WriteHeader(&tar.Header{Name: entry[0], Typeflag: tar.TypeSymlink, Linkname: entry[1], Mode: 0777})
+		} else {
+			w.WriteHeader(&tar.Header{Name: entry[0], Typeflag: tar.TypeReg, Size: int64(len(entry[0])), Mode: 0644})
+			w.Write([]byte(entry[0]))
+		}
+	}
+	w.Close()
+
+	return buf.String()
+}
+
+// The archives created, listed and extracted, with the entries that lead out
+// of the destination and the limits.
+func TestArchive(t *testing.T) {
+	cc := getClientConnection()
+	defer cc.Close()
+
+	c := filepb.NewFileServiceClient(cc)
+	ctx := context.Background()
+	c.DeleteDir(ctx, &filepb.DeleteDirRequest{Path: "/archive_test"})
+	defer c.DeleteDir(ctx, &filepb.DeleteDirRequest{Path: "/archive_test"})
+
+	c.CreateDir(ctx, &filepb.CreateDirRequest{Path: "/", Name: "archive_test"})
+	c.CreateDir(ctx, &filepb.CreateDirRequest{Path: "/archive_test", Name: "a"})
+	c.CreateDir(ctx, &filepb.CreateDirRequest{Path: "/archive_test/a", Name: "c"})
+	saveFile(c, "/archive_test/a/b.txt", "first", 0, nil, filepb.SaveMode_OVERWRITE)
+	saveFile(c, "/archive_test/a/c/d.txt", "tree", 0, nil, filepb.SaveMode_OVERWRITE)
+
+	// A streamed zip.
+	data, _, err := createArchive(c, &filepb.CreateArchiveRequest{Paths: []string{"/archive_test/a"}, Format: filepb.ArchiveFormat_ZIP})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := ""
+	for _, f := range r.File {
+		names += f.Name + " "
+	}
+	if names != "a/ a/b.txt a/c/ a/c/d.txt " {
+		t.Fatalf("unexpected entries %q", names)
+	}
+
+	// The archives saved in Root.
+	for _, format := range []filepb.ArchiveFormat{filepb.ArchiveFormat_ZIP, filepb.ArchiveFormat_TAR_GZ} {
+		dst := "/archive_test/a.zip"
+		if format == filepb.ArchiveFormat_TAR_GZ {
+			dst = "/archive_test/a.tar.gz"
+		}
+		_, progress, err := createArchive(c, &filepb.CreateArchiveRequest{Paths: []string{"/archive_test/a"}, Format: format, Destination: dst})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if progress.Destination != dst || progress.Files != 2 || progress.Size != 9 {
+			t.Fatalf("unexpected progress %v", progress)
+		}
+
+		list, err := listArchive(c, dst)
+		if err != nil || list != "a/:0 a/b.txt:5 a/c/:0 a/c/d.txt:4 " {
+			t.Fatalf("unexpected list %q of %s: %v", list, dst, err)
+		}
+	}
+
+	_, _, err = createArchive(c, &filepb.CreateArchiveRequest{Paths: []string{"/archive_test/a"}, Destination: "/archive_test/a.zip"})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("expected AlreadyExists, got %v", err)
+	}
+
+	progress, err := extractArchive(c, &filepb.ExtractArchiveRequest{Path: "/archive_test/a.tar.gz", Destination: "/archive_test/x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if progress.Files != 2 || progress.TotalFiles != 2 || progress.Size != 9 || progress.Destination != "/archive_test/x" {
+		t.Fatalf("unexpected progress %v", progress)
+	}
+	if data, _ := readFile(c, "/archive_test/x/a/c/d.txt"); data != "tree" {
+		t.Fatalf("unexpected extraction %q", data)
+	}
+
+	// The existing files fail before anything is written, or are kept.
+	_, err = extractArchive(c, &filepb.ExtractArchiveRequest{Path: "/archive_test/a.zip", Destination: "/archive_test/x"})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("expected AlreadyExists, got %v", err)
+	}
+	saveFile(c, "/archive_test/x/a/b.txt", "second", 0, nil, filepb.SaveMode_OVERWRITE)
+	progress, err = extractArchive(c, &filepb.ExtractArchiveRequest{Path: "/archive_test/a.zip", Destination: "/archive_test/x", Conflict: filepb.ConflictPolicy_SKIP})
+	if err != nil || progress.Skipped != 2 {
+		t.Fatalf("unexpected skip %v: %v", progress, err)
+	}
+	if data, _ := readFile(c, "/archive_test/x/a/b.txt"); data != "second" {
+		t.Fatalf("the file is not kept %q", data)
+	}
+
+	// The entries that lead out of the destination.
+	saveFile(c, "/archive_test/slip.tar", createTar([2]string{"ok.txt"}, [2]string{"../slip.txt"}), 0, nil, filepb.SaveMode_OVERWRITE)
+	_, err = extractArchive(c, &filepb.ExtractArchiveRequest{Path: "/archive_test/slip.tar", Destination: "/archive_test/y"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+
+	saveFile(c, "/archive_test/link.tar", createTar([2]string{"ok.txt"}, [2]string{"l", ".."}, [2]string{"l/link.txt"}), 0, nil, filepb.SaveMode_OVERWRITE)
+	_, err = extractArchive(c, &filepb.ExtractArchiveRequest{Path: "/archive_test/link.tar", Destination: "/archive_test/y"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied, got %v", err)
+	}
+	for _, path := range []string{"/archive_test/y", "/archive_test/link.txt", "/archive_test/slip.txt"} {
+		if _, err = c.GetFileInfo(ctx, &filepb.GetFileInfoRequest{Path: path}); status.Code(err) != codes.NotFound {
+			t.Errorf("%s: expected NotFound, got %v", path, err)
+		}
+	}
+
+	// The limits.
+	_, err = extractArchive(c, &filepb.ExtractArchiveRequest{Path: "/archive_test/a.zip", Destination: "/archive_test/z", MaxSize: 8})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("expected ResourceExhausted, got %v", err)
+	}
+	_, err = extractArchive(c, &filepb.ExtractArchiveRequest{Path: "/archive_test/a.zip", Destination: "/archive_test/z", MaxFiles: 3})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("expected ResourceExhausted, got %v", err)
+	}
+
+	if _, err = listArchive(c, "/archive_test/a/b.txt"); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
+
 // Test delete file on the server
 func TestDeleteFile(t *testing.T) {
 	fmt.Println("Get File info test")
@@ -349,3 +1344,166 @@ func TestDeleteFile(t *testing.T) {
 
 	log.Println("Delete file succeed:", rsp.Result)
 }
+
+// The paths that try to go out of the Root.
+var hostilePaths = []string{
+	"../../etc/passwd",
+	"/../../etc/passwd",
+	"..",
+	"/photos/../../..",
+	"..\\..\\Windows\\win.ini",
+	"\\..\\..\\etc",
+}
+
+/**
+ * Call every operations of the service on a path and return their errors.
+ */
+func callAll(c filepb.FileServiceClient, path string) map[string]error {
+	errs := make(map[string]error, 0)
+	ctx := context.Background()
+
+	readDir, err := c.ReadDir(ctx, &filepb.ReadDirRequest{Path: path})
+	if err == nil {
+		_, err = readDir.Recv()
+	}
+	errs["ReadDir"] = err
+
+	_, errs["CreateDir"] = c.CreateDir(ctx, &filepb.CreateDirRequest{Path: path, Name: "test"})
+	_, errs["Rename"] = c.Rename(ctx, &filepb.RenameRequest{Path: path, OldName: "a", NewName: "b"})
+	_, errs["DeleteDir"] = c.DeleteDir(ctx, &filepb.DeleteDirRequest{Path: path})
+	_, errs["GetFileInfo"] = c.GetFileInfo(ctx, &filepb.GetFileInfoRequest{Path: path})
+	_, errs["DeleteFile"] = c.DeleteFile(ctx, &filepb.DeleteFileRequest{Path: path})
+
+	readFile, err := c.ReadFile(ctx, &filepb.ReadFileRequest{Path: path})
+	if err == nil {
+		_, err = readFile.Recv()
+	}
+	errs["ReadFile"] = err
+
+	thumbnails, err := c.GetThumbnails(ctx, &filepb.GetThumbnailsRequest{Path: path})
+	if err == nil {
+		_, err = thumbnails.Recv()
+	}
+	errs["GetThumbnails"] = err
+
+	saveFile, err := c.SaveFile(ctx)
+	if err == nil {
+		saveFile.Send(&filepb.SaveFileRequest{File: &filepb.SaveFileRequest_Path{Path: path}})
+		saveFile.Send(&filepb.SaveFileRequest{File: &filepb.SaveFileRequest_Data{Data: []byte("test")}})
+		_, err = saveFile.CloseAndRecv()
+	}
+	errs["SaveFile"] = err
+
+	createArchive, err := c.CreateArchive(ctx, &filepb.CreateArchiveRequest{Paths: []string{path}})
+	if err == nil {
+		_, err = createArchive.Recv()
+	}
+	errs["CreateArchive"] = err
+
+	extractArchive, err := c.ExtractArchive(ctx, &filepb.ExtractArchiveRequest{Path: "/archive.zip", Destination: path})
+	if err == nil {
+		_, err = extractArchive.Recv()
+	}
+	errs["ExtractArchive"] = err
+
+	listArchive, err := c.ListArchive(ctx, &filepb.ListArchiveRequest{Path: path})
+	if err == nil {
+		_, err = listArchive.Recv()
+	}
+	errs["ListArchive"] = err
+
+	return errs
+}
+
+/**
+ * Fail for every operation that was not refused with PermissionDenied.
+ */
+func expectDenied(t *testing.T, path string, errs map[string]error) {
+	for operation, err := range errs {
+		if status.Code(err) != codes.PermissionDenied {
+			t.Errorf("%s %q: expected PermissionDenied, got %v", operation, path, err)
+		}
+	}
+}
+
+// The paths out of the Root are refused by every operations.
+func TestHostilePaths(t *testing.T) {
+	cc := getClientConnection()
+	defer cc.Close()
+
+	c := filepb.NewFileServiceClient(cc)
+	for _, path := range hostilePaths {
+		expectDenied(t, path, callAll(c, path))
+	}
+
+	// A name can not go out of it directory.
+	_, err := c.CreateDir(context.Background(), &filepb.CreateDirRequest{Path: "/", Name: "../../tmp/test"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("CreateDir: expected PermissionDenied, got %v", err)
+	}
+
+	_, err = c.Rename(context.Background(), &filepb.RenameRequest{Path: "/", OldName: "test", NewName: "../../tmp/test"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Rename: expected PermissionDenied, got %v", err)
+	}
+
+	// The Root itself can not be deleted.
+	for _, path := range []string{"", "/", ".", "/photos/.."} {
+		_, err = c.DeleteDir(context.Background(), &filepb.DeleteDirRequest{Path: path})
+		if status.Code(err) != codes.PermissionDenied {
+			t.Errorf("DeleteDir %q: expected PermissionDenied, got %v", path, err)
+		}
+	}
+
+	// A null character is refused.
+	_, err = c.GetFileInfo(context.Background(), &filepb.GetFileInfoRequest{Path: "/test\x00.txt"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetFileInfo: expected InvalidArgument, got %v", err)
+	}
+}
+
+// The symbolic links to files out of the Root are refused.
+func TestHostileLinks(t *testing.T) {
+	if len(root) == 0 {
+		t.Skip("the root of the service is not set")
+	}
+
+	outside, err := ioutil.TempDir("", "file_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(outside)
+
+	err = ioutil.WriteFile(filepath.Join(outside, "secret.txt"), []byte("secret"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	links := map[string]string{
+		"link_dir":    outside,
+		"link_file":   filepath.Join(outside, "secret.txt"),
+		"link_broken": filepath.Join(outside, "missing.txt"),
+	}
+
+	for name, target := range links {
+		err = os.Symlink(target, filepath.Join(root, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(filepath.Join(root, name))
+	}
+
+	cc := getClientConnection()
+	defer cc.Close()
+
+	c := filepb.NewFileServiceClient(cc)
+	for _, path := range []string{"/link_dir", "/link_dir/secret.txt", "/link_dir/new/test.txt", "/link_file", "/link_broken"} {
+		expectDenied(t, path, callAll(c, path))
+	}
+
+	// The target of the broken link is not created.
+	_, err = os.Stat(filepath.Join(outside, "missing.txt"))
+	if !os.IsNotExist(err) {
+		t.Error("a file was created out of the root")
+	}
+}
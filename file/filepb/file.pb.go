@@ -8,6 +8,8 @@ import (
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
 	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
 	math "math"
 )
 
@@ -22,6 +24,242 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+// The order of the entries of the directories, they are sorted by name when
+// the order is equal.
+type SortOrder int32
+
+const (
+	SortOrder_SORT_BY_NAME SortOrder = 0
+	SortOrder_SORT_BY_SIZE SortOrder = 1
+	SortOrder_SORT_BY_TIME SortOrder = 2
+	SortOrder_SORT_BY_TYPE SortOrder = 3
+)
+
+var SortOrder_name = map[int32]string{
+	0: "SORT_BY_NAME",
+	1: "SORT_BY_SIZE",
+	2: "SORT_BY_TIME",
+	3: "SORT_BY_TYPE",
+}
+
+var SortOrder_value = map[string]int32{
+	"SORT_BY_NAME": 0,
+	"SORT_BY_SIZE": 1,
+	"SORT_BY_TIME": 2,
+	"SORT_BY_TYPE": 3,
+}
+
+func (x SortOrder) String() string {
+	return proto.EnumName(SortOrder_name, int32(x))
+}
+
+func (SortOrder) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{0}
+}
+
+// What is done when the destination of a copy or a move exist.
+type ConflictPolicy int32
+
+const (
+	ConflictPolicy_FAIL    ConflictPolicy = 0
+	ConflictPolicy_REPLACE ConflictPolicy = 1
+	ConflictPolicy_SKIP    ConflictPolicy = 2
+	ConflictPolicy_RENAME  ConflictPolicy = 3
+)
+
+var ConflictPolicy_name = map[int32]string{
+	0: "FAIL",
+	1: "REPLACE",
+	2: "SKIP",
+	3: "RENAME",
+}
+
+var ConflictPolicy_value = map[string]int32{
+	"FAIL":    0,
+	"REPLACE": 1,
+	"SKIP":    2,
+	"RENAME":  3,
+}
+
+func (x ConflictPolicy) String() string {
+	return proto.EnumName(ConflictPolicy_name, int32(x))
+}
+
+func (ConflictPolicy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{1}
+}
+
+// The type of a change of a file.
+type FileChangeType int32
+
+const (
+	FileChangeType_FILE_CREATED  FileChangeType = 0
+	FileChangeType_FILE_MODIFIED FileChangeType = 1
+	FileChangeType_FILE_DELETED  FileChangeType = 2
+	FileChangeType_FILE_RENAMED  FileChangeType = 3
+)
+
+var FileChangeType_name = map[int32]string{
+	0: "FILE_CREATED",
+	1: "FILE_MODIFIED",
+	2: "FILE_DELETED",
+	3: "FILE_RENAMED",
+}
+
+var FileChangeType_value = map[string]int32{
+	"FILE_CREATED":  0,
+	"FILE_MODIFIED": 1,
+	"FILE_DELETED":  2,
+	"FILE_RENAMED":  3,
+}
+
+func (x FileChangeType) String() string {
+	return proto.EnumName(FileChangeType_name, int32(x))
+}
+
+func (FileChangeType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{2}
+}
+
+// How a saved file is write over an existing one.
+type SaveMode int32
+
+const (
+	SaveMode_OVERWRITE SaveMode = 0
+	SaveMode_CREATE    SaveMode = 1
+	SaveMode_APPEND    SaveMode = 2
+)
+
+var SaveMode_name = map[int32]string{
+	0: "OVERWRITE",
+	1: "CREATE",
+	2: "APPEND",
+}
+
+var SaveMode_value = map[string]int32{
+	"OVERWRITE": 0,
+	"CREATE":    1,
+	"APPEND":    2,
+}
+
+func (x SaveMode) String() string {
+	return proto.EnumName(SaveMode_name, int32(x))
+}
+
+func (SaveMode) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{3}
+}
+
+// How an image is resized.
+type ResizeMode int32
+
+const (
+	ResizeMode_RESIZE_FIT     ResizeMode = 0
+	ResizeMode_RESIZE_FILL    ResizeMode = 1
+	ResizeMode_RESIZE_STRETCH ResizeMode = 2
+)
+
+var ResizeMode_name = map[int32]string{
+	0: "RESIZE_FIT",
+	1: "RESIZE_FILL",
+	2: "RESIZE_STRETCH",
+}
+
+var ResizeMode_value = map[string]int32{
+	"RESIZE_FIT":     0,
+	"RESIZE_FILL":    1,
+	"RESIZE_STRETCH": 2,
+}
+
+func (x ResizeMode) String() string {
+	return proto.EnumName(ResizeMode_name, int32(x))
+}
+
+func (ResizeMode) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{4}
+}
+
+type FlipDirection int32
+
+const (
+	FlipDirection_FLIP_HORIZONTAL FlipDirection = 0
+	FlipDirection_FLIP_VERTICAL   FlipDirection = 1
+)
+
+var FlipDirection_name = map[int32]string{
+	0: "FLIP_HORIZONTAL",
+	1: "FLIP_VERTICAL",
+}
+
+var FlipDirection_value = map[string]int32{
+	"FLIP_HORIZONTAL": 0,
+	"FLIP_VERTICAL":   1,
+}
+
+func (x FlipDirection) String() string {
+	return proto.EnumName(FlipDirection_name, int32(x))
+}
+
+func (FlipDirection) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{5}
+}
+
+type ImageFormat int32
+
+const (
+	ImageFormat_ORIGINAL_FORMAT ImageFormat = 0
+	ImageFormat_JPEG            ImageFormat = 1
+	ImageFormat_PNG             ImageFormat = 2
+	ImageFormat_GIF             ImageFormat = 3
+)
+
+var ImageFormat_name = map[int32]string{
+	0: "ORIGINAL_FORMAT",
+	1: "JPEG",
+	2: "PNG",
+	3: "GIF",
+}
+
+var ImageFormat_value = map[string]int32{
+	"ORIGINAL_FORMAT": 0,
+	"JPEG":            1,
+	"PNG":             2,
+	"GIF":             3,
+}
+
+func (x ImageFormat) String() string {
+	return proto.EnumName(ImageFormat_name, int32(x))
+}
+
+func (ImageFormat) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{6}
+}
+
+type ArchiveFormat int32
+
+const (
+	ArchiveFormat_ZIP    ArchiveFormat = 0
+	ArchiveFormat_TAR_GZ ArchiveFormat = 1
+)
+
+var ArchiveFormat_name = map[int32]string{
+	0: "ZIP",
+	1: "TAR_GZ",
+}
+
+var ArchiveFormat_value = map[string]int32{
+	"ZIP":    0,
+	"TAR_GZ": 1,
+}
+
+func (x ArchiveFormat) String() string {
+	return proto.EnumName(ArchiveFormat_name, int32(x))
+}
+
+func (ArchiveFormat) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{7}
+}
+
 type Empty struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -53,21 +291,139 @@ func (m *Empty) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Empty proto.InternalMessageInfo
 
-type ReadDirRequest struct {
-	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
-	Recursive            bool     `protobuf:"varint,2,opt,name=recursive,proto3" json:"recursive,omitempty"`
-	ThumnailWidth        int32    `protobuf:"varint,3,opt,name=thumnailWidth,proto3" json:"thumnailWidth,omitempty"`
-	ThumnailHeight       int32    `protobuf:"varint,4,opt,name=thumnailHeight,proto3" json:"thumnailHeight,omitempty"`
+// A file or a directory of a listing.
+type FileInfo struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Path                 string   `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Size                 int64    `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Mode                 uint32   `protobuf:"varint,4,opt,name=mode,proto3" json:"mode,omitempty"`
+	ModTime              int64    `protobuf:"varint,5,opt,name=modTime,proto3" json:"modTime,omitempty"`
+	IsDir                bool     `protobuf:"varint,6,opt,name=isDir,proto3" json:"isDir,omitempty"`
+	Mime                 string   `protobuf:"bytes,7,opt,name=mime,proto3" json:"mime,omitempty"`
+	Thumbnail            string   `protobuf:"bytes,8,opt,name=thumbnail,proto3" json:"thumbnail,omitempty"`
+	Depth                int32    `protobuf:"varint,9,opt,name=depth,proto3" json:"depth,omitempty"`
+	Error                string   `protobuf:"bytes,10,opt,name=error,proto3" json:"error,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
+func (m *FileInfo) Reset()         { *m = FileInfo{} }
+func (m *FileInfo) String() string { return proto.CompactTextString(m) }
+func (*FileInfo) ProtoMessage()    {}
+func (*FileInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{1}
+}
+
+func (m *FileInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileInfo.Unmarshal(m, b)
+}
+func (m *FileInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileInfo.Marshal(b, m, deterministic)
+}
+func (m *FileInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileInfo.Merge(m, src)
+}
+func (m *FileInfo) XXX_Size() int {
+	return xxx_messageInfo_FileInfo.Size(m)
+}
+func (m *FileInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileInfo proto.InternalMessageInfo
+
+func (m *FileInfo) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *FileInfo) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *FileInfo) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *FileInfo) GetMode() uint32 {
+	if m != nil {
+		return m.Mode
+	}
+	return 0
+}
+
+func (m *FileInfo) GetModTime() int64 {
+	if m != nil {
+		return m.ModTime
+	}
+	return 0
+}
+
+func (m *FileInfo) GetIsDir() bool {
+	if m != nil {
+		return m.IsDir
+	}
+	return false
+}
+
+func (m *FileInfo) GetMime() string {
+	if m != nil {
+		return m.Mime
+	}
+	return ""
+}
+
+func (m *FileInfo) GetThumbnail() string {
+	if m != nil {
+		return m.Thumbnail
+	}
+	return ""
+}
+
+func (m *FileInfo) GetDepth() int32 {
+	if m != nil {
+		return m.Depth
+	}
+	return 0
+}
+
+func (m *FileInfo) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+// The entries of a sub-directory follow it, in the same order.
+type ReadDirRequest struct {
+	Path                 string    `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Recursive            bool      `protobuf:"varint,2,opt,name=recursive,proto3" json:"recursive,omitempty"`
+	ThumnailWidth        int32     `protobuf:"varint,3,opt,name=thumnailWidth,proto3" json:"thumnailWidth,omitempty"`
+	ThumnailHeight       int32     `protobuf:"varint,4,opt,name=thumnailHeight,proto3" json:"thumnailHeight,omitempty"`
+	Sort                 SortOrder `protobuf:"varint,5,opt,name=sort,proto3,enum=file.SortOrder" json:"sort,omitempty"`
+	Descending           bool      `protobuf:"varint,6,opt,name=descending,proto3" json:"descending,omitempty"`
+	PageSize             int32     `protobuf:"varint,7,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	PageToken            string    `protobuf:"bytes,8,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	Depth                int32     `protobuf:"varint,9,opt,name=depth,proto3" json:"depth,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
 func (m *ReadDirRequest) Reset()         { *m = ReadDirRequest{} }
 func (m *ReadDirRequest) String() string { return proto.CompactTextString(m) }
 func (*ReadDirRequest) ProtoMessage()    {}
 func (*ReadDirRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fe29353663d6fe2c, []int{1}
+	return fileDescriptor_fe29353663d6fe2c, []int{2}
 }
 
 func (m *ReadDirRequest) XXX_Unmarshal(b []byte) error {
@@ -116,18 +472,56 @@ func (m *ReadDirRequest) GetThumnailHeight() int32 {
 	return 0
 }
 
+func (m *ReadDirRequest) GetSort() SortOrder {
+	if m != nil {
+		return m.Sort
+	}
+	return SortOrder_SORT_BY_NAME
+}
+
+func (m *ReadDirRequest) GetDescending() bool {
+	if m != nil {
+		return m.Descending
+	}
+	return false
+}
+
+func (m *ReadDirRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ReadDirRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *ReadDirRequest) GetDepth() int32 {
+	if m != nil {
+		return m.Depth
+	}
+	return 0
+}
+
+// One message by entry, the last message of a page that is not the last one
+// give only the token of the next one.
 type ReadDirResponse struct {
-	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Info                 *FileInfo `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+	NextPageToken        string    `protobuf:"bytes,3,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
 func (m *ReadDirResponse) Reset()         { *m = ReadDirResponse{} }
 func (m *ReadDirResponse) String() string { return proto.CompactTextString(m) }
 func (*ReadDirResponse) ProtoMessage()    {}
 func (*ReadDirResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fe29353663d6fe2c, []int{2}
+	return fileDescriptor_fe29353663d6fe2c, []int{3}
 }
 
 func (m *ReadDirResponse) XXX_Unmarshal(b []byte) error {
@@ -148,13 +542,20 @@ func (m *ReadDirResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_ReadDirResponse proto.InternalMessageInfo
 
-func (m *ReadDirResponse) GetData() []byte {
+func (m *ReadDirResponse) GetInfo() *FileInfo {
 	if m != nil {
-		return m.Data
+		return m.Info
 	}
 	return nil
 }
 
+func (m *ReadDirResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
 type CreateDirRequest struct {
 	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
 	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
@@ -167,7 +568,7 @@ func (m *CreateDirRequest) Reset()         { *m = CreateDirRequest{} }
 func (m *CreateDirRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateDirRequest) ProtoMessage()    {}
 func (*CreateDirRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fe29353663d6fe2c, []int{3}
+	return fileDescriptor_fe29353663d6fe2c, []int{4}
 }
 
 func (m *CreateDirRequest) XXX_Unmarshal(b []byte) error {
@@ -213,7 +614,7 @@ func (m *CreateDirResponse) Reset()         { *m = CreateDirResponse{} }
 func (m *CreateDirResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateDirResponse) ProtoMessage()    {}
 func (*CreateDirResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fe29353663d6fe2c, []int{4}
+	return fileDescriptor_fe29353663d6fe2c, []int{5}
 }
 
 func (m *CreateDirResponse) XXX_Unmarshal(b []byte) error {
@@ -252,7 +653,7 @@ func (m *DeleteDirRequest) Reset()         { *m = DeleteDirRequest{} }
 func (m *DeleteDirRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteDirRequest) ProtoMessage()    {}
 func (*DeleteDirRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fe29353663d6fe2c, []int{5}
+	return fileDescriptor_fe29353663d6fe2c, []int{6}
 }
 
 func (m *DeleteDirRequest) XXX_Unmarshal(b []byte) error {
@@ -291,7 +692,7 @@ func (m *DeleteDirResponse) Reset()         { *m = DeleteDirResponse{} }
 func (m *DeleteDirResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteDirResponse) ProtoMessage()    {}
 func (*DeleteDirResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fe29353663d6fe2c, []int{6}
+	return fileDescriptor_fe29353663d6fe2c, []int{7}
 }
 
 func (m *DeleteDirResponse) XXX_Unmarshal(b []byte) error {
@@ -332,7 +733,7 @@ func (m *RenameRequest) Reset()         { *m = RenameRequest{} }
 func (m *RenameRequest) String() string { return proto.CompactTextString(m) }
 func (*RenameRequest) ProtoMessage()    {}
 func (*RenameRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fe29353663d6fe2c, []int{7}
+	return fileDescriptor_fe29353663d6fe2c, []int{8}
 }
 
 func (m *RenameRequest) XXX_Unmarshal(b []byte) error {
@@ -385,7 +786,7 @@ func (m *RenameResponse) Reset()         { *m = RenameResponse{} }
 func (m *RenameResponse) String() string { return proto.CompactTextString(m) }
 func (*RenameResponse) ProtoMessage()    {}
 func (*RenameResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fe29353663d6fe2c, []int{8}
+	return fileDescriptor_fe29353663d6fe2c, []int{9}
 }
 
 func (m *RenameResponse) XXX_Unmarshal(b []byte) error {
@@ -413,376 +814,2016 @@ func (m *RenameResponse) GetResult() bool {
 	return false
 }
 
-type GetFileInfoRequest struct {
+// The progress of a copy or a move.
+type TransferProgress struct {
 	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
-	ThumnailWidth        int32    `protobuf:"varint,2,opt,name=thumnailWidth,proto3" json:"thumnailWidth,omitempty"`
-	ThumnailHeight       int32    `protobuf:"varint,3,opt,name=thumnailHeight,proto3" json:"thumnailHeight,omitempty"`
+	Files                int64    `protobuf:"varint,2,opt,name=files,proto3" json:"files,omitempty"`
+	TotalFiles           int64    `protobuf:"varint,3,opt,name=totalFiles,proto3" json:"totalFiles,omitempty"`
+	Size                 int64    `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	TotalSize            int64    `protobuf:"varint,5,opt,name=totalSize,proto3" json:"totalSize,omitempty"`
+	Skipped              int64    `protobuf:"varint,6,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	Destination          string   `protobuf:"bytes,7,opt,name=destination,proto3" json:"destination,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetFileInfoRequest) Reset()         { *m = GetFileInfoRequest{} }
-func (m *GetFileInfoRequest) String() string { return proto.CompactTextString(m) }
-func (*GetFileInfoRequest) ProtoMessage()    {}
-func (*GetFileInfoRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fe29353663d6fe2c, []int{9}
+func (m *TransferProgress) Reset()         { *m = TransferProgress{} }
+func (m *TransferProgress) String() string { return proto.CompactTextString(m) }
+func (*TransferProgress) ProtoMessage()    {}
+func (*TransferProgress) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{10}
 }
 
-func (m *GetFileInfoRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetFileInfoRequest.Unmarshal(m, b)
+func (m *TransferProgress) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransferProgress.Unmarshal(m, b)
 }
-func (m *GetFileInfoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetFileInfoRequest.Marshal(b, m, deterministic)
+func (m *TransferProgress) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransferProgress.Marshal(b, m, deterministic)
 }
-func (m *GetFileInfoRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetFileInfoRequest.Merge(m, src)
+func (m *TransferProgress) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferProgress.Merge(m, src)
 }
-func (m *GetFileInfoRequest) XXX_Size() int {
-	return xxx_messageInfo_GetFileInfoRequest.Size(m)
+func (m *TransferProgress) XXX_Size() int {
+	return xxx_messageInfo_TransferProgress.Size(m)
 }
-func (m *GetFileInfoRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetFileInfoRequest.DiscardUnknown(m)
+func (m *TransferProgress) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferProgress.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetFileInfoRequest proto.InternalMessageInfo
+var xxx_messageInfo_TransferProgress proto.InternalMessageInfo
 
-func (m *GetFileInfoRequest) GetPath() string {
+func (m *TransferProgress) GetPath() string {
 	if m != nil {
 		return m.Path
 	}
 	return ""
 }
 
-func (m *GetFileInfoRequest) GetThumnailWidth() int32 {
+func (m *TransferProgress) GetFiles() int64 {
 	if m != nil {
-		return m.ThumnailWidth
+		return m.Files
 	}
 	return 0
 }
 
-func (m *GetFileInfoRequest) GetThumnailHeight() int32 {
+func (m *TransferProgress) GetTotalFiles() int64 {
 	if m != nil {
-		return m.ThumnailHeight
+		return m.TotalFiles
 	}
 	return 0
 }
 
-type GetFileInfoResponse struct {
-	Data                 string   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
-
-func (m *GetFileInfoResponse) Reset()         { *m = GetFileInfoResponse{} }
-func (m *GetFileInfoResponse) String() string { return proto.CompactTextString(m) }
-func (*GetFileInfoResponse) ProtoMessage()    {}
-func (*GetFileInfoResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fe29353663d6fe2c, []int{10}
+func (m *TransferProgress) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
 }
 
-func (m *GetFileInfoResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetFileInfoResponse.Unmarshal(m, b)
-}
-func (m *GetFileInfoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetFileInfoResponse.Marshal(b, m, deterministic)
-}
-func (m *GetFileInfoResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetFileInfoResponse.Merge(m, src)
-}
-func (m *GetFileInfoResponse) XXX_Size() int {
-	return xxx_messageInfo_GetFileInfoResponse.Size(m)
-}
-func (m *GetFileInfoResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetFileInfoResponse.DiscardUnknown(m)
+func (m *TransferProgress) GetTotalSize() int64 {
+	if m != nil {
+		return m.TotalSize
+	}
+	return 0
 }
 
-var xxx_messageInfo_GetFileInfoResponse proto.InternalMessageInfo
+func (m *TransferProgress) GetSkipped() int64 {
+	if m != nil {
+		return m.Skipped
+	}
+	return 0
+}
 
-func (m *GetFileInfoResponse) GetData() string {
+func (m *TransferProgress) GetDestination() string {
 	if m != nil {
-		return m.Data
+		return m.Destination
 	}
 	return ""
 }
 
-// Read file as a binary file.
-type ReadFileRequest struct {
-	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+// Copy a file or a directory tree, the destination is the path of the copy.
+type CopyRequest struct {
+	Source               string         `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Destination          string         `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
+	Conflict             ConflictPolicy `protobuf:"varint,3,opt,name=conflict,proto3,enum=file.ConflictPolicy" json:"conflict,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
-func (m *ReadFileRequest) Reset()         { *m = ReadFileRequest{} }
-func (m *ReadFileRequest) String() string { return proto.CompactTextString(m) }
-func (*ReadFileRequest) ProtoMessage()    {}
-func (*ReadFileRequest) Descriptor() ([]byte, []int) {
+func (m *CopyRequest) Reset()         { *m = CopyRequest{} }
+func (m *CopyRequest) String() string { return proto.CompactTextString(m) }
+func (*CopyRequest) ProtoMessage()    {}
+func (*CopyRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_fe29353663d6fe2c, []int{11}
 }
 
-func (m *ReadFileRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ReadFileRequest.Unmarshal(m, b)
+func (m *CopyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CopyRequest.Unmarshal(m, b)
 }
-func (m *ReadFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ReadFileRequest.Marshal(b, m, deterministic)
+func (m *CopyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CopyRequest.Marshal(b, m, deterministic)
 }
-func (m *ReadFileRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ReadFileRequest.Merge(m, src)
+func (m *CopyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CopyRequest.Merge(m, src)
 }
-func (m *ReadFileRequest) XXX_Size() int {
-	return xxx_messageInfo_ReadFileRequest.Size(m)
+func (m *CopyRequest) XXX_Size() int {
+	return xxx_messageInfo_CopyRequest.Size(m)
 }
-func (m *ReadFileRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ReadFileRequest.DiscardUnknown(m)
+func (m *CopyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CopyRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ReadFileRequest proto.InternalMessageInfo
+var xxx_messageInfo_CopyRequest proto.InternalMessageInfo
 
-func (m *ReadFileRequest) GetPath() string {
+func (m *CopyRequest) GetSource() string {
 	if m != nil {
-		return m.Path
+		return m.Source
 	}
 	return ""
 }
 
-type ReadFileResponse struct {
-	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *CopyRequest) GetDestination() string {
+	if m != nil {
+		return m.Destination
+	}
+	return ""
 }
 
-func (m *ReadFileResponse) Reset()         { *m = ReadFileResponse{} }
-func (m *ReadFileResponse) String() string { return proto.CompactTextString(m) }
-func (*ReadFileResponse) ProtoMessage()    {}
-func (*ReadFileResponse) Descriptor() ([]byte, []int) {
+func (m *CopyRequest) GetConflict() ConflictPolicy {
+	if m != nil {
+		return m.Conflict
+	}
+	return ConflictPolicy_FAIL
+}
+
+type CopyResponse struct {
+	Progress             *TransferProgress `protobuf:"bytes,1,opt,name=progress,proto3" json:"progress,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *CopyResponse) Reset()         { *m = CopyResponse{} }
+func (m *CopyResponse) String() string { return proto.CompactTextString(m) }
+func (*CopyResponse) ProtoMessage()    {}
+func (*CopyResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_fe29353663d6fe2c, []int{12}
 }
 
-func (m *ReadFileResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ReadFileResponse.Unmarshal(m, b)
+func (m *CopyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CopyResponse.Unmarshal(m, b)
 }
-func (m *ReadFileResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ReadFileResponse.Marshal(b, m, deterministic)
+func (m *CopyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CopyResponse.Marshal(b, m, deterministic)
 }
-func (m *ReadFileResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ReadFileResponse.Merge(m, src)
+func (m *CopyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CopyResponse.Merge(m, src)
 }
-func (m *ReadFileResponse) XXX_Size() int {
-	return xxx_messageInfo_ReadFileResponse.Size(m)
+func (m *CopyResponse) XXX_Size() int {
+	return xxx_messageInfo_CopyResponse.Size(m)
 }
-func (m *ReadFileResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ReadFileResponse.DiscardUnknown(m)
+func (m *CopyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CopyResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ReadFileResponse proto.InternalMessageInfo
+var xxx_messageInfo_CopyResponse proto.InternalMessageInfo
 
-func (m *ReadFileResponse) GetData() []byte {
+func (m *CopyResponse) GetProgress() *TransferProgress {
 	if m != nil {
-		return m.Data
+		return m.Progress
 	}
 	return nil
 }
 
-// Read file as a binary file.
-type SaveFileRequest struct {
-	// Types that are valid to be assigned to File:
-	//	*SaveFileRequest_Path
-	//	*SaveFileRequest_Data
-	File                 isSaveFileRequest_File `protobuf_oneof:"file"`
-	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
-	XXX_unrecognized     []byte                 `json:"-"`
-	XXX_sizecache        int32                  `json:"-"`
+// Move a file or a directory tree, the destination is the new path.
+type MoveRequest struct {
+	Source               string         `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Destination          string         `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
+	Conflict             ConflictPolicy `protobuf:"varint,3,opt,name=conflict,proto3,enum=file.ConflictPolicy" json:"conflict,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
-func (m *SaveFileRequest) Reset()         { *m = SaveFileRequest{} }
-func (m *SaveFileRequest) String() string { return proto.CompactTextString(m) }
-func (*SaveFileRequest) ProtoMessage()    {}
-func (*SaveFileRequest) Descriptor() ([]byte, []int) {
+func (m *MoveRequest) Reset()         { *m = MoveRequest{} }
+func (m *MoveRequest) String() string { return proto.CompactTextString(m) }
+func (*MoveRequest) ProtoMessage()    {}
+func (*MoveRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_fe29353663d6fe2c, []int{13}
 }
 
-func (m *SaveFileRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SaveFileRequest.Unmarshal(m, b)
+func (m *MoveRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MoveRequest.Unmarshal(m, b)
 }
-func (m *SaveFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SaveFileRequest.Marshal(b, m, deterministic)
+func (m *MoveRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MoveRequest.Marshal(b, m, deterministic)
 }
-func (m *SaveFileRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SaveFileRequest.Merge(m, src)
+func (m *MoveRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MoveRequest.Merge(m, src)
 }
-func (m *SaveFileRequest) XXX_Size() int {
-	return xxx_messageInfo_SaveFileRequest.Size(m)
+func (m *MoveRequest) XXX_Size() int {
+	return xxx_messageInfo_MoveRequest.Size(m)
 }
-func (m *SaveFileRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_SaveFileRequest.DiscardUnknown(m)
+func (m *MoveRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MoveRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SaveFileRequest proto.InternalMessageInfo
+var xxx_messageInfo_MoveRequest proto.InternalMessageInfo
 
-type isSaveFileRequest_File interface {
-	isSaveFileRequest_File()
+func (m *MoveRequest) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
 }
 
-type SaveFileRequest_Path struct {
-	Path string `protobuf:"bytes,1,opt,name=path,proto3,oneof"`
+func (m *MoveRequest) GetDestination() string {
+	if m != nil {
+		return m.Destination
+	}
+	return ""
 }
 
-type SaveFileRequest_Data struct {
-	Data []byte `protobuf:"bytes,2,opt,name=data,proto3,oneof"`
+func (m *MoveRequest) GetConflict() ConflictPolicy {
+	if m != nil {
+		return m.Conflict
+	}
+	return ConflictPolicy_FAIL
 }
 
-func (*SaveFileRequest_Path) isSaveFileRequest_File() {}
+type MoveResponse struct {
+	Progress             *TransferProgress `protobuf:"bytes,1,opt,name=progress,proto3" json:"progress,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
 
-func (*SaveFileRequest_Data) isSaveFileRequest_File() {}
+func (m *MoveResponse) Reset()         { *m = MoveResponse{} }
+func (m *MoveResponse) String() string { return proto.CompactTextString(m) }
+func (*MoveResponse) ProtoMessage()    {}
+func (*MoveResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{14}
+}
 
-func (m *SaveFileRequest) GetFile() isSaveFileRequest_File {
+func (m *MoveResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MoveResponse.Unmarshal(m, b)
+}
+func (m *MoveResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MoveResponse.Marshal(b, m, deterministic)
+}
+func (m *MoveResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MoveResponse.Merge(m, src)
+}
+func (m *MoveResponse) XXX_Size() int {
+	return xxx_messageInfo_MoveResponse.Size(m)
+}
+func (m *MoveResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MoveResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MoveResponse proto.InternalMessageInfo
+
+func (m *MoveResponse) GetProgress() *TransferProgress {
 	if m != nil {
-		return m.File
+		return m.Progress
 	}
 	return nil
 }
 
-func (m *SaveFileRequest) GetPath() string {
-	if x, ok := m.GetFile().(*SaveFileRequest_Path); ok {
-		return x.Path
+type FileChange struct {
+	Type                 FileChangeType `protobuf:"varint,1,opt,name=type,proto3,enum=file.FileChangeType" json:"type,omitempty"`
+	Path                 string         `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	OldPath              string         `protobuf:"bytes,3,opt,name=oldPath,proto3" json:"oldPath,omitempty"`
+	IsDir                bool           `protobuf:"varint,4,opt,name=isDir,proto3" json:"isDir,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *FileChange) Reset()         { *m = FileChange{} }
+func (m *FileChange) String() string { return proto.CompactTextString(m) }
+func (*FileChange) ProtoMessage()    {}
+func (*FileChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{15}
+}
+
+func (m *FileChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileChange.Unmarshal(m, b)
+}
+func (m *FileChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileChange.Marshal(b, m, deterministic)
+}
+func (m *FileChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileChange.Merge(m, src)
+}
+func (m *FileChange) XXX_Size() int {
+	return xxx_messageInfo_FileChange.Size(m)
+}
+func (m *FileChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileChange proto.InternalMessageInfo
+
+func (m *FileChange) GetType() FileChangeType {
+	if m != nil {
+		return m.Type
+	}
+	return FileChangeType_FILE_CREATED
+}
+
+func (m *FileChange) GetPath() string {
+	if m != nil {
+		return m.Path
 	}
 	return ""
 }
 
-func (m *SaveFileRequest) GetData() []byte {
-	if x, ok := m.GetFile().(*SaveFileRequest_Data); ok {
-		return x.Data
+func (m *FileChange) GetOldPath() string {
+	if m != nil {
+		return m.OldPath
 	}
-	return nil
+	return ""
 }
 
-// XXX_OneofWrappers is for the internal use of the proto package.
-func (*SaveFileRequest) XXX_OneofWrappers() []interface{} {
-	return []interface{}{
-		(*SaveFileRequest_Path)(nil),
-		(*SaveFileRequest_Data)(nil),
+func (m *FileChange) GetIsDir() bool {
+	if m != nil {
+		return m.IsDir
 	}
+	return false
 }
 
-type SaveFileResponse struct {
-	Result               bool     `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+// Watch the changes of the files of a directory.
+type WatchDirRequest struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Recursive            bool     `protobuf:"varint,2,opt,name=recursive,proto3" json:"recursive,omitempty"`
+	Delay                int32    `protobuf:"varint,3,opt,name=delay,proto3" json:"delay,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *SaveFileResponse) Reset()         { *m = SaveFileResponse{} }
-func (m *SaveFileResponse) String() string { return proto.CompactTextString(m) }
-func (*SaveFileResponse) ProtoMessage()    {}
-func (*SaveFileResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fe29353663d6fe2c, []int{14}
+func (m *WatchDirRequest) Reset()         { *m = WatchDirRequest{} }
+func (m *WatchDirRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchDirRequest) ProtoMessage()    {}
+func (*WatchDirRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{16}
 }
 
-func (m *SaveFileResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SaveFileResponse.Unmarshal(m, b)
+func (m *WatchDirRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchDirRequest.Unmarshal(m, b)
 }
-func (m *SaveFileResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SaveFileResponse.Marshal(b, m, deterministic)
+func (m *WatchDirRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchDirRequest.Marshal(b, m, deterministic)
 }
-func (m *SaveFileResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SaveFileResponse.Merge(m, src)
+func (m *WatchDirRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchDirRequest.Merge(m, src)
 }
-func (m *SaveFileResponse) XXX_Size() int {
-	return xxx_messageInfo_SaveFileResponse.Size(m)
+func (m *WatchDirRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchDirRequest.Size(m)
 }
-func (m *SaveFileResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_SaveFileResponse.DiscardUnknown(m)
+func (m *WatchDirRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchDirRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SaveFileResponse proto.InternalMessageInfo
+var xxx_messageInfo_WatchDirRequest proto.InternalMessageInfo
 
-func (m *SaveFileResponse) GetResult() bool {
+func (m *WatchDirRequest) GetPath() string {
 	if m != nil {
-		return m.Result
+		return m.Path
+	}
+	return ""
+}
+
+func (m *WatchDirRequest) GetRecursive() bool {
+	if m != nil {
+		return m.Recursive
 	}
 	return false
 }
 
-// Delete file
-type DeleteFileRequest struct {
+func (m *WatchDirRequest) GetDelay() int32 {
+	if m != nil {
+		return m.Delay
+	}
+	return 0
+}
+
+// The first message, without change, is sent once the directory is watched.
+type WatchDirResponse struct {
+	Changes              []*FileChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *WatchDirResponse) Reset()         { *m = WatchDirResponse{} }
+func (m *WatchDirResponse) String() string { return proto.CompactTextString(m) }
+func (*WatchDirResponse) ProtoMessage()    {}
+func (*WatchDirResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{17}
+}
+
+func (m *WatchDirResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchDirResponse.Unmarshal(m, b)
+}
+func (m *WatchDirResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchDirResponse.Marshal(b, m, deterministic)
+}
+func (m *WatchDirResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchDirResponse.Merge(m, src)
+}
+func (m *WatchDirResponse) XXX_Size() int {
+	return xxx_messageInfo_WatchDirResponse.Size(m)
+}
+func (m *WatchDirResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchDirResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchDirResponse proto.InternalMessageInfo
+
+func (m *WatchDirResponse) GetChanges() []*FileChange {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+// Search the files of a directory tree, the files found match all the given
+// criteria. The directories are found by their name and date only.
+type SearchRequest struct {
 	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Regex                string   `protobuf:"bytes,3,opt,name=regex,proto3" json:"regex,omitempty"`
+	Mime                 string   `protobuf:"bytes,4,opt,name=mime,proto3" json:"mime,omitempty"`
+	MinSize              int64    `protobuf:"varint,5,opt,name=minSize,proto3" json:"minSize,omitempty"`
+	MaxSize              int64    `protobuf:"varint,6,opt,name=maxSize,proto3" json:"maxSize,omitempty"`
+	ModifiedAfter        int64    `protobuf:"varint,7,opt,name=modifiedAfter,proto3" json:"modifiedAfter,omitempty"`
+	ModifiedBefore       int64    `protobuf:"varint,8,opt,name=modifiedBefore,proto3" json:"modifiedBefore,omitempty"`
+	Content              string   `protobuf:"bytes,9,opt,name=content,proto3" json:"content,omitempty"`
+	IgnoreCase           bool     `protobuf:"varint,10,opt,name=ignoreCase,proto3" json:"ignoreCase,omitempty"`
+	Limit                int32    `protobuf:"varint,11,opt,name=limit,proto3" json:"limit,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DeleteFileRequest) Reset()         { *m = DeleteFileRequest{} }
-func (m *DeleteFileRequest) String() string { return proto.CompactTextString(m) }
-func (*DeleteFileRequest) ProtoMessage()    {}
-func (*DeleteFileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fe29353663d6fe2c, []int{15}
+func (m *SearchRequest) Reset()         { *m = SearchRequest{} }
+func (m *SearchRequest) String() string { return proto.CompactTextString(m) }
+func (*SearchRequest) ProtoMessage()    {}
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{18}
 }
 
-func (m *DeleteFileRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DeleteFileRequest.Unmarshal(m, b)
+func (m *SearchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchRequest.Unmarshal(m, b)
 }
-func (m *DeleteFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DeleteFileRequest.Marshal(b, m, deterministic)
+func (m *SearchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchRequest.Marshal(b, m, deterministic)
 }
-func (m *DeleteFileRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteFileRequest.Merge(m, src)
+func (m *SearchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchRequest.Merge(m, src)
 }
-func (m *DeleteFileRequest) XXX_Size() int {
-	return xxx_messageInfo_DeleteFileRequest.Size(m)
+func (m *SearchRequest) XXX_Size() int {
+	return xxx_messageInfo_SearchRequest.Size(m)
 }
-func (m *DeleteFileRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteFileRequest.DiscardUnknown(m)
+func (m *SearchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteFileRequest proto.InternalMessageInfo
+var xxx_messageInfo_SearchRequest proto.InternalMessageInfo
 
-func (m *DeleteFileRequest) GetPath() string {
+func (m *SearchRequest) GetPath() string {
 	if m != nil {
 		return m.Path
 	}
 	return ""
 }
 
-type DeleteFileResponse struct {
-	Result               bool     `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *SearchRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
 }
 
-func (m *DeleteFileResponse) Reset()         { *m = DeleteFileResponse{} }
-func (m *DeleteFileResponse) String() string { return proto.CompactTextString(m) }
-func (*DeleteFileResponse) ProtoMessage()    {}
-func (*DeleteFileResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fe29353663d6fe2c, []int{16}
+func (m *SearchRequest) GetRegex() string {
+	if m != nil {
+		return m.Regex
+	}
+	return ""
+}
+
+func (m *SearchRequest) GetMime() string {
+	if m != nil {
+		return m.Mime
+	}
+	return ""
+}
+
+func (m *SearchRequest) GetMinSize() int64 {
+	if m != nil {
+		return m.MinSize
+	}
+	return 0
+}
+
+func (m *SearchRequest) GetMaxSize() int64 {
+	if m != nil {
+		return m.MaxSize
+	}
+	return 0
+}
+
+func (m *SearchRequest) GetModifiedAfter() int64 {
+	if m != nil {
+		return m.ModifiedAfter
+	}
+	return 0
+}
+
+func (m *SearchRequest) GetModifiedBefore() int64 {
+	if m != nil {
+		return m.ModifiedBefore
+	}
+	return 0
+}
+
+func (m *SearchRequest) GetContent() string {
+	if m != nil {
+		return m.Content
+	}
+	return ""
+}
+
+func (m *SearchRequest) GetIgnoreCase() bool {
+	if m != nil {
+		return m.IgnoreCase
+	}
+	return false
+}
+
+func (m *SearchRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type SearchResponse struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Size                 int64    `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	ModTime              int64    `protobuf:"varint,3,opt,name=modTime,proto3" json:"modTime,omitempty"`
+	IsDir                bool     `protobuf:"varint,4,opt,name=isDir,proto3" json:"isDir,omitempty"`
+	Mime                 string   `protobuf:"bytes,5,opt,name=mime,proto3" json:"mime,omitempty"`
+	Line                 int32    `protobuf:"varint,6,opt,name=line,proto3" json:"line,omitempty"`
+	Match                string   `protobuf:"bytes,7,opt,name=match,proto3" json:"match,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchResponse) Reset()         { *m = SearchResponse{} }
+func (m *SearchResponse) String() string { return proto.CompactTextString(m) }
+func (*SearchResponse) ProtoMessage()    {}
+func (*SearchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{19}
+}
+
+func (m *SearchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchResponse.Unmarshal(m, b)
+}
+func (m *SearchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchResponse.Marshal(b, m, deterministic)
+}
+func (m *SearchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchResponse.Merge(m, src)
+}
+func (m *SearchResponse) XXX_Size() int {
+	return xxx_messageInfo_SearchResponse.Size(m)
+}
+func (m *SearchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchResponse proto.InternalMessageInfo
+
+func (m *SearchResponse) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *SearchResponse) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *SearchResponse) GetModTime() int64 {
+	if m != nil {
+		return m.ModTime
+	}
+	return 0
+}
+
+func (m *SearchResponse) GetIsDir() bool {
+	if m != nil {
+		return m.IsDir
+	}
+	return false
+}
+
+func (m *SearchResponse) GetMime() string {
+	if m != nil {
+		return m.Mime
+	}
+	return ""
+}
+
+func (m *SearchResponse) GetLine() int32 {
+	if m != nil {
+		return m.Line
+	}
+	return 0
+}
+
+func (m *SearchResponse) GetMatch() string {
+	if m != nil {
+		return m.Match
+	}
+	return ""
+}
+
+type GetFileInfoRequest struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	ThumnailWidth        int32    `protobuf:"varint,2,opt,name=thumnailWidth,proto3" json:"thumnailWidth,omitempty"`
+	ThumnailHeight       int32    `protobuf:"varint,3,opt,name=thumnailHeight,proto3" json:"thumnailHeight,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetFileInfoRequest) Reset()         { *m = GetFileInfoRequest{} }
+func (m *GetFileInfoRequest) String() string { return proto.CompactTextString(m) }
+func (*GetFileInfoRequest) ProtoMessage()    {}
+func (*GetFileInfoRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{20}
+}
+
+func (m *GetFileInfoRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetFileInfoRequest.Unmarshal(m, b)
+}
+func (m *GetFileInfoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetFileInfoRequest.Marshal(b, m, deterministic)
+}
+func (m *GetFileInfoRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetFileInfoRequest.Merge(m, src)
+}
+func (m *GetFileInfoRequest) XXX_Size() int {
+	return xxx_messageInfo_GetFileInfoRequest.Size(m)
+}
+func (m *GetFileInfoRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetFileInfoRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetFileInfoRequest proto.InternalMessageInfo
+
+func (m *GetFileInfoRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *GetFileInfoRequest) GetThumnailWidth() int32 {
+	if m != nil {
+		return m.ThumnailWidth
+	}
+	return 0
+}
+
+func (m *GetFileInfoRequest) GetThumnailHeight() int32 {
+	if m != nil {
+		return m.ThumnailHeight
+	}
+	return 0
+}
+
+type GetFileInfoResponse struct {
+	Data                 string   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetFileInfoResponse) Reset()         { *m = GetFileInfoResponse{} }
+func (m *GetFileInfoResponse) String() string { return proto.CompactTextString(m) }
+func (*GetFileInfoResponse) ProtoMessage()    {}
+func (*GetFileInfoResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{21}
+}
+
+func (m *GetFileInfoResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetFileInfoResponse.Unmarshal(m, b)
+}
+func (m *GetFileInfoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetFileInfoResponse.Marshal(b, m, deterministic)
+}
+func (m *GetFileInfoResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetFileInfoResponse.Merge(m, src)
+}
+func (m *GetFileInfoResponse) XXX_Size() int {
+	return xxx_messageInfo_GetFileInfoResponse.Size(m)
+}
+func (m *GetFileInfoResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetFileInfoResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetFileInfoResponse proto.InternalMessageInfo
+
+func (m *GetFileInfoResponse) GetData() string {
+	if m != nil {
+		return m.Data
+	}
+	return ""
+}
+
+// Read file as a binary file, or a part of it.
+type ReadFileRequest struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Offset               int64    `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Length               int64    `protobuf:"varint,3,opt,name=length,proto3" json:"length,omitempty"`
+	ChunkSize            int32    `protobuf:"varint,4,opt,name=chunkSize,proto3" json:"chunkSize,omitempty"`
+	Checksum             bool     `protobuf:"varint,5,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadFileRequest) Reset()         { *m = ReadFileRequest{} }
+func (m *ReadFileRequest) String() string { return proto.CompactTextString(m) }
+func (*ReadFileRequest) ProtoMessage()    {}
+func (*ReadFileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{22}
+}
+
+func (m *ReadFileRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadFileRequest.Unmarshal(m, b)
+}
+func (m *ReadFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadFileRequest.Marshal(b, m, deterministic)
+}
+func (m *ReadFileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadFileRequest.Merge(m, src)
+}
+func (m *ReadFileRequest) XXX_Size() int {
+	return xxx_messageInfo_ReadFileRequest.Size(m)
+}
+func (m *ReadFileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadFileRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadFileRequest proto.InternalMessageInfo
+
+func (m *ReadFileRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *ReadFileRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *ReadFileRequest) GetLength() int64 {
+	if m != nil {
+		return m.Length
+	}
+	return 0
+}
+
+func (m *ReadFileRequest) GetChunkSize() int32 {
+	if m != nil {
+		return m.ChunkSize
+	}
+	return 0
+}
+
+func (m *ReadFileRequest) GetChecksum() bool {
+	if m != nil {
+		return m.Checksum
+	}
+	return false
+}
+
+type ReadFileResponse struct {
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// Given with the first message, to resume a read of the same file.
+	Size                 int64    `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	ModTimeNano          int64    `protobuf:"varint,3,opt,name=modTimeNano,proto3" json:"modTimeNano,omitempty"`
+	Sha256               []byte   `protobuf:"bytes,4,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadFileResponse) Reset()         { *m = ReadFileResponse{} }
+func (m *ReadFileResponse) String() string { return proto.CompactTextString(m) }
+func (*ReadFileResponse) ProtoMessage()    {}
+func (*ReadFileResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{23}
+}
+
+func (m *ReadFileResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadFileResponse.Unmarshal(m, b)
+}
+func (m *ReadFileResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadFileResponse.Marshal(b, m, deterministic)
+}
+func (m *ReadFileResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadFileResponse.Merge(m, src)
+}
+func (m *ReadFileResponse) XXX_Size() int {
+	return xxx_messageInfo_ReadFileResponse.Size(m)
+}
+func (m *ReadFileResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadFileResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadFileResponse proto.InternalMessageInfo
+
+func (m *ReadFileResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *ReadFileResponse) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *ReadFileResponse) GetModTimeNano() int64 {
+	if m != nil {
+		return m.ModTimeNano
+	}
+	return 0
+}
+
+func (m *ReadFileResponse) GetSha256() []byte {
+	if m != nil {
+		return m.Sha256
+	}
+	return nil
+}
+
+// The path is the first message, the data follow it.
+type SaveFileRequest struct {
+	// Types that are valid to be assigned to File:
+	//	*SaveFileRequest_Path
+	//	*SaveFileRequest_Data
+	File isSaveFileRequest_File `protobuf_oneof:"file"`
+	// Given with the path.
+	Size                 int64    `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Sha256               []byte   `protobuf:"bytes,4,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	Mode                 SaveMode `protobuf:"varint,5,opt,name=mode,proto3,enum=file.SaveMode" json:"mode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SaveFileRequest) Reset()         { *m = SaveFileRequest{} }
+func (m *SaveFileRequest) String() string { return proto.CompactTextString(m) }
+func (*SaveFileRequest) ProtoMessage()    {}
+func (*SaveFileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{24}
+}
+
+func (m *SaveFileRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SaveFileRequest.Unmarshal(m, b)
+}
+func (m *SaveFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SaveFileRequest.Marshal(b, m, deterministic)
+}
+func (m *SaveFileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SaveFileRequest.Merge(m, src)
+}
+func (m *SaveFileRequest) XXX_Size() int {
+	return xxx_messageInfo_SaveFileRequest.Size(m)
+}
+func (m *SaveFileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SaveFileRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SaveFileRequest proto.InternalMessageInfo
+
+type isSaveFileRequest_File interface {
+	isSaveFileRequest_File()
+}
+
+type SaveFileRequest_Path struct {
+	Path string `protobuf:"bytes,1,opt,name=path,proto3,oneof"`
+}
+
+type SaveFileRequest_Data struct {
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3,oneof"`
+}
+
+func (*SaveFileRequest_Path) isSaveFileRequest_File() {}
+
+func (*SaveFileRequest_Data) isSaveFileRequest_File() {}
+
+func (m *SaveFileRequest) GetFile() isSaveFileRequest_File {
+	if m != nil {
+		return m.File
+	}
+	return nil
+}
+
+func (m *SaveFileRequest) GetPath() string {
+	if x, ok := m.GetFile().(*SaveFileRequest_Path); ok {
+		return x.Path
+	}
+	return ""
+}
+
+func (m *SaveFileRequest) GetData() []byte {
+	if x, ok := m.GetFile().(*SaveFileRequest_Data); ok {
+		return x.Data
+	}
+	return nil
+}
+
+func (m *SaveFileRequest) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *SaveFileRequest) GetSha256() []byte {
+	if m != nil {
+		return m.Sha256
+	}
+	return nil
+}
+
+func (m *SaveFileRequest) GetMode() SaveMode {
+	if m != nil {
+		return m.Mode
+	}
+	return SaveMode_OVERWRITE
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*SaveFileRequest) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*SaveFileRequest_Path)(nil),
+		(*SaveFileRequest_Data)(nil),
+	}
+}
+
+type SaveFileResponse struct {
+	Result               bool     `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+	Size                 int64    `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SaveFileResponse) Reset()         { *m = SaveFileResponse{} }
+func (m *SaveFileResponse) String() string { return proto.CompactTextString(m) }
+func (*SaveFileResponse) ProtoMessage()    {}
+func (*SaveFileResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{25}
+}
+
+func (m *SaveFileResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SaveFileResponse.Unmarshal(m, b)
+}
+func (m *SaveFileResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SaveFileResponse.Marshal(b, m, deterministic)
+}
+func (m *SaveFileResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SaveFileResponse.Merge(m, src)
+}
+func (m *SaveFileResponse) XXX_Size() int {
+	return xxx_messageInfo_SaveFileResponse.Size(m)
+}
+func (m *SaveFileResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SaveFileResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SaveFileResponse proto.InternalMessageInfo
+
+func (m *SaveFileResponse) GetResult() bool {
+	if m != nil {
+		return m.Result
+	}
+	return false
+}
+
+func (m *SaveFileResponse) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+// Delete file
+type DeleteFileRequest struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteFileRequest) Reset()         { *m = DeleteFileRequest{} }
+func (m *DeleteFileRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteFileRequest) ProtoMessage()    {}
+func (*DeleteFileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{26}
+}
+
+func (m *DeleteFileRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteFileRequest.Unmarshal(m, b)
+}
+func (m *DeleteFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteFileRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteFileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteFileRequest.Merge(m, src)
+}
+func (m *DeleteFileRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteFileRequest.Size(m)
+}
+func (m *DeleteFileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteFileRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteFileRequest proto.InternalMessageInfo
+
+func (m *DeleteFileRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+type DeleteFileResponse struct {
+	Result               bool     `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteFileResponse) Reset()         { *m = DeleteFileResponse{} }
+func (m *DeleteFileResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteFileResponse) ProtoMessage()    {}
+func (*DeleteFileResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{27}
+}
+
+func (m *DeleteFileResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteFileResponse.Unmarshal(m, b)
+}
+func (m *DeleteFileResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteFileResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteFileResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteFileResponse.Merge(m, src)
+}
+func (m *DeleteFileResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteFileResponse.Size(m)
+}
+func (m *DeleteFileResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteFileResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteFileResponse proto.InternalMessageInfo
+
+func (m *DeleteFileResponse) GetResult() bool {
+	if m != nil {
+		return m.Result
+	}
+	return false
+}
+
+// The thumbnail of an image, turned as the EXIF orientation give and scaled
+// to fit in the width and height. It is computed once for a same file.
+type GetThumbnailRequest struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Width                int32    `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`
+	Height               int32    `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetThumbnailRequest) Reset()         { *m = GetThumbnailRequest{} }
+func (m *GetThumbnailRequest) String() string { return proto.CompactTextString(m) }
+func (*GetThumbnailRequest) ProtoMessage()    {}
+func (*GetThumbnailRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{28}
+}
+
+func (m *GetThumbnailRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetThumbnailRequest.Unmarshal(m, b)
+}
+func (m *GetThumbnailRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetThumbnailRequest.Marshal(b, m, deterministic)
+}
+func (m *GetThumbnailRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetThumbnailRequest.Merge(m, src)
+}
+func (m *GetThumbnailRequest) XXX_Size() int {
+	return xxx_messageInfo_GetThumbnailRequest.Size(m)
+}
+func (m *GetThumbnailRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetThumbnailRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetThumbnailRequest proto.InternalMessageInfo
+
+func (m *GetThumbnailRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *GetThumbnailRequest) GetWidth() int32 {
+	if m != nil {
+		return m.Width
+	}
+	return 0
+}
+
+func (m *GetThumbnailRequest) GetHeight() int32 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type GetThumbnailResponse struct {
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Mime                 string   `protobuf:"bytes,2,opt,name=mime,proto3" json:"mime,omitempty"`
+	Width                int32    `protobuf:"varint,3,opt,name=width,proto3" json:"width,omitempty"`
+	Height               int32    `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetThumbnailResponse) Reset()         { *m = GetThumbnailResponse{} }
+func (m *GetThumbnailResponse) String() string { return proto.CompactTextString(m) }
+func (*GetThumbnailResponse) ProtoMessage()    {}
+func (*GetThumbnailResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{29}
+}
+
+func (m *GetThumbnailResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetThumbnailResponse.Unmarshal(m, b)
+}
+func (m *GetThumbnailResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetThumbnailResponse.Marshal(b, m, deterministic)
+}
+func (m *GetThumbnailResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetThumbnailResponse.Merge(m, src)
+}
+func (m *GetThumbnailResponse) XXX_Size() int {
+	return xxx_messageInfo_GetThumbnailResponse.Size(m)
+}
+func (m *GetThumbnailResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetThumbnailResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetThumbnailResponse proto.InternalMessageInfo
+
+func (m *GetThumbnailResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *GetThumbnailResponse) GetMime() string {
+	if m != nil {
+		return m.Mime
+	}
+	return ""
+}
+
+func (m *GetThumbnailResponse) GetWidth() int32 {
+	if m != nil {
+		return m.Width
+	}
+	return 0
+}
+
+func (m *GetThumbnailResponse) GetHeight() int32 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type ResizeOperation struct {
+	Width                int32      `protobuf:"varint,1,opt,name=width,proto3" json:"width,omitempty"`
+	Height               int32      `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	Mode                 ResizeMode `protobuf:"varint,3,opt,name=mode,proto3,enum=file.ResizeMode" json:"mode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ResizeOperation) Reset()         { *m = ResizeOperation{} }
+func (m *ResizeOperation) String() string { return proto.CompactTextString(m) }
+func (*ResizeOperation) ProtoMessage()    {}
+func (*ResizeOperation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{30}
+}
+
+func (m *ResizeOperation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResizeOperation.Unmarshal(m, b)
+}
+func (m *ResizeOperation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResizeOperation.Marshal(b, m, deterministic)
+}
+func (m *ResizeOperation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResizeOperation.Merge(m, src)
+}
+func (m *ResizeOperation) XXX_Size() int {
+	return xxx_messageInfo_ResizeOperation.Size(m)
+}
+func (m *ResizeOperation) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResizeOperation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResizeOperation proto.InternalMessageInfo
+
+func (m *ResizeOperation) GetWidth() int32 {
+	if m != nil {
+		return m.Width
+	}
+	return 0
+}
+
+func (m *ResizeOperation) GetHeight() int32 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ResizeOperation) GetMode() ResizeMode {
+	if m != nil {
+		return m.Mode
+	}
+	return ResizeMode_RESIZE_FIT
+}
+
+type CropOperation struct {
+	X                    int32    `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y                    int32    `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
+	Width                int32    `protobuf:"varint,3,opt,name=width,proto3" json:"width,omitempty"`
+	Height               int32    `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CropOperation) Reset()         { *m = CropOperation{} }
+func (m *CropOperation) String() string { return proto.CompactTextString(m) }
+func (*CropOperation) ProtoMessage()    {}
+func (*CropOperation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{31}
+}
+
+func (m *CropOperation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CropOperation.Unmarshal(m, b)
+}
+func (m *CropOperation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CropOperation.Marshal(b, m, deterministic)
+}
+func (m *CropOperation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CropOperation.Merge(m, src)
+}
+func (m *CropOperation) XXX_Size() int {
+	return xxx_messageInfo_CropOperation.Size(m)
+}
+func (m *CropOperation) XXX_DiscardUnknown() {
+	xxx_messageInfo_CropOperation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CropOperation proto.InternalMessageInfo
+
+func (m *CropOperation) GetX() int32 {
+	if m != nil {
+		return m.X
+	}
+	return 0
+}
+
+func (m *CropOperation) GetY() int32 {
+	if m != nil {
+		return m.Y
+	}
+	return 0
+}
+
+func (m *CropOperation) GetWidth() int32 {
+	if m != nil {
+		return m.Width
+	}
+	return 0
+}
+
+func (m *CropOperation) GetHeight() int32 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+// An operation on an image, they are done in order after the EXIF
+// orientation is applied.
+type ImageOperation struct {
+	// Types that are valid to be assigned to Operation:
+	//	*ImageOperation_Resize
+	//	*ImageOperation_Crop
+	//	*ImageOperation_Rotate
+	//	*ImageOperation_Flip
+	//	*ImageOperation_Grayscale
+	Operation            isImageOperation_Operation `protobuf_oneof:"operation"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *ImageOperation) Reset()         { *m = ImageOperation{} }
+func (m *ImageOperation) String() string { return proto.CompactTextString(m) }
+func (*ImageOperation) ProtoMessage()    {}
+func (*ImageOperation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{32}
+}
+
+func (m *ImageOperation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImageOperation.Unmarshal(m, b)
+}
+func (m *ImageOperation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImageOperation.Marshal(b, m, deterministic)
+}
+func (m *ImageOperation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImageOperation.Merge(m, src)
+}
+func (m *ImageOperation) XXX_Size() int {
+	return xxx_messageInfo_ImageOperation.Size(m)
+}
+func (m *ImageOperation) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImageOperation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImageOperation proto.InternalMessageInfo
+
+type isImageOperation_Operation interface {
+	isImageOperation_Operation()
+}
+
+type ImageOperation_Resize struct {
+	Resize *ResizeOperation `protobuf:"bytes,1,opt,name=resize,proto3,oneof"`
+}
+
+type ImageOperation_Crop struct {
+	Crop *CropOperation `protobuf:"bytes,2,opt,name=crop,proto3,oneof"`
+}
+
+type ImageOperation_Rotate struct {
+	Rotate int32 `protobuf:"varint,3,opt,name=rotate,proto3,oneof"`
+}
+
+type ImageOperation_Flip struct {
+	Flip FlipDirection `protobuf:"varint,4,opt,name=flip,proto3,enum=file.FlipDirection,oneof"`
+}
+
+type ImageOperation_Grayscale struct {
+	Grayscale bool `protobuf:"varint,5,opt,name=grayscale,proto3,oneof"`
+}
+
+func (*ImageOperation_Resize) isImageOperation_Operation() {}
+
+func (*ImageOperation_Crop) isImageOperation_Operation() {}
+
+func (*ImageOperation_Rotate) isImageOperation_Operation() {}
+
+func (*ImageOperation_Flip) isImageOperation_Operation() {}
+
+func (*ImageOperation_Grayscale) isImageOperation_Operation() {}
+
+func (m *ImageOperation) GetOperation() isImageOperation_Operation {
+	if m != nil {
+		return m.Operation
+	}
+	return nil
+}
+
+func (m *ImageOperation) GetResize() *ResizeOperation {
+	if x, ok := m.GetOperation().(*ImageOperation_Resize); ok {
+		return x.Resize
+	}
+	return nil
+}
+
+func (m *ImageOperation) GetCrop() *CropOperation {
+	if x, ok := m.GetOperation().(*ImageOperation_Crop); ok {
+		return x.Crop
+	}
+	return nil
+}
+
+func (m *ImageOperation) GetRotate() int32 {
+	if x, ok := m.GetOperation().(*ImageOperation_Rotate); ok {
+		return x.Rotate
+	}
+	return 0
+}
+
+func (m *ImageOperation) GetFlip() FlipDirection {
+	if x, ok := m.GetOperation().(*ImageOperation_Flip); ok {
+		return x.Flip
+	}
+	return FlipDirection_FLIP_HORIZONTAL
+}
+
+func (m *ImageOperation) GetGrayscale() bool {
+	if x, ok := m.GetOperation().(*ImageOperation_Grayscale); ok {
+		return x.Grayscale
+	}
+	return false
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*ImageOperation) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*ImageOperation_Resize)(nil),
+		(*ImageOperation_Crop)(nil),
+		(*ImageOperation_Rotate)(nil),
+		(*ImageOperation_Flip)(nil),
+		(*ImageOperation_Grayscale)(nil),
+	}
+}
+
+type ImageTransformRequest struct {
+	Path                 string            `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Operations           []*ImageOperation `protobuf:"bytes,2,rep,name=operations,proto3" json:"operations,omitempty"`
+	Format               ImageFormat       `protobuf:"varint,3,opt,name=format,proto3,enum=file.ImageFormat" json:"format,omitempty"`
+	Quality              int32             `protobuf:"varint,4,opt,name=quality,proto3" json:"quality,omitempty"`
+	Destination          string            `protobuf:"bytes,5,opt,name=destination,proto3" json:"destination,omitempty"`
+	Conflict             ConflictPolicy    `protobuf:"varint,6,opt,name=conflict,proto3,enum=file.ConflictPolicy" json:"conflict,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ImageTransformRequest) Reset()         { *m = ImageTransformRequest{} }
+func (m *ImageTransformRequest) String() string { return proto.CompactTextString(m) }
+func (*ImageTransformRequest) ProtoMessage()    {}
+func (*ImageTransformRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{33}
+}
+
+func (m *ImageTransformRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImageTransformRequest.Unmarshal(m, b)
+}
+func (m *ImageTransformRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImageTransformRequest.Marshal(b, m, deterministic)
+}
+func (m *ImageTransformRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImageTransformRequest.Merge(m, src)
+}
+func (m *ImageTransformRequest) XXX_Size() int {
+	return xxx_messageInfo_ImageTransformRequest.Size(m)
+}
+func (m *ImageTransformRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImageTransformRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImageTransformRequest proto.InternalMessageInfo
+
+func (m *ImageTransformRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *ImageTransformRequest) GetOperations() []*ImageOperation {
+	if m != nil {
+		return m.Operations
+	}
+	return nil
+}
+
+func (m *ImageTransformRequest) GetFormat() ImageFormat {
+	if m != nil {
+		return m.Format
+	}
+	return ImageFormat_ORIGINAL_FORMAT
+}
+
+func (m *ImageTransformRequest) GetQuality() int32 {
+	if m != nil {
+		return m.Quality
+	}
+	return 0
+}
+
+func (m *ImageTransformRequest) GetDestination() string {
+	if m != nil {
+		return m.Destination
+	}
+	return ""
+}
+
+func (m *ImageTransformRequest) GetConflict() ConflictPolicy {
+	if m != nil {
+		return m.Conflict
+	}
+	return ConflictPolicy_FAIL
+}
+
+// The first message give the image type and size, the data follow it when
+// the image is not saved.
+type ImageTransformResponse struct {
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Mime                 string   `protobuf:"bytes,2,opt,name=mime,proto3" json:"mime,omitempty"`
+	Width                int32    `protobuf:"varint,3,opt,name=width,proto3" json:"width,omitempty"`
+	Height               int32    `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	Path                 string   `protobuf:"bytes,5,opt,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImageTransformResponse) Reset()         { *m = ImageTransformResponse{} }
+func (m *ImageTransformResponse) String() string { return proto.CompactTextString(m) }
+func (*ImageTransformResponse) ProtoMessage()    {}
+func (*ImageTransformResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{34}
+}
+
+func (m *ImageTransformResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImageTransformResponse.Unmarshal(m, b)
+}
+func (m *ImageTransformResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImageTransformResponse.Marshal(b, m, deterministic)
+}
+func (m *ImageTransformResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImageTransformResponse.Merge(m, src)
+}
+func (m *ImageTransformResponse) XXX_Size() int {
+	return xxx_messageInfo_ImageTransformResponse.Size(m)
+}
+func (m *ImageTransformResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImageTransformResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImageTransformResponse proto.InternalMessageInfo
+
+func (m *ImageTransformResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *ImageTransformResponse) GetMime() string {
+	if m != nil {
+		return m.Mime
+	}
+	return ""
+}
+
+func (m *ImageTransformResponse) GetWidth() int32 {
+	if m != nil {
+		return m.Width
+	}
+	return 0
+}
+
+func (m *ImageTransformResponse) GetHeight() int32 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ImageTransformResponse) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+type CreateArchiveRequest struct {
+	Paths                []string       `protobuf:"bytes,1,rep,name=paths,proto3" json:"paths,omitempty"`
+	Format               ArchiveFormat  `protobuf:"varint,2,opt,name=format,proto3,enum=file.ArchiveFormat" json:"format,omitempty"`
+	Destination          string         `protobuf:"bytes,3,opt,name=destination,proto3" json:"destination,omitempty"`
+	Conflict             ConflictPolicy `protobuf:"varint,4,opt,name=conflict,proto3,enum=file.ConflictPolicy" json:"conflict,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *CreateArchiveRequest) Reset()         { *m = CreateArchiveRequest{} }
+func (m *CreateArchiveRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateArchiveRequest) ProtoMessage()    {}
+func (*CreateArchiveRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{35}
+}
+
+func (m *CreateArchiveRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateArchiveRequest.Unmarshal(m, b)
+}
+func (m *CreateArchiveRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateArchiveRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateArchiveRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateArchiveRequest.Merge(m, src)
+}
+func (m *CreateArchiveRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateArchiveRequest.Size(m)
+}
+func (m *CreateArchiveRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateArchiveRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateArchiveRequest proto.InternalMessageInfo
+
+func (m *CreateArchiveRequest) GetPaths() []string {
+	if m != nil {
+		return m.Paths
+	}
+	return nil
+}
+
+func (m *CreateArchiveRequest) GetFormat() ArchiveFormat {
+	if m != nil {
+		return m.Format
+	}
+	return ArchiveFormat_ZIP
+}
+
+func (m *CreateArchiveRequest) GetDestination() string {
+	if m != nil {
+		return m.Destination
+	}
+	return ""
+}
+
+func (m *CreateArchiveRequest) GetConflict() ConflictPolicy {
+	if m != nil {
+		return m.Conflict
+	}
+	return ConflictPolicy_FAIL
+}
+
+// The data of the archive follow one an other when it is not saved, the
+// progress is sent until it is saved otherwise.
+type CreateArchiveResponse struct {
+	Data                 []byte            `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Progress             *TransferProgress `protobuf:"bytes,2,opt,name=progress,proto3" json:"progress,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *CreateArchiveResponse) Reset()         { *m = CreateArchiveResponse{} }
+func (m *CreateArchiveResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateArchiveResponse) ProtoMessage()    {}
+func (*CreateArchiveResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{36}
+}
+
+func (m *CreateArchiveResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateArchiveResponse.Unmarshal(m, b)
+}
+func (m *CreateArchiveResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateArchiveResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateArchiveResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateArchiveResponse.Merge(m, src)
+}
+func (m *CreateArchiveResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateArchiveResponse.Size(m)
+}
+func (m *CreateArchiveResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateArchiveResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateArchiveResponse proto.InternalMessageInfo
+
+func (m *CreateArchiveResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *CreateArchiveResponse) GetProgress() *TransferProgress {
+	if m != nil {
+		return m.Progress
+	}
+	return nil
+}
+
+type ExtractArchiveRequest struct {
+	Path                 string         `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Destination          string         `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
+	Conflict             ConflictPolicy `protobuf:"varint,3,opt,name=conflict,proto3,enum=file.ConflictPolicy" json:"conflict,omitempty"`
+	MaxSize              int64          `protobuf:"varint,4,opt,name=maxSize,proto3" json:"maxSize,omitempty"`
+	MaxFiles             int64          `protobuf:"varint,5,opt,name=maxFiles,proto3" json:"maxFiles,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ExtractArchiveRequest) Reset()         { *m = ExtractArchiveRequest{} }
+func (m *ExtractArchiveRequest) String() string { return proto.CompactTextString(m) }
+func (*ExtractArchiveRequest) ProtoMessage()    {}
+func (*ExtractArchiveRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{37}
+}
+
+func (m *ExtractArchiveRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExtractArchiveRequest.Unmarshal(m, b)
+}
+func (m *ExtractArchiveRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExtractArchiveRequest.Marshal(b, m, deterministic)
+}
+func (m *ExtractArchiveRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExtractArchiveRequest.Merge(m, src)
+}
+func (m *ExtractArchiveRequest) XXX_Size() int {
+	return xxx_messageInfo_ExtractArchiveRequest.Size(m)
+}
+func (m *ExtractArchiveRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExtractArchiveRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExtractArchiveRequest proto.InternalMessageInfo
+
+func (m *ExtractArchiveRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *ExtractArchiveRequest) GetDestination() string {
+	if m != nil {
+		return m.Destination
+	}
+	return ""
+}
+
+func (m *ExtractArchiveRequest) GetConflict() ConflictPolicy {
+	if m != nil {
+		return m.Conflict
+	}
+	return ConflictPolicy_FAIL
+}
+
+func (m *ExtractArchiveRequest) GetMaxSize() int64 {
+	if m != nil {
+		return m.MaxSize
+	}
+	return 0
+}
+
+func (m *ExtractArchiveRequest) GetMaxFiles() int64 {
+	if m != nil {
+		return m.MaxFiles
+	}
+	return 0
+}
+
+type ExtractArchiveResponse struct {
+	Progress             *TransferProgress `protobuf:"bytes,1,opt,name=progress,proto3" json:"progress,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ExtractArchiveResponse) Reset()         { *m = ExtractArchiveResponse{} }
+func (m *ExtractArchiveResponse) String() string { return proto.CompactTextString(m) }
+func (*ExtractArchiveResponse) ProtoMessage()    {}
+func (*ExtractArchiveResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{38}
+}
+
+func (m *ExtractArchiveResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExtractArchiveResponse.Unmarshal(m, b)
+}
+func (m *ExtractArchiveResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExtractArchiveResponse.Marshal(b, m, deterministic)
+}
+func (m *ExtractArchiveResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExtractArchiveResponse.Merge(m, src)
+}
+func (m *ExtractArchiveResponse) XXX_Size() int {
+	return xxx_messageInfo_ExtractArchiveResponse.Size(m)
+}
+func (m *ExtractArchiveResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExtractArchiveResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExtractArchiveResponse proto.InternalMessageInfo
+
+func (m *ExtractArchiveResponse) GetProgress() *TransferProgress {
+	if m != nil {
+		return m.Progress
+	}
+	return nil
+}
+
+type ListArchiveRequest struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListArchiveRequest) Reset()         { *m = ListArchiveRequest{} }
+func (m *ListArchiveRequest) String() string { return proto.CompactTextString(m) }
+func (*ListArchiveRequest) ProtoMessage()    {}
+func (*ListArchiveRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{39}
+}
+
+func (m *ListArchiveRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListArchiveRequest.Unmarshal(m, b)
+}
+func (m *ListArchiveRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListArchiveRequest.Marshal(b, m, deterministic)
+}
+func (m *ListArchiveRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListArchiveRequest.Merge(m, src)
+}
+func (m *ListArchiveRequest) XXX_Size() int {
+	return xxx_messageInfo_ListArchiveRequest.Size(m)
+}
+func (m *ListArchiveRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListArchiveRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListArchiveRequest proto.InternalMessageInfo
+
+func (m *ListArchiveRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+type ArchiveEntry struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Size                 int64    `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	CompressedSize       int64    `protobuf:"varint,3,opt,name=compressedSize,proto3" json:"compressedSize,omitempty"`
+	Mode                 uint32   `protobuf:"varint,4,opt,name=mode,proto3" json:"mode,omitempty"`
+	ModTime              int64    `protobuf:"varint,5,opt,name=modTime,proto3" json:"modTime,omitempty"`
+	IsDir                bool     `protobuf:"varint,6,opt,name=isDir,proto3" json:"isDir,omitempty"`
+	Link                 string   `protobuf:"bytes,7,opt,name=link,proto3" json:"link,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ArchiveEntry) Reset()         { *m = ArchiveEntry{} }
+func (m *ArchiveEntry) String() string { return proto.CompactTextString(m) }
+func (*ArchiveEntry) ProtoMessage()    {}
+func (*ArchiveEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{40}
+}
+
+func (m *ArchiveEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ArchiveEntry.Unmarshal(m, b)
+}
+func (m *ArchiveEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ArchiveEntry.Marshal(b, m, deterministic)
+}
+func (m *ArchiveEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArchiveEntry.Merge(m, src)
+}
+func (m *ArchiveEntry) XXX_Size() int {
+	return xxx_messageInfo_ArchiveEntry.Size(m)
+}
+func (m *ArchiveEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArchiveEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ArchiveEntry proto.InternalMessageInfo
+
+func (m *ArchiveEntry) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ArchiveEntry) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *ArchiveEntry) GetCompressedSize() int64 {
+	if m != nil {
+		return m.CompressedSize
+	}
+	return 0
+}
+
+func (m *ArchiveEntry) GetMode() uint32 {
+	if m != nil {
+		return m.Mode
+	}
+	return 0
+}
+
+func (m *ArchiveEntry) GetModTime() int64 {
+	if m != nil {
+		return m.ModTime
+	}
+	return 0
+}
+
+func (m *ArchiveEntry) GetIsDir() bool {
+	if m != nil {
+		return m.IsDir
+	}
+	return false
+}
+
+func (m *ArchiveEntry) GetLink() string {
+	if m != nil {
+		return m.Link
+	}
+	return ""
+}
+
+// One message by entry.
+type ListArchiveResponse struct {
+	Entry                *ArchiveEntry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ListArchiveResponse) Reset()         { *m = ListArchiveResponse{} }
+func (m *ListArchiveResponse) String() string { return proto.CompactTextString(m) }
+func (*ListArchiveResponse) ProtoMessage()    {}
+func (*ListArchiveResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fe29353663d6fe2c, []int{41}
 }
 
-func (m *DeleteFileResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DeleteFileResponse.Unmarshal(m, b)
+func (m *ListArchiveResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListArchiveResponse.Unmarshal(m, b)
 }
-func (m *DeleteFileResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DeleteFileResponse.Marshal(b, m, deterministic)
+func (m *ListArchiveResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListArchiveResponse.Marshal(b, m, deterministic)
 }
-func (m *DeleteFileResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteFileResponse.Merge(m, src)
+func (m *ListArchiveResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListArchiveResponse.Merge(m, src)
 }
-func (m *DeleteFileResponse) XXX_Size() int {
-	return xxx_messageInfo_DeleteFileResponse.Size(m)
+func (m *ListArchiveResponse) XXX_Size() int {
+	return xxx_messageInfo_ListArchiveResponse.Size(m)
 }
-func (m *DeleteFileResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteFileResponse.DiscardUnknown(m)
+func (m *ListArchiveResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListArchiveResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteFileResponse proto.InternalMessageInfo
+var xxx_messageInfo_ListArchiveResponse proto.InternalMessageInfo
 
-func (m *DeleteFileResponse) GetResult() bool {
+func (m *ListArchiveResponse) GetEntry() *ArchiveEntry {
 	if m != nil {
-		return m.Result
+		return m.Entry
 	}
-	return false
+	return nil
 }
 
 // Return all images thumnails from a directory
@@ -800,7 +2841,7 @@ func (m *GetThumbnailsRequest) Reset()         { *m = GetThumbnailsRequest{} }
 func (m *GetThumbnailsRequest) String() string { return proto.CompactTextString(m) }
 func (*GetThumbnailsRequest) ProtoMessage()    {}
 func (*GetThumbnailsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fe29353663d6fe2c, []int{17}
+	return fileDescriptor_fe29353663d6fe2c, []int{42}
 }
 
 func (m *GetThumbnailsRequest) XXX_Unmarshal(b []byte) error {
@@ -849,18 +2890,19 @@ func (m *GetThumbnailsRequest) GetThumnailHeight() int32 {
 	return 0
 }
 
+// One message by image.
 type GetThumbnailsResponse struct {
-	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Info                 *FileInfo `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
 func (m *GetThumbnailsResponse) Reset()         { *m = GetThumbnailsResponse{} }
 func (m *GetThumbnailsResponse) String() string { return proto.CompactTextString(m) }
 func (*GetThumbnailsResponse) ProtoMessage()    {}
 func (*GetThumbnailsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fe29353663d6fe2c, []int{18}
+	return fileDescriptor_fe29353663d6fe2c, []int{43}
 }
 
 func (m *GetThumbnailsResponse) XXX_Unmarshal(b []byte) error {
@@ -881,15 +2923,24 @@ func (m *GetThumbnailsResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_GetThumbnailsResponse proto.InternalMessageInfo
 
-func (m *GetThumbnailsResponse) GetData() []byte {
+func (m *GetThumbnailsResponse) GetInfo() *FileInfo {
 	if m != nil {
-		return m.Data
+		return m.Info
 	}
 	return nil
 }
 
 func init() {
+	proto.RegisterEnum("file.SortOrder", SortOrder_name, SortOrder_value)
+	proto.RegisterEnum("file.ConflictPolicy", ConflictPolicy_name, ConflictPolicy_value)
+	proto.RegisterEnum("file.FileChangeType", FileChangeType_name, FileChangeType_value)
+	proto.RegisterEnum("file.SaveMode", SaveMode_name, SaveMode_value)
+	proto.RegisterEnum("file.ResizeMode", ResizeMode_name, ResizeMode_value)
+	proto.RegisterEnum("file.FlipDirection", FlipDirection_name, FlipDirection_value)
+	proto.RegisterEnum("file.ImageFormat", ImageFormat_name, ImageFormat_value)
+	proto.RegisterEnum("file.ArchiveFormat", ArchiveFormat_name, ArchiveFormat_value)
 	proto.RegisterType((*Empty)(nil), "file.Empty")
+	proto.RegisterType((*FileInfo)(nil), "file.FileInfo")
 	proto.RegisterType((*ReadDirRequest)(nil), "file.ReadDirRequest")
 	proto.RegisterType((*ReadDirResponse)(nil), "file.ReadDirResponse")
 	proto.RegisterType((*CreateDirRequest)(nil), "file.CreateDirRequest")
@@ -898,6 +2949,16 @@ func init() {
 	proto.RegisterType((*DeleteDirResponse)(nil), "file.DeleteDirResponse")
 	proto.RegisterType((*RenameRequest)(nil), "file.RenameRequest")
 	proto.RegisterType((*RenameResponse)(nil), "file.RenameResponse")
+	proto.RegisterType((*TransferProgress)(nil), "file.TransferProgress")
+	proto.RegisterType((*CopyRequest)(nil), "file.CopyRequest")
+	proto.RegisterType((*CopyResponse)(nil), "file.CopyResponse")
+	proto.RegisterType((*MoveRequest)(nil), "file.MoveRequest")
+	proto.RegisterType((*MoveResponse)(nil), "file.MoveResponse")
+	proto.RegisterType((*FileChange)(nil), "file.FileChange")
+	proto.RegisterType((*WatchDirRequest)(nil), "file.WatchDirRequest")
+	proto.RegisterType((*WatchDirResponse)(nil), "file.WatchDirResponse")
+	proto.RegisterType((*SearchRequest)(nil), "file.SearchRequest")
+	proto.RegisterType((*SearchResponse)(nil), "file.SearchResponse")
 	proto.RegisterType((*GetFileInfoRequest)(nil), "file.GetFileInfoRequest")
 	proto.RegisterType((*GetFileInfoResponse)(nil), "file.GetFileInfoResponse")
 	proto.RegisterType((*ReadFileRequest)(nil), "file.ReadFileRequest")
@@ -906,6 +2967,20 @@ func init() {
 	proto.RegisterType((*SaveFileResponse)(nil), "file.SaveFileResponse")
 	proto.RegisterType((*DeleteFileRequest)(nil), "file.DeleteFileRequest")
 	proto.RegisterType((*DeleteFileResponse)(nil), "file.DeleteFileResponse")
+	proto.RegisterType((*GetThumbnailRequest)(nil), "file.GetThumbnailRequest")
+	proto.RegisterType((*GetThumbnailResponse)(nil), "file.GetThumbnailResponse")
+	proto.RegisterType((*ResizeOperation)(nil), "file.ResizeOperation")
+	proto.RegisterType((*CropOperation)(nil), "file.CropOperation")
+	proto.RegisterType((*ImageOperation)(nil), "file.ImageOperation")
+	proto.RegisterType((*ImageTransformRequest)(nil), "file.ImageTransformRequest")
+	proto.RegisterType((*ImageTransformResponse)(nil), "file.ImageTransformResponse")
+	proto.RegisterType((*CreateArchiveRequest)(nil), "file.CreateArchiveRequest")
+	proto.RegisterType((*CreateArchiveResponse)(nil), "file.CreateArchiveResponse")
+	proto.RegisterType((*ExtractArchiveRequest)(nil), "file.ExtractArchiveRequest")
+	proto.RegisterType((*ExtractArchiveResponse)(nil), "file.ExtractArchiveResponse")
+	proto.RegisterType((*ListArchiveRequest)(nil), "file.ListArchiveRequest")
+	proto.RegisterType((*ArchiveEntry)(nil), "file.ArchiveEntry")
+	proto.RegisterType((*ListArchiveResponse)(nil), "file.ListArchiveResponse")
 	proto.RegisterType((*GetThumbnailsRequest)(nil), "file.GetThumbnailsRequest")
 	proto.RegisterType((*GetThumbnailsResponse)(nil), "file.GetThumbnailsResponse")
 }
@@ -913,44 +2988,148 @@ func init() {
 func init() { proto.RegisterFile("file/filepb/file.proto", fileDescriptor_fe29353663d6fe2c) }
 
 var fileDescriptor_fe29353663d6fe2c = []byte{
-	// 591 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x55, 0xcd, 0x6e, 0xd3, 0x40,
-	0x10, 0x8e, 0x9b, 0x34, 0x71, 0xa6, 0x4d, 0x1b, 0xa6, 0x69, 0x70, 0x0d, 0x87, 0xc8, 0x82, 0x10,
-	0x28, 0x2a, 0x08, 0xc4, 0xa5, 0x52, 0x91, 0x28, 0x29, 0x2d, 0x12, 0xe2, 0xe0, 0x22, 0x21, 0xb8,
-	0x20, 0xa7, 0x99, 0x12, 0x4b, 0x8e, 0x1d, 0xec, 0x4d, 0x2a, 0xde, 0x82, 0x13, 0x6f, 0xc6, 0xfb,
-	0xa0, 0xf5, 0x7a, 0x9d, 0xb5, 0x1d, 0x62, 0x8e, 0xbd, 0x24, 0xeb, 0xf9, 0xf9, 0xfc, 0xcd, 0xcf,
-	0xb7, 0x86, 0xee, 0xb5, 0xeb, 0xd1, 0x33, 0xfe, 0x33, 0x1b, 0xc5, 0x7f, 0x47, 0xb3, 0x30, 0x60,
-	0x01, 0xd6, 0xf8, 0xd9, 0x6a, 0xc0, 0xe6, 0xd9, 0x74, 0xc6, 0x7e, 0x5a, 0xbf, 0x34, 0xd8, 0xb1,
-	0xc9, 0x19, 0x0f, 0xdd, 0xd0, 0xa6, 0x1f, 0x73, 0x8a, 0x18, 0x22, 0xd4, 0x66, 0x0e, 0x9b, 0x18,
-	0x5a, 0x4f, 0x1b, 0x34, 0xed, 0xf8, 0x8c, 0xf7, 0xa1, 0x19, 0xd2, 0xd5, 0x3c, 0x8c, 0xdc, 0x05,
-	0x19, 0x1b, 0x3d, 0x6d, 0xa0, 0xdb, 0x4b, 0x03, 0x3e, 0x80, 0x16, 0x9b, 0xcc, 0xa7, 0xbe, 0xe3,
-	0x7a, 0x9f, 0xdd, 0x31, 0x9b, 0x18, 0xd5, 0x9e, 0x36, 0xd8, 0xb4, 0xb3, 0x46, 0xec, 0xc3, 0x8e,
-	0x34, 0x5c, 0x90, 0xfb, 0x7d, 0xc2, 0x8c, 0x5a, 0x1c, 0x96, 0xb3, 0x5a, 0x0f, 0x61, 0x37, 0x65,
-	0x14, 0xcd, 0x02, 0x3f, 0x22, 0x4e, 0x69, 0xec, 0x30, 0x27, 0xa6, 0xb4, 0x6d, 0xc7, 0x67, 0xeb,
-	0x18, 0xda, 0x6f, 0x43, 0x72, 0x18, 0x95, 0x50, 0x47, 0xa8, 0xf9, 0xce, 0x54, 0xb0, 0x6e, 0xda,
-	0xf1, 0xd9, 0x3a, 0x84, 0x3b, 0x4a, 0x6e, 0xf2, 0x92, 0x2e, 0xd4, 0x43, 0x8a, 0xe6, 0x1e, 0x8b,
-	0xd3, 0x75, 0x3b, 0x79, 0xb2, 0xfa, 0xd0, 0x1e, 0x92, 0x47, 0x65, 0x2f, 0xe2, 0xa0, 0x4a, 0x5c,
-	0x09, 0xe8, 0x17, 0x68, 0xd9, 0xc4, 0xb9, 0xac, 0xa3, 0x7e, 0x00, 0xba, 0x4f, 0x37, 0xdf, 0x14,
-	0xfa, 0x0d, 0x9f, 0x6e, 0x3e, 0x3a, 0x53, 0xe2, 0xae, 0xc0, 0x1b, 0x0b, 0x57, 0x55, 0xb8, 0x02,
-	0x6f, 0xcc, 0x5d, 0xd6, 0x80, 0x4f, 0x54, 0x40, 0x97, 0x90, 0x58, 0x00, 0x9e, 0x13, 0x7b, 0xe7,
-	0x7a, 0xf4, 0xde, 0xbf, 0x0e, 0xd6, 0x31, 0x29, 0x4c, 0x78, 0xe3, 0xff, 0x26, 0x5c, 0x5d, 0x39,
-	0xe1, 0xc7, 0xb0, 0x97, 0x79, 0xef, 0x8a, 0x29, 0x37, 0x93, 0x29, 0x27, 0xcb, 0xc0, 0x63, 0xd7,
-	0xf5, 0xbe, 0x0f, 0xed, 0x65, 0xd8, 0x9a, 0xa5, 0x39, 0x83, 0xdd, 0x4b, 0x67, 0x41, 0x2a, 0x5c,
-	0x47, 0x85, 0xbb, 0xa8, 0x24, 0x05, 0x77, 0x92, 0x64, 0x5e, 0xe7, 0x36, 0xb7, 0xf2, 0xa7, 0xd3,
-	0x3a, 0x08, 0xf9, 0x3c, 0x81, 0xf6, 0x12, 0xa6, 0xa4, 0xc9, 0x8f, 0xe4, 0x5a, 0x94, 0xd5, 0xf0,
-	0x14, 0x50, 0x0d, 0x2c, 0x81, 0xfd, 0xad, 0x41, 0xe7, 0x9c, 0xd8, 0xa7, 0xc9, 0x7c, 0x3a, 0xe2,
-	0xad, 0x8d, 0x6e, 0x8b, 0x7c, 0x0f, 0x61, 0x3f, 0xc7, 0xeb, 0xdf, 0xf3, 0x78, 0xf1, 0xa7, 0x06,
-	0x5b, 0xbc, 0xdc, 0x4b, 0x0a, 0x17, 0xee, 0x15, 0xe1, 0x31, 0x34, 0x12, 0xed, 0x63, 0xe7, 0x28,
-	0xbe, 0xb5, 0xb2, 0x97, 0x93, 0xb9, 0x9f, 0xb3, 0x0a, 0x6c, 0xab, 0xf2, 0x5c, 0xc3, 0xd7, 0xd0,
-	0x4c, 0x45, 0x8d, 0x5d, 0x11, 0x97, 0xbf, 0x21, 0xcc, 0xbb, 0x05, 0xbb, 0x44, 0xe0, 0xf9, 0xa9,
-	0x7e, 0x65, 0x7e, 0x5e, 0xf8, 0x32, 0xbf, 0x20, 0x74, 0xab, 0x82, 0xaf, 0xa0, 0x2e, 0x74, 0x87,
-	0x7b, 0x92, 0xa4, 0x22, 0x70, 0xb3, 0x93, 0x35, 0xa6, 0x69, 0x43, 0xd8, 0x52, 0xc4, 0x80, 0x86,
-	0x08, 0x2b, 0xea, 0xd2, 0x3c, 0x58, 0xe1, 0x49, 0x51, 0x4e, 0x40, 0x97, 0x02, 0x40, 0xa5, 0x47,
-	0xca, 0xce, 0x99, 0xdd, 0xbc, 0x59, 0xe9, 0xdd, 0x09, 0xe8, 0x72, 0xa1, 0x65, 0x7a, 0x4e, 0x27,
-	0x32, 0x3d, 0xbf, 0xf7, 0x56, 0x65, 0xa0, 0xe1, 0x1b, 0x80, 0xe5, 0xea, 0x62, 0xa6, 0x47, 0x2a,
-	0x84, 0x51, 0x74, 0xa4, 0x05, 0x7c, 0x80, 0x56, 0x66, 0x6d, 0xd0, 0x4c, 0xcb, 0x2d, 0xec, 0xb8,
-	0x79, 0x6f, 0xa5, 0x6f, 0x59, 0xcf, 0xa9, 0xfe, 0xb5, 0x2e, 0x3e, 0x7d, 0xa3, 0x7a, 0xfc, 0xd9,
-	0x7b, 0xf9, 0x37, 0x00, 0x00, 0xff, 0xff, 0xe2, 0xc5, 0xa6, 0x78, 0x10, 0x07, 0x00, 0x00,
+	// 2245 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x59, 0xdd, 0x6e, 0xdb, 0xc8,
+	0x15, 0x36, 0x25, 0x4a, 0xa6, 0x8e, 0x6c, 0x99, 0x99, 0xd8, 0x5a, 0x45, 0x09, 0x16, 0x06, 0x1b,
+	0xa4, 0x8a, 0xb7, 0xd8, 0xdd, 0xb8, 0xdd, 0x2e, 0xb0, 0x40, 0x52, 0xc8, 0x16, 0x65, 0x6b, 0x2b,
+	0x5b, 0xc2, 0x48, 0xd8, 0x20, 0xb9, 0x31, 0x18, 0x69, 0x64, 0x11, 0x91, 0x48, 0x2d, 0x49, 0x3b,
+	0x56, 0x2e, 0xfb, 0x02, 0xbd, 0x6a, 0xdf, 0xa0, 0xbd, 0xeb, 0x55, 0x7f, 0x1e, 0xa2, 0x0f, 0xd1,
+	0xeb, 0x3e, 0x43, 0xaf, 0x8a, 0xf9, 0xa3, 0x86, 0x34, 0x63, 0x7b, 0x91, 0x2e, 0xba, 0x37, 0xf6,
+	0x9c, 0x33, 0x33, 0xe7, 0xe7, 0x9b, 0x33, 0x73, 0x0e, 0x8f, 0xa0, 0x3a, 0x71, 0x67, 0xe4, 0x0b,
+	0xfa, 0x67, 0xf1, 0x86, 0xfd, 0xfb, 0x7c, 0x11, 0xf8, 0x91, 0x8f, 0x74, 0x3a, 0xb6, 0xd6, 0xa1,
+	0x60, 0xcf, 0x17, 0xd1, 0xd2, 0xfa, 0xb7, 0x06, 0x46, 0xdb, 0x9d, 0x91, 0x8e, 0x37, 0xf1, 0x11,
+	0x02, 0xdd, 0x73, 0xe6, 0xa4, 0xa6, 0xed, 0x6a, 0x8d, 0x12, 0x66, 0x63, 0xca, 0x5b, 0x38, 0xd1,
+	0xb4, 0x96, 0xe3, 0x3c, 0x3a, 0xa6, 0xbc, 0xd0, 0x7d, 0x4f, 0x6a, 0xf9, 0x5d, 0xad, 0x91, 0xc7,
+	0x6c, 0x4c, 0x79, 0x73, 0x7f, 0x4c, 0x6a, 0xfa, 0xae, 0xd6, 0xd8, 0xc4, 0x6c, 0x8c, 0x6a, 0xb0,
+	0x3e, 0xf7, 0xc7, 0x43, 0x77, 0x4e, 0x6a, 0x05, 0xb6, 0x54, 0x92, 0x68, 0x1b, 0x0a, 0x6e, 0xd8,
+	0x72, 0x83, 0x5a, 0x71, 0x57, 0x6b, 0x18, 0x98, 0x13, 0x4c, 0x06, 0x5d, 0xbc, 0xce, 0x75, 0xd1,
+	0x31, 0x7a, 0x04, 0xa5, 0x68, 0x7a, 0x31, 0x7f, 0xe3, 0x39, 0xee, 0xac, 0x66, 0xb0, 0x89, 0x15,
+	0x83, 0xca, 0x19, 0x93, 0x45, 0x34, 0xad, 0x95, 0x76, 0xb5, 0x46, 0x01, 0x73, 0x82, 0x72, 0x49,
+	0x10, 0xf8, 0x41, 0x0d, 0xd8, 0x7a, 0x4e, 0x58, 0x7f, 0xca, 0x41, 0x05, 0x13, 0x67, 0xdc, 0x72,
+	0x03, 0x4c, 0xbe, 0xbf, 0x20, 0x61, 0x14, 0x3b, 0xa7, 0x29, 0xce, 0x3d, 0x82, 0x52, 0x40, 0x46,
+	0x17, 0x41, 0xe8, 0x5e, 0x12, 0xe6, 0xb5, 0x81, 0x57, 0x0c, 0xf4, 0x18, 0x36, 0xa9, 0x76, 0xaa,
+	0xfc, 0xa5, 0x3b, 0x8e, 0xa6, 0x0c, 0x83, 0x02, 0x4e, 0x32, 0xd1, 0x13, 0xa8, 0x48, 0xc6, 0x31,
+	0x71, 0xcf, 0xa7, 0x11, 0x83, 0xa5, 0x80, 0x53, 0x5c, 0xf4, 0x33, 0xd0, 0x43, 0x3f, 0x88, 0x18,
+	0x3a, 0x95, 0xfd, 0xad, 0xcf, 0xd9, 0x39, 0x0d, 0xfc, 0x20, 0xea, 0x05, 0x63, 0x12, 0x60, 0x36,
+	0x89, 0x3e, 0x05, 0x18, 0x93, 0x70, 0x44, 0xbc, 0xb1, 0xeb, 0x9d, 0x0b, 0xc0, 0x14, 0x0e, 0xaa,
+	0x83, 0xb1, 0x70, 0xce, 0xc9, 0xc0, 0x7d, 0xcf, 0x91, 0x2b, 0xe0, 0x98, 0xa6, 0xce, 0xd0, 0xf1,
+	0xd0, 0x7f, 0x4b, 0x3c, 0x89, 0x5e, 0xcc, 0xc8, 0x46, 0xcf, 0x72, 0x60, 0x2b, 0x86, 0x29, 0x5c,
+	0xf8, 0x5e, 0x48, 0x90, 0x05, 0xba, 0xeb, 0x4d, 0x7c, 0x06, 0x47, 0x79, 0xbf, 0xc2, 0xed, 0x94,
+	0x61, 0x83, 0xd9, 0x1c, 0x45, 0xc6, 0x23, 0x57, 0x51, 0x3f, 0x56, 0x97, 0x67, 0xea, 0x92, 0xcc,
+	0x6f, 0x75, 0x43, 0x33, 0x73, 0xd6, 0x37, 0x60, 0x1e, 0x06, 0xc4, 0x89, 0xc8, 0x2d, 0x67, 0x21,
+	0x03, 0x32, 0xb7, 0x0a, 0x48, 0xeb, 0x33, 0xb8, 0xa7, 0xec, 0x15, 0x06, 0x56, 0xa1, 0x18, 0x90,
+	0xf0, 0x62, 0x16, 0xb1, 0xed, 0x06, 0x16, 0x94, 0xf5, 0x04, 0xcc, 0x16, 0x99, 0x91, 0xdb, 0x14,
+	0x51, 0xa1, 0xca, 0xba, 0x5b, 0x84, 0xbe, 0x82, 0x4d, 0x4c, 0xa8, 0x2d, 0x37, 0x99, 0xfe, 0x00,
+	0x0c, 0x8f, 0xbc, 0x3b, 0x53, 0xcc, 0x5f, 0xf7, 0xc8, 0xbb, 0x53, 0x7a, 0xa5, 0x1e, 0x80, 0xe1,
+	0xcf, 0xc6, 0x7c, 0x8a, 0x83, 0xb4, 0xee, 0xcf, 0xc6, 0x74, 0xca, 0x6a, 0xd0, 0x10, 0xe5, 0xa2,
+	0x6f, 0x31, 0xe2, 0x9f, 0x1a, 0x98, 0xc3, 0xc0, 0xf1, 0xc2, 0x09, 0x09, 0xfa, 0x81, 0x7f, 0x1e,
+	0x90, 0x30, 0xcc, 0x34, 0x64, 0x1b, 0x0a, 0xf4, 0xb8, 0x42, 0x66, 0x45, 0x1e, 0x73, 0x82, 0x06,
+	0x55, 0xe4, 0x47, 0xce, 0xac, 0xcd, 0xa6, 0xf8, 0x45, 0x56, 0x38, 0xf1, 0x15, 0xd7, 0x95, 0x2b,
+	0x4e, 0xaf, 0x22, 0x5d, 0xc1, 0x22, 0x8d, 0x5f, 0xe8, 0x15, 0x83, 0x5e, 0xf6, 0xf0, 0xad, 0xbb,
+	0x58, 0x90, 0x31, 0x8b, 0xd1, 0x3c, 0x96, 0x24, 0xda, 0x85, 0xf2, 0x98, 0x84, 0x91, 0xeb, 0x39,
+	0x91, 0xeb, 0x7b, 0xe2, 0x76, 0xab, 0x2c, 0x6b, 0x09, 0xe5, 0x43, 0x7f, 0xb1, 0x94, 0x78, 0x56,
+	0xa1, 0x18, 0xfa, 0x17, 0xc1, 0x48, 0xbe, 0x44, 0x82, 0x4a, 0x0b, 0xca, 0x5d, 0x13, 0x84, 0xbe,
+	0x04, 0x63, 0xe4, 0x7b, 0x93, 0x99, 0x3b, 0x8a, 0x98, 0x53, 0x95, 0xfd, 0x6d, 0x1e, 0xac, 0x87,
+	0x82, 0xdb, 0xf7, 0x67, 0xee, 0x68, 0x89, 0xe3, 0x55, 0xd6, 0x01, 0x6c, 0x70, 0xd5, 0x02, 0xef,
+	0x7d, 0x30, 0x16, 0x02, 0x4e, 0xa6, 0xbd, 0xbc, 0x5f, 0xe5, 0x12, 0xd2, 0x60, 0xe3, 0x78, 0x1d,
+	0x35, 0xff, 0xc4, 0xbf, 0x24, 0xff, 0x27, 0xf3, 0xb9, 0xea, 0x8f, 0x30, 0xff, 0x3d, 0x00, 0x3d,
+	0xf4, 0xc3, 0xa9, 0xe3, 0x9d, 0x13, 0xd4, 0x00, 0x3d, 0x5a, 0x2e, 0xb8, 0xed, 0xb1, 0xfe, 0xd5,
+	0xfc, 0x70, 0xb9, 0x20, 0x98, 0xad, 0xc8, 0x4c, 0x0d, 0x35, 0xa0, 0xb1, 0xdc, 0x77, 0xa2, 0xa9,
+	0x12, 0xda, 0x7d, 0x11, 0x87, 0xfc, 0xc9, 0xd7, 0x95, 0x27, 0xdf, 0x7a, 0x05, 0x5b, 0x2f, 0x9d,
+	0x68, 0x34, 0xfd, 0xa8, 0x47, 0x99, 0xbd, 0x63, 0x33, 0x67, 0x29, 0x1e, 0x63, 0x4e, 0x58, 0x2f,
+	0xc0, 0x5c, 0x89, 0x16, 0xf0, 0xec, 0xc1, 0xfa, 0x88, 0xb9, 0x41, 0xd1, 0xc9, 0x37, 0xca, 0xfb,
+	0x66, 0xda, 0x3f, 0x2c, 0x17, 0x58, 0x7f, 0xcd, 0xc1, 0xe6, 0x80, 0x38, 0xc1, 0x68, 0xfa, 0x03,
+	0x9f, 0x28, 0x6a, 0x4f, 0x40, 0xce, 0xc9, 0x95, 0x80, 0x80, 0x13, 0x71, 0x76, 0xd3, 0x95, 0xec,
+	0x46, 0x33, 0xa4, 0xeb, 0x29, 0x17, 0x4a, 0x92, 0x6c, 0xc6, 0xb9, 0x62, 0x33, 0xe2, 0x3a, 0x09,
+	0x92, 0x3e, 0xb4, 0x73, 0x7f, 0xec, 0x4e, 0x5c, 0x32, 0x6e, 0x4e, 0x22, 0x12, 0xb0, 0x0b, 0x95,
+	0xc7, 0x49, 0x26, 0x4d, 0x41, 0x92, 0x71, 0x40, 0x26, 0x7e, 0x40, 0xd8, 0xf3, 0x9f, 0xc7, 0x29,
+	0x2e, 0xd5, 0x33, 0xf2, 0xbd, 0x88, 0x78, 0x11, 0xcb, 0x02, 0x25, 0x2c, 0x49, 0xfa, 0x44, 0xb8,
+	0xe7, 0x9e, 0x1f, 0x90, 0x43, 0x27, 0x24, 0x2c, 0x95, 0x1a, 0x58, 0xe1, 0x50, 0x2f, 0x67, 0xee,
+	0xdc, 0x8d, 0x6a, 0x65, 0x8e, 0x3a, 0x23, 0xac, 0x3f, 0x6b, 0x50, 0x91, 0xa8, 0x09, 0xd0, 0x3f,
+	0x00, 0x1b, 0x7b, 0x5f, 0x72, 0xca, 0xfb, 0xa2, 0x94, 0x0b, 0xf9, 0x0f, 0x94, 0x0b, 0x7a, 0x56,
+	0xb9, 0x50, 0x50, 0x00, 0x45, 0xa0, 0xcf, 0x5c, 0x8f, 0x63, 0x56, 0xc0, 0x6c, 0x4c, 0x77, 0xcf,
+	0x69, 0x20, 0x88, 0x97, 0x87, 0x13, 0xd6, 0x25, 0xa0, 0x23, 0x12, 0xc5, 0x49, 0xec, 0x86, 0x23,
+	0xbe, 0x96, 0xf3, 0x73, 0x77, 0xcb, 0xf9, 0xf9, 0xac, 0x9c, 0x6f, 0x3d, 0x85, 0xfb, 0x09, 0xbd,
+	0x2b, 0x90, 0xc6, 0x4e, 0xe4, 0x48, 0xc5, 0x74, 0x6c, 0xfd, 0x5e, 0xe3, 0xa9, 0x98, 0x2e, 0xbe,
+	0xc9, 0xc0, 0x2a, 0x14, 0xfd, 0xc9, 0x24, 0x24, 0x91, 0x80, 0x53, 0x50, 0x94, 0x3f, 0x23, 0xde,
+	0xb9, 0xb8, 0x8b, 0x79, 0x2c, 0x28, 0x7a, 0x9b, 0x46, 0xd3, 0x0b, 0xef, 0xed, 0x40, 0xbe, 0xf0,
+	0x05, 0xbc, 0x62, 0xd0, 0x7a, 0x62, 0x34, 0x25, 0xa3, 0xb7, 0xe1, 0xc5, 0x9c, 0x41, 0x6b, 0xe0,
+	0x98, 0xb6, 0x22, 0x30, 0x57, 0x06, 0x65, 0x58, 0xbe, 0xc1, 0x2d, 0xcf, 0x3c, 0xde, 0x5d, 0x28,
+	0x8b, 0xf3, 0x3c, 0x75, 0x3c, 0x5f, 0x98, 0xa4, 0xb2, 0xd8, 0xc3, 0x39, 0x75, 0xf6, 0xbf, 0xfa,
+	0x35, 0x33, 0x6a, 0x03, 0x0b, 0xca, 0xfa, 0x83, 0x06, 0x5b, 0x03, 0xe7, 0x92, 0xa8, 0x38, 0x6c,
+	0xab, 0x38, 0x1c, 0xaf, 0xc5, 0xc9, 0x8e, 0xdb, 0x42, 0xf5, 0x6e, 0x50, 0x6e, 0xc2, 0x1a, 0xb5,
+	0x5e, 0xfd, 0x80, 0x2e, 0x5a, 0xea, 0xb0, 0x3a, 0x96, 0x97, 0x64, 0xa2, 0xd4, 0xa1, 0xca, 0x4f,
+	0xfc, 0x31, 0xe1, 0x75, 0xed, 0x41, 0x11, 0x78, 0x15, 0xfd, 0x02, 0xcc, 0x95, 0x59, 0x37, 0xe7,
+	0xeb, 0x2c, 0x44, 0xac, 0x9f, 0xcb, 0xaa, 0xe3, 0x96, 0x03, 0xb6, 0x7e, 0x01, 0x48, 0x5d, 0x78,
+	0x4b, 0x69, 0xf0, 0x92, 0x45, 0xd8, 0x50, 0x16, 0xc9, 0x37, 0x45, 0xce, 0x36, 0x14, 0xde, 0x29,
+	0x21, 0xcd, 0x09, 0x2a, 0x78, 0xaa, 0x86, 0xb0, 0xa0, 0xac, 0x19, 0x6c, 0x27, 0x05, 0xdf, 0x1c,
+	0x01, 0xec, 0x72, 0xe6, 0x94, 0xcb, 0x19, 0x6b, 0xcb, 0x67, 0x6b, 0xd3, 0x13, 0xda, 0x08, 0x0d,
+	0x7e, 0x8a, 0x53, 0x6f, 0x41, 0x02, 0x9e, 0x1f, 0x63, 0x01, 0x5a, 0xb6, 0x80, 0x9c, 0x2a, 0x00,
+	0x3d, 0x16, 0x47, 0xc9, 0x33, 0xa9, 0x78, 0xe9, 0xb9, 0xc8, 0xd5, 0x61, 0xd2, 0x6a, 0xee, 0x30,
+	0xf0, 0x17, 0x2b, 0x25, 0x1b, 0xa0, 0x5d, 0x09, 0x05, 0xda, 0x15, 0xa5, 0x96, 0x42, 0xae, 0xb6,
+	0xfc, 0x81, 0x1e, 0xfc, 0x4b, 0x83, 0x4a, 0x67, 0xee, 0x9c, 0x2b, 0x1e, 0x7c, 0xc1, 0xce, 0x8c,
+	0x06, 0x02, 0xcf, 0xce, 0x3b, 0xaa, 0x55, 0xf1, 0xb2, 0xe3, 0x35, 0x2c, 0x96, 0xa1, 0xa7, 0xa0,
+	0x8f, 0x02, 0x7f, 0x21, 0x4a, 0xef, 0xfb, 0xa2, 0x1c, 0x50, 0x0d, 0xa6, 0x61, 0x4e, 0x97, 0xa0,
+	0x1a, 0x14, 0x03, 0x3f, 0x72, 0x22, 0xee, 0x71, 0x81, 0x09, 0x61, 0x34, 0x15, 0x32, 0x99, 0xb9,
+	0x0b, 0x66, 0x5e, 0x45, 0x0a, 0x69, 0xcf, 0xdc, 0x45, 0xcb, 0x0d, 0xc8, 0x48, 0x0a, 0xa1, 0x4b,
+	0xd0, 0xa7, 0x50, 0x3a, 0x0f, 0x9c, 0x65, 0x38, 0x72, 0x66, 0xfc, 0x12, 0x18, 0xc7, 0x6b, 0x78,
+	0xc5, 0x3a, 0x28, 0x43, 0xc9, 0x97, 0x9a, 0xad, 0xff, 0x68, 0xb0, 0xc3, 0x1c, 0xe4, 0xd5, 0x85,
+	0x1f, 0xcc, 0x6f, 0x0a, 0xb6, 0x5f, 0x01, 0xc4, 0x5b, 0x69, 0x39, 0x4a, 0xf3, 0xaf, 0xa8, 0x2f,
+	0x92, 0x28, 0x61, 0x65, 0x1d, 0x7a, 0x0a, 0x45, 0x2a, 0xd8, 0x91, 0x15, 0xd1, 0x3d, 0x65, 0x47,
+	0x9b, 0x4d, 0x60, 0xb1, 0x80, 0x26, 0x90, 0xef, 0x2f, 0x9c, 0x99, 0x1b, 0x2d, 0xc5, 0x41, 0x48,
+	0x32, 0x5d, 0x7a, 0x15, 0x6e, 0x2e, 0xbd, 0x8a, 0x77, 0x2a, 0xbd, 0x7e, 0xa7, 0x41, 0x35, 0xed,
+	0xfc, 0x8f, 0x79, 0x21, 0x62, 0x4c, 0x0b, 0xca, 0xcb, 0xf0, 0x17, 0x0d, 0xb6, 0xf9, 0xe7, 0x50,
+	0x33, 0x18, 0x4d, 0xdd, 0x4b, 0xe5, 0x7d, 0x2c, 0xd0, 0x05, 0xbc, 0xce, 0x29, 0x61, 0x4e, 0xa0,
+	0xcf, 0x62, 0x30, 0x73, 0x6a, 0x28, 0x88, 0xbd, 0x29, 0x38, 0x53, 0xa0, 0xe5, 0x6f, 0x06, 0x4d,
+	0xbf, 0x13, 0x68, 0x67, 0xb0, 0x93, 0x32, 0xf7, 0x06, 0xc8, 0xd4, 0x62, 0x36, 0x77, 0xc7, 0x62,
+	0xf6, 0xef, 0x1a, 0xec, 0xd8, 0x57, 0x51, 0xe0, 0x8c, 0xa2, 0x14, 0x22, 0x59, 0x21, 0xf9, 0x23,
+	0x94, 0xe4, 0x6a, 0xe5, 0xa6, 0x27, 0x2b, 0xb7, 0x3a, 0x18, 0x73, 0xe7, 0x8a, 0x7f, 0x72, 0xf1,
+	0x72, 0x2f, 0xa6, 0xad, 0x2e, 0x54, 0xd3, 0x66, 0x7f, 0x44, 0x49, 0xdf, 0x00, 0xd4, 0x75, 0xc3,
+	0x3b, 0x20, 0x60, 0xfd, 0x43, 0x83, 0x0d, 0xb1, 0xcc, 0xf6, 0xa2, 0x60, 0xf9, 0xa1, 0x26, 0xd0,
+	0xb5, 0x74, 0xfe, 0x04, 0x2a, 0x23, 0x7f, 0xbe, 0xa0, 0xea, 0xc8, 0x78, 0xb0, 0x4a, 0xaf, 0x29,
+	0xee, 0xff, 0xaa, 0x31, 0x34, 0x73, 0xbd, 0xb7, 0xb2, 0x31, 0x44, 0xc7, 0xd6, 0x6f, 0xe0, 0x7e,
+	0xc2, 0x45, 0x81, 0x56, 0x03, 0x0a, 0x84, 0xfa, 0x21, 0xa0, 0x42, 0x89, 0x00, 0x67, 0x1e, 0x62,
+	0xbe, 0xc0, 0xfa, 0xa3, 0x96, 0x4c, 0x67, 0xe1, 0x4f, 0xa4, 0x2b, 0x64, 0x35, 0x61, 0x27, 0x65,
+	0xd7, 0xdd, 0xdb, 0x30, 0xbc, 0xc1, 0xb2, 0x37, 0x80, 0x52, 0xdc, 0x46, 0x42, 0x26, 0x6c, 0x0c,
+	0x7a, 0x78, 0x78, 0x76, 0xf0, 0xea, 0xec, 0xb4, 0x79, 0x62, 0x9b, 0x6b, 0x2a, 0x67, 0xd0, 0x79,
+	0x6d, 0x9b, 0x9a, 0xca, 0x19, 0x76, 0x4e, 0x6c, 0x33, 0x97, 0xe0, 0xbc, 0xea, 0xdb, 0x66, 0x7e,
+	0xef, 0x39, 0x54, 0x92, 0x41, 0x8f, 0x0c, 0xd0, 0xdb, 0xcd, 0x4e, 0xd7, 0x5c, 0x43, 0x65, 0x58,
+	0xc7, 0x76, 0xbf, 0xdb, 0x3c, 0xa4, 0xc2, 0x0c, 0xd0, 0x07, 0xbf, 0xed, 0xf4, 0xcd, 0x1c, 0x02,
+	0x28, 0x62, 0x9b, 0x29, 0xcd, 0xef, 0xbd, 0x82, 0x4a, 0xf2, 0x33, 0x92, 0xaa, 0x68, 0x77, 0xba,
+	0xf6, 0xd9, 0x21, 0xb6, 0x9b, 0x43, 0xbb, 0x65, 0xae, 0xa1, 0x7b, 0xb0, 0xc9, 0x38, 0x27, 0xbd,
+	0x56, 0xa7, 0xdd, 0xb1, 0x5b, 0xdc, 0x32, 0xc6, 0x6a, 0xd9, 0x5d, 0x9b, 0x2e, 0xca, 0xc5, 0x1c,
+	0x2e, 0xb9, 0x65, 0xe6, 0xf7, 0x9e, 0x81, 0x21, 0x4b, 0x34, 0xb4, 0x09, 0xa5, 0xde, 0x77, 0x36,
+	0x7e, 0x89, 0x3b, 0x43, 0xea, 0x2a, 0x40, 0x91, 0x8b, 0x37, 0x35, 0x3a, 0x6e, 0xf6, 0xfb, 0xf6,
+	0x69, 0xcb, 0xcc, 0xed, 0x35, 0x01, 0x56, 0xa5, 0x00, 0xaa, 0x00, 0x60, 0x9b, 0x42, 0x71, 0xd6,
+	0xee, 0x0c, 0xcd, 0x35, 0xb4, 0x05, 0xe5, 0x98, 0xee, 0x76, 0x4d, 0x0d, 0x21, 0xa8, 0x08, 0xc6,
+	0x60, 0x88, 0xed, 0xe1, 0xe1, 0xb1, 0x99, 0xdb, 0xfb, 0x1a, 0x36, 0x13, 0x39, 0x14, 0xdd, 0x87,
+	0xad, 0x76, 0xb7, 0xd3, 0x3f, 0x3b, 0xee, 0xe1, 0xce, 0xeb, 0xde, 0xe9, 0xb0, 0xd9, 0x15, 0x2e,
+	0x51, 0xe6, 0x77, 0x36, 0x1e, 0x76, 0x0e, 0x9b, 0x5d, 0x53, 0xdb, 0x7b, 0x01, 0x65, 0x25, 0x7d,
+	0xd1, 0x6d, 0x3d, 0xdc, 0x39, 0xea, 0x9c, 0x36, 0xbb, 0x67, 0xed, 0x1e, 0x3e, 0x69, 0x52, 0x0b,
+	0x0c, 0xd0, 0xbf, 0xed, 0xdb, 0x47, 0xa6, 0x86, 0xd6, 0x21, 0xdf, 0x3f, 0x3d, 0x32, 0x73, 0x74,
+	0x70, 0xd4, 0x69, 0x9b, 0xf9, 0xbd, 0xc7, 0xb0, 0x99, 0x78, 0xb1, 0xe9, 0xcc, 0xeb, 0x4e, 0x9f,
+	0x7b, 0x3b, 0x6c, 0xe2, 0xb3, 0xa3, 0xd7, 0xa6, 0xb6, 0xff, 0xb7, 0x12, 0x94, 0x29, 0xe0, 0x03,
+	0x12, 0x5c, 0xba, 0x23, 0x82, 0xbe, 0x81, 0x75, 0xd1, 0xd7, 0x43, 0xdb, 0xb2, 0xea, 0x50, 0xbb,
+	0xa1, 0xf5, 0x9d, 0x14, 0x97, 0x47, 0x9d, 0xb5, 0xf6, 0xa5, 0x86, 0x5e, 0x40, 0x29, 0x6e, 0xba,
+	0xa1, 0xaa, 0x2c, 0x42, 0x92, 0x1d, 0xbc, 0xfa, 0x27, 0xd7, 0xf8, 0x52, 0x02, 0xdd, 0x1f, 0xf7,
+	0xd7, 0xe4, 0xfe, 0x74, 0x63, 0xae, 0xfe, 0xc9, 0x35, 0x7e, 0xbc, 0xff, 0x2b, 0x28, 0xf2, 0xbe,
+	0x18, 0xba, 0x2f, 0x8d, 0x54, 0x1a, 0x70, 0xf5, 0xed, 0x24, 0x33, 0xde, 0xf6, 0x0c, 0x74, 0xda,
+	0xdc, 0x41, 0xf7, 0xe4, 0x93, 0x1d, 0xf7, 0x98, 0xea, 0x48, 0x65, 0x29, 0x9e, 0x3e, 0x03, 0x9d,
+	0x36, 0x54, 0xe4, 0x16, 0xa5, 0xaf, 0x53, 0x47, 0x2a, 0x4b, 0xd9, 0xf2, 0x1c, 0x0c, 0xd9, 0x68,
+	0x40, 0x02, 0xc3, 0x54, 0x4f, 0xa3, 0x5e, 0x4d, 0xb3, 0x95, 0xed, 0x5f, 0x43, 0x91, 0x7f, 0x30,
+	0x4b, 0xdf, 0x12, 0x4d, 0x87, 0xfa, 0x76, 0x92, 0xa9, 0x6c, 0x6c, 0x41, 0x59, 0xf9, 0x92, 0x44,
+	0x35, 0xbe, 0xf0, 0xfa, 0x47, 0x6d, 0xfd, 0x41, 0xc6, 0x4c, 0x8c, 0xd1, 0x73, 0x30, 0xe4, 0x27,
+	0x1d, 0x52, 0x22, 0x40, 0xf9, 0x24, 0xa9, 0x57, 0xd3, 0xec, 0xa4, 0xf3, 0xf2, 0x1b, 0x48, 0x6e,
+	0x4f, 0x7d, 0xaa, 0xd5, 0xab, 0x69, 0xb6, 0xdc, 0xde, 0xd0, 0x50, 0x13, 0x60, 0xf5, 0x65, 0x83,
+	0x12, 0x11, 0xa0, 0x8a, 0xa8, 0x5d, 0x9f, 0x88, 0x1d, 0xe8, 0xc2, 0x66, 0xe2, 0xb9, 0x44, 0xf5,
+	0xd8, 0xdd, 0x6b, 0x6f, 0x7b, 0xfd, 0x61, 0xe6, 0x9c, 0xe2, 0xcf, 0x11, 0x6c, 0xa8, 0x93, 0xe8,
+	0xc1, 0xf5, 0x0d, 0x52, 0x56, 0x3d, 0x6b, 0x2a, 0x36, 0xab, 0x27, 0x6a, 0xff, 0xb8, 0x3a, 0x44,
+	0x0f, 0x95, 0xca, 0x35, 0x5d, 0x30, 0xd7, 0x1f, 0x65, 0x4f, 0x2a, 0x96, 0x75, 0x61, 0x93, 0x5f,
+	0x2d, 0x71, 0xf7, 0xa5, 0x9f, 0x59, 0xe5, 0x5f, 0xfd, 0x61, 0xe6, 0x9c, 0x22, 0xad, 0x07, 0x95,
+	0x64, 0xbd, 0x21, 0xcd, 0xcb, 0x2c, 0x9e, 0xea, 0x8f, 0xb2, 0x27, 0x15, 0x81, 0x6d, 0x28, 0x2b,
+	0xf9, 0x58, 0x46, 0xe3, 0xf5, 0x2a, 0xa4, 0xfe, 0x20, 0x63, 0x66, 0x25, 0xe7, 0xc0, 0x78, 0x5d,
+	0xe4, 0xbf, 0x5a, 0xbd, 0x29, 0xb2, 0x5f, 0xac, 0x7e, 0xf9, 0xdf, 0x01, 0x00, 0xcb, 0xf9, 0x63,
+	0x97, 0xcb, 0x1a, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -965,9 +3144,8 @@ const _ = grpc.SupportPackageIsVersion4
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type FileServiceClient interface {
-	// Return a JOSN string containing the directories informations.
-	// In case of image I will return a thumnail of the image so
-	// so the response string can became large.
+	// Return the entries of a directory one by one, by page. In case of image
+	// I will return a thumnail of the image.
 	ReadDir(ctx context.Context, in *ReadDirRequest, opts ...grpc.CallOption) (FileService_ReadDirClient, error)
 	// Create a new directory
 	CreateDir(ctx context.Context, in *CreateDirRequest, opts ...grpc.CallOption) (*CreateDirResponse, error)
@@ -975,9 +3153,17 @@ type FileServiceClient interface {
 	DeleteDir(ctx context.Context, in *DeleteDirRequest, opts ...grpc.CallOption) (*DeleteDirResponse, error)
 	// Rename a file/directory
 	Rename(ctx context.Context, in *RenameRequest, opts ...grpc.CallOption) (*RenameResponse, error)
+	// Copy a file/directory, the progress is sent until it is done.
+	Copy(ctx context.Context, in *CopyRequest, opts ...grpc.CallOption) (FileService_CopyClient, error)
+	// Move a file/directory anywhere in the root.
+	Move(ctx context.Context, in *MoveRequest, opts ...grpc.CallOption) (FileService_MoveClient, error)
+	// Send the changes of the files of a directory until the client stop.
+	WatchDir(ctx context.Context, in *WatchDirRequest, opts ...grpc.CallOption) (FileService_WatchDirClient, error)
+	// Search files by name, type, size, date and content.
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (FileService_SearchClient, error)
 	// Get file info, if the file exist it return the file size, name, thumnail...
 	GetFileInfo(ctx context.Context, in *GetFileInfoRequest, opts ...grpc.CallOption) (*GetFileInfoResponse, error)
-	// Read file, can be use for small to medium file...
+	// Read file, or a part of it with offset and length...
 	ReadFile(ctx context.Context, in *ReadFileRequest, opts ...grpc.CallOption) (FileService_ReadFileClient, error)
 	// Save a file on the server...
 	SaveFile(ctx context.Context, opts ...grpc.CallOption) (FileService_SaveFileClient, error)
@@ -985,6 +3171,16 @@ type FileServiceClient interface {
 	DeleteFile(ctx context.Context, in *DeleteFileRequest, opts ...grpc.CallOption) (*DeleteFileResponse, error)
 	// Specific files...
 	GetThumbnails(ctx context.Context, in *GetThumbnailsRequest, opts ...grpc.CallOption) (FileService_GetThumbnailsClient, error)
+	// Return the thumbnail of an image.
+	GetThumbnail(ctx context.Context, in *GetThumbnailRequest, opts ...grpc.CallOption) (*GetThumbnailResponse, error)
+	// Resize, crop, rotate... an image and convert it.
+	ImageTransform(ctx context.Context, in *ImageTransformRequest, opts ...grpc.CallOption) (FileService_ImageTransformClient, error)
+	// Create a zip or tar.gz archive of files and directories.
+	CreateArchive(ctx context.Context, in *CreateArchiveRequest, opts ...grpc.CallOption) (FileService_CreateArchiveClient, error)
+	// Extract an archive in a directory, the progress is sent until it is done.
+	ExtractArchive(ctx context.Context, in *ExtractArchiveRequest, opts ...grpc.CallOption) (FileService_ExtractArchiveClient, error)
+	// Return the entries of an archive without extracting them.
+	ListArchive(ctx context.Context, in *ListArchiveRequest, opts ...grpc.CallOption) (FileService_ListArchiveClient, error)
 }
 
 type fileServiceClient struct {
@@ -1015,60 +3211,336 @@ type FileService_ReadDirClient interface {
 	grpc.ClientStream
 }
 
-type fileServiceReadDirClient struct {
+type fileServiceReadDirClient struct {
+	grpc.ClientStream
+}
+
+func (x *fileServiceReadDirClient) Recv() (*ReadDirResponse, error) {
+	m := new(ReadDirResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *fileServiceClient) CreateDir(ctx context.Context, in *CreateDirRequest, opts ...grpc.CallOption) (*CreateDirResponse, error) {
+	out := new(CreateDirResponse)
+	err := c.cc.Invoke(ctx, "/file.FileService/CreateDir", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) DeleteDir(ctx context.Context, in *DeleteDirRequest, opts ...grpc.CallOption) (*DeleteDirResponse, error) {
+	out := new(DeleteDirResponse)
+	err := c.cc.Invoke(ctx, "/file.FileService/DeleteDir", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) Rename(ctx context.Context, in *RenameRequest, opts ...grpc.CallOption) (*RenameResponse, error) {
+	out := new(RenameResponse)
+	err := c.cc.Invoke(ctx, "/file.FileService/Rename", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) Copy(ctx context.Context, in *CopyRequest, opts ...grpc.CallOption) (FileService_CopyClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_FileService_serviceDesc.Streams[1], "/file.FileService/Copy", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &fileServiceCopyClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type FileService_CopyClient interface {
+	Recv() (*CopyResponse, error)
+	grpc.ClientStream
+}
+
+type fileServiceCopyClient struct {
+	grpc.ClientStream
+}
+
+func (x *fileServiceCopyClient) Recv() (*CopyResponse, error) {
+	m := new(CopyResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *fileServiceClient) Move(ctx context.Context, in *MoveRequest, opts ...grpc.CallOption) (FileService_MoveClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_FileService_serviceDesc.Streams[2], "/file.FileService/Move", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &fileServiceMoveClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type FileService_MoveClient interface {
+	Recv() (*MoveResponse, error)
+	grpc.ClientStream
+}
+
+type fileServiceMoveClient struct {
+	grpc.ClientStream
+}
+
+func (x *fileServiceMoveClient) Recv() (*MoveResponse, error) {
+	m := new(MoveResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *fileServiceClient) WatchDir(ctx context.Context, in *WatchDirRequest, opts ...grpc.CallOption) (FileService_WatchDirClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_FileService_serviceDesc.Streams[3], "/file.FileService/WatchDir", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &fileServiceWatchDirClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type FileService_WatchDirClient interface {
+	Recv() (*WatchDirResponse, error)
+	grpc.ClientStream
+}
+
+type fileServiceWatchDirClient struct {
+	grpc.ClientStream
+}
+
+func (x *fileServiceWatchDirClient) Recv() (*WatchDirResponse, error) {
+	m := new(WatchDirResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *fileServiceClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (FileService_SearchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_FileService_serviceDesc.Streams[4], "/file.FileService/Search", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &fileServiceSearchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type FileService_SearchClient interface {
+	Recv() (*SearchResponse, error)
+	grpc.ClientStream
+}
+
+type fileServiceSearchClient struct {
+	grpc.ClientStream
+}
+
+func (x *fileServiceSearchClient) Recv() (*SearchResponse, error) {
+	m := new(SearchResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *fileServiceClient) GetFileInfo(ctx context.Context, in *GetFileInfoRequest, opts ...grpc.CallOption) (*GetFileInfoResponse, error) {
+	out := new(GetFileInfoResponse)
+	err := c.cc.Invoke(ctx, "/file.FileService/GetFileInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) ReadFile(ctx context.Context, in *ReadFileRequest, opts ...grpc.CallOption) (FileService_ReadFileClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_FileService_serviceDesc.Streams[5], "/file.FileService/ReadFile", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &fileServiceReadFileClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type FileService_ReadFileClient interface {
+	Recv() (*ReadFileResponse, error)
+	grpc.ClientStream
+}
+
+type fileServiceReadFileClient struct {
+	grpc.ClientStream
+}
+
+func (x *fileServiceReadFileClient) Recv() (*ReadFileResponse, error) {
+	m := new(ReadFileResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *fileServiceClient) SaveFile(ctx context.Context, opts ...grpc.CallOption) (FileService_SaveFileClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_FileService_serviceDesc.Streams[6], "/file.FileService/SaveFile", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &fileServiceSaveFileClient{stream}
+	return x, nil
+}
+
+type FileService_SaveFileClient interface {
+	Send(*SaveFileRequest) error
+	CloseAndRecv() (*SaveFileResponse, error)
+	grpc.ClientStream
+}
+
+type fileServiceSaveFileClient struct {
+	grpc.ClientStream
+}
+
+func (x *fileServiceSaveFileClient) Send(m *SaveFileRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *fileServiceSaveFileClient) CloseAndRecv() (*SaveFileResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(SaveFileResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *fileServiceClient) DeleteFile(ctx context.Context, in *DeleteFileRequest, opts ...grpc.CallOption) (*DeleteFileResponse, error) {
+	out := new(DeleteFileResponse)
+	err := c.cc.Invoke(ctx, "/file.FileService/DeleteFile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) GetThumbnails(ctx context.Context, in *GetThumbnailsRequest, opts ...grpc.CallOption) (FileService_GetThumbnailsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_FileService_serviceDesc.Streams[7], "/file.FileService/GetThumbnails", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &fileServiceGetThumbnailsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type FileService_GetThumbnailsClient interface {
+	Recv() (*GetThumbnailsResponse, error)
+	grpc.ClientStream
+}
+
+type fileServiceGetThumbnailsClient struct {
 	grpc.ClientStream
 }
 
-func (x *fileServiceReadDirClient) Recv() (*ReadDirResponse, error) {
-	m := new(ReadDirResponse)
+func (x *fileServiceGetThumbnailsClient) Recv() (*GetThumbnailsResponse, error) {
+	m := new(GetThumbnailsResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *fileServiceClient) CreateDir(ctx context.Context, in *CreateDirRequest, opts ...grpc.CallOption) (*CreateDirResponse, error) {
-	out := new(CreateDirResponse)
-	err := c.cc.Invoke(ctx, "/file.FileService/CreateDir", in, out, opts...)
+func (c *fileServiceClient) GetThumbnail(ctx context.Context, in *GetThumbnailRequest, opts ...grpc.CallOption) (*GetThumbnailResponse, error) {
+	out := new(GetThumbnailResponse)
+	err := c.cc.Invoke(ctx, "/file.FileService/GetThumbnail", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *fileServiceClient) DeleteDir(ctx context.Context, in *DeleteDirRequest, opts ...grpc.CallOption) (*DeleteDirResponse, error) {
-	out := new(DeleteDirResponse)
-	err := c.cc.Invoke(ctx, "/file.FileService/DeleteDir", in, out, opts...)
+func (c *fileServiceClient) ImageTransform(ctx context.Context, in *ImageTransformRequest, opts ...grpc.CallOption) (FileService_ImageTransformClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_FileService_serviceDesc.Streams[8], "/file.FileService/ImageTransform", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-func (c *fileServiceClient) Rename(ctx context.Context, in *RenameRequest, opts ...grpc.CallOption) (*RenameResponse, error) {
-	out := new(RenameResponse)
-	err := c.cc.Invoke(ctx, "/file.FileService/Rename", in, out, opts...)
-	if err != nil {
+	x := &fileServiceImageTransformClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (c *fileServiceClient) GetFileInfo(ctx context.Context, in *GetFileInfoRequest, opts ...grpc.CallOption) (*GetFileInfoResponse, error) {
-	out := new(GetFileInfoResponse)
-	err := c.cc.Invoke(ctx, "/file.FileService/GetFileInfo", in, out, opts...)
-	if err != nil {
+type FileService_ImageTransformClient interface {
+	Recv() (*ImageTransformResponse, error)
+	grpc.ClientStream
+}
+
+type fileServiceImageTransformClient struct {
+	grpc.ClientStream
+}
+
+func (x *fileServiceImageTransformClient) Recv() (*ImageTransformResponse, error) {
+	m := new(ImageTransformResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return m, nil
 }
 
-func (c *fileServiceClient) ReadFile(ctx context.Context, in *ReadFileRequest, opts ...grpc.CallOption) (FileService_ReadFileClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_FileService_serviceDesc.Streams[1], "/file.FileService/ReadFile", opts...)
+func (c *fileServiceClient) CreateArchive(ctx context.Context, in *CreateArchiveRequest, opts ...grpc.CallOption) (FileService_CreateArchiveClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_FileService_serviceDesc.Streams[9], "/file.FileService/CreateArchive", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &fileServiceReadFileClient{stream}
+	x := &fileServiceCreateArchiveClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -1078,72 +3550,61 @@ func (c *fileServiceClient) ReadFile(ctx context.Context, in *ReadFileRequest, o
 	return x, nil
 }
 
-type FileService_ReadFileClient interface {
-	Recv() (*ReadFileResponse, error)
+type FileService_CreateArchiveClient interface {
+	Recv() (*CreateArchiveResponse, error)
 	grpc.ClientStream
 }
 
-type fileServiceReadFileClient struct {
+type fileServiceCreateArchiveClient struct {
 	grpc.ClientStream
 }
 
-func (x *fileServiceReadFileClient) Recv() (*ReadFileResponse, error) {
-	m := new(ReadFileResponse)
+func (x *fileServiceCreateArchiveClient) Recv() (*CreateArchiveResponse, error) {
+	m := new(CreateArchiveResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *fileServiceClient) SaveFile(ctx context.Context, opts ...grpc.CallOption) (FileService_SaveFileClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_FileService_serviceDesc.Streams[2], "/file.FileService/SaveFile", opts...)
+func (c *fileServiceClient) ExtractArchive(ctx context.Context, in *ExtractArchiveRequest, opts ...grpc.CallOption) (FileService_ExtractArchiveClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_FileService_serviceDesc.Streams[10], "/file.FileService/ExtractArchive", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &fileServiceSaveFileClient{stream}
+	x := &fileServiceExtractArchiveClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
 	return x, nil
 }
 
-type FileService_SaveFileClient interface {
-	Send(*SaveFileRequest) error
-	CloseAndRecv() (*SaveFileResponse, error)
+type FileService_ExtractArchiveClient interface {
+	Recv() (*ExtractArchiveResponse, error)
 	grpc.ClientStream
 }
 
-type fileServiceSaveFileClient struct {
+type fileServiceExtractArchiveClient struct {
 	grpc.ClientStream
 }
 
-func (x *fileServiceSaveFileClient) Send(m *SaveFileRequest) error {
-	return x.ClientStream.SendMsg(m)
-}
-
-func (x *fileServiceSaveFileClient) CloseAndRecv() (*SaveFileResponse, error) {
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	m := new(SaveFileResponse)
+func (x *fileServiceExtractArchiveClient) Recv() (*ExtractArchiveResponse, error) {
+	m := new(ExtractArchiveResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *fileServiceClient) DeleteFile(ctx context.Context, in *DeleteFileRequest, opts ...grpc.CallOption) (*DeleteFileResponse, error) {
-	out := new(DeleteFileResponse)
-	err := c.cc.Invoke(ctx, "/file.FileService/DeleteFile", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-func (c *fileServiceClient) GetThumbnails(ctx context.Context, in *GetThumbnailsRequest, opts ...grpc.CallOption) (FileService_GetThumbnailsClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_FileService_serviceDesc.Streams[3], "/file.FileService/GetThumbnails", opts...)
+func (c *fileServiceClient) ListArchive(ctx context.Context, in *ListArchiveRequest, opts ...grpc.CallOption) (FileService_ListArchiveClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_FileService_serviceDesc.Streams[11], "/file.FileService/ListArchive", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &fileServiceGetThumbnailsClient{stream}
+	x := &fileServiceListArchiveClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -1153,17 +3614,17 @@ func (c *fileServiceClient) GetThumbnails(ctx context.Context, in *GetThumbnails
 	return x, nil
 }
 
-type FileService_GetThumbnailsClient interface {
-	Recv() (*GetThumbnailsResponse, error)
+type FileService_ListArchiveClient interface {
+	Recv() (*ListArchiveResponse, error)
 	grpc.ClientStream
 }
 
-type fileServiceGetThumbnailsClient struct {
+type fileServiceListArchiveClient struct {
 	grpc.ClientStream
 }
 
-func (x *fileServiceGetThumbnailsClient) Recv() (*GetThumbnailsResponse, error) {
-	m := new(GetThumbnailsResponse)
+func (x *fileServiceListArchiveClient) Recv() (*ListArchiveResponse, error) {
+	m := new(ListArchiveResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
@@ -1172,9 +3633,8 @@ func (x *fileServiceGetThumbnailsClient) Recv() (*GetThumbnailsResponse, error)
 
 // FileServiceServer is the server API for FileService service.
 type FileServiceServer interface {
-	// Return a JOSN string containing the directories informations.
-	// In case of image I will return a thumnail of the image so
-	// so the response string can became large.
+	// Return the entries of a directory one by one, by page. In case of image
+	// I will return a thumnail of the image.
 	ReadDir(*ReadDirRequest, FileService_ReadDirServer) error
 	// Create a new directory
 	CreateDir(context.Context, *CreateDirRequest) (*CreateDirResponse, error)
@@ -1182,9 +3642,17 @@ type FileServiceServer interface {
 	DeleteDir(context.Context, *DeleteDirRequest) (*DeleteDirResponse, error)
 	// Rename a file/directory
 	Rename(context.Context, *RenameRequest) (*RenameResponse, error)
+	// Copy a file/directory, the progress is sent until it is done.
+	Copy(*CopyRequest, FileService_CopyServer) error
+	// Move a file/directory anywhere in the root.
+	Move(*MoveRequest, FileService_MoveServer) error
+	// Send the changes of the files of a directory until the client stop.
+	WatchDir(*WatchDirRequest, FileService_WatchDirServer) error
+	// Search files by name, type, size, date and content.
+	Search(*SearchRequest, FileService_SearchServer) error
 	// Get file info, if the file exist it return the file size, name, thumnail...
 	GetFileInfo(context.Context, *GetFileInfoRequest) (*GetFileInfoResponse, error)
-	// Read file, can be use for small to medium file...
+	// Read file, or a part of it with offset and length...
 	ReadFile(*ReadFileRequest, FileService_ReadFileServer) error
 	// Save a file on the server...
 	SaveFile(FileService_SaveFileServer) error
@@ -1192,6 +3660,75 @@ type FileServiceServer interface {
 	DeleteFile(context.Context, *DeleteFileRequest) (*DeleteFileResponse, error)
 	// Specific files...
 	GetThumbnails(*GetThumbnailsRequest, FileService_GetThumbnailsServer) error
+	// Return the thumbnail of an image.
+	GetThumbnail(context.Context, *GetThumbnailRequest) (*GetThumbnailResponse, error)
+	// Resize, crop, rotate... an image and convert it.
+	ImageTransform(*ImageTransformRequest, FileService_ImageTransformServer) error
+	// Create a zip or tar.gz archive of files and directories.
+	CreateArchive(*CreateArchiveRequest, FileService_CreateArchiveServer) error
+	// Extract an archive in a directory, the progress is sent until it is done.
+	ExtractArchive(*ExtractArchiveRequest, FileService_ExtractArchiveServer) error
+	// Return the entries of an archive without extracting them.
+	ListArchive(*ListArchiveRequest, FileService_ListArchiveServer) error
+}
+
+// UnimplementedFileServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedFileServiceServer struct {
+}
+
+func (*UnimplementedFileServiceServer) ReadDir(req *ReadDirRequest, srv FileService_ReadDirServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReadDir not implemented")
+}
+func (*UnimplementedFileServiceServer) CreateDir(ctx context.Context, req *CreateDirRequest) (*CreateDirResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDir not implemented")
+}
+func (*UnimplementedFileServiceServer) DeleteDir(ctx context.Context, req *DeleteDirRequest) (*DeleteDirResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteDir not implemented")
+}
+func (*UnimplementedFileServiceServer) Rename(ctx context.Context, req *RenameRequest) (*RenameResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Rename not implemented")
+}
+func (*UnimplementedFileServiceServer) Copy(req *CopyRequest, srv FileService_CopyServer) error {
+	return status.Errorf(codes.Unimplemented, "method Copy not implemented")
+}
+func (*UnimplementedFileServiceServer) Move(req *MoveRequest, srv FileService_MoveServer) error {
+	return status.Errorf(codes.Unimplemented, "method Move not implemented")
+}
+func (*UnimplementedFileServiceServer) WatchDir(req *WatchDirRequest, srv FileService_WatchDirServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchDir not implemented")
+}
+func (*UnimplementedFileServiceServer) Search(req *SearchRequest, srv FileService_SearchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (*UnimplementedFileServiceServer) GetFileInfo(ctx context.Context, req *GetFileInfoRequest) (*GetFileInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFileInfo not implemented")
+}
+func (*UnimplementedFileServiceServer) ReadFile(req *ReadFileRequest, srv FileService_ReadFileServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReadFile not implemented")
+}
+func (*UnimplementedFileServiceServer) SaveFile(srv FileService_SaveFileServer) error {
+	return status.Errorf(codes.Unimplemented, "method SaveFile not implemented")
+}
+func (*UnimplementedFileServiceServer) DeleteFile(ctx context.Context, req *DeleteFileRequest) (*DeleteFileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFile not implemented")
+}
+func (*UnimplementedFileServiceServer) GetThumbnails(req *GetThumbnailsRequest, srv FileService_GetThumbnailsServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetThumbnails not implemented")
+}
+func (*UnimplementedFileServiceServer) GetThumbnail(ctx context.Context, req *GetThumbnailRequest) (*GetThumbnailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetThumbnail not implemented")
+}
+func (*UnimplementedFileServiceServer) ImageTransform(req *ImageTransformRequest, srv FileService_ImageTransformServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImageTransform not implemented")
+}
+func (*UnimplementedFileServiceServer) CreateArchive(req *CreateArchiveRequest, srv FileService_CreateArchiveServer) error {
+	return status.Errorf(codes.Unimplemented, "method CreateArchive not implemented")
+}
+func (*UnimplementedFileServiceServer) ExtractArchive(req *ExtractArchiveRequest, srv FileService_ExtractArchiveServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExtractArchive not implemented")
+}
+func (*UnimplementedFileServiceServer) ListArchive(req *ListArchiveRequest, srv FileService_ListArchiveServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListArchive not implemented")
 }
 
 func RegisterFileServiceServer(s *grpc.Server, srv FileServiceServer) {
@@ -1273,6 +3810,90 @@ func _FileService_Rename_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FileService_Copy_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CopyRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FileServiceServer).Copy(m, &fileServiceCopyServer{stream})
+}
+
+type FileService_CopyServer interface {
+	Send(*CopyResponse) error
+	grpc.ServerStream
+}
+
+type fileServiceCopyServer struct {
+	grpc.ServerStream
+}
+
+func (x *fileServiceCopyServer) Send(m *CopyResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _FileService_Move_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(MoveRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FileServiceServer).Move(m, &fileServiceMoveServer{stream})
+}
+
+type FileService_MoveServer interface {
+	Send(*MoveResponse) error
+	grpc.ServerStream
+}
+
+type fileServiceMoveServer struct {
+	grpc.ServerStream
+}
+
+func (x *fileServiceMoveServer) Send(m *MoveResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _FileService_WatchDir_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchDirRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FileServiceServer).WatchDir(m, &fileServiceWatchDirServer{stream})
+}
+
+type FileService_WatchDirServer interface {
+	Send(*WatchDirResponse) error
+	grpc.ServerStream
+}
+
+type fileServiceWatchDirServer struct {
+	grpc.ServerStream
+}
+
+func (x *fileServiceWatchDirServer) Send(m *WatchDirResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _FileService_Search_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SearchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FileServiceServer).Search(m, &fileServiceSearchServer{stream})
+}
+
+type FileService_SearchServer interface {
+	Send(*SearchResponse) error
+	grpc.ServerStream
+}
+
+type fileServiceSearchServer struct {
+	grpc.ServerStream
+}
+
+func (x *fileServiceSearchServer) Send(m *SearchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _FileService_GetFileInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetFileInfoRequest)
 	if err := dec(in); err != nil {
@@ -1377,6 +3998,108 @@ func (x *fileServiceGetThumbnailsServer) Send(m *GetThumbnailsResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _FileService_GetThumbnail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetThumbnailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).GetThumbnail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/file.FileService/GetThumbnail",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).GetThumbnail(ctx, req.(*GetThumbnailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_ImageTransform_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ImageTransformRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FileServiceServer).ImageTransform(m, &fileServiceImageTransformServer{stream})
+}
+
+type FileService_ImageTransformServer interface {
+	Send(*ImageTransformResponse) error
+	grpc.ServerStream
+}
+
+type fileServiceImageTransformServer struct {
+	grpc.ServerStream
+}
+
+func (x *fileServiceImageTransformServer) Send(m *ImageTransformResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _FileService_CreateArchive_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CreateArchiveRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FileServiceServer).CreateArchive(m, &fileServiceCreateArchiveServer{stream})
+}
+
+type FileService_CreateArchiveServer interface {
+	Send(*CreateArchiveResponse) error
+	grpc.ServerStream
+}
+
+type fileServiceCreateArchiveServer struct {
+	grpc.ServerStream
+}
+
+func (x *fileServiceCreateArchiveServer) Send(m *CreateArchiveResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _FileService_ExtractArchive_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExtractArchiveRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FileServiceServer).ExtractArchive(m, &fileServiceExtractArchiveServer{stream})
+}
+
+type FileService_ExtractArchiveServer interface {
+	Send(*ExtractArchiveResponse) error
+	grpc.ServerStream
+}
+
+type fileServiceExtractArchiveServer struct {
+	grpc.ServerStream
+}
+
+func (x *fileServiceExtractArchiveServer) Send(m *ExtractArchiveResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _FileService_ListArchive_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListArchiveRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FileServiceServer).ListArchive(m, &fileServiceListArchiveServer{stream})
+}
+
+type FileService_ListArchiveServer interface {
+	Send(*ListArchiveResponse) error
+	grpc.ServerStream
+}
+
+type fileServiceListArchiveServer struct {
+	grpc.ServerStream
+}
+
+func (x *fileServiceListArchiveServer) Send(m *ListArchiveResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _FileService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "file.FileService",
 	HandlerType: (*FileServiceServer)(nil),
@@ -1401,6 +4124,10 @@ var _FileService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteFile",
 			Handler:    _FileService_DeleteFile_Handler,
 		},
+		{
+			MethodName: "GetThumbnail",
+			Handler:    _FileService_GetThumbnail_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1408,6 +4135,26 @@ var _FileService_serviceDesc = grpc.ServiceDesc{
 			Handler:       _FileService_ReadDir_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Copy",
+			Handler:       _FileService_Copy_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Move",
+			Handler:       _FileService_Move_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchDir",
+			Handler:       _FileService_WatchDir_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Search",
+			Handler:       _FileService_Search_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "ReadFile",
 			Handler:       _FileService_ReadFile_Handler,
@@ -1423,6 +4170,26 @@ var _FileService_serviceDesc = grpc.ServiceDesc{
 			Handler:       _FileService_GetThumbnails_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ImageTransform",
+			Handler:       _FileService_ImageTransform_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "CreateArchive",
+			Handler:       _FileService_CreateArchive_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExtractArchive",
+			Handler:       _FileService_ExtractArchive_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListArchive",
+			Handler:       _FileService_ListArchive_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "file/filepb/file.proto",
 }
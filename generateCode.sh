@@ -1,5 +1,6 @@
 #!/bin/bash
 protoc echo/echopb/echo.proto --go_out=plugins=grpc:.
+protoc admin/adminpb/admin.proto --go_out=plugins=grpc:.
 #protoc oauth2/oauth2pb/oauth2.proto --go_out=plugins=grpc:.
 protoc storage/storagepb/storage.proto --go_out=plugins=grpc:.
 protoc file/filepb/file.proto --go_out=plugins=grpc:.
@@ -7,6 +8,7 @@ protoc sql/sqlpb/sql.proto --go_out=plugins=grpc:.
 protoc ldap/ldappb/ldap.proto --go_out=plugins=grpc:.
 protoc smtp/smtppb/smtp.proto --go_out=plugins=grpc:.
 protoc persistence/persistencepb/persistence.proto --go_out=plugins=grpc:.
+protoc event/eventpb/event.proto --go_out=plugins=grpc:.
 protoc spc/spcpb/spc.proto --grpc_out=spc/spcpb/cpp --plugin=protoc-gen-grpc=grpc_cpp_plugin 
 protoc spc/spcpb/spc.proto --cpp_out=spc/spcpb/cpp
 
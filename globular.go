@@ -1,11 +1,13 @@
 package main
 
 import (
-	"bufio"
 	"encoding/json"
+	"errors"
 	"io"
 	"io/ioutil"
 	"log"
+	"mime/multipart"
+	"net"
 	"net/http"
 	"os"
 	"os/exec"
@@ -15,17 +17,36 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
+	"github.com/davecourtois/Globular/config"
+	"github.com/davecourtois/Globular/event/event_client"
+	"github.com/davecourtois/Globular/resolver"
 	"github.com/davecourtois/Utility"
 )
 
 var (
 	root    string
 	globule *Globule
+
+	// The version of Globular, can be set at build time whit
+	// go build -ldflags "-X main.version=x.y.z"
+	version = "1.0.0"
+
+	// The configuration schema version (see package config), 2 moved PublicKeys to public_keys.
+	configVersion = 2
+
+	// The services the browsers must not call with grpc-web even if their
+	// configuration give a Proxy port, they receive the events at /events.
+	noWebProxyServices = []string{"event_server"}
 )
 
+// The delay between two health checks of the processes that receive
+// requests.
+const healthCheckInterval = 5 * time.Second
+
 /**
  * The web server.
  */
@@ -37,24 +58,116 @@ type Globule struct {
 	IP       string // The local address...
 	Services map[string]interface{}
 
+	// The version of the configuration schema.
+	ConfigVersion int
+
+	// The port of the administration service (use by the command line).
+	AdminPort int
+
+	// The address the administration service listen, 127.0.0.1 by default.
+	// The calls must give the token of GLOBULAR_ADMIN_TOKEN, the same on all
+	// the nodes of a cluster, or of the file admin_token.
+	AdminAddress string
+
+	// The admin addresses (ip:adminPort) of the Globules to join in a cluster.
+	Peers []string
+
+	// The web applications by name, the requests that are not for one of them
+	// are serve from WebRoot.
+	Applications map[string]*Application
+
+	// The Cache-Control of the static files by path pattern.
+	CacheRules []CacheRule
+
+	// The topics of the services the browsers can listen to at /events, ex:
+	// file.*, the client.* topics are always allowed.
+	EventTopics []string
+
+	// The uploads limits in bytes, 0 for no limit: the size of a file, the
+	// size of the uploads in progress of a user and of all users. The uploads
+	// not completed after UploadExpiration seconds are removed.
+	UploadMaxSize      int64
+	UploadUserMaxSize  int64
+	UploadTotalMaxSize int64
+	UploadExpiration   int
+
+	// The backups are written in BackupDir, relative to the Globular
+	// directory or absolute, with the directories of BackupRoots. A backup is
+	// created each BackupInterval seconds if it's not 0, the BackupRetention
+	// newest are kept, all if it's 0.
+	BackupDir       string
+	BackupRoots     []string
+	BackupInterval  int
+	BackupRetention int
+
 	// Local info.
-	webRoot string // The root of the http file server.
-	path    string // The path of the exec...
+	webRoot    string // The root of the http file server.
+	adminToken string // The token of the admin service calls.
+	path       string // The path of the exec...
 
 	// The list of avalaible services.
 	services map[string]interface{}
 
+	// Protect the services map, services can be start and stop from the
+	// admin service.
+	mutex sync.Mutex
+
+	// The output of the services processes, the key is the service name.
+	logs map[string]*logBuffer
+
+	// The time when the Globule start listening.
+	startTime time.Time
+
+	// The routers listening at the services ports.
+	routers map[string]*serviceRouter
+
+	// The grpc-web proxies listening at the services proxy ports.
+	webProxies map[string]*webProxy
+
+	// The other Globules of the cluster by id and the heartbeat of this
+	// one, incremented at each gossip round.
+	nodes     map[string]*clusterNode
+	heartbeat int64
+
+	// The admin clients of the other nodes by address.
+	peerClients map[string]*Admin_Client
+
+	// The services that run on other nodes.
+	remoteServices map[string]*remoteService
+
+	// Closed when the addresses of the services change.
+	registryChanged chan bool
+
+	// The resumable uploads by id.
+	uploads map[string]*upload
+
+	// True while a backup or a restore run.
+	backupRunning bool
+
+	// The topics of the event service listen by the browsers.
+	topics map[string]*eventTopic
+
+	// The requests in progress on the http services instances.
+	httpCalls map[*serviceInstance]int
+	httpNext  int
+
 	// The map of client...
 	clients map[string]Client
 }
 
 /**
- * Globule constructor.
+ * Globule constructor. The configuration is read from globular.json and
+ * the environment variables GLOBULAR_PORT, GLOBULAR_ADMIN_PORT and GLOBULAR_IP
+ * take precedence over it. An error is return if the configuration is not
+ * valid.
  */
-func NewGlobule(port int) *Globule {
+func NewGlobule() (*Globule, error) {
 	// Here I will initialyse configuration.
 	g := new(Globule)
-	g.Port = port // The default port number.
+	g.ConfigVersion = configVersion
+	g.Port = 10000      // The default port number.
+	g.AdminPort = 10015 // The default admin port number.
+	g.AdminAddress = "127.0.0.1"
 	g.Name = Utility.GetExecName(os.Args[0])
 	g.Protocol = "http"
 	g.IP = Utility.MyIP()
@@ -68,28 +181,107 @@ func NewGlobule(port int) *Globule {
 	// Set the map of client.
 	g.clients = make(map[string]Client, 0)
 
+	// Set the services logs.
+	g.logs = make(map[string]*logBuffer, 0)
+
+	// Set the services routers.
+	g.routers = make(map[string]*serviceRouter, 0)
+
+	// Set the services grpc-web proxies.
+	g.webProxies = make(map[string]*webProxy, 0)
+
+	// Set the cluster.
+	g.Peers = make([]string, 0)
+	g.nodes = make(map[string]*clusterNode, 0)
+	g.peerClients = make(map[string]*Admin_Client, 0)
+	g.remoteServices = make(map[string]*remoteService, 0)
+	g.registryChanged = make(chan bool)
+
+	// Set the http services requests.
+	g.httpCalls = make(map[*serviceInstance]int, 0)
+
+	// Set the web applications.
+	g.Applications = make(map[string]*Application, 0)
+
+	// Set the static files cache rules.
+	g.CacheRules = make([]CacheRule, 0)
+
+	// Set the browsers events.
+	g.topics = make(map[string]*eventTopic, 0)
+	g.EventTopics = make([]string, 0)
+
+	// Set the uploads.
+	g.uploads = make(map[string]*upload, 0)
+	g.UploadMaxSize = 2 << 30
+	g.UploadUserMaxSize = 4 << 30
+	g.UploadTotalMaxSize = 16 << 30
+	g.UploadExpiration = 24 * 60 * 60
+
+	// Set the backups.
+	g.BackupDir = "backups"
+	g.BackupRoots = []string{"WebRoot"}
+	g.BackupRetention = 7
+
 	dir, err := filepath.Abs(filepath.Dir(os.Args[0]))
 	g.path = dir // keep the installation patn.
 
 	if err == nil {
 		g.webRoot = dir + string(os.PathSeparator) + "WebRoot" // The default directory to server.
 		Utility.CreateDirIfNotExist(g.webRoot)                 // Create the directory if it not exist.
-		file, err := ioutil.ReadFile(g.webRoot + string(os.PathSeparator) + "config.json")
+
+		// The configuration was in WebRoot before, where it was serve and
+		// could be uploaded.
+		previous := g.webRoot + string(os.PathSeparator) + "config.json"
+		if !Utility.Exists(getConfigPath(dir)) && Utility.Exists(previous) {
+			log.Println("Move the configuration ", previous, " to ", getConfigPath(dir))
+			err = os.Rename(previous, getConfigPath(dir))
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		// Init the servce with the default port address
-		if err == nil {
-			json.Unmarshal([]byte(file), g)
+		err = config.Load(getConfigPath(dir), g, configVersion)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
 		}
 	}
 
+	// The environment overide the configuration file.
+	g.initEnv()
+
 	// keep the root in global variable for the file handler.
 	root = g.webRoot
 	globule = g
 
-	return g
+	return g, nil
+}
+
+/**
+ * Set the configuration values from the environment variables.
+ */
+func (self *Globule) initEnv() {
+	if port, err := strconv.Atoi(os.Getenv("GLOBULAR_PORT")); err == nil {
+		self.Port = port
+	}
+
+	if port, err := strconv.Atoi(os.Getenv("GLOBULAR_ADMIN_PORT")); err == nil {
+		self.AdminPort = port
+	}
+
+	if ip := os.Getenv("GLOBULAR_IP"); len(ip) > 0 {
+		self.IP = ip
+	}
+
+	// The token is not kept in the configuration, it is serve to the browsers,
+	// nor given to the services.
+	self.adminToken = os.Getenv("GLOBULAR_ADMIN_TOKEN")
+	os.Unsetenv("GLOBULAR_ADMIN_TOKEN")
 }
 
 /**
- * Here I will set services
+ * Here I will set services. The services already known are skipped, so it is
+ * also use to start the services added since the Globule start.
  */
 func (self *Globule) initServices() {
 	log.Println("Initialyse services")
@@ -97,16 +289,30 @@ func (self *Globule) initServices() {
 	// Each service contain a file name config.json that describe service.
 	// I will keep services info in services map and also it running process.
 	basePath, _ := filepath.Abs(filepath.Dir(os.Args[0]))
+	webRoot, _ := filepath.Abs(self.webRoot)
 	filepath.Walk(basePath, func(path string, info os.FileInfo, err error) error {
-		if err == nil && info.Name() == "config.json" {
+		// Skip the packages being installed and the backups.
+		if err == nil && info.IsDir() && path != basePath && strings.HasPrefix(info.Name(), ".") {
+			return filepath.SkipDir
+		}
+
+		// The configuration of the Globule is not the one of a service.
+		if err == nil && info.Name() == "config.json" && filepath.Dir(path) != webRoot {
 			// println(path, info.Name())
 			// So here I will read the content of the file.
 			s := make(map[string]interface{})
 			config, err := ioutil.ReadFile(path)
 			if err == nil {
 				// Read the config file.
-				json.Unmarshal(config, &s)
-				if s["Protocol"].(string) == "grpc" {
+				err = json.Unmarshal(config, &s)
+				if err != nil {
+					log.Println("Fail to read the service configuration ", path, " with error ", err)
+					return nil
+				}
+
+				if _, ok := s["Name"].(string); !ok && (s["Protocol"] == "grpc" || isHttpService(s)) {
+					log.Println("Fail to read the service configuration ", path, " with error the service has no name")
+				} else if s["Protocol"] == "grpc" || isHttpService(s) {
 
 					path_ := path[:strings.LastIndex(path, string(os.PathSeparator))]
 					servicePath := path_ + string(os.PathSeparator) + s["Name"].(string)
@@ -114,48 +320,35 @@ func (self *Globule) initServices() {
 						servicePath += ".exe" // in case of windows.
 					}
 
-					// Start the process.
-					log.Println("try to start process ", s["Name"].(string))
-					if s["Name"].(string) == "file" {
-						s["Process"] = exec.Command(servicePath, Utility.ToString(s["Port"]), globule.webRoot)
-					} else {
-						s["Process"] = exec.Command(servicePath, Utility.ToString(s["Port"]))
-					}
+					// Keep the executable path to be able to restart it.
+					s["Path"] = servicePath
 
-					err = s["Process"].(*exec.Cmd).Start()
-					if err != nil {
-						log.Println("Fail to start service: ", s["Name"].(string), " at port ", s["Port"], " with error ", err)
+					// The version of services installed from a package.
+					manifest, err := readManifest(path_ + string(os.PathSeparator) + "manifest.json")
+					if err == nil {
+						s["Version"] = manifest.Version
 					}
 
-					// Now I will start the proxy that will be use by javascript client.
-					proxyPath := self.path + string(os.PathSeparator) + "bin" + string(os.PathSeparator) + "grpcwebproxy"
-					if string(os.PathSeparator) == "\\" {
-						proxyPath += ".exe" // in case of windows.
+					self.mutex.Lock()
+					known := self.services[s["Name"].(string)] != nil
+					if !known {
+						self.services[s["Name"].(string)] = s
+					}
+					self.mutex.Unlock()
+					if known {
+						return nil
 					}
 
-					// This is the grpc service to connect with the proxy
-					proxyBackendAddress := "localhost:" + Utility.ToString(s["Port"])
-					proxyAllowAllOrgins := Utility.ToString(s["AllowAllOrigins"])
-
-					// start the proxy service.
-					s["ProxyProcess"] = exec.Command(proxyPath, "--backend_addr="+proxyBackendAddress, "--server_http_debug_port="+Utility.ToString(s["Proxy"]), "--run_tls_server=false", "--allow_all_origins="+proxyAllowAllOrgins)
-					err = s["ProxyProcess"].(*exec.Cmd).Start()
+					err = self.startService(s)
 					if err != nil {
-						log.Println("Fail to start grpcwebproxy: ", s["Name"].(string), " at port ", s["Proxy"], " with error ", err)
+						log.Println("Fail to start service: ", s["Name"].(string), " at port ", s["Port"], " with error ", err)
 					}
 
-					self.services[s["Name"].(string)] = s
-
-					s_ := make(map[string]interface{})
-
 					// export public service values.
-					s_["Proxy"] = s["Proxy"]
-					s_["Port"] = s["Port"]
-
-					self.Services[s["Name"].(string)] = s_
+					self.mutex.Lock()
+					self.Services[s["Name"].(string)] = getPublicInfo(s)
+					self.mutex.Unlock()
 					self.saveConfig()
-
-					log.Println("Service ", s["Name"].(string), "is running at port", s["Port"], "it's proxy port is", s["Proxy"])
 				}
 			}
 		}
@@ -163,58 +356,626 @@ func (self *Globule) initServices() {
 	})
 }
 
-// That function resolve import path.
-func resolveImportPath(path string, importPath string) (string, error) {
+/**
+ * Return the output of a service, or the Globule output.
+ */
+func (self *Globule) getLogs(name string) *logBuffer {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
 
-	// firt of all i will keep only the path part of the import...
-	startIndex := strings.Index(importPath, `'@`) + 1
-	endIndex := strings.LastIndex(importPath, `'`)
-	importPath_ := importPath[startIndex:endIndex]
+	if self.logs[name] == nil {
+		self.logs[name] = newLogBuffer()
+	}
 
-	filepath.Walk(root+path[0:strings.Index(path, "/")],
-		func(path string, info os.FileInfo, err error) error {
-			if err != nil {
-				return err
+	return self.logs[name]
+}
+
+/**
+ * A process running a replica of a service.
+ */
+type serviceInstance struct {
+	process *exec.Cmd
+	port    int       // The port the process listen, the service port is listen by the router.
+	exited  chan bool // Closed when the process exit.
+
+	// Set when the process fail the health check, it receive no requests
+	// until it answer again.
+	unhealthy bool
+}
+
+func (self *serviceInstance) isRunning() bool {
+	select {
+	case <-self.exited:
+		return false
+	default:
+		return true
+	}
+}
+
+func (self *serviceInstance) address() string {
+	return "localhost:" + strconv.Itoa(self.port)
+}
+
+/**
+ * Return the number of processes to run for a service, one if it is not set
+ * in the service configuration.
+ */
+func getReplicas(s map[string]interface{}) int {
+	if s["Replicas"] == nil || Utility.ToInt(s["Replicas"]) < 1 {
+		return 1
+	}
+
+	return Utility.ToInt(s["Replicas"])
+}
+
+/**
+ * Return the service values exported in the Globule configuration, the web
+ * clients use them to reach the service.
+ */
+func getPublicInfo(s map[string]interface{}) map[string]interface{} {
+	s_ := make(map[string]interface{})
+	if isHttpService(s) {
+		s_["Hostname"] = s["Hostname"]
+		s_["PathPrefix"] = s["PathPrefix"]
+	} else {
+		s_["Proxy"] = s["Proxy"]
+		s_["Port"] = s["Port"]
+	}
+
+	return s_
+}
+
+/**
+ * Return the processes of a service.
+ */
+func getInstances(s map[string]interface{}) []*serviceInstance {
+	instances, _ := s["Instances"].([]*serviceInstance)
+	return instances
+}
+
+/**
+ * Start the processes of a service and the grpcwebproxy use by the
+ * javascript client to access it. The port of the service is listen by a
+ * router that spread the connections over the processes, so the processes
+ * can be replace without interruption.
+ */
+func (self *Globule) startService(s map[string]interface{}) error {
+	name := s["Name"].(string)
+	if self.isRunning(name) {
+		return errors.New("service " + name + " is already running")
+	}
+
+	self.mutex.Lock()
+	router := self.routers[name]
+	proxy := self.webProxies[name]
+	self.mutex.Unlock()
+
+	// The http services are reach by the Globule http server, the others
+	// at their port.
+	if router == nil && !isHttpService(s) {
+		var err error
+		router, err = newServiceRouter(Utility.ToInt(s["Port"]))
+		if err != nil {
+			return err
+		}
+
+		self.mutex.Lock()
+		self.routers[name] = router
+		self.mutex.Unlock()
+	}
+
+	// Start the processes.
+	log.Println("try to start process ", name)
+	instances, err := self.startInstances(s, s["Path"].(string), getReplicas(s))
+	if err != nil {
+		return err
+	}
+
+	self.mutex.Lock()
+	s["Instances"] = instances
+	self.mutex.Unlock()
+
+	if isHttpService(s) {
+		log.Println("Service ", name, "is running with", len(instances), "replicas")
+		self.notifyRegistry()
+		event_client.Publish(event_client.ServiceStarted, map[string]interface{}{"name": name, "replicas": len(instances)})
+		return nil
+	}
+
+	addresses := make([]string, len(instances))
+	for i, instance := range instances {
+		addresses[i] = instance.address()
+	}
+	router.setBackends(addresses)
+
+	// Now I will start the proxy that will be use by javascript client, it
+	// stay connected to the router when the processes are replace. The
+	// services without Proxy port are not reach by the browsers.
+	if proxy == nil && Utility.ToInt(s["Proxy"]) > 0 && !contains(noWebProxyServices, name) {
+		proxy, err = newWebProxy(Utility.ToInt(s["Proxy"]), getWebProxyPath(self.path), "localhost:"+Utility.ToString(s["Port"]), Utility.ToString(s["AllowAllOrigins"]))
+		if err != nil {
+			log.Println("Fail to start grpcwebproxy: ", name, " at port ", s["Proxy"], " with error ", err)
+		} else {
+			self.mutex.Lock()
+			self.webProxies[name] = proxy
+			self.mutex.Unlock()
+		}
+	}
+
+	if proxy != nil {
+		err = proxy.setReplicas(len(instances), upgradeDrainTimeout)
+		if err != nil {
+			log.Println("Fail to start grpcwebproxy: ", name, " at port ", s["Proxy"], " with error ", err)
+		}
+	}
+
+	log.Println("Service ", name, "is running at port", s["Port"], "it's proxy port is", s["Proxy"], "with", len(instances), "replicas")
+	self.notifyRegistry()
+	event_client.Publish(event_client.ServiceStarted, map[string]interface{}{"name": name, "replicas": len(instances)})
+
+	return nil
+}
+
+/**
+ * Start count processes of a service, if one fail the others are stopped.
+ */
+func (self *Globule) startInstances(s map[string]interface{}, path string, count int) ([]*serviceInstance, error) {
+	instances := make([]*serviceInstance, 0, count)
+	for i := 0; i < count; i++ {
+		instance, err := self.startInstance(s, path)
+		if err != nil {
+			for _, instance := range instances {
+				instance.process.Process.Kill()
 			}
-			path = strings.Replace(path, "\\", "/", -1) // Windows back slash replacement here...
-			if strings.HasSuffix(path, importPath_) {
-				importPath_ = path
-				return io.EOF
+			return nil, err
+		}
+		instances = append(instances, instance)
+	}
+
+	return instances, nil
+}
+
+/**
+ * Start a process of a service at a free port. The process is restarted if
+ * it exit while it is still one of the service instances.
+ */
+func (self *Globule) startInstance(s map[string]interface{}, path string) (*serviceInstance, error) {
+	name := s["Name"].(string)
+	port, err := getFreePort()
+	if err != nil {
+		return nil, err
+	}
+
+	var process *exec.Cmd
+	if name == "file_server" {
+		process = exec.Command(path, strconv.Itoa(port), globule.webRoot)
+	} else {
+		process = exec.Command(path, strconv.Itoa(port))
+	}
+
+	// The http services can also take their port from the environment.
+	if isHttpService(s) {
+		process.Env = append(os.Environ(), "PORT="+strconv.Itoa(port))
+	}
+
+	// The output of the service will be keep in it logs.
+	logs := self.getLogs(name)
+	process.Stdout = logs
+	process.Stderr = logs
+
+	err = process.Start()
+	if err != nil {
+		return nil, err
+	}
+
+	instance := &serviceInstance{process: process, port: port, exited: make(chan bool)}
+	go func() {
+		err := process.Wait()
+		log.Println("Service ", name, " process ", process.Process.Pid, " exit ", err)
+		close(instance.exited)
+
+		msg := ""
+		if err != nil {
+			msg = err.Error()
+		}
+		event_client.Publish(event_client.ServiceExited, map[string]interface{}{"name": name, "pid": process.Process.Pid, "error": msg})
+		self.notifyRegistry()
+		self.restartInstance(s, instance)
+	}()
+
+	return instance, nil
+}
+
+/**
+ * Replace a process of a service that exit. The restart is retry with an
+ * increasing delay until it succeed or the process is no more one of the
+ * service instances, when the service is stopped or upgraded.
+ */
+func (self *Globule) restartInstance(s map[string]interface{}, instance *serviceInstance) {
+	name := s["Name"].(string)
+	delay := time.Second
+	for {
+		time.Sleep(delay)
+
+		self.mutex.Lock()
+		path := s["Path"].(string)
+		router := self.routers[name]
+		self.mutex.Unlock()
+
+		if (router == nil && !isHttpService(s)) || self.getInstanceIndex(s, instance) == -1 {
+			return
+		}
+
+		replica, err := self.startInstance(s, path)
+		if err == nil {
+			self.mutex.Lock()
+			instances := getInstances(s)
+			index := -1
+			for i, instance_ := range instances {
+				if instance_ == instance {
+					index = i
+				}
+			}
+
+			if index != -1 {
+				instances[index] = replica
 			}
+			self.mutex.Unlock()
+
+			if index == -1 {
+				replica.process.Process.Kill()
+				return
+			}
+
+			if router != nil {
+				router.replaceBackend(instance.address(), replica.address())
+			}
+			self.notifyRegistry()
+			log.Println("Service ", name, " process ", replica.process.Process.Pid, " replace process ", instance.process.Process.Pid)
+			event_client.Publish(event_client.ServiceRestarted, map[string]interface{}{"name": name, "pid": replica.process.Process.Pid})
+			return
+		}
 
+		log.Println("Fail to restart service ", name, " with error ", err)
+		if delay < 30*time.Second {
+			delay *= 2
+		}
+	}
+}
+
+/**
+ * Return the index of a process in the service instances, -1 if it is not
+ * one of them.
+ */
+func (self *Globule) getInstanceIndex(s map[string]interface{}, instance *serviceInstance) int {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	for i, instance_ := range getInstances(s) {
+		if instance_ == instance {
+			return i
+		}
+	}
+
+	return -1
+}
+
+/**
+ * Kill the processes of a service, it proxies and close it ports.
+ */
+func (self *Globule) stopService(name string) error {
+	self.mutex.Lock()
+	s := self.services[name]
+	if s == nil {
+		self.mutex.Unlock()
+		return errors.New("no service found with name " + name)
+	}
+
+	instances := getInstances(s.(map[string]interface{}))
+	s.(map[string]interface{})["Instances"] = nil
+	router := self.routers[name]
+	delete(self.routers, name)
+	proxy := self.webProxies[name]
+	delete(self.webProxies, name)
+	self.mutex.Unlock()
+
+	if router != nil {
+		router.close()
+	}
+
+	if proxy != nil {
+		proxy.close()
+	}
+
+	self.notifyRegistry()
+
+	var err error
+	for _, instance := range instances {
+		log.Println("kill service process ", instance.process.Process.Pid)
+		err_ := instance.process.Process.Kill()
+		if err_ != nil && instance.isRunning() {
+			err = err_
+		}
+	}
+
+	event_client.Publish(event_client.ServiceStopped, map[string]interface{}{"name": name})
+
+	return err
+}
+
+/**
+ * Replace the processes of a service by new ones without interruption. The
+ * new processes run the executable at path, once they accept connections
+ * the router send them the new connections and the old processes are stopped
+ * when their connections are closed or after drainTimeout. If a new process
+ * fail to start the old ones continue to serve.
+ */
+func (self *Globule) upgradeService(name string, path string, drainTimeout time.Duration) error {
+	self.mutex.Lock()
+	s := self.services[name].(map[string]interface{})
+	router := self.routers[name]
+	proxy := self.webProxies[name]
+	previous := getInstances(s)
+	self.mutex.Unlock()
+
+	if !self.isRunning(name) || (router == nil && !isHttpService(s)) {
+		// Nothing to drain.
+		self.stopService(name)
+		s["Path"] = path
+		err := self.startService(s)
+		if err == nil {
+			err = self.waitHealthy(name, installHealthTimeout)
+		}
+		return err
+	}
+
+	log.Println("try to start new process of ", name)
+	instances, err := self.startInstances(s, path, getReplicas(s))
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(installHealthTimeout)
+	addresses := make([]string, len(instances))
+	for i, instance := range instances {
+		addresses[i] = instance.address()
+		if err == nil {
+			err = waitInstanceHealthy(s, instance, time.Until(deadline))
+		}
+	}
+
+	if err != nil {
+		for _, instance := range instances {
+			instance.process.Process.Kill()
+		}
+		return errors.New("the new process of " + name + " is not healthy: " + err.Error())
+	}
+
+	// From now the new connections go to the new processes.
+	backends := make([]*routerBackend, 0)
+	if router != nil {
+		backends = router.setBackends(addresses)
+	}
+
+	self.mutex.Lock()
+	s["Path"] = path
+	s["Instances"] = instances
+	self.mutex.Unlock()
+
+	if proxy != nil {
+		proxy.setReplicas(len(instances), drainTimeout)
+	}
+
+	// The clients of the globular resolver receive the new addresses, they
+	// finish their calls on the old processes before closing their
+	// connections.
+	self.notifyRegistry()
+	time.Sleep(drainIdleDelay)
+
+	if !drainBackends(backends, drainTimeout) || !self.drainHttpInstances(previous, drainTimeout) {
+		log.Println("Service ", name, " old processes still have connections after ", drainTimeout)
+	}
+
+	for _, instance := range previous {
+		log.Println("kill service process ", instance.process.Process.Pid)
+		instance.process.Process.Kill()
+	}
+
+	for _, backend := range backends {
+		backend.closeConnections(0)
+	}
+
+	log.Println("Service ", name, " is now served by ", len(instances), " new processes")
+	event_client.Publish(event_client.ServiceUpgraded, map[string]interface{}{"name": name, "replicas": len(instances)})
+
+	return nil
+}
+
+/**
+ * Wait until the processes of a service are running and healthy.
+ */
+func (self *Globule) waitHealthy(name string, timeout time.Duration) error {
+	self.mutex.Lock()
+	s := self.services[name].(map[string]interface{})
+	instances := getInstances(s)
+	self.mutex.Unlock()
+
+	deadline := time.Now().Add(timeout)
+	for _, instance := range instances {
+		err := waitInstanceHealthy(s, instance, time.Until(deadline))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/**
+ * Wait until a process of a service accept connections, and for the http
+ * services with a health path until it answer with a success status.
+ */
+func waitInstanceHealthy(s map[string]interface{}, instance *serviceInstance, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	err := waitListening(instance.port, instance.isRunning, timeout)
+	if err != nil {
+		return err
+	}
+
+	healthPath := getHealthPath(s)
+	if len(healthPath) == 0 {
+		return nil
+	}
+
+	for {
+		if !instance.isRunning() {
+			return errors.New("the process is not running")
+		}
+
+		err := probeHealth(instance.port, healthPath)
+		if err == nil {
 			return nil
-		})
+		}
 
-	importPath_ = strings.Replace(importPath_, strings.Replace(root, "\\", "/", -1), "", -1)
+		if time.Now().After(deadline) {
+			return errors.New("the health check " + healthPath + " at port " + strconv.Itoa(instance.port) + " fail: " + err.Error())
+		}
 
-	// Now i will make the path relative.
-	importPath__ := strings.Split(importPath_, "/")
-	path__ := strings.Split(path, "/")
+		time.Sleep(200 * time.Millisecond)
+	}
+}
 
-	var index int
-	for ; importPath__[index] == path__[index]; index++ {
+/**
+ * Return the health path of an http service, empty for the other services.
+ */
+func getHealthPath(s map[string]interface{}) string {
+	if !isHttpService(s) {
+		return ""
 	}
 
-	importPath_ = ""
+	return Utility.ToString(s["HealthPath"])
+}
+
+/**
+ * Check once that a process accept connections at port, and that it answer
+ * healthPath with a success status if it is not empty.
+ */
+func probeHealth(port int, healthPath string) error {
+	address := "localhost:" + strconv.Itoa(port)
+	if len(healthPath) == 0 {
+		conn, err := net.DialTimeout("tcp", address, time.Second)
+		if err != nil {
+			return err
+		}
+		conn.Close()
+		return nil
+	}
+
+	client := &http.Client{Timeout: time.Second}
+	rsp, err := client.Get("http://" + address + healthPath)
+	if err != nil {
+		return err
+	}
+	rsp.Body.Close()
+
+	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
+		return errors.New(rsp.Status)
+	}
+
+	return nil
+}
+
+/**
+ * Probe the processes of the http services and the grpcwebproxy at regular
+ * interval, the unhealthy ones receive no requests until they answer again.
+ */
+func (self *Globule) checkHealth() {
+	for {
+		time.Sleep(healthCheckInterval)
+
+		self.mutex.Lock()
+		names := make(map[*serviceInstance]string, 0)
+		healthPaths := make(map[*serviceInstance]string, 0)
+		for name, s := range self.services {
+			s := s.(map[string]interface{})
+			if !isHttpService(s) {
+				continue
+			}
+
+			for _, instance := range getInstances(s) {
+				if instance.isRunning() {
+					names[instance] = name
+					healthPaths[instance] = getHealthPath(s)
+				}
+			}
+		}
+
+		proxies := make([]*webProxy, 0)
+		for _, proxy := range self.webProxies {
+			proxies = append(proxies, proxy)
+		}
+		self.mutex.Unlock()
+
+		for instance, name := range names {
+			err := probeHealth(instance.port, healthPaths[instance])
+
+			self.mutex.Lock()
+			changed := instance.unhealthy != (err != nil)
+			instance.unhealthy = err != nil
+			self.mutex.Unlock()
+
+			if changed && err != nil {
+				log.Println("Process ", instance.process.Process.Pid, " of ", name, " is unhealthy: ", err)
+			} else if changed {
+				log.Println("Process ", instance.process.Process.Pid, " of ", name, " is healthy again")
+			}
+		}
 
-	// move up part..
-	for i := index; i < len(path__)-1; i++ {
-		importPath_ += "../"
+		for _, proxy := range proxies {
+			proxy.checkHealth()
+		}
 	}
+}
+
+/**
+ * Wait until a port accept connections, running tell if the process that
+ * must listen it is still alive.
+ */
+func waitListening(port int, running func() bool, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		if !running() {
+			return errors.New("the process is not running")
+		}
 
-	// go down to the file.
-	for i := index; i < len(importPath__); i++ {
-		importPath_ += importPath__[i]
-		if i < len(importPath__)-1 {
-			importPath_ += "/"
+		conn, err := net.DialTimeout("tcp", "localhost:"+strconv.Itoa(port), time.Second)
+		if err == nil {
+			conn.Close()
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return errors.New("the process does not accept connection at port " + strconv.Itoa(port))
 		}
+
+		time.Sleep(200 * time.Millisecond)
 	}
+}
 
-	// remove the
-	importPath_ = strings.Replace(importPath_, root, "", 1)
+/**
+ * Return true if the service must be running, a process that exit is
+ * restarted.
+ */
+func (self *Globule) isRunning(name string) bool {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	s := self.services[name]
+	if s == nil {
+		return false
+	}
 
-	// remove the root path part and the leading / caracter.
-	return importPath_, nil
+	return len(getInstances(s.(map[string]interface{}))) > 0
 }
 
 /**
@@ -248,7 +1009,9 @@ func HttpQueryHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get the client connected to the required service.
+	globule.mutex.Lock()
 	service := globule.clients[inputs[0]]
+	globule.mutex.Unlock()
 
 	if service == nil {
 		w.Header().Set("Content-Type", "application/text")
@@ -304,10 +1067,15 @@ func HttpQueryHandler(w http.ResponseWriter, r *http.Request) {
  * via http request.
  */
 func FileUploadHandler(w http.ResponseWriter, r *http.Request) {
+	// The form can not be bigger than the upload limit.
+	if globule.UploadMaxSize > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, globule.UploadMaxSize)
+	}
+
 	// I will
-	err := r.ParseMultipartForm(200000) // grab the multipart form
+	err := r.ParseMultipartForm(32 << 20) // grab the multipart form
 	if err != nil {
-		log.Println(w, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
@@ -315,35 +1083,39 @@ func FileUploadHandler(w http.ResponseWriter, r *http.Request) {
 
 	//get the *fileheaders
 	files := formdata.File["multiplefiles"] // grab the filenames
-	var path string                         // grab the filenames
 
-	// Get the path where to upload the file.
-	path = r.FormValue("path")
-	if strings.HasPrefix(path, "/") {
-		path = globule.webRoot + path
-	}
+	// Get the path where to upload the file, it stay in the web root.
+	path_ := filepath.Join(globule.webRoot, filepath.FromSlash(path.Clean("/"+r.FormValue("path"))))
 
 	for i, _ := range files { // loop through the files one by one
-		file, err := files[i].Open()
-		defer file.Close()
+		err := saveUploadedFile(files[i], path_)
 		if err != nil {
-			log.Println(w, err)
+			log.Println("Fail to upload ", files[i].Filename, " with error ", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
+	}
+}
 
-		// Create the file.
-		out, err := os.Create(path + "/" + files[i].Filename)
-		defer out.Close()
-		if err != nil {
-			log.Println(w, "Unable to create the file for writing. Check your write access privilege")
-			return
-		}
-		_, err = io.Copy(out, file) // file not files[i] !
-		if err != nil {
-			log.Println(w, err)
-			return
-		}
+/**
+ * Save a file of a multipart form in a directory.
+ */
+func saveUploadedFile(header *multipart.FileHeader, dir string) error {
+	file, err := header.Open()
+	if err != nil {
+		return err
 	}
+	defer file.Close()
+
+	// Create the file.
+	out, err := os.Create(filepath.Join(dir, filepath.Base(header.Filename)))
+	if err != nil {
+		return errors.New("Unable to create the file for writing. Check your write access privilege")
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, file) // file not files[i] !
+	return err
 }
 
 // Custom file server implementation.
@@ -364,86 +1136,111 @@ func ServeFileHandler(w http.ResponseWriter, r *http.Request) {
 
 	upath = path.Clean(upath)
 
+	// The web applications have their own root.
+	app := globule.getApplication(r)
+	if app != nil {
+		dir = globule.getApplicationRoot(app)
+		upath = path.Clean("/" + strings.TrimPrefix(upath, strings.TrimSuffix(app.PathPrefix, "/")))
+	} else if upath == "/config.json" {
+		globule.serveConfig(nil, w, r)
+		return
+	}
+
 	//path to file
 	name := path.Join(dir, upath)
 
 	//check if file exists
-	f, err := os.Open(name)
+	info, err := os.Stat(name)
 	if err != nil {
 		if os.IsNotExist(err) {
+			if app != nil {
+				globule.serveApplicationNotFound(app, dir, upath, w, r)
+				return
+			}
 			http.Error(w, "File "+upath+" not found!", http.StatusBadRequest)
 			return
 		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
-	defer f.Close()
-
-	// If the file is a javascript file...
-	//log.Println("Serve file name: ", name)
-	var code string
-	hasChange := false
-	if strings.HasSuffix(name, ".js") {
-		w.Header().Add("Content-Type", "application/javascript")
-		if err == nil {
-			//hasChange = true
-			scanner := bufio.NewScanner(f)
-			for scanner.Scan() {
-				line := scanner.Text()
-				if strings.HasPrefix(line, "import") {
-					if strings.Index(line, `'@`) > -1 {
-						path_, err := resolveImportPath(upath, line)
-						if err == nil {
-							line = line[0:strings.Index(line, `'@`)] + `'` + path_ + `'`
-							hasChange = true
-						}
-					}
-				}
 
-				code += line + "\n"
-			}
+	// The directories give their index.html
+	if info.IsDir() {
+		index := path.Join(name, "index.html")
+		info_, err := os.Stat(index)
+		if err != nil || !strings.HasSuffix(r.URL.Path, "/") {
+			http.ServeFile(w, r, name) // redirect or list the directory.
+			return
 		}
+		name = index
+		info = info_
+	}
 
-	} else if strings.HasSuffix(name, ".css") {
-		w.Header().Add("Content-Type", "text/css")
-	} else if strings.HasSuffix(name, ".html") || strings.HasSuffix(name, ".htm") {
-		w.Header().Add("Content-Type", "text/html")
-	} else if strings.HasSuffix(name, "config.json") {
-		b, err := ioutil.ReadAll(f) // b has type []byte
-		if err != nil {
-			log.Fatal(err)
+	var rewrite func([]byte) ([]byte, bool, []string)
+	if strings.HasSuffix(name, ".js") || strings.HasSuffix(name, ".mjs") {
+		// The imports of the javascript files are resolved.
+		rewrite = func(data []byte) ([]byte, bool, []string) {
+			return modules.rewrite(dir, name, data)
 		}
+	} else if strings.HasSuffix(name, "config.json") {
 		// set the global variable here.
-		code = "window.globularConfig = " + string(b)
-		hasChange = true
+		rewrite = func(data []byte) ([]byte, bool, []string) {
+			return append([]byte("window.globularConfig = "), data...), true, nil
+		}
 	}
 
-	// if the file has change...
-	if !hasChange {
-		http.ServeFile(w, r, name)
-	} else {
-		// log.Println(code)
-		http.ServeContent(w, r, name, time.Now(), strings.NewReader(code))
-	}
+	globule.serveAsset(w, r, dir, name, r.URL.Path, info, rewrite)
 }
 
 func (self *Globule) saveConfig() {
 	// Here I will save the server attribute
+	self.mutex.Lock()
 	str, err := Utility.ToJson(self)
 	if err == nil {
-		ioutil.WriteFile(self.webRoot+string(os.PathSeparator)+"config.json", []byte(str), 0644)
+		self.writeConfig(str)
 	}
+	self.mutex.Unlock()
+}
+
+/**
+ * Write the configuration file, the mutex must be lock so the writes follow
+ * the changes.
+ */
+func (self *Globule) writeConfig(str string) {
+	ioutil.WriteFile(getConfigPath(self.path), []byte(str), 0644)
+}
+
+/**
+ * Return the path of the Globule configuration in the Globular directory. It
+ * is not in WebRoot, the files there are serve and can be uploaded, the
+ * browsers receive the configuration from the Globule at /config.json.
+ */
+func getConfigPath(dir string) string {
+	return filepath.Join(dir, "globular.json")
 }
 
 /**
- * Init client side connection to service.
+ * Init client side connection to service. The client address the service by
+ * it name, the globular resolver give it the addresses of the processes.
  */
 func (self *Globule) initClient(name string) {
+	self.mutex.Lock()
+	client := self.clients[name+"_service"]
+	self.mutex.Unlock()
+
+	// The addresses of an existing client are kept up to date.
+	if client != nil {
+		return
+	}
+
 	log.Println("connecto to service ", name)
-	port := int(self.Services[name+"_server"].(map[string]interface{})["Port"].(float64))
 	fct := "New" + strings.ToUpper(name[0:1]) + name[1:] + "_Client"
 	log.Println(fct)
-	results, err := Utility.CallFunction(fct, "localhost:"+strconv.Itoa(port))
+	results, err := Utility.CallFunction(fct, resolver.Scheme+":///"+name+"_server")
 	if err == nil {
+		self.mutex.Lock()
 		self.clients[name+"_service"] = results[0].Interface().(Client)
+		self.mutex.Unlock()
 	}
 }
 
@@ -454,6 +1251,7 @@ func (self *Globule) initClients() {
 	// Register service constructor function here.
 	// The name of the contructor must follow the same pattern.
 	Utility.RegisterFunction("NewEcho_Client", NewEcho_Client)
+	Utility.RegisterFunction("NewEvent_Client", NewEvent_Client)
 	Utility.RegisterFunction("NewSql_Client", NewSql_Client)
 	Utility.RegisterFunction("NewFile_Client", NewFile_Client)
 	Utility.RegisterFunction("NewPersistence_Client", NewPersistence_Client)
@@ -474,16 +1272,40 @@ func (self *Globule) initClients() {
 func (self *Globule) Listen() {
 
 	log.Println("Start Globular at port ", self.Port)
+	self.startTime = time.Now()
 
 	// Set the log information in case of crash...
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
 
+	// Keep the Globule output to make it available from the admin service.
+	log.SetOutput(io.MultiWriter(os.Stderr, self.getLogs(self.Name)))
+
+	// The globular resolver of the services started by the Globule, and of
+	// it own clients, get the addresses from it admin service.
+	os.Setenv("GLOBULAR_ADDRESS", "localhost")
+	os.Setenv("GLOBULAR_ADMIN_PORT", strconv.Itoa(self.AdminPort))
+
+	// start the administration service.
+	err := self.startAdminService()
+	if err != nil {
+		log.Println("Fail to start admin service at port ", self.AdminPort, " with error ", err)
+	}
+
 	// set the services.
 	self.initServices()
 
 	// set the client services.
 	self.initClients()
 
+	// Exchange the services registries with the other nodes of the cluster.
+	go self.gossip()
+
+	// Create the scheduled backups.
+	go self.scheduleBackups()
+
+	// Stop sending requests to the processes that does not answer.
+	go self.checkHealth()
+
 	r := http.NewServeMux()
 
 	// Start listen for http request.
@@ -492,8 +1314,18 @@ func (self *Globule) Listen() {
 	// The file upload handler.
 	r.HandleFunc("/uploads", FileUploadHandler)
 
+	// The resumable uploads.
+	self.initUploads()
+	r.HandleFunc("/uploads/", self.UploadsHandler)
+
+	// The downloads of the files of the file service.
+	r.HandleFunc("/downloads/", self.DownloadsHandler)
+
+	// The events of the services.
+	r.HandleFunc("/events", self.EventsHandler)
+
 	// Give access to service.
-	r.HandleFunc("/api/", HttpQueryHandler)
+	r.HandleFunc("/api/", compressHandler(HttpQueryHandler))
 
 	// Here I will save the server attribute
 	self.saveConfig()
@@ -518,24 +1350,9 @@ func (self *Globule) Listen() {
 		// so I will close the services.
 		log.Println("Clean ressources.")
 
-		for key, value := range self.services {
+		for key, _ := range self.services {
 			log.Println("Stop service ", key)
-
-			if value.(map[string]interface{})["Process"] != nil {
-				p := value.(map[string]interface{})["Process"]
-				if reflect.TypeOf(p).String() == "*exec.Cmd" {
-					log.Println("kill service process ", p.(*exec.Cmd).Process.Pid)
-					p.(*exec.Cmd).Process.Kill()
-				}
-			}
-
-			if value.(map[string]interface{})["ProxyProcess"] != nil {
-				p := value.(map[string]interface{})["ProxyProcess"]
-				if reflect.TypeOf(p).String() == "*exec.Cmd" {
-					log.Println("kill proxy process ", p.(*exec.Cmd).Process.Pid)
-					p.(*exec.Cmd).Process.Kill()
-				}
-			}
+			self.stopService(key)
 		}
 
 		for _, value := range self.clients {
@@ -548,7 +1365,7 @@ func (self *Globule) Listen() {
 	}()
 
 	log.Println("Listening...")
-	err := http.ListenAndServe(":"+strconv.Itoa(self.Port), r)
+	err = http.ListenAndServe(":"+strconv.Itoa(self.Port), self.httpServicesHandler(r))
 	if err != nil {
 		panic("ListenAndServe: " + err.Error())
 	}
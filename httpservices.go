@@ -0,0 +1,274 @@
+package main
+
+import (
+	"log"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+	"time"
+
+	"github.com/davecourtois/Utility"
+)
+
+/**
+ * The http services are web applications started and supervised by the
+ * Globule like the grpc services. The Globule http server forward them the
+ * requests for their hostname or under their path prefix, ex:
+ *
+ *	{
+ *		"Name": "blog_server",
+ *		"Protocol": "http",
+ *		"PathPrefix": "/blog",
+ *		"Hostname": "blog.example.com",
+ *		"HealthPath": "/health",
+ *		"RequestHeaders": {"X-Globule": "globular", "Cookie": ""},
+ *		"ResponseHeaders": {"X-Frame-Options": "DENY"}
+ *	}
+ *
+ * The process receive it port as first argument and in the PORT environment
+ * variable.
+ */
+
+/**
+ * Return true if the service is an http service, it must give the requests it
+ * receive by it Hostname or it PathPrefix.
+ */
+func isHttpService(s map[string]interface{}) bool {
+	return s["Protocol"] == "http" && (len(Utility.ToString(s["Hostname"])) > 0 || len(Utility.ToString(s["PathPrefix"])) > 0)
+}
+
+/**
+ * Return the path prefix of an http service without the trailing slash.
+ */
+func getPathPrefix(s map[string]interface{}) string {
+	return strings.TrimSuffix(Utility.ToString(s["PathPrefix"]), "/")
+}
+
+/**
+ * Return true if path is prefix or a path under it.
+ */
+func hasPathPrefix(path string, prefix string) bool {
+	if !strings.HasPrefix(path, prefix) {
+		return false
+	}
+
+	return len(prefix) == 0 || len(path) == len(prefix) || path[len(prefix)] == '/'
+}
+
+/**
+ * Forward the requests for the http services, the other requests are handled
+ * by next.
+ */
+func (self *Globule) httpServicesHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s := self.getHttpService(r)
+		if s == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		self.serveHttpService(s, w, r)
+	})
+}
+
+/**
+ * Return how much a request match an hostname and a path prefix, -1 if it
+ * does not match. A route with an hostname take precedence over the one
+ * without, next the longest path prefix win.
+ */
+func matchRoute(r *http.Request, hostname string, prefix string) int {
+	host, _, err := net.SplitHostPort(r.Host)
+	if err != nil {
+		host = r.Host
+	}
+
+	if len(hostname) == 0 && len(prefix) == 0 {
+		return -1 // not routed.
+	}
+
+	if len(hostname) > 0 && !strings.EqualFold(hostname, host) {
+		return -1
+	}
+
+	if !hasPathPrefix(r.URL.Path, prefix) {
+		return -1
+	}
+
+	score := len(prefix)
+	if len(hostname) > 0 {
+		score += 1 << 16
+	}
+
+	return score
+}
+
+/**
+ * Return the http service of a request, nil if the request is not for an
+ * http service.
+ */
+func (self *Globule) getHttpService(r *http.Request) map[string]interface{} {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	var service map[string]interface{}
+	score := -1
+	for _, s := range self.services {
+		s := s.(map[string]interface{})
+		if !isHttpService(s) {
+			continue
+		}
+
+		score_ := matchRoute(r, Utility.ToString(s["Hostname"]), getPathPrefix(s))
+		if score_ > score {
+			service = s
+			score = score_
+		}
+	}
+
+	return service
+}
+
+/**
+ * Return the instance of an http service with the less requests in progress
+ * and count the new request, done must be call at the end of the request.
+ */
+func (self *Globule) getHttpInstance(s map[string]interface{}) *serviceInstance {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	instances := getInstances(s)
+	var instance *serviceInstance
+	self.httpNext++
+	for i := 0; i < len(instances); i++ {
+		instance_ := instances[(self.httpNext+i)%len(instances)]
+		if !instance_.isRunning() || instance_.unhealthy {
+			continue
+		}
+
+		if instance == nil || self.httpCalls[instance_] < self.httpCalls[instance] {
+			instance = instance_
+		}
+	}
+
+	if instance != nil {
+		self.httpCalls[instance]++
+	}
+
+	return instance
+}
+
+func (self *Globule) httpRequestDone(instance *serviceInstance) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	self.httpCalls[instance]--
+	if self.httpCalls[instance] <= 0 {
+		delete(self.httpCalls, instance)
+	}
+}
+
+/**
+ * Forward a request to an http service. The WebSocket upgrades are forwarded
+ * by the reverse proxy as the other requests.
+ */
+func (self *Globule) serveHttpService(s map[string]interface{}, w http.ResponseWriter, r *http.Request) {
+	self.mutex.Lock()
+	name := s["Name"].(string)
+	prefix := getPathPrefix(s)
+	stripPrefix := s["StripPrefix"] != false
+	requestHeaders, _ := s["RequestHeaders"].(map[string]interface{})
+	responseHeaders, _ := s["ResponseHeaders"].(map[string]interface{})
+	self.mutex.Unlock()
+
+	instance := self.getHttpInstance(s)
+	if instance == nil {
+		http.Error(w, "service "+name+" is not running", http.StatusServiceUnavailable)
+		return
+	}
+	defer self.httpRequestDone(instance)
+
+	proto := "http"
+	if r.TLS != nil {
+		proto = "https"
+	}
+
+	proxy := &httputil.ReverseProxy{
+		Director: func(r_ *http.Request) {
+			r_.URL.Scheme = "http"
+			r_.URL.Host = instance.address()
+
+			if stripPrefix && len(prefix) > 0 {
+				r_.URL.Path = strings.TrimPrefix(r_.URL.Path, prefix)
+				r_.URL.RawPath = ""
+				if len(r_.URL.Path) == 0 {
+					r_.URL.Path = "/"
+				}
+				r_.Header.Set("X-Forwarded-Prefix", prefix)
+			}
+
+			r_.Header.Set("X-Forwarded-Host", r.Host)
+			r_.Header.Set("X-Forwarded-Proto", proto)
+
+			// An empty value remove the header.
+			for key, value := range requestHeaders {
+				if len(Utility.ToString(value)) == 0 {
+					r_.Header.Del(key)
+				} else {
+					r_.Header.Set(key, Utility.ToString(value))
+				}
+			}
+		},
+		ModifyResponse: func(rsp *http.Response) error {
+			// The redirections of the service stay under it prefix.
+			location := rsp.Header.Get("Location")
+			if stripPrefix && len(prefix) > 0 && strings.HasPrefix(location, "/") && !strings.HasPrefix(location, "//") {
+				rsp.Header.Set("Location", prefix+location)
+			}
+
+			for key, value := range responseHeaders {
+				if len(Utility.ToString(value)) == 0 {
+					rsp.Header.Del(key)
+				} else {
+					rsp.Header.Set(key, Utility.ToString(value))
+				}
+			}
+			return nil
+		},
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			log.Println("Fail to forward request ", r.URL.Path, " to service ", name, " with error ", err)
+			http.Error(w, "service "+name+" is not available", http.StatusBadGateway)
+		},
+
+		// The server sent events must be sent as they come.
+		FlushInterval: -1,
+	}
+
+	proxy.ServeHTTP(w, r)
+}
+
+/**
+ * Wait until the requests in progress on the instances of an http service
+ * are done. Return false if requests remain after timeout.
+ */
+func (self *Globule) drainHttpInstances(instances []*serviceInstance, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for {
+		count := 0
+		self.mutex.Lock()
+		for _, instance := range instances {
+			count += self.httpCalls[instance]
+		}
+		self.mutex.Unlock()
+
+		if count == 0 {
+			return true
+		}
+
+		if time.Now().After(deadline) {
+			return false
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+}
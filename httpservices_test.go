@@ -0,0 +1,62 @@
+package main
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// The probe check the connection, and the status of the health path if the
+// service give one.
+func TestProbeHealth(t *testing.T) {
+	healthy := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health" || !healthy {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	port := server.Listener.Addr().(*net.TCPAddr).Port
+	if probeHealth(port, "") != nil || probeHealth(port, "/health") != nil {
+		t.Fatal("the healthy process fail the health check")
+	}
+
+	healthy = false
+	if probeHealth(port, "") != nil || probeHealth(port, "/health") == nil {
+		t.Fatal("the health path status is not checked")
+	}
+
+	server.Close()
+	if probeHealth(port, "") == nil {
+		t.Fatal("the process that does not accept connections pass the health check")
+	}
+}
+
+// The requests go to the running processes that pass the health check.
+func TestGetHttpInstance(t *testing.T) {
+	g := new(Globule)
+	g.httpCalls = make(map[*serviceInstance]int, 0)
+
+	exited := make(chan bool)
+	close(exited)
+
+	healthy := &serviceInstance{port: 1, exited: make(chan bool)}
+	unhealthy := &serviceInstance{port: 2, exited: make(chan bool), unhealthy: true}
+	stopped := &serviceInstance{port: 3, exited: exited}
+	s := map[string]interface{}{"Instances": []*serviceInstance{unhealthy, stopped, healthy}}
+
+	for i := 0; i < 3; i++ {
+		instance := g.getHttpInstance(s)
+		if instance != healthy {
+			t.Fatalf("the request go to the process at port %d", instance.port)
+		}
+		g.httpRequestDone(instance)
+	}
+
+	healthy.unhealthy = true
+	if g.getHttpInstance(s) != nil {
+		t.Fatal("the request go to an unhealthy process")
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"google.golang.org/grpc/grpclog"
 	"google.golang.org/grpc/status"
 
+	"github.com/davecourtois/Globular/config"
 	"github.com/davecourtois/Globular/ldap/ldappb"
 	"github.com/davecourtois/Utility"
 
@@ -34,6 +35,9 @@ var (
 
 	// comma separeated values.
 	allowed_origins string = ""
+
+	// The configuration schema version, see package config.
+	configVersion = 1
 )
 
 // Keep connection information here.
@@ -57,6 +61,11 @@ type server struct {
 	AllowAllOrigins bool
 	AllowedOrigins  string // comma separated string.
 
+	// The number of processes run by the Globule and the version of the
+	// configuration schema.
+	Replicas      int
+	ConfigVersion int
+
 	// The map of connection...
 	Connections map[string]connection
 }
@@ -64,11 +73,12 @@ type server struct {
 func (self *server) init() {
 	// Here I will retreive the list of connections from file if there are some...
 	dir, _ := filepath.Abs(filepath.Dir(os.Args[0]))
-	file, err := ioutil.ReadFile(dir + "/config.json")
-	if err == nil {
-		json.Unmarshal([]byte(file), self)
-	} else {
+	self.ConfigVersion = configVersion
+	err := config.Load(dir+"/config.json", self, configVersion)
+	if os.IsNotExist(err) {
 		self.save()
+	} else if err != nil {
+		log.Fatalln(err)
 	}
 }
 
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"strings"
+	"sync"
+)
+
+// The number of lines kept in memory by service.
+const maxLogLines = 1000
+
+/**
+ * Keep the last lines written by a process and send new one to it followers.
+ * It's use as Stdout and Stderr of the services processes.
+ */
+type logBuffer struct {
+	sync.Mutex
+	lines     []string
+	partial   string
+	followers map[chan string]bool
+}
+
+func newLogBuffer() *logBuffer {
+	buffer := new(logBuffer)
+	buffer.lines = make([]string, 0)
+	buffer.followers = make(map[chan string]bool, 0)
+	return buffer
+}
+
+// Implement the io.Writer interface.
+func (self *logBuffer) Write(p []byte) (int, error) {
+	self.Lock()
+	defer self.Unlock()
+
+	// The last line can be incomplete so I will keep it until the next write.
+	data := self.partial + string(p)
+	lines := strings.Split(data, "\n")
+	self.partial = lines[len(lines)-1]
+
+	for i := 0; i < len(lines)-1; i++ {
+		line := strings.TrimSuffix(lines[i], "\r")
+		self.lines = append(self.lines, line)
+		for follower, _ := range self.followers {
+			// Never block the process output because of a slow follower.
+			select {
+			case follower <- line:
+			default:
+			}
+		}
+	}
+
+	if len(self.lines) > maxLogLines {
+		self.lines = self.lines[len(self.lines)-maxLogLines:]
+	}
+
+	return len(p), nil
+}
+
+// Return the n last lines, all kept lines if n <= 0
+func (self *logBuffer) Tail(n int) []string {
+	self.Lock()
+	defer self.Unlock()
+
+	start := 0
+	if n > 0 && n < len(self.lines) {
+		start = len(self.lines) - n
+	}
+
+	lines := make([]string, len(self.lines)-start)
+	copy(lines, self.lines[start:])
+	return lines
+}
+
+// Return a channel where new lines will be sent.
+func (self *logBuffer) Follow() chan string {
+	self.Lock()
+	defer self.Unlock()
+
+	follower := make(chan string, 100)
+	self.followers[follower] = true
+	return follower
+}
+
+// Stop sending lines to a follower.
+func (self *logBuffer) Unfollow(follower chan string) {
+	self.Lock()
+	defer self.Unlock()
+
+	delete(self.followers, follower)
+}
@@ -1,18 +1,62 @@
 package main
 
 import (
+	"fmt"
 	"os"
 	"strconv"
+	"strings"
 )
 
 func main() {
 
-	var port int = 10000 // The default port.
-	if len(os.Args) > 1 {
-		port, _ = strconv.Atoi(os.Args[1])
+	// Without command the Globule is started.
+	command := "serve"
+	args := os.Args[1:]
+	if len(args) > 0 {
+		if _, err := strconv.Atoi(args[0]); err == nil {
+			// Keep the old way to start it: Globular 10000
+			args = append([]string{"-port"}, args...)
+		} else if !strings.HasPrefix(args[0], "-") {
+			command = args[0]
+			args = args[1:]
+		}
 	}
 
-	g := NewGlobule(port)
-	g.Listen()
+	var err error
+	switch command {
+	case "serve":
+		err = serve(args)
+	case "status":
+		err = printStatus(args)
+	case "services":
+		err = manageServices(args)
+	case "logs":
+		err = printLogs(args)
+	case "config":
+		err = manageConfig(args)
+	case "cluster":
+		err = printCluster(args)
+	case "apps":
+		err = manageApplications(args)
+	case "backup":
+		err = manageBackups(args)
+	case "generate":
+		err = generateService(args)
+	case "package":
+		err = createPackageFile(args)
+	case "keygen":
+		err = generateKeys(args)
+	case "version":
+		fmt.Println("Globular", version)
+	case "help":
+		usage()
+	default:
+		usage()
+		os.Exit(2)
+	}
 
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 }
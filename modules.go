@@ -0,0 +1,389 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+/**
+ * The module resolver rewrite the specifiers of the javascript imports so the
+ * browser can load them:
+ *
+ *	import { html } from "lit"        -> "../node_modules/lit/index.js"
+ *	export * from '@polymer/polymer'  -> "./node_modules/@polymer/polymer/polymer.js"
+ *	import("./views/home")            -> "./views/home.js"
+ *
+ * The bare specifiers are resolved with the import map (importmap.json at the
+ * root of the web application) then from the node_modules directories, from
+ * the directory of the file up to the root. The relative specifiers without
+ * extension receive it. The specifiers that can not be resolved are kept.
+ */
+
+var (
+	// import x from "a", import {\n x,\n y\n} from 'a', import "a"
+	staticImportRegexp = regexp.MustCompile(`(?m)(?:^|[;}])[ \t]*import\s*(?:[\w\s{},*$]+?\s*from\s*)?(?:"([^"\r\n]+)"|'([^'\r\n]+)')`)
+
+	// export * from "a", export * as x from "a", export { x as y } from 'a'
+	exportFromRegexp = regexp.MustCompile(`(?m)(?:^|[;}])[ \t]*export\s*(?:\*(?:\s*as\s+[\w$]+)?|\{[^}]*\})\s*from\s*(?:"([^"\r\n]+)"|'([^'\r\n]+)')`)
+
+	// import("a")
+	dynamicImportRegexp = regexp.MustCompile(`\bimport\s*\(\s*(?:"([^"\r\n]+)"|'([^'\r\n]+)')\s*\)`)
+)
+
+/**
+ * A json file (package.json or importmap.json) kept until it change.
+ */
+type cachedJson struct {
+	modTime time.Time
+	size    int64
+	value   map[string]interface{}
+}
+
+type moduleResolver struct {
+	mutex sync.Mutex
+	files map[string]*cachedJson
+}
+
+var modules = &moduleResolver{files: make(map[string]*cachedJson, 0)}
+
+/**
+ * Rewrite the module specifiers of a javascript file, root is the directory
+ * of the web application and name the path of the file. Return the new
+ * content, true if it change and the files the result depend on.
+ */
+func (self *moduleResolver) rewrite(root string, name string, data []byte) ([]byte, bool, []string) {
+	deps := make([]string, 0)
+
+	// The positions of the specifiers in the file.
+	type specifier struct {
+		start, end int
+	}
+	specifiers := make([]specifier, 0)
+	for _, re := range []*regexp.Regexp{staticImportRegexp, exportFromRegexp, dynamicImportRegexp} {
+		for _, match := range re.FindAllSubmatchIndex(data, -1) {
+			if match[2] != -1 {
+				specifiers = append(specifiers, specifier{match[2], match[3]})
+			} else {
+				specifiers = append(specifiers, specifier{match[4], match[5]})
+			}
+		}
+	}
+
+	sort.Slice(specifiers, func(i, j int) bool { return specifiers[i].start < specifiers[j].start })
+
+	var content []byte
+	hasChange := false
+	last := 0
+	for _, s := range specifiers {
+		if s.start < last {
+			continue // found by two expressions.
+		}
+
+		value := string(data[s.start:s.end])
+		resolved, ok := self.resolve(root, name, value, &deps)
+		if !ok || resolved == value {
+			continue
+		}
+
+		content = append(content, data[last:s.start]...)
+		content = append(content, resolved...)
+		last = s.end
+		hasChange = true
+	}
+
+	if !hasChange {
+		return data, false, deps
+	}
+
+	content = append(content, data[last:]...)
+
+	return content, true, deps
+}
+
+/**
+ * Return the specifier to use in the file name for a module specifier.
+ */
+func (self *moduleResolver) resolve(root string, name string, specifier string, deps *[]string) (string, bool) {
+	if isUrl(specifier) || strings.HasPrefix(specifier, "/") {
+		return specifier, false
+	}
+
+	var resolved string
+	if strings.HasPrefix(specifier, "./") || strings.HasPrefix(specifier, "../") {
+		resolved = resolveModuleFile(filepath.Join(filepath.Dir(name), filepath.FromSlash(specifier)))
+	} else {
+		mapped, ok := self.resolveImportMap(root, name, specifier, deps)
+		if ok {
+			if isUrl(mapped) {
+				return mapped, true
+			}
+			resolved = resolveModuleFile(mapped)
+			if len(resolved) == 0 {
+				resolved = mapped
+			}
+		} else {
+			resolved = self.resolvePackage(root, name, specifier, deps)
+		}
+	}
+
+	if len(resolved) == 0 || !isUnder(root, resolved) {
+		return specifier, false
+	}
+
+	*deps = append(*deps, resolved)
+
+	rel, err := filepath.Rel(filepath.Dir(name), resolved)
+	if err != nil {
+		return specifier, false
+	}
+
+	rel = filepath.ToSlash(rel)
+	if !strings.HasPrefix(rel, "../") {
+		rel = "./" + rel
+	}
+
+	return rel, true
+}
+
+/**
+ * Resolve a bare specifier with the import map of the web application, the
+ * scopes that match the file are use first. Return the path of the module or
+ * it url.
+ */
+func (self *moduleResolver) resolveImportMap(root string, name string, specifier string, deps *[]string) (string, bool) {
+	mapPath := filepath.Join(root, "importmap.json")
+	*deps = append(*deps, mapPath)
+
+	importMap := self.readJson(mapPath)
+	if importMap == nil {
+		return "", false
+	}
+
+	// The scopes are the url paths of the files they apply to.
+	filePath, _ := filepath.Rel(root, name)
+	filePath = "/" + filepath.ToSlash(filePath)
+
+	maps := make([]map[string]interface{}, 0)
+	if scopes, ok := importMap["scopes"].(map[string]interface{}); ok {
+		prefixes := make([]string, 0)
+		for prefix, _ := range scopes {
+			if strings.HasPrefix(filePath, path.Clean("/"+prefix)) {
+				prefixes = append(prefixes, prefix)
+			}
+		}
+
+		// The most specific scope first.
+		sort.Slice(prefixes, func(i, j int) bool { return len(prefixes[i]) > len(prefixes[j]) })
+		for _, prefix := range prefixes {
+			if imports, ok := scopes[prefix].(map[string]interface{}); ok {
+				maps = append(maps, imports)
+			}
+		}
+	}
+
+	if imports, ok := importMap["imports"].(map[string]interface{}); ok {
+		maps = append(maps, imports)
+	}
+
+	for _, imports := range maps {
+		target, ok := matchImport(imports, specifier)
+		if ok {
+			if isUrl(target) {
+				return target, true
+			}
+
+			// The paths are relative to the root of the application.
+			return filepath.Join(root, filepath.FromSlash(path.Clean("/"+target))), true
+		}
+	}
+
+	return "", false
+}
+
+/**
+ * Return the target of a specifier in the imports of an import map, the
+ * exact key or the longest key ending with / that prefix it.
+ */
+func matchImport(imports map[string]interface{}, specifier string) (string, bool) {
+	if target, ok := imports[specifier].(string); ok {
+		return target, true
+	}
+
+	prefix := ""
+	for key, _ := range imports {
+		if strings.HasSuffix(key, "/") && strings.HasPrefix(specifier, key) && len(key) > len(prefix) {
+			prefix = key
+		}
+	}
+
+	if len(prefix) > 0 {
+		if target, ok := imports[prefix].(string); ok {
+			return target + specifier[len(prefix):], true
+		}
+	}
+
+	return "", false
+}
+
+/**
+ * Resolve a bare specifier from the node_modules directories, from the one
+ * next to the file up to the one at the root.
+ */
+func (self *moduleResolver) resolvePackage(root string, name string, specifier string, deps *[]string) string {
+	// The package name is @scope/name or name, the remaining is a path in it.
+	values := strings.SplitN(specifier, "/", 3)
+	packageName := values[0]
+	subpath := strings.Join(values[1:], "/")
+	if strings.HasPrefix(specifier, "@") && len(values) > 1 {
+		packageName = values[0] + "/" + values[1]
+		subpath = strings.Join(values[2:], "/")
+	}
+
+	for dir := filepath.Dir(name); isUnder(root, dir); dir = filepath.Dir(dir) {
+		modulesDir := filepath.Join(dir, "node_modules")
+
+		// A package installed later change the directory.
+		*deps = append(*deps, modulesDir)
+
+		packageDir := filepath.Join(modulesDir, filepath.FromSlash(packageName))
+		info, err := os.Stat(packageDir)
+		if err == nil && info.IsDir() {
+			if len(subpath) > 0 {
+				return resolveModuleFile(filepath.Join(packageDir, filepath.FromSlash(subpath)))
+			}
+
+			packagePath := filepath.Join(packageDir, "package.json")
+			*deps = append(*deps, packagePath)
+			return resolveModuleFile(filepath.Join(packageDir, filepath.FromSlash(getPackageEntry(self.readJson(packagePath)))))
+		}
+
+		if dir == root || dir == filepath.Dir(dir) {
+			break
+		}
+	}
+
+	return ""
+}
+
+/**
+ * Return the entry point of a package for the browser, from it package.json
+ * exports, module, browser or main, index.js if none.
+ */
+func getPackageEntry(pkg map[string]interface{}) string {
+	if pkg == nil {
+		return "index.js"
+	}
+
+	exports := pkg["exports"]
+	if values, ok := exports.(map[string]interface{}); ok && values["."] != nil {
+		exports = values["."]
+	}
+
+	if entry := getConditionalExport(exports); len(entry) > 0 {
+		return entry
+	}
+
+	for _, key := range []string{"module", "browser", "main"} {
+		if entry, ok := pkg[key].(string); ok && len(entry) > 0 {
+			return entry
+		}
+	}
+
+	return "index.js"
+}
+
+/**
+ * Return the path of a package export, a string or the first of the import,
+ * browser and default conditions.
+ */
+func getConditionalExport(export interface{}) string {
+	switch value := export.(type) {
+	case string:
+		return value
+	case map[string]interface{}:
+		for _, condition := range []string{"import", "browser", "default"} {
+			if entry := getConditionalExport(value[condition]); len(entry) > 0 {
+				return entry
+			}
+		}
+	}
+
+	return ""
+}
+
+/**
+ * Return the file of a module path, the path itself, with the .js or .mjs
+ * extension or it index.js. Return an empty string if there is none.
+ */
+func resolveModuleFile(path string) string {
+	for _, path_ := range []string{path, path + ".js", path + ".mjs", filepath.Join(path, "index.js")} {
+		info, err := os.Stat(path_)
+		if err == nil && !info.IsDir() {
+			return path_
+		}
+	}
+
+	return ""
+}
+
+/**
+ * Read a json file, the content is kept until the file change. Return nil
+ * if the file does not exist or is not valid.
+ */
+func (self *moduleResolver) readJson(path string) map[string]interface{} {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil
+	}
+
+	self.mutex.Lock()
+	file := self.files[path]
+	self.mutex.Unlock()
+
+	if file != nil && file.modTime.Equal(info.ModTime()) && file.size == info.Size() {
+		return file.value
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	file = &cachedJson{modTime: info.ModTime(), size: info.Size()}
+	json.Unmarshal(data, &file.value)
+
+	self.mutex.Lock()
+	self.files[path] = file
+	self.mutex.Unlock()
+
+	return file.value
+}
+
+/**
+ * Return true if a specifier is an url the browser load as is.
+ */
+func isUrl(specifier string) bool {
+	for _, prefix := range []string{"http://", "https://", "//", "data:", "blob:"} {
+		if strings.HasPrefix(specifier, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+/**
+ * Return true if path is dir or a path in it.
+ */
+func isUnder(dir string, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
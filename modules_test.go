@@ -0,0 +1,166 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+/**
+ * Write the files of a web application in a new directory, the keys are the
+ * slash separated paths.
+ */
+func writeFiles(t *testing.T, files map[string]string) string {
+	root, err := ioutil.TempDir("", "modules_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		if err == nil {
+			err = ioutil.WriteFile(path, []byte(content), 0644)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return root
+}
+
+// The specifiers of the imports and exports are rewritten, the other strings
+// are kept.
+func TestRewriteModules(t *testing.T) {
+	root := writeFiles(t, map[string]string{
+		"node_modules/lit/package.json":              `{"main": "index.cjs", "exports": {".": {"require": "./index.cjs", "import": "./index.js"}}}`,
+		"node_modules/lit/index.js":                  "",
+		"node_modules/lit/index.cjs":                 "",
+		"node_modules/lit/directives/class-map.js":   "",
+		"node_modules/@polymer/polymer/package.json": `{"main": "main.cjs", "module": "polymer.js"}`,
+		"node_modules/@polymer/polymer/polymer.js":   "",
+		"node_modules/@polymer/polymer/lib/utils.js": "",
+		"app/util.js":             "",
+		"app/views/home.js":       "",
+		"app/components/index.js": "",
+		"importmap.json":          `{"imports": {"lib": "/vendor/lib.js", "pkg/": "/vendor/pkg/", "cdn": "https://cdn.example.com/cdn.js"}, "scopes": {"/legacy/": {"lib": "/vendor/lib-old.js"}}}`,
+		"vendor/lib.js":           "",
+		"vendor/lib-old.js":       "",
+		"vendor/pkg/a.js":         "",
+	})
+	defer os.RemoveAll(root)
+
+	tests := []struct {
+		name   string // The file of the code.
+		code   string
+		result string
+	}{
+		{"app/main.js", `import { html } from "lit";`, `import { html } from "../node_modules/lit/index.js";`},
+		{"app/main.js", "import {\n  html,\n  css\n} from 'lit'\n", "import {\n  html,\n  css\n} from '../node_modules/lit/index.js'\n"},
+		{"app/main.js", "import * as lit from \"lit\"\r\nimport x from './util'\r\n", "import * as lit from \"../node_modules/lit/index.js\"\r\nimport x from './util.js'\r\n"},
+		{"app/main.js", `export * from "./util"`, `export * from "./util.js"`},
+		{"app/main.js", `export * as util from './util'`, `export * as util from './util.js'`},
+		{"app/main.js", "export {\n  classMap as cm\n} from \"lit/directives/class-map.js\"", "export {\n  classMap as cm\n} from \"../node_modules/lit/directives/class-map.js\""},
+		{"app/main.js", `const page = await import("./views/home")`, `const page = await import("./views/home.js")`},
+		{"app/main.js", `import('lit').then(m => m)`, `import('../node_modules/lit/index.js').then(m => m)`},
+		{"app/main.js", `import "@polymer/polymer"`, `import "../node_modules/@polymer/polymer/polymer.js"`},
+		{"app/main.js", `import "@polymer/polymer/lib/utils"`, `import "../node_modules/@polymer/polymer/lib/utils.js"`},
+		{"app/main.js", `import "./components"`, `import "./components/index.js"`},
+		{"app/main.js", `import a from "lit";import b from "./util"`, `import a from "../node_modules/lit/index.js";import b from "./util.js"`},
+		{"app/main.js", `}import"lit"`, `}import"../node_modules/lit/index.js"`},
+		{"app/main.js", `import x from "https://cdn.example.com/x.js"`, `import x from "https://cdn.example.com/x.js"`},
+		{"app/main.js", `import x from "/js/x.js"`, `import x from "/js/x.js"`},
+		{"app/main.js", `import "missing"`, `import "missing"`},
+		{"app/main.js", `const s = "import x from 'lit'"`, `const s = "import x from 'lit'"`},
+		{"app/main.js", `import { html } from "lit`, `import { html } from "lit`},
+		{"app/main.js", `import(`, `import(`},
+		{"app/main.js", `import "lit" from "lit"`, `import "../node_modules/lit/index.js" from "lit"`},
+		{"app/main.js", `import "lib"`, `import "../vendor/lib.js"`},
+		{"app/main.js", `import "pkg/a"`, `import "../vendor/pkg/a.js"`},
+		{"app/main.js", `import "cdn"`, `import "https://cdn.example.com/cdn.js"`},
+		{"legacy/old.js", `import "lib"`, `import "../vendor/lib-old.js"`},
+		{"legacy/old.js", `import "pkg/a"`, `import "../vendor/pkg/a.js"`},
+	}
+
+	resolver := &moduleResolver{files: make(map[string]*cachedJson, 0)}
+	for _, test := range tests {
+		name := filepath.Join(root, filepath.FromSlash(test.name))
+		result, changed, _ := resolver.rewrite(root, name, []byte(test.code))
+		if string(result) != test.result || changed != (test.result != test.code) {
+			t.Errorf("%s: %q is rewritten %q, %v, %q is expected", test.name, test.code, result, changed, test.result)
+		}
+	}
+}
+
+// A package.json that change give the new entry of the package.
+func TestRewriteModulesPackageChange(t *testing.T) {
+	root := writeFiles(t, map[string]string{
+		"node_modules/dep/package.json": `{"main": "a.js"}`,
+		"node_modules/dep/a.js":         "",
+		"node_modules/dep/b.js":         "",
+	})
+	defer os.RemoveAll(root)
+
+	resolver := &moduleResolver{files: make(map[string]*cachedJson, 0)}
+	name := filepath.Join(root, "main.js")
+	packagePath := filepath.Join(root, "node_modules", "dep", "package.json")
+
+	result, _, deps := resolver.rewrite(root, name, []byte(`import "dep"`))
+	if string(result) != `import "./node_modules/dep/a.js"` {
+		t.Fatalf("unexpected result %s", result)
+	}
+
+	if !contains(deps, packagePath) || !contains(deps, filepath.Join(root, "node_modules")) {
+		t.Fatalf("the package files are not in the dependencies %v", deps)
+	}
+
+	err := ioutil.WriteFile(packagePath, []byte(`{"module": "b.js", "main": "a.js"}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Second)
+	os.Chtimes(packagePath, later, later)
+
+	result, _, _ = resolver.rewrite(root, name, []byte(`import "dep"`))
+	if string(result) != `import "./node_modules/dep/b.js"` {
+		t.Fatalf("the package change is not seen %s", result)
+	}
+}
+
+// The entry of a package is taken from exports, module, browser then main.
+func TestGetPackageEntry(t *testing.T) {
+	tests := []struct {
+		pkg   string
+		entry string
+	}{
+		{`null`, "index.js"},
+		{`{}`, "index.js"},
+		{`{"exports": "./e.js", "module": "m.js"}`, "./e.js"},
+		{`{"exports": {".": {"require": "./r.cjs", "import": "./i.js"}}}`, "./i.js"},
+		{`{"exports": {".": {"require": "./r.cjs", "default": "./d.js"}}}`, "./d.js"},
+		{`{"exports": {".": {"browser": {"import": "./bi.js"}}}}`, "./bi.js"},
+		{`{"exports": {"import": "./i.js", "require": "./r.cjs"}}`, "./i.js"},
+		{`{"exports": {".": {"require": "./r.cjs"}}, "module": "m.js"}`, "m.js"},
+		{`{"module": "m.js", "browser": "b.js", "main": "main.js"}`, "m.js"},
+		{`{"browser": "b.js", "main": "main.js"}`, "b.js"},
+		{`{"browser": {"./x.js": false}, "main": "main.js"}`, "main.js"},
+		{`{"main": "main.js"}`, "main.js"},
+	}
+
+	for _, test := range tests {
+		var pkg map[string]interface{}
+		err := json.Unmarshal([]byte(test.pkg), &pkg)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		entry := getPackageEntry(pkg)
+		if entry != test.entry {
+			t.Errorf("the entry of %s is %s, %s is expected", test.pkg, entry, test.entry)
+		}
+	}
+}
@@ -0,0 +1,596 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/davecourtois/Globular/config"
+	"github.com/davecourtois/Utility"
+)
+
+// The time given to a newly installed service to become healthy.
+const installHealthTimeout = 30 * time.Second
+
+// The time given to the connections of the previous version of a service to
+// finish before it is stopped.
+const upgradeDrainTimeout = 30 * time.Second
+
+// The file of the trusted public keys in the Globular directory.
+const publicKeysName = "public_keys"
+
+func init() {
+	// Version 2 read the trusted keys from public_keys only.
+	config.RegisterMigration(2, func(values map[string]interface{}) error {
+		if keys, ok := values["PublicKeys"].([]interface{}); ok && len(keys) > 0 {
+			log.Println("The PublicKeys of the configuration are no more trusted, add them to ", publicKeysName)
+		}
+		delete(values, "PublicKeys")
+		return nil
+	})
+}
+
+/**
+ * The description of a service package, it's the file manifest.json at the
+ * root of the archive. The archive is a tar.gz file.
+ */
+type packageManifest struct {
+	Name        string            // The service name ex: echo_server
+	Version     string            // The package version ex: 1.0.0
+	Executables map[string]string // The executable path by platform ex: linux_amd64 -> bin/linux_amd64/echo_server
+	Proto       string            // The path of the proto file.
+	Client      []string          // The paths of the JavaScript client files.
+	Config      string            // The path of the default config.json
+	Replicas    int               // The number of processes to run, 1 by default.
+}
+
+/**
+ * The checksum and signature of a package, the publisher give it in a file
+ * next to the archive (echo_server.1.0.0.tar.gz.sig)
+ */
+type packageSignature struct {
+	Checksum  string // hex encoded sha256 of the archive.
+	Signature string // base64 encoded ed25519 signature of the checksum.
+}
+
+/**
+ * Sign the package data with a base64 encoded ed25519 private key.
+ */
+func signPackage(data []byte, privateKey string) (*packageSignature, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(privateKey))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(key) != ed25519.PrivateKeySize {
+		return nil, errors.New("invalid private key size")
+	}
+
+	checksum := sha256.Sum256(data)
+	signature := ed25519.Sign(ed25519.PrivateKey(key), checksum[:])
+
+	return &packageSignature{
+		Checksum:  hex.EncodeToString(checksum[:]),
+		Signature: base64.StdEncoding.EncodeToString(signature),
+	}, nil
+}
+
+/**
+ * Return the trusted public keys, one base64 encoded ed25519 key by line of
+ * the file public_keys in the Globular directory. The file is not in the
+ * configuration nor in WebRoot, only the administrator write it, so it is
+ * refused if the other users can write it.
+ */
+func (self *Globule) getPublicKeys() ([]string, error) {
+	path := filepath.Join(self.path, publicKeysName)
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return []string{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0022 != 0 {
+		return nil, errors.New("the file " + path + " can be written by other users, remove their write permission")
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0)
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) > 0 && !strings.HasPrefix(line, "#") {
+			keys = append(keys, line)
+		}
+	}
+
+	return keys, nil
+}
+
+/**
+ * Verify the checksum of a package and that it was signed with one of the
+ * trusted public keys of the Globule.
+ */
+func (self *Globule) verifyPackage(data []byte, signature *packageSignature) error {
+	checksum := sha256.Sum256(data)
+	if hex.EncodeToString(checksum[:]) != strings.ToLower(signature.Checksum) {
+		return errors.New("the package checksum does not match")
+	}
+
+	publicKeys, err := self.getPublicKeys()
+	if err != nil {
+		return err
+	}
+
+	if len(publicKeys) == 0 {
+		return errors.New("no trusted public key is configured, add one to " + filepath.Join(self.path, publicKeysName))
+	}
+
+	signature_, err := base64.StdEncoding.DecodeString(signature.Signature)
+	if err != nil {
+		return errors.New("the package signature is not valid base64")
+	}
+
+	for _, publicKey := range publicKeys {
+		key, err := base64.StdEncoding.DecodeString(publicKey)
+		if err == nil && len(key) == ed25519.PublicKeySize {
+			if ed25519.Verify(ed25519.PublicKey(key), checksum[:], signature_) {
+				return nil
+			}
+		}
+	}
+
+	return errors.New("the package is not signed by a trusted key")
+}
+
+/**
+ * Extract a tar.gz archive into a directory. Entries that are not regular
+ * files or directories, or that are outside the directory, are refused.
+ */
+func extractPackage(data []byte, dir string) error {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		path := filepath.Join(dir, filepath.FromSlash(header.Name))
+		if !strings.HasPrefix(path, filepath.Clean(dir)+string(os.PathSeparator)) {
+			return errors.New("the package entry " + header.Name + " is outside the package")
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(path, 0755)
+		case tar.TypeReg:
+			err = os.MkdirAll(filepath.Dir(path), 0755)
+			if err == nil {
+				var f *os.File
+				f, err = os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(header.Mode)&0755|0600)
+				if err == nil {
+					_, err = io.Copy(f, tr)
+					f.Close()
+				}
+			}
+		default:
+			err = errors.New("the package entry " + header.Name + " is not a regular file")
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/**
+ * Create a package archive from a directory that contain a manifest.json
+ */
+func createPackage(dir string) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || path == dir {
+			return err
+		}
+
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(name)
+
+		err = tw.WriteHeader(header)
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = io.Copy(tw, f)
+		return err
+	})
+
+	if err == nil {
+		err = tw.Close()
+	}
+
+	if err == nil {
+		err = gz.Close()
+	}
+
+	return buf.Bytes(), err
+}
+
+/**
+ * Read the manifest of a package.
+ */
+func readManifest(path string) (*packageManifest, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := new(packageManifest)
+	err = json.Unmarshal(data, manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	if !regexp.MustCompile(`^[a-z][a-z0-9]*_server$`).MatchString(manifest.Name) {
+		return nil, errors.New("the package name " + manifest.Name + " is not a valid service name")
+	}
+
+	return manifest, nil
+}
+
+/**
+ * Copy a file of the extracted package into the service directory.
+ */
+func copyPackageFile(source string, dest string, mode os.FileMode) error {
+	data, err := ioutil.ReadFile(source)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(dest), 0755)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(dest, data, mode)
+}
+
+/**
+ * Return true if a port is already use by another service.
+ */
+func (self *Globule) isPortUsed(name string, port int) bool {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if port == self.Port || port == self.AdminPort {
+		return true
+	}
+
+	for name_, s := range self.services {
+		if name_ != name {
+			if Utility.ToInt(s.(map[string]interface{})["Port"]) == port || Utility.ToInt(s.(map[string]interface{})["Proxy"]) == port {
+				return true
+			}
+		}
+	}
+
+	// The ports listen for the services of the other nodes.
+	for name_, remote := range self.remoteServices {
+		if name_ != name && (remote.port == port || remote.proxy == port) {
+			return true
+		}
+	}
+
+	return false
+}
+
+/**
+ * Install or upgrade a service from a package. The files are placed in a
+ * directory named as the service (echo_server -> echo) in the Globular
+ * directory, the service is registered and started. If the service is not
+ * healthy the previous version is restored. Return the service name and the
+ * previous version.
+ */
+func (self *Globule) installService(data []byte, signature *packageSignature) (string, string, error) {
+	err := self.verifyPackage(data, signature)
+	if err != nil {
+		return "", "", err
+	}
+
+	tmp, err := ioutil.TempDir(self.path, ".install_")
+	if err != nil {
+		return "", "", err
+	}
+	defer os.RemoveAll(tmp)
+
+	packageDir := filepath.Join(tmp, "package")
+	err = extractPackage(data, packageDir)
+	if err != nil {
+		return "", "", err
+	}
+
+	manifest, err := readManifest(filepath.Join(packageDir, "manifest.json"))
+	if err != nil {
+		return "", "", err
+	}
+
+	name := manifest.Name
+	platform := runtime.GOOS + "_" + runtime.GOARCH
+	if len(manifest.Executables[platform]) == 0 {
+		return "", "", errors.New("the package " + name + " has no executable for " + platform)
+	}
+
+	// Prepare the service directory.
+	serviceDir := filepath.Join(tmp, "service")
+	executable := name
+	if runtime.GOOS == "windows" {
+		executable += ".exe" // in case of windows.
+	}
+
+	files := map[string]string{
+		manifest.Executables[platform]: executable,
+		"manifest.json":                "manifest.json",
+	}
+
+	if len(manifest.Config) > 0 {
+		files[manifest.Config] = "config.json"
+	}
+
+	if len(manifest.Proto) > 0 {
+		files[manifest.Proto] = filepath.Join("proto", filepath.Base(manifest.Proto))
+	}
+
+	for _, path := range manifest.Client {
+		files[path] = filepath.Join("js", filepath.Base(path))
+	}
+
+	for source, dest := range files {
+		mode := os.FileMode(0644)
+		if dest == executable {
+			mode = 0755
+		}
+
+		err := copyPackageFile(filepath.Join(packageDir, filepath.FromSlash(source)), filepath.Join(serviceDir, dest), mode)
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	dir := filepath.Join(self.path, strings.TrimSuffix(name, "_server"))
+
+	self.mutex.Lock()
+	previous, _ := self.services[name].(map[string]interface{})
+	self.mutex.Unlock()
+
+	// The configuration of the installed version is keep.
+	previousVersion := ""
+	if previous != nil {
+		// The installed version is replaced in it own directory, the
+		// services that are not installed from a package are part of the
+		// sources and are not replaced.
+		dir = filepath.Dir(previous["Path"].(string))
+		if !Utility.Exists(filepath.Join(dir, "manifest.json")) {
+			return "", "", errors.New("the service " + name + " was not installed from a package")
+		}
+
+		previousVersion = Utility.ToString(previous["Version"])
+		err = copyPackageFile(filepath.Join(dir, "config.json"), filepath.Join(serviceDir, "config.json"), 0644)
+		if err != nil {
+			return "", "", err
+		}
+	} else if Utility.Exists(dir) {
+		return "", "", errors.New("the directory " + dir + " already exist")
+	}
+
+	// Set the service configuration.
+	s := make(map[string]interface{})
+	config, err := ioutil.ReadFile(filepath.Join(serviceDir, "config.json"))
+	if err == nil {
+		json.Unmarshal(config, &s)
+	}
+
+	s["Name"] = name
+	if !isHttpService(s) {
+		s["Protocol"] = "grpc"
+	}
+	if manifest.Replicas > 0 {
+		s["Replicas"] = manifest.Replicas
+	}
+	if previous == nil && !isHttpService(s) {
+		// Take free ports if the default one are use.
+		if Utility.ToInt(s["Port"]) == 0 || self.isPortUsed(name, Utility.ToInt(s["Port"])) || self.isPortUsed(name, Utility.ToInt(s["Proxy"])) {
+			port := getMaxServicePort(self.path) + 1
+			for self.isPortUsed(name, port) || self.isPortUsed(name, port+1) {
+				port++
+			}
+			s["Port"] = port
+			s["Proxy"] = port + 1
+		}
+	}
+
+	str, err := Utility.ToJson(s)
+	if err != nil {
+		return "", "", err
+	}
+
+	err = ioutil.WriteFile(filepath.Join(serviceDir, "config.json"), []byte(str), 0644)
+	if err != nil {
+		return "", "", err
+	}
+
+	// Replace the installed version, the previous process continue to serve
+	// until the new one is ready.
+	backup := filepath.Join(filepath.Dir(dir), "."+filepath.Base(dir)+".backup")
+	if previous != nil {
+		os.RemoveAll(backup)
+		err = os.Rename(dir, backup)
+		if err != nil {
+			// The executable of a running process can't be move on some
+			// systems, so the service is stop first.
+			self.stopService(name)
+			err = os.Rename(dir, backup)
+			if err != nil {
+				self.startService(previous)
+				return "", "", err
+			}
+		}
+	}
+
+	err = os.Rename(serviceDir, dir)
+	if err == nil {
+		if previous != nil {
+			self.mutex.Lock()
+			replicas := previous["Replicas"]
+			previous["Replicas"] = s["Replicas"]
+			self.mutex.Unlock()
+
+			err = self.upgradeService(name, filepath.Join(dir, executable), upgradeDrainTimeout)
+			self.mutex.Lock()
+			if err == nil {
+				previous["Version"] = manifest.Version
+				s = previous
+			} else {
+				previous["Replicas"] = replicas
+			}
+			self.mutex.Unlock()
+		} else {
+			s["Path"] = filepath.Join(dir, executable)
+			s["Version"] = manifest.Version
+
+			// The service was maybe use from another node.
+			self.closeRemoteService(name)
+
+			self.mutex.Lock()
+			self.services[name] = s
+			self.mutex.Unlock()
+
+			err = self.startService(s)
+			if err == nil {
+				err = self.waitHealthy(name, installHealthTimeout)
+			}
+		}
+	}
+
+	if err != nil {
+		// Roll back to the previous version.
+		os.RemoveAll(dir)
+		if previous != nil {
+			os.Rename(backup, dir)
+			if !self.isRunning(name) {
+				self.startService(previous)
+			}
+		} else {
+			self.stopService(name)
+			self.mutex.Lock()
+			delete(self.services, name)
+			self.mutex.Unlock()
+		}
+
+		return "", "", errors.New("the service " + name + " " + manifest.Version + " was not installed: " + err.Error())
+	}
+
+	os.RemoveAll(backup)
+
+	// Give access to the JavaScript client from the web server.
+	jsDir := filepath.Join(self.webRoot, "js", strings.TrimSuffix(name, "_server"))
+	os.RemoveAll(jsDir)
+	for _, path := range manifest.Client {
+		copyPackageFile(filepath.Join(dir, "js", filepath.Base(path)), filepath.Join(jsDir, filepath.Base(path)), 0644)
+	}
+
+	self.mutex.Lock()
+	self.Services[name] = getPublicInfo(s)
+	self.mutex.Unlock()
+	self.saveConfig()
+
+	// Connect the web-api to the service if the client is known.
+	self.initClient(strings.TrimSuffix(name, "_server"))
+
+	return name, previousVersion, nil
+}
+
+/**
+ * Stop a service installed from a package and remove it files.
+ */
+func (self *Globule) uninstallService(name string) error {
+	self.mutex.Lock()
+	s, _ := self.services[name].(map[string]interface{})
+	self.mutex.Unlock()
+
+	if s == nil {
+		return errors.New("no service found with name " + name)
+	}
+
+	// Services that are not installed from a package are part of the sources.
+	dir := filepath.Dir(s["Path"].(string))
+	if !Utility.Exists(filepath.Join(dir, "manifest.json")) {
+		return errors.New("the service " + name + " was not installed from a package")
+	}
+
+	err := self.stopService(name)
+	if err != nil {
+		return err
+	}
+
+	self.mutex.Lock()
+	delete(self.services, name)
+	delete(self.Services, name)
+	client := self.clients[strings.TrimSuffix(name, "_server")+"_service"]
+	delete(self.clients, strings.TrimSuffix(name, "_server")+"_service")
+	self.mutex.Unlock()
+
+	if client != nil {
+		client.Close()
+	}
+
+	self.saveConfig()
+
+	os.RemoveAll(filepath.Join(self.webRoot, "js", strings.TrimSuffix(name, "_server")))
+	return os.RemoveAll(dir)
+}
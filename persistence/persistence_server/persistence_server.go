@@ -14,6 +14,8 @@ import (
 	"path/filepath"
 	"strconv"
 
+	"github.com/davecourtois/Globular/config"
+	"github.com/davecourtois/Globular/event/event_client"
 	"github.com/davecourtois/Globular/persistence/persistence_store"
 	"github.com/davecourtois/Globular/persistence/persistencepb"
 	"github.com/davecourtois/Utility"
@@ -32,6 +34,9 @@ var (
 
 	// comma separeated values.
 	allowed_origins string = ""
+
+	// The configuration schema version, see package config.
+	configVersion = 1
 )
 
 // This is the connction to a datastore.
@@ -57,6 +62,11 @@ type server struct {
 	AllowAllOrigins bool
 	AllowedOrigins  string // comma separated string.
 
+	// The number of processes run by the Globule and the version of the
+	// configuration schema.
+	Replicas      int
+	ConfigVersion int
+
 	Connections map[string]connection
 
 	// The map of store (also connections...)
@@ -67,11 +77,12 @@ type server struct {
 func (self *server) init() {
 	// Here I will retreive the list of connections from file if there are some...
 	dir, _ := filepath.Abs(filepath.Dir(os.Args[0]))
-	file, err := ioutil.ReadFile(dir + "/config.json")
-	if err == nil {
-		json.Unmarshal([]byte(file), self)
-	} else {
+	self.ConfigVersion = configVersion
+	err := config.Load(dir+"/config.json", self, configVersion)
+	if os.IsNotExist(err) {
 		self.save()
+	} else if err != nil {
+		log.Fatalln(err)
 	}
 	self.Connections = make(map[string]connection)
 	self.stores = make(map[string]persistence_store.Store)
@@ -173,6 +184,8 @@ func (self *server) CreateDatabase(ctx context.Context, rqst *persistencepb.Crea
 			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
 	}
 
+	event_client.Publish(event_client.DatabaseCreated, map[string]interface{}{"connection": rqst.Id, "database": rqst.Database})
+
 	return &persistencepb.CreateDatabaseRsp{
 		Result: true,
 	}, nil
@@ -195,6 +208,8 @@ func (self *server) DeleteDatabase(ctx context.Context, rqst *persistencepb.Dele
 			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
 	}
 
+	event_client.Publish(event_client.DatabaseDeleted, map[string]interface{}{"connection": rqst.Id, "database": rqst.Database})
+
 	return &persistencepb.DeleteDatabaseRsp{
 		Result: true,
 	}, nil
@@ -217,6 +232,8 @@ func (self *server) CreateCollection(ctx context.Context, rqst *persistencepb.Cr
 			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
 	}
 
+	event_client.Publish(event_client.CollectionCreated, map[string]interface{}{"connection": rqst.Id, "database": rqst.Database, "collection": rqst.Collection})
+
 	return &persistencepb.CreateCollectionRsp{
 		Result: true,
 	}, nil
@@ -239,6 +256,8 @@ func (self *server) DeleteCollection(ctx context.Context, rqst *persistencepb.De
 			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
 	}
 
+	event_client.Publish(event_client.CollectionDeleted, map[string]interface{}{"connection": rqst.Id, "database": rqst.Database, "collection": rqst.Collection})
+
 	return &persistencepb.DeleteCollectionRsp{
 		Result: true,
 	}, nil
@@ -329,6 +348,8 @@ func (self *server) InsertOne(ctx context.Context, rqst *persistencepb.InsertOne
 			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
 	}
 
+	event_client.Publish(event_client.EntityInserted, map[string]interface{}{"connection": rqst.Id, "database": rqst.Database, "collection": rqst.Collection, "id": id})
+
 	return &persistencepb.InsertOneRsp{
 		Id: string(jsonStr),
 	}, nil
@@ -382,6 +403,8 @@ func (self *server) InsertMany(stream persistencepb.PersistenceService_InsertMan
 		// append to the list of ids.
 		ids = append(ids, results...)
 
+		event_client.Publish(event_client.EntityInserted, map[string]interface{}{"connection": rqst.Id, "database": rqst.Database, "collection": rqst.Collection, "count": len(results)})
+
 	}
 }
 
@@ -483,6 +506,8 @@ func (self *server) Update(ctx context.Context, rqst *persistencepb.UpdateRqst)
 		return nil, err
 	}
 
+	event_client.Publish(event_client.EntityUpdated, map[string]interface{}{"connection": rqst.Id, "database": rqst.Database, "collection": rqst.Collection, "query": rqst.Query})
+
 	return &persistencepb.UpdateRsp{
 		Result: true,
 	}, nil
@@ -503,6 +528,8 @@ func (self *server) UpdateOne(ctx context.Context, rqst *persistencepb.UpdateOne
 		return nil, err
 	}
 
+	event_client.Publish(event_client.EntityUpdated, map[string]interface{}{"connection": rqst.Id, "database": rqst.Database, "collection": rqst.Collection, "query": rqst.Query})
+
 	return &persistencepb.UpdateOneRsp{
 		Result: true,
 	}, nil
@@ -523,6 +550,8 @@ func (self *server) ReplaceOne(ctx context.Context, rqst *persistencepb.ReplaceO
 		return nil, err
 	}
 
+	event_client.Publish(event_client.EntityReplaced, map[string]interface{}{"connection": rqst.Id, "database": rqst.Database, "collection": rqst.Collection, "query": rqst.Query})
+
 	return &persistencepb.ReplaceOneRsp{
 		Result: true,
 	}, nil
@@ -543,6 +572,8 @@ func (self *server) Delete(ctx context.Context, rqst *persistencepb.DeleteRqst)
 		return nil, err
 	}
 
+	event_client.Publish(event_client.EntityDeleted, map[string]interface{}{"connection": rqst.Id, "database": rqst.Database, "collection": rqst.Collection, "query": rqst.Query})
+
 	return &persistencepb.DeleteRsp{
 		Result: true,
 	}, nil
@@ -563,6 +594,8 @@ func (self *server) DeleteOne(ctx context.Context, rqst *persistencepb.DeleteOne
 		return nil, err
 	}
 
+	event_client.Publish(event_client.EntityDeleted, map[string]interface{}{"connection": rqst.Id, "database": rqst.Database, "collection": rqst.Collection, "query": rqst.Query})
+
 	return &persistencepb.DeleteOneRsp{
 		Result: true,
 	}, nil
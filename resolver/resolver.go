@@ -0,0 +1,127 @@
+/**
+ * The globular resolver give the addresses of a service from the Globule
+ * registry, so a client address a service by it name:
+ *
+ *	conn, err := resolver.Dial("sql_server", grpc.WithInsecure())
+ *
+ * or with grpc.Dial("globular:///sql_server", ...) once the package is
+ * imported. The addresses are pushed by the Globule when the service is
+ * started, stopped, upgraded or scaled, and the calls are spread round robin
+ * over them.
+ */
+package resolver
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/davecourtois/Globular/admin/adminpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/balancer/roundrobin"
+	grpc_resolver "google.golang.org/grpc/resolver"
+)
+
+// The scheme of the services targets, ex: globular:///sql_server
+const Scheme = "globular"
+
+func init() {
+	grpc_resolver.Register(new(builder))
+}
+
+/**
+ * Connect to a service by it name.
+ */
+func Dial(name string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	return grpc.Dial(Scheme+":///"+name, append(opts, grpc.WithBalancerName(roundrobin.Name))...)
+}
+
+/**
+ * Return the address of the admin service of the Globule, the Globule set
+ * GLOBULAR_ADMIN_PORT for the services it start.
+ */
+func getAdminAddress() string {
+	address := os.Getenv("GLOBULAR_ADDRESS")
+	if len(address) == 0 {
+		address = "localhost"
+	}
+
+	port := os.Getenv("GLOBULAR_ADMIN_PORT")
+	if len(port) == 0 {
+		port = "10015"
+	}
+
+	return address + ":" + port
+}
+
+type builder struct{}
+
+func (self *builder) Scheme() string {
+	return Scheme
+}
+
+func (self *builder) Build(target grpc_resolver.Target, cc grpc_resolver.ClientConn, opts grpc_resolver.BuildOption) (grpc_resolver.Resolver, error) {
+	conn, err := grpc.Dial(getAdminAddress(), grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+
+	r := new(globularResolver)
+	r.name = target.Endpoint
+	r.cc = cc
+	r.conn = conn
+	r.ctx, r.cancel = context.WithCancel(context.Background())
+
+	go r.watch()
+
+	return r, nil
+}
+
+/**
+ * Keep the addresses of a client connection up to date.
+ */
+type globularResolver struct {
+	name   string
+	cc     grpc_resolver.ClientConn
+	conn   *grpc.ClientConn
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+/**
+ * Receive the addresses from the Globule, the stream is open again if the
+ * Globule restart.
+ */
+func (self *globularResolver) watch() {
+	client := adminpb.NewAdminServiceClient(self.conn)
+	for {
+		stream, err := client.WatchService(self.ctx, &adminpb.WatchServiceRequest{Name: self.name})
+		for err == nil {
+			var rsp *adminpb.WatchServiceResponse
+			rsp, err = stream.Recv()
+			if err == nil {
+				addresses := make([]grpc_resolver.Address, len(rsp.Addresses))
+				for i, address := range rsp.Addresses {
+					addresses[i] = grpc_resolver.Address{Addr: address}
+				}
+				self.cc.UpdateState(grpc_resolver.State{Addresses: addresses})
+			}
+		}
+
+		select {
+		case <-self.ctx.Done():
+			return
+		case <-time.After(time.Second):
+			log.Println("fail to watch ", self.name, " addresses: ", err)
+		}
+	}
+}
+
+// The addresses are pushed by the Globule.
+func (self *globularResolver) ResolveNow(opts grpc_resolver.ResolveNowOption) {}
+
+func (self *globularResolver) Close() {
+	self.cancel()
+	self.conn.Close()
+}
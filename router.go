@@ -0,0 +1,331 @@
+package main
+
+import (
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// A connection without traffic for that delay is consider idle when a
+// backend is drained.
+const drainIdleDelay = 2 * time.Second
+
+/**
+ * The router accept the connections at the port of a service and forward
+ * them to the processes that serve it. A new connection go to the process
+ * with the less connections, so the clients are spread across the replicas
+ * of the service. The processes can be replace without closing the port, the
+ * grpcwebproxy and the clients stay connected to the router and the new
+ * connections go to the new processes.
+ */
+type serviceRouter struct {
+	mutex    sync.Mutex
+	listener net.Listener
+
+	// Where the new connections are forwarded.
+	backends []*routerBackend
+
+	// Use to choose between backends with the same number of connections.
+	next int
+}
+
+/**
+ * A process address and the connections forwarded to it.
+ */
+type routerBackend struct {
+	mutex       sync.Mutex
+	address     string
+	connections map[*routedConn]bool
+}
+
+/**
+ * A connection between a client and a backend.
+ */
+type routedConn struct {
+	mutex        sync.Mutex
+	client       net.Conn
+	server       net.Conn
+	lastActivity time.Time
+}
+
+/**
+ * Keep the time of the last read or write of the connection.
+ */
+type activityWriter struct {
+	conn *routedConn
+	w    io.Writer
+}
+
+func (self *activityWriter) Write(p []byte) (int, error) {
+	self.conn.mutex.Lock()
+	self.conn.lastActivity = time.Now()
+	self.conn.mutex.Unlock()
+	return self.w.Write(p)
+}
+
+/**
+ * Start listening at the port of a service.
+ */
+func newServiceRouter(port int) (*serviceRouter, error) {
+	listener, err := net.Listen("tcp", ":"+strconv.Itoa(port))
+	if err != nil {
+		return nil, err
+	}
+
+	router := new(serviceRouter)
+	router.listener = listener
+	router.backends = make([]*routerBackend, 0)
+	go router.serve()
+
+	return router, nil
+}
+
+func newRouterBackend(address string) *routerBackend {
+	backend := new(routerBackend)
+	backend.address = address
+	backend.connections = make(map[*routedConn]bool, 0)
+	return backend
+}
+
+func (self *serviceRouter) serve() {
+	for {
+		conn, err := self.listener.Accept()
+		if err != nil {
+			return // The router is closed.
+		}
+
+		go self.forward(conn)
+	}
+}
+
+/**
+ * Return the backends ordered by number of connections, the first one is the
+ * less loaded.
+ */
+func (self *serviceRouter) getBackends() []*routerBackend {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	count := len(self.backends)
+	backends := make([]*routerBackend, 0, count)
+	loads := make([]int, 0, count)
+	self.next++
+	for i := 0; i < count; i++ {
+		backend := self.backends[(self.next+i)%count]
+		load := backend.count()
+
+		// Insertion sort, the backends with the same load keep the round
+		// robin order.
+		j := len(backends)
+		for j > 0 && loads[j-1] > load {
+			j--
+		}
+		backends = append(backends, nil)
+		loads = append(loads, 0)
+		copy(backends[j+1:], backends[j:])
+		copy(loads[j+1:], loads[j:])
+		backends[j] = backend
+		loads[j] = load
+	}
+
+	return backends
+}
+
+/**
+ * Forward a connection to the less loaded backend, the next one is tried if
+ * the backend does not accept the connection.
+ */
+func (self *serviceRouter) forward(client net.Conn) {
+	for _, backend := range self.getBackends() {
+		server, err := net.DialTimeout("tcp", backend.address, 5*time.Second)
+		if err == nil {
+			backend.forward(client, server)
+			return
+		}
+	}
+
+	client.Close()
+}
+
+/**
+ * Send the new connections to the processes listening at addresses and
+ * return the previous backends.
+ */
+func (self *serviceRouter) setBackends(addresses []string) []*routerBackend {
+	backends := make([]*routerBackend, len(addresses))
+	for i, address := range addresses {
+		backends[i] = newRouterBackend(address)
+	}
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	previous := self.backends
+	self.backends = backends
+
+	return previous
+}
+
+/**
+ * Send the new connections to the processes listening at addresses, the
+ * connections to the addresses that remain are kept and the others are
+ * closed.
+ */
+func (self *serviceRouter) updateBackends(addresses []string) {
+	self.mutex.Lock()
+	previous := make(map[string]*routerBackend, 0)
+	for _, backend := range self.backends {
+		previous[backend.address] = backend
+	}
+
+	backends := make([]*routerBackend, len(addresses))
+	for i, address := range addresses {
+		backends[i] = previous[address]
+		if backends[i] == nil {
+			backends[i] = newRouterBackend(address)
+		}
+		delete(previous, address)
+	}
+	self.backends = backends
+	self.mutex.Unlock()
+
+	for _, backend := range previous {
+		backend.closeConnections(0)
+	}
+}
+
+/**
+ * Replace the backend at address by a new one, the connections of the
+ * previous backend are closed.
+ */
+func (self *serviceRouter) replaceBackend(address string, newAddress string) {
+	self.mutex.Lock()
+	var previous *routerBackend
+	for i, backend := range self.backends {
+		if backend.address == address {
+			previous = backend
+			self.backends[i] = newRouterBackend(newAddress)
+		}
+	}
+	self.mutex.Unlock()
+
+	if previous != nil {
+		previous.closeConnections(0)
+	}
+}
+
+/**
+ * Stop listening and close the forwarded connections.
+ */
+func (self *serviceRouter) close() {
+	self.listener.Close()
+
+	for _, backend := range self.setBackends([]string{}) {
+		backend.closeConnections(0)
+	}
+}
+
+/**
+ * Return the number of connections forwarded to the backend.
+ */
+func (self *routerBackend) count() int {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	return len(self.connections)
+}
+
+func (self *routerBackend) forward(client net.Conn, server net.Conn) {
+	conn := &routedConn{client: client, server: server, lastActivity: time.Now()}
+
+	self.mutex.Lock()
+	self.connections[conn] = true
+	self.mutex.Unlock()
+
+	done := make(chan bool, 2)
+	go func() {
+		io.Copy(&activityWriter{conn, server}, client)
+		done <- true
+	}()
+
+	go func() {
+		io.Copy(&activityWriter{conn, client}, server)
+		done <- true
+	}()
+
+	// When one side is closed the other one is closed too.
+	<-done
+	client.Close()
+	server.Close()
+	<-done
+
+	self.mutex.Lock()
+	delete(self.connections, conn)
+	self.mutex.Unlock()
+}
+
+/**
+ * Close the connections without traffic since idle and return the number of
+ * connections that remain open.
+ */
+func (self *routerBackend) closeConnections(idle time.Duration) int {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	count := 0
+	for conn, _ := range self.connections {
+		conn.mutex.Lock()
+		lastActivity := conn.lastActivity
+		conn.mutex.Unlock()
+
+		if time.Since(lastActivity) >= idle {
+			conn.client.Close()
+			conn.server.Close()
+		} else {
+			count++
+		}
+	}
+
+	return count
+}
+
+/**
+ * Wait until all the connections of the backends are closed, a connection
+ * is closed when it stay idle long enough for the client to reconnect
+ * without losing a request. Return false if connections remain open after
+ * timeout.
+ */
+func drainBackends(backends []*routerBackend, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for {
+		count := 0
+		for _, backend := range backends {
+			count += backend.closeConnections(drainIdleDelay)
+		}
+
+		if count == 0 {
+			return true
+		}
+
+		if time.Now().After(deadline) {
+			return false
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+/**
+ * Return a port free on the local host.
+ */
+func getFreePort() (int, error) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
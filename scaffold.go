@@ -0,0 +1,577 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/davecourtois/Utility"
+)
+
+/**
+ * The values use by the service templates.
+ */
+type serviceTemplate struct {
+	Name  string // ex: echo
+	Title string // ex: Echo
+	Port  int
+	Proxy int
+}
+
+// The service definition.
+const protoTemplate = `/**
+ * The {{.Name}} service.
+ */
+syntax = "proto3";
+
+package {{.Name}};
+
+option go_package="{{.Name}}pb";
+
+message PingRequest {
+	string message = 1;
+}
+
+message PingResponse {
+	string message = 1;
+}
+
+service {{.Title}}Service {
+	// Return the message, can be use to know if the service is alive.
+	rpc Ping(PingRequest) returns (PingResponse){};
+}
+`
+
+// The service implementation.
+const serverTemplate = `package main
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+
+	"github.com/davecourtois/Globular/config"
+	"github.com/davecourtois/Globular/{{.Name}}/{{.Name}}pb"
+	"github.com/davecourtois/Utility"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/grpclog"
+)
+
+// TODO take care of TLS/https
+var (
+	defaultPort  = {{.Port}}
+	defaultProxy = {{.Proxy}}
+
+	// By default all origins are allowed.
+	allow_all_origins = true
+
+	// comma separeated values.
+	allowed_origins string = ""
+
+	// The configuration schema version, see package config.
+	configVersion = 1
+)
+
+// Value need by Globular to start the services...
+type server struct {
+	// The global attribute of the services.
+	Name            string
+	Port            int
+	Proxy           int
+	AllowAllOrigins bool
+	AllowedOrigins  string // comma separated string.
+	Protocol        string
+
+	// The number of processes run by the Globule and the version of the
+	// configuration schema.
+	Replicas      int
+	ConfigVersion int
+}
+
+// Create the configuration file if is not already exist.
+func (self *server) init() {
+	// Here I will retreive the list of connections from file if there are some...
+	dir, _ := filepath.Abs(filepath.Dir(os.Args[0]))
+	self.ConfigVersion = configVersion
+	err := config.Load(dir+"/config.json", self, configVersion)
+	if os.IsNotExist(err) {
+		self.save()
+	} else if err != nil {
+		log.Fatalln(err)
+	}
+}
+
+// Save the configuration values.
+func (self *server) save() error {
+	// Create the file...
+	str, err := Utility.ToJson(self)
+	if err != nil {
+		return err
+	}
+
+	dir, err := filepath.Abs(filepath.Dir(os.Args[0]))
+	if err != nil {
+		return err
+	}
+
+	ioutil.WriteFile(dir+"/config.json", []byte(str), 0644)
+	return nil
+}
+
+// Return the message, can be use to know if the service is alive.
+func (self *server) Ping(ctx context.Context, rqst *{{.Name}}pb.PingRequest) (*{{.Name}}pb.PingResponse, error) {
+	return &{{.Name}}pb.PingResponse{
+		Message: rqst.Message,
+	}, nil
+}
+
+// The {{.Name}} service.
+// port number must be pass as argument.
+func main() {
+
+	// set the logger.
+	grpclog.SetLogger(log.New(os.Stdout, "{{.Name}}_service: ", log.LstdFlags))
+
+	// Set the log information in case of crash...
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+
+	// The first argument must be the port number to listen to.
+	port := defaultPort // the default value.
+
+	if len(os.Args) > 1 {
+		port, _ = strconv.Atoi(os.Args[1]) // The second argument must be the port number
+	}
+
+	// First of all I will creat a listener.
+	lis, err := net.Listen("tcp", "0.0.0.0:"+strconv.Itoa(port))
+	if err != nil {
+		log.Fatalf("Failed to listen: %v", err)
+	}
+
+	// The actual server implementation.
+	s_impl := new(server)
+	s_impl.Name = Utility.GetExecName(os.Args[0])
+	s_impl.Port = port
+	s_impl.Proxy = defaultProxy
+	s_impl.Protocol = "grpc"
+
+	// TODO set it from the program arguments...
+	s_impl.AllowAllOrigins = allow_all_origins
+	s_impl.AllowedOrigins = allowed_origins
+
+	// Here I will retreive the list of connections from file if there are some...
+	s_impl.init()
+
+	grpcServer := grpc.NewServer()
+	{{.Name}}pb.Register{{.Title}}ServiceServer(grpcServer, s_impl)
+
+	// Here I will make a signal hook to interrupt to exit cleanly.
+	go func() {
+		log.Println(s_impl.Name + " grpc service is starting")
+		// no web-rpc server.
+		if err := grpcServer.Serve(lis); err != nil {
+			log.Fatalf("failed to serve: %v", err)
+		}
+		log.Println(s_impl.Name + " grpc service is closed")
+	}()
+
+	// Wait for signal to stop.
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, os.Interrupt)
+	<-ch
+
+}
+`
+
+// The service test.
+const testTemplate = `package Globular
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/davecourtois/Globular/{{.Name}}/{{.Name}}pb"
+	"google.golang.org/grpc"
+
+	"testing"
+)
+
+// Set the correct addresse here as needed.
+var (
+	addresse = "localhost:{{.Port}}"
+)
+
+/**
+ * Get the client connection.
+ */
+func getClientConnection() *grpc.ClientConn {
+	var err error
+	var cc *grpc.ClientConn
+	if cc == nil {
+		cc, err = grpc.Dial(addresse, grpc.WithInsecure())
+		if err != nil {
+			log.Fatalf("could not connect: %v", err)
+		}
+
+	}
+	return cc
+}
+
+// Test the service is alive.
+func TestPing(t *testing.T) {
+	fmt.Println("Ping test.")
+
+	cc := getClientConnection()
+
+	// when done the connection will be close.
+	defer cc.Close()
+
+	// Create a new client service...
+	c := {{.Name}}pb.New{{.Title}}ServiceClient(cc)
+
+	rqst := &{{.Name}}pb.PingRequest{
+		Message: "Hello Globular",
+	}
+
+	rsp, err := c.Ping(context.Background(), rqst)
+	if err != nil {
+		log.Fatalf("error while Ping: %v", err)
+	}
+
+	log.Println("Response form Ping:", rsp.Message)
+}
+`
+
+// The Go client use by the Globule web-api.
+const clientTemplate = `package main
+
+import (
+	"context"
+
+	"github.com/davecourtois/Globular/{{.Name}}/{{.Name}}pb"
+	"github.com/davecourtois/Utility"
+	"google.golang.org/grpc"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// {{.Name}} Client Service
+////////////////////////////////////////////////////////////////////////////////
+
+type {{.Title}}_Client struct {
+	cc *grpc.ClientConn
+	c  {{.Name}}pb.{{.Title}}ServiceClient
+}
+
+// Register the constructor use by initClients.
+func init() {
+	Utility.RegisterFunction("New{{.Title}}_Client", New{{.Title}}_Client)
+}
+
+// Create a connection to the service.
+func New{{.Title}}_Client(addresse string) *{{.Title}}_Client {
+	client := new({{.Title}}_Client)
+	client.cc = getClientConnection(addresse)
+	client.c = {{.Name}}pb.New{{.Title}}ServiceClient(client.cc)
+	return client
+}
+
+// must be close when no more needed.
+func (self *{{.Title}}_Client) Close() {
+	self.cc.Close()
+}
+
+func (self *{{.Title}}_Client) Ping(msg interface{}) (string, error) {
+	rqst := &{{.Name}}pb.PingRequest{
+		Message: Utility.ToString(msg),
+	}
+
+	rsp, err := self.c.Ping(context.Background(), rqst)
+	if err != nil {
+		return "", err
+	}
+
+	return rsp.Message, nil
+}
+`
+
+// The JavaScript import of the generated client.
+const jsImportTemplate = `////////////////////////////////////////////////////////////////////////////
+// {{.Title}} service
+////////////////////////////////////////////////////////////////////////////
+window.{{.Title}} = require('./{{.Name}}/{{.Name}}pb/{{.Name}}_pb.js');
+window.{{.Title}} = Object.assign(window.{{.Title}}, require('./{{.Name}}/{{.Name}}pb/{{.Name}}_grpc_web_pb.js'));
+
+`
+
+// The JavaScript service initialisation in the Globular class.
+const jsInitTemplate = `        if (this.config.Services.{{.Name}}_server != null) {
+            this.{{.Name}}Service = new {{.Title}}.{{.Title}}ServiceClient(this.config.Protocol + '://' + this.config.IP + ":" + this.config.Services.{{.Name}}_server.Proxy);
+            this.{{.Name}}ServicePromise = new {{.Title}}.{{.Title}}ServicePromiseClient(this.config.Protocol + '://' + this.config.IP + ":" + this.config.Services.{{.Name}}_server.Proxy);
+            console.log("{{.Name}} service is init.")
+        }
+
+`
+
+// The code generation commands.
+const generateTemplate = `
+# {{.Name}} service
+protoc {{.Name}}/{{.Name}}pb/{{.Name}}.proto --go_out=plugins=grpc:.
+protoc {{.Name}}/{{.Name}}pb/{{.Name}}.proto --js_out=import_style=commonjs:client
+protoc {{.Name}}/{{.Name}}pb/{{.Name}}.proto --grpc-web_out=import_style=commonjs,mode=grpcwebtext:client
+`
+
+/**
+ * Write a template into a file.
+ */
+func writeTemplate(path string, text string, values *serviceTemplate) error {
+	t, err := template.New(filepath.Base(path)).Parse(text)
+	if err != nil {
+		return err
+	}
+
+	err = Utility.CreateDirIfNotExist(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return t.Execute(f, values)
+}
+
+/**
+ * Return the text of a template.
+ */
+func executeTemplate(text string, values *serviceTemplate) (string, error) {
+	t, err := template.New("").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	err = t.Execute(&b, values)
+	return b.String(), err
+}
+
+/**
+ * Return the higher port number use by the services found in a directory.
+ */
+func getMaxServicePort(dir string) int {
+	max := 10015 // The default admin port.
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && info.Name() == "config.json" {
+			s := make(map[string]interface{})
+			config, err := ioutil.ReadFile(path)
+			if err == nil && json.Unmarshal(config, &s) == nil {
+				for _, key := range []string{"Port", "Proxy", "AdminPort"} {
+					if Utility.ToInt(s[key]) > max {
+						max = Utility.ToInt(s[key])
+					}
+				}
+			}
+		}
+		return nil
+	})
+
+	return max
+}
+
+/**
+ * Insert the JavaScript client of a service in client/services.js
+ */
+func appendJsClient(path string, values *serviceTemplate) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	code := string(data)
+
+	imports, err := executeTemplate(jsImportTemplate, values)
+	if err != nil {
+		return err
+	}
+
+	init, err := executeTemplate(jsInitTemplate, values)
+	if err != nil {
+		return err
+	}
+
+	// The imports are put before the Globular class and the initialisation at
+	// the end of it constructor.
+	importIndex := strings.Index(code, "////////////////////////////////////////////////////////////////////////////\n// Server singleton object")
+	initIndex := strings.Index(code, `        console.log("services are all initialysed!")`)
+	if importIndex == -1 || initIndex == -1 {
+		return errors.New("fail to find where to insert the service in " + path)
+	}
+
+	code = code[:importIndex] + imports + code[importIndex:initIndex] + init + code[initIndex:]
+
+	return ioutil.WriteFile(path, []byte(code), 0644)
+}
+
+/**
+ * Run a command in a directory, it output is print.
+ */
+func runCommand(dir string, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	fmt.Println(name, strings.Join(args, " "))
+	return cmd.Run()
+}
+
+/**
+ * Create a new service from templates: the proto file, the server, it
+ * configuration, a test, the Go client and the JavaScript client. With -build
+ * the code is generated with protoc, the server is compiled and started by
+ * the running Globule.
+ */
+func generateService(args []string) error {
+	fs := flag.NewFlagSet("generate", flag.ExitOnError)
+	dir := fs.String("dir", ".", "the Globular source directory")
+	build := fs.Bool("build", true, "generate the grpc code, compile the server and start it")
+	newClient := adminFlags(fs)
+	args = parseFlags(fs, args)
+
+	if len(args) != 1 {
+		return errors.New("usage: Globular generate name [-dir path] [-build=false]")
+	}
+
+	name := args[0]
+	if !regexp.MustCompile(`^[a-z][a-z0-9]*$`).MatchString(name) {
+		return errors.New("the service name must contain only lower case letters and digits and start with a letter")
+	}
+
+	dir_, err := filepath.Abs(*dir)
+	if err != nil {
+		return err
+	}
+
+	if Utility.Exists(filepath.Join(dir_, name)) {
+		return errors.New("the directory " + filepath.Join(dir_, name) + " already exist")
+	}
+
+	port := getMaxServicePort(dir_) + 1
+	values := &serviceTemplate{
+		Name:  name,
+		Title: strings.ToUpper(name[0:1]) + name[1:],
+		Port:  port,
+		Proxy: port + 1,
+	}
+
+	serverDir := filepath.Join(dir_, name, name+"_server")
+	files := []struct {
+		path string
+		text string
+	}{
+		{filepath.Join(dir_, name, name+"pb", name+".proto"), protoTemplate},
+		{filepath.Join(serverDir, name+"_server.go"), serverTemplate},
+		{filepath.Join(dir_, name, name+"_test", name+"_test.go"), testTemplate},
+		{filepath.Join(dir_, name+"_client.go"), clientTemplate},
+	}
+
+	for _, file := range files {
+		err := writeTemplate(file.path, file.text, values)
+		if err != nil {
+			return err
+		}
+		fmt.Println("create", file.path)
+	}
+
+	// The configuration of the service.
+	config := struct {
+		Name            string
+		Port            int
+		Proxy           int
+		AllowAllOrigins bool
+		AllowedOrigins  string
+		Protocol        string
+		ConfigVersion   int
+	}{name + "_server", values.Port, values.Proxy, true, "", "grpc", 1}
+
+	str, err := Utility.ToJson(config)
+	if err != nil {
+		return err
+	}
+
+	err = ioutil.WriteFile(filepath.Join(serverDir, "config.json"), []byte(str), 0644)
+	if err != nil {
+		return err
+	}
+	fmt.Println("create", filepath.Join(serverDir, "config.json"))
+
+	// The JavaScript client.
+	err = appendJsClient(filepath.Join(dir_, "client", "services.js"), values)
+	if err != nil {
+		return err
+	}
+	fmt.Println("update", filepath.Join(dir_, "client", "services.js"))
+
+	// The code generation commands.
+	commands, err := executeTemplate(generateTemplate, values)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(filepath.Join(dir_, "generateCode.sh"), os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	f.WriteString(commands)
+	f.Close()
+	fmt.Println("update", filepath.Join(dir_, "generateCode.sh"))
+
+	if !*build {
+		return nil
+	}
+
+	// Generate the grpc code for Go and JavaScript.
+	for _, line := range strings.Split(strings.TrimSpace(commands), "\n") {
+		if strings.HasPrefix(line, "protoc") {
+			err := runCommand(dir_, "protoc", strings.Fields(line)[1:]...)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// Compile the server and start it with the running Globule, or the next
+	// time it start.
+	err = runCommand(serverDir, "go", "build")
+	if err != nil {
+		return err
+	}
+
+	client := newClient()
+	defer client.Close()
+
+	_, err = client.StartService(name + "_server")
+	if err != nil {
+		fmt.Println("The service", name+"_server", "is ready, it will run the next time Globular start:", err)
+	} else {
+		fmt.Println("The service", name+"_server", "is running at port", values.Port, "and proxy port", values.Proxy)
+	}
+
+	// The web-api and the browsers clients are compiled.
+	fmt.Println("Rebuild and restart Globular to use it from the web-api (/api/" + name + "_service/...), and run npx webpack in client for the JavaScript client.")
+	return nil
+}
@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -13,11 +12,13 @@ import (
 	"path/filepath"
 	"strconv"
 
+	"github.com/davecourtois/Globular/config"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/grpclog"
 	"google.golang.org/grpc/status"
 
+	"github.com/davecourtois/Globular/event/event_client"
 	"github.com/davecourtois/Globular/smtp/smtppb"
 	"github.com/davecourtois/Utility"
 
@@ -33,6 +34,9 @@ var (
 
 	// comma separeated values.
 	allowed_origins string = ""
+
+	// The configuration schema version, see package config.
+	configVersion = 1
 )
 
 // Keep connection information here.
@@ -53,6 +57,11 @@ type server struct {
 	AllowAllOrigins bool
 	AllowedOrigins  string // comma separated string.
 
+	// The number of processes run by the Globule and the version of the
+	// configuration schema.
+	Replicas      int
+	ConfigVersion int
+
 	// The map of connection...
 	Connections map[string]connection
 }
@@ -60,11 +69,12 @@ type server struct {
 func (self *server) init() {
 	// Here I will retreive the list of connections from file if there are some...
 	dir, _ := filepath.Abs(filepath.Dir(os.Args[0]))
-	file, err := ioutil.ReadFile(dir + "/config.json")
-	if err == nil {
-		json.Unmarshal([]byte(file), self)
-	} else {
+	self.ConfigVersion = configVersion
+	err := config.Load(dir+"/config.json", self, configVersion)
+	if os.IsNotExist(err) {
 		self.save()
+	} else if err != nil {
+		log.Fatalln(err)
 	}
 }
 
@@ -206,6 +216,9 @@ func (self *server) sendEmail(id string, from string, to []string, cc []*CarbonC
 		log.Println("--> 193 fail to send email: ", err)
 		return err
 	}
+
+	event_client.Publish(event_client.EmailSent, map[string]interface{}{"id": id, "from": from, "to": to, "subject": subject})
+
 	return nil
 }
 
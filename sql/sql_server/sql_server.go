@@ -23,6 +23,7 @@ import (
 	"reflect"
 	"runtime"
 
+	"github.com/davecourtois/Globular/config"
 	"github.com/davecourtois/Globular/sql/sqlpb"
 	"github.com/davecourtois/Utility"
 
@@ -50,6 +51,9 @@ var (
 
 	// comma separeated values.
 	allowed_origins string = ""
+
+	// The configuration schema version, see package config.
+	configVersion = 1
 )
 
 // Keep connection information here.
@@ -124,6 +128,11 @@ type server struct {
 	AllowAllOrigins bool
 	AllowedOrigins  string // comma separated string.
 
+	// The number of processes run by the Globule and the version of the
+	// configuration schema.
+	Replicas      int
+	ConfigVersion int
+
 	// The map of connection...
 	Connections map[string]connection
 }
@@ -131,11 +140,12 @@ type server struct {
 func (self *server) init() {
 	// Here I will retreive the list of connections from file if there are some...
 	dir, _ := filepath.Abs(filepath.Dir(os.Args[0]))
-	file, err := ioutil.ReadFile(dir + "/config.json")
-	if err == nil {
-		json.Unmarshal([]byte(file), self)
-	} else {
+	self.ConfigVersion = configVersion
+	err := config.Load(dir+"/config.json", self, configVersion)
+	if os.IsNotExist(err) {
 		self.save()
+	} else if err != nil {
+		log.Fatalln(err)
 	}
 }
 
@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 
 	"errors"
 	"fmt"
@@ -16,6 +15,7 @@ import (
 
 	//	"time"
 
+	"github.com/davecourtois/Globular/config"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/grpclog"
@@ -35,14 +35,18 @@ var (
 
 	// comma separeated values.
 	allowed_origins string = ""
+
+	// The configuration schema version, see package config.
+	configVersion = 1
 )
 
 // Keep connection information here.
 type connection struct {
-	Id    string // The connection id
-	Name  string // The kv store name
-	Type  storagepb.StoreType
-	store storage_store.Store
+	Id      string // The connection id
+	Name    string // The kv store name
+	Type    storagepb.StoreType
+	Options string // The options of the last open, the LevelDB path and name.
+	store   storage_store.Store
 }
 
 type server struct {
@@ -55,6 +59,11 @@ type server struct {
 	AllowAllOrigins bool
 	AllowedOrigins  string // comma separated string.
 
+	// The number of processes run by the Globule and the version of the
+	// configuration schema.
+	Replicas      int
+	ConfigVersion int
+
 	// The map of connection...
 	Connections map[string]connection
 }
@@ -62,11 +71,12 @@ type server struct {
 func (self *server) init() {
 	// Here I will retreive the list of connections from file if there are some...
 	dir, _ := filepath.Abs(filepath.Dir(os.Args[0]))
-	file, err := ioutil.ReadFile(dir + "/config.json")
-	if err == nil {
-		json.Unmarshal([]byte(file), self)
-	} else {
+	self.ConfigVersion = configVersion
+	err := config.Load(dir+"/config.json", self, configVersion)
+	if os.IsNotExist(err) {
 		self.save()
+	} else if err != nil {
+		log.Fatalln(err)
 	}
 }
 
@@ -179,6 +189,7 @@ func (self *server) Open(ctx context.Context, rqst *storagepb.OpenRqst) (*storag
 	}
 
 	conn.store = store
+	conn.Options = rqst.GetOptions()
 
 	if store == nil {
 		return nil, status.Errorf(
@@ -196,6 +207,14 @@ func (self *server) Open(ctx context.Context, rqst *storagepb.OpenRqst) (*storag
 
 	self.Connections[rqst.GetId()] = conn
 
+	// Keep the options so the store can be found by the backups.
+	err = self.save()
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+
 	return &storagepb.OpenRsp{
 		Result: true,
 	}, nil
@@ -0,0 +1,576 @@
+package main
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"hash"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/davecourtois/Utility"
+)
+
+/**
+ * The resumable uploads follow the tus protocol 1.0.0 (https://tus.io) with
+ * the creation, checksum, termination and expiration extensions, so the tus
+ * clients (tus-js-client...) can be use:
+ *
+ *	POST /uploads/ Upload-Length: 1000, Upload-Metadata: filename cGhvdG8uanBn,path L3Bob3Rvcw==
+ *		-> 201 Location: /uploads/4f8e...
+ *	HEAD /uploads/4f8e... -> 200 Upload-Offset: 300
+ *	PATCH /uploads/4f8e... Upload-Offset: 300, Upload-Checksum: sha1 ... -> 204 Upload-Offset: 1000
+ *
+ * The chunks are kept in the .uploads directory of the Globule until the
+ * upload is complete, then the file is saved at path/filename by the file
+ * service. A sha256 metadata (base64) is verified against the whole file.
+ */
+
+const (
+	tusVersion    = "1.0.0"
+	tusExtensions = "creation,checksum,termination,expiration"
+
+	// The status of a chunk whose checksum does not match, from the tus
+	// checksum extension.
+	statusChecksumMismatch = 460
+)
+
+/**
+ * An upload in progress, it is saved next to it data so it can be resumed
+ * after a restart of the Globule.
+ */
+type upload struct {
+	Id       string
+	Length   int64
+	Offset   int64
+	Metadata map[string]string
+	User     string
+	Expires  time.Time
+
+	// Set when the file is saved by the file service.
+	Done bool
+
+	// Set while a chunk is received.
+	locked bool
+}
+
+/**
+ * Return the directory of the uploads in progress.
+ */
+func (self *Globule) getUploadsDir() string {
+	return filepath.Join(self.path, ".uploads")
+}
+
+/**
+ * Load the uploads that was in progress and remove the expired ones at
+ * regular interval.
+ */
+func (self *Globule) initUploads() {
+	Utility.CreateDirIfNotExist(self.getUploadsDir())
+
+	files, _ := ioutil.ReadDir(self.getUploadsDir())
+	for _, info := range files {
+		if !strings.HasSuffix(info.Name(), ".json") {
+			continue
+		}
+
+		data, err := ioutil.ReadFile(filepath.Join(self.getUploadsDir(), info.Name()))
+		if err != nil {
+			continue
+		}
+
+		u := new(upload)
+		if json.Unmarshal(data, u) == nil {
+			self.mutex.Lock()
+			self.uploads[u.Id] = u
+			self.mutex.Unlock()
+		}
+	}
+
+	go func() {
+		for {
+			self.removeExpiredUploads()
+			time.Sleep(time.Minute)
+		}
+	}()
+}
+
+/**
+ * Remove the uploads not completed before their expiration and the
+ * completed ones after it.
+ */
+func (self *Globule) removeExpiredUploads() {
+	self.mutex.Lock()
+	expired := make([]*upload, 0)
+	for _, u := range self.uploads {
+		if time.Now().After(u.Expires) && !u.locked {
+			expired = append(expired, u)
+		}
+	}
+	self.mutex.Unlock()
+
+	for _, u := range expired {
+		log.Println("Upload ", u.Id, " expired at offset ", u.Offset, " of ", u.Length)
+		self.removeUpload(u)
+	}
+}
+
+func (self *Globule) removeUpload(u *upload) {
+	self.mutex.Lock()
+	delete(self.uploads, u.Id)
+	self.mutex.Unlock()
+
+	os.Remove(filepath.Join(self.getUploadsDir(), u.Id))
+	os.Remove(filepath.Join(self.getUploadsDir(), u.Id+".json"))
+}
+
+/**
+ * Save the state of an upload.
+ */
+func (self *Globule) saveUpload(u *upload) error {
+	self.mutex.Lock()
+	data, err := json.Marshal(u)
+	self.mutex.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(self.getUploadsDir(), u.Id+".json"), data, 0644)
+}
+
+/**
+ * Return the user of an upload request. The Globule does not authenticate
+ * the users, so the user is the client address, a name given by the client
+ * would let it take the quota of anyone.
+ */
+func getUploadUser(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+/**
+ * Parse the Upload-Metadata header, comma separated keys and base64 values.
+ */
+func parseUploadMetadata(header string) (map[string]string, error) {
+	metadata := make(map[string]string, 0)
+	for _, pair := range strings.Split(header, ",") {
+		values := strings.Fields(pair)
+		if len(values) == 0 {
+			continue
+		}
+
+		value := ""
+		if len(values) > 1 {
+			data, err := base64.StdEncoding.DecodeString(values[1])
+			if err != nil {
+				return nil, errors.New("the metadata " + values[0] + " is not base64 encoded")
+			}
+			value = string(data)
+		}
+		metadata[values[0]] = value
+	}
+
+	return metadata, nil
+}
+
+func formatUploadMetadata(metadata map[string]string) string {
+	pairs := make([]string, 0)
+	for key, value := range metadata {
+		pairs = append(pairs, key+" "+base64.StdEncoding.EncodeToString([]byte(value)))
+	}
+
+	return strings.Join(pairs, ",")
+}
+
+/**
+ * Return the hash of an Upload-Checksum header and the expected sum.
+ */
+func parseUploadChecksum(header string) (hash.Hash, []byte, error) {
+	values := strings.Fields(header)
+	if len(values) != 2 {
+		return nil, nil, errors.New("the checksum must be the algorithm and the base64 sum")
+	}
+
+	sum, err := base64.StdEncoding.DecodeString(values[1])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch values[0] {
+	case "sha1":
+		return sha1.New(), sum, nil
+	case "sha256":
+		return sha256.New(), sum, nil
+	case "md5":
+		return md5.New(), sum, nil
+	}
+
+	return nil, nil, errors.New("the checksum algorithm " + values[0] + " is not supported")
+}
+
+/**
+ * The tus endpoint, /uploads/ to create an upload and /uploads/id for an
+ * upload.
+ */
+func (self *Globule) UploadsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Tus-Resumable", tusVersion)
+	w.Header().Set("Cache-Control", "no-store")
+
+	if r.Method == http.MethodOptions {
+		w.Header().Set("Tus-Version", tusVersion)
+		w.Header().Set("Tus-Extension", tusExtensions)
+		w.Header().Set("Tus-Checksum-Algorithm", "md5,sha1,sha256")
+		if self.UploadMaxSize > 0 {
+			w.Header().Set("Tus-Max-Size", strconv.FormatInt(self.UploadMaxSize, 10))
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	version := r.Header.Get("Tus-Resumable")
+	if len(version) > 0 && version != tusVersion {
+		w.Header().Set("Tus-Version", tusVersion)
+		http.Error(w, "the tus version "+version+" is not supported", http.StatusPreconditionFailed)
+		return
+	}
+
+	id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/uploads"), "/")
+	if len(id) == 0 {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method "+r.Method+" not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		self.createUpload(w, r)
+		return
+	}
+
+	self.mutex.Lock()
+	u := self.uploads[id]
+	self.mutex.Unlock()
+
+	if u == nil {
+		http.Error(w, "upload "+id+" not found", http.StatusNotFound)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodHead:
+		self.mutex.Lock()
+		w.Header().Set("Upload-Offset", strconv.FormatInt(u.Offset, 10))
+		w.Header().Set("Upload-Length", strconv.FormatInt(u.Length, 10))
+		w.Header().Set("Upload-Metadata", formatUploadMetadata(u.Metadata))
+		w.Header().Set("Upload-Expires", u.Expires.UTC().Format(http.TimeFormat))
+		self.mutex.Unlock()
+		w.WriteHeader(http.StatusOK)
+	case http.MethodPatch:
+		self.writeUpload(u, w, r)
+	case http.MethodDelete:
+		self.mutex.Lock()
+		locked := u.locked
+		self.mutex.Unlock()
+		if locked {
+			http.Error(w, "upload "+id+" is receiving data", http.StatusConflict)
+			return
+		}
+		self.removeUpload(u)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "method "+r.Method+" not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+/**
+ * Create an upload if it respect the size limits.
+ */
+func (self *Globule) createUpload(w http.ResponseWriter, r *http.Request) {
+	length, err := strconv.ParseInt(r.Header.Get("Upload-Length"), 10, 64)
+	if err != nil || length < 0 {
+		http.Error(w, "Upload-Length is missing or invalid", http.StatusBadRequest)
+		return
+	}
+
+	metadata, err := parseUploadMetadata(r.Header.Get("Upload-Metadata"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	filename := metadata["filename"]
+	if len(filename) == 0 || filename != path.Base(filename) || filename == ".." || strings.Contains(filename, "\\") {
+		http.Error(w, "the filename metadata is missing or invalid", http.StatusBadRequest)
+		return
+	}
+
+	if self.UploadMaxSize > 0 && length > self.UploadMaxSize {
+		http.Error(w, "the upload size is bigger than "+strconv.FormatInt(self.UploadMaxSize, 10)+" bytes", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	id := make([]byte, 16)
+	_, err = rand.Read(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	u := &upload{
+		Id:       hex.EncodeToString(id),
+		Length:   length,
+		Metadata: metadata,
+		User:     getUploadUser(r),
+		Expires:  time.Now().Add(time.Duration(self.UploadExpiration) * time.Second),
+	}
+
+	// The space taken by the uploads in progress.
+	self.mutex.Lock()
+	var total, user int64
+	for _, u_ := range self.uploads {
+		if !u_.Done {
+			total += u_.Length
+			if u_.User == u.User {
+				user += u_.Length
+			}
+		}
+	}
+
+	if self.UploadUserMaxSize > 0 && user+length > self.UploadUserMaxSize {
+		self.mutex.Unlock()
+		http.Error(w, "the uploads of "+u.User+" exceed "+strconv.FormatInt(self.UploadUserMaxSize, 10)+" bytes", http.StatusInsufficientStorage)
+		return
+	}
+
+	if self.UploadTotalMaxSize > 0 && total+length > self.UploadTotalMaxSize {
+		self.mutex.Unlock()
+		http.Error(w, "the uploads in progress exceed "+strconv.FormatInt(self.UploadTotalMaxSize, 10)+" bytes", http.StatusInsufficientStorage)
+		return
+	}
+
+	self.uploads[u.Id] = u
+	self.mutex.Unlock()
+
+	err = ioutil.WriteFile(filepath.Join(self.getUploadsDir(), u.Id), []byte{}, 0644)
+	if err == nil {
+		err = self.saveUpload(u)
+	}
+
+	if err != nil {
+		self.removeUpload(u)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// An empty file is complete at creation.
+	if length == 0 {
+		status, err := self.completeUpload(u)
+		if err != nil {
+			http.Error(w, err.Error(), status)
+			return
+		}
+	}
+
+	w.Header().Set("Location", strings.TrimSuffix(r.URL.Path, "/")+"/"+u.Id)
+	w.Header().Set("Upload-Expires", u.Expires.UTC().Format(http.TimeFormat))
+	w.WriteHeader(http.StatusCreated)
+}
+
+/**
+ * Append a chunk to an upload. The chunk is remove if it checksum does not
+ * match, a chunk interrupted by the connection is kept so the client resume
+ * from it end.
+ */
+func (self *Globule) writeUpload(u *upload, w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Content-Type") != "application/offset+octet-stream" {
+		http.Error(w, "the content type must be application/offset+octet-stream", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	offset, err := strconv.ParseInt(r.Header.Get("Upload-Offset"), 10, 64)
+	if err != nil {
+		http.Error(w, "Upload-Offset is missing or invalid", http.StatusBadRequest)
+		return
+	}
+
+	var checksum hash.Hash
+	var sum []byte
+	if len(r.Header.Get("Upload-Checksum")) > 0 {
+		checksum, sum, err = parseUploadChecksum(r.Header.Get("Upload-Checksum"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	self.mutex.Lock()
+	if u.locked {
+		self.mutex.Unlock()
+		http.Error(w, "upload "+u.Id+" is already receiving data", http.StatusConflict)
+		return
+	}
+
+	if offset != u.Offset {
+		current := u.Offset
+		self.mutex.Unlock()
+		w.Header().Set("Upload-Offset", strconv.FormatInt(current, 10))
+		http.Error(w, "the upload offset is "+strconv.FormatInt(current, 10), http.StatusConflict)
+		return
+	}
+	u.locked = true
+	self.mutex.Unlock()
+
+	defer func() {
+		self.mutex.Lock()
+		u.locked = false
+		self.mutex.Unlock()
+	}()
+
+	// The data of a completed upload is already sent to the file service,
+	// an empty chunk retry it if it fail.
+	if offset < u.Length {
+		status, err := self.appendChunk(u, r, checksum, sum)
+		if err != nil {
+			w.Header().Set("Upload-Offset", strconv.FormatInt(u.Offset, 10))
+			http.Error(w, err.Error(), status)
+			return
+		}
+	}
+
+	if u.Offset == u.Length && !u.Done {
+		status, err := self.completeUpload(u)
+		if err != nil {
+			w.Header().Set("Upload-Offset", strconv.FormatInt(u.Offset, 10))
+			http.Error(w, err.Error(), status)
+			return
+		}
+	}
+
+	w.Header().Set("Upload-Offset", strconv.FormatInt(u.Offset, 10))
+	w.Header().Set("Upload-Expires", u.Expires.UTC().Format(http.TimeFormat))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+/**
+ * Write the request body at the end of the upload data, return the http
+ * status of the error if any.
+ */
+func (self *Globule) appendChunk(u *upload, r *http.Request, checksum hash.Hash, sum []byte) (int, error) {
+	file, err := os.OpenFile(filepath.Join(self.getUploadsDir(), u.Id), os.O_WRONLY, 0644)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	defer file.Close()
+
+	_, err = file.Seek(u.Offset, io.SeekStart)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	// One more byte to know if the body is too long.
+	var writer io.Writer = file
+	if checksum != nil {
+		writer = io.MultiWriter(file, checksum)
+	}
+	n, err := io.Copy(writer, io.LimitReader(r.Body, u.Length-u.Offset+1))
+
+	status := http.StatusInternalServerError
+	if err == nil && u.Offset+n > u.Length {
+		err = errors.New("the data exceed the upload length")
+		status = http.StatusRequestEntityTooLarge
+	} else if err == nil && checksum != nil && string(checksum.Sum(nil)) != string(sum) {
+		err = errors.New("the chunk checksum does not match")
+		status = statusChecksumMismatch
+	} else if err != nil && checksum == nil {
+		// The data received before the connection was lost are kept.
+		err = nil
+	}
+
+	if err != nil {
+		file.Truncate(u.Offset)
+		return status, err
+	}
+
+	self.mutex.Lock()
+	u.Offset += n
+	self.mutex.Unlock()
+
+	err = self.saveUpload(u)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusNoContent, nil
+}
+
+/**
+ * Verify the sha256 of a completed upload and give it to the file service,
+ * return the http status of the error if any. The upload is kept if the file
+ * service fail, an empty chunk retry it.
+ */
+func (self *Globule) completeUpload(u *upload) (int, error) {
+	dataPath := filepath.Join(self.getUploadsDir(), u.Id)
+
+	if expected := u.Metadata["sha256"]; len(expected) > 0 {
+		file, err := os.Open(dataPath)
+		if err != nil {
+			return http.StatusInternalServerError, err
+		}
+
+		checksum := sha256.New()
+		_, err = io.Copy(checksum, file)
+		file.Close()
+		if err != nil {
+			return http.StatusInternalServerError, err
+		}
+
+		if base64.StdEncoding.EncodeToString(checksum.Sum(nil)) != expected {
+			// The upload must be done again.
+			self.removeUpload(u)
+			return statusChecksumMismatch, errors.New("the file checksum does not match")
+		}
+	}
+
+	self.mutex.Lock()
+	client, _ := self.clients["file_service"].(*File_Client)
+	self.mutex.Unlock()
+
+	if client == nil {
+		return http.StatusServiceUnavailable, errors.New("the file service is not available")
+	}
+
+	dest := path.Join("/", path.Clean("/"+u.Metadata["path"]), u.Metadata["filename"])
+	err := client.MoveFile(dataPath, dest)
+	if err != nil {
+		return http.StatusBadGateway, err
+	}
+
+	log.Println("Upload ", u.Id, " of ", u.User, " is saved at ", dest)
+
+	self.mutex.Lock()
+	u.Done = true
+	self.mutex.Unlock()
+
+	os.Remove(dataPath)
+	err = self.saveUpload(u)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusNoContent, nil
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+/**
+ * Return a Globule that receive the uploads in a new directory, without file
+ * service the completed uploads are kept.
+ */
+func newUploadsGlobule(t *testing.T) *Globule {
+	g := newTestGlobule(t, 10015)
+	g.uploads = make(map[string]*upload, 0)
+	g.UploadExpiration = 60
+	err := os.MkdirAll(g.getUploadsDir(), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return g
+}
+
+/**
+ * Send a tus request from a client address and return the response.
+ */
+func sendUploadRequest(g *Globule, method string, target string, remoteAddr string, headers map[string]string, body io.Reader) *http.Response {
+	r := httptest.NewRequest(method, target, body)
+	r.RemoteAddr = remoteAddr
+	r.Header.Set("Tus-Resumable", tusVersion)
+	for key, value := range headers {
+		r.Header.Set(key, value)
+	}
+
+	w := httptest.NewRecorder()
+	g.UploadsHandler(w, r)
+
+	return w.Result()
+}
+
+/**
+ * Create an upload and return it location.
+ */
+func createTestUpload(t *testing.T, g *Globule, remoteAddr string, length int, metadata map[string]string) string {
+	metadata["filename"] = "test.txt"
+	rsp := sendUploadRequest(g, http.MethodPost, "/uploads/", remoteAddr, map[string]string{
+		"Upload-Length":   strconv.Itoa(length),
+		"Upload-Metadata": formatUploadMetadata(metadata),
+	}, nil)
+	if rsp.StatusCode != http.StatusCreated {
+		t.Fatalf("unexpected status %d", rsp.StatusCode)
+	}
+
+	return rsp.Header.Get("Location")
+}
+
+/**
+ * Send a chunk of an upload.
+ */
+func patchTestUpload(g *Globule, location string, offset int, checksum string, body io.Reader) *http.Response {
+	headers := map[string]string{
+		"Content-Type":  "application/offset+octet-stream",
+		"Upload-Offset": strconv.Itoa(offset),
+	}
+	if len(checksum) > 0 {
+		headers["Upload-Checksum"] = checksum
+	}
+
+	return sendUploadRequest(g, http.MethodPatch, location, "10.0.0.1:1234", headers, body)
+}
+
+/**
+ * A body whose connection is lost after it data.
+ */
+type lostConnectionReader struct {
+	data io.Reader
+}
+
+func (self *lostConnectionReader) Read(p []byte) (int, error) {
+	n, err := self.data.Read(p)
+	if err == io.EOF {
+		return n, errors.New("connection lost")
+	}
+	return n, err
+}
+
+// The chunks are append at the upload offset, a lost chunk is kept and the
+// client resume from the offset given by HEAD.
+func TestUploadResume(t *testing.T) {
+	g := newUploadsGlobule(t)
+	defer os.RemoveAll(g.path)
+
+	location := createTestUpload(t, g, "10.0.0.1:1234", 10, map[string]string{})
+	id := strings.TrimPrefix(location, "/uploads/")
+
+	rsp := patchTestUpload(g, location, 0, "", strings.NewReader("0123"))
+	if rsp.StatusCode != http.StatusNoContent || rsp.Header.Get("Upload-Offset") != "4" {
+		t.Fatalf("unexpected response %d offset %s", rsp.StatusCode, rsp.Header.Get("Upload-Offset"))
+	}
+
+	rsp = patchTestUpload(g, location, 4, "", &lostConnectionReader{strings.NewReader("45")})
+	if rsp.Header.Get("Upload-Offset") != "6" {
+		t.Fatalf("the data before the connection lost are not kept, offset %s", rsp.Header.Get("Upload-Offset"))
+	}
+
+	rsp = sendUploadRequest(g, http.MethodHead, location, "10.0.0.1:1234", nil, nil)
+	if rsp.StatusCode != http.StatusOK || rsp.Header.Get("Upload-Offset") != "6" || rsp.Header.Get("Upload-Length") != "10" {
+		t.Fatalf("unexpected response %d offset %s", rsp.StatusCode, rsp.Header.Get("Upload-Offset"))
+	}
+
+	// A chunk at an other offset is refused.
+	rsp = patchTestUpload(g, location, 4, "", strings.NewReader("4567"))
+	if rsp.StatusCode != http.StatusConflict || rsp.Header.Get("Upload-Offset") != "6" {
+		t.Fatalf("unexpected response %d offset %s", rsp.StatusCode, rsp.Header.Get("Upload-Offset"))
+	}
+
+	rsp = patchTestUpload(g, location, 6, "", strings.NewReader("6789A"))
+	if rsp.StatusCode != http.StatusRequestEntityTooLarge || rsp.Header.Get("Upload-Offset") != "6" {
+		t.Fatalf("unexpected response %d offset %s", rsp.StatusCode, rsp.Header.Get("Upload-Offset"))
+	}
+
+	// The upload is complete but the file service is not there to save it.
+	rsp = patchTestUpload(g, location, 6, "", strings.NewReader("6789"))
+	if rsp.StatusCode != http.StatusServiceUnavailable || rsp.Header.Get("Upload-Offset") != "10" {
+		t.Fatalf("unexpected response %d offset %s", rsp.StatusCode, rsp.Header.Get("Upload-Offset"))
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(g.getUploadsDir(), id))
+	if err != nil || string(data) != "0123456789" {
+		t.Fatalf("unexpected upload data %s %v", data, err)
+	}
+
+	rsp = sendUploadRequest(g, http.MethodDelete, location, "10.0.0.1:1234", nil, nil)
+	if rsp.StatusCode != http.StatusNoContent || g.uploads[id] != nil {
+		t.Fatalf("the upload is not removed %d", rsp.StatusCode)
+	}
+}
+
+// A chunk whose checksum does not match is removed, a file whose sha256 does
+// not match must be upload again.
+func TestUploadChecksum(t *testing.T) {
+	g := newUploadsGlobule(t)
+	defer os.RemoveAll(g.path)
+
+	sum := sha1.Sum([]byte("0123"))
+	checksum := "sha1 " + base64.StdEncoding.EncodeToString(sum[:])
+
+	location := createTestUpload(t, g, "10.0.0.1:1234", 8, map[string]string{})
+	rsp := patchTestUpload(g, location, 0, checksum, strings.NewReader("0124"))
+	if rsp.StatusCode != statusChecksumMismatch || rsp.Header.Get("Upload-Offset") != "0" {
+		t.Fatalf("unexpected response %d offset %s", rsp.StatusCode, rsp.Header.Get("Upload-Offset"))
+	}
+
+	rsp = patchTestUpload(g, location, 0, "crc32 AAAA", strings.NewReader("0123"))
+	if rsp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("unexpected response %d", rsp.StatusCode)
+	}
+
+	rsp = patchTestUpload(g, location, 0, checksum, strings.NewReader("0123"))
+	if rsp.StatusCode != http.StatusNoContent || rsp.Header.Get("Upload-Offset") != "4" {
+		t.Fatalf("unexpected response %d offset %s", rsp.StatusCode, rsp.Header.Get("Upload-Offset"))
+	}
+
+	fileSum := sha256.Sum256([]byte("01234567"))
+	location = createTestUpload(t, g, "10.0.0.1:1234", 8, map[string]string{"sha256": base64.StdEncoding.EncodeToString(fileSum[:])})
+	id := strings.TrimPrefix(location, "/uploads/")
+	rsp = patchTestUpload(g, location, 0, "", strings.NewReader("01234568"))
+	if rsp.StatusCode != statusChecksumMismatch || g.uploads[id] != nil {
+		t.Fatalf("unexpected response %d", rsp.StatusCode)
+	}
+}
+
+// The uploads in progress are limited by client address and for all the
+// clients, a name given by the client does not change it quota.
+func TestUploadQuotas(t *testing.T) {
+	g := newUploadsGlobule(t)
+	defer os.RemoveAll(g.path)
+
+	g.UploadMaxSize = 90
+	g.UploadUserMaxSize = 100
+	g.UploadTotalMaxSize = 150
+
+	tests := []struct {
+		remoteAddr string
+		user       string // The basic authentication name.
+		length     int
+		status     int
+	}{
+		{"10.0.0.1:1234", "", 91, http.StatusRequestEntityTooLarge},
+		{"10.0.0.1:1234", "", 80, http.StatusCreated},
+		{"10.0.0.1:1234", "", 30, http.StatusInsufficientStorage},
+		{"10.0.0.1:5678", "", 30, http.StatusInsufficientStorage},
+		{"10.0.0.1:5678", "other", 30, http.StatusInsufficientStorage},
+		{"10.0.0.2:1234", "10.0.0.1", 20, http.StatusCreated},
+		{"10.0.0.2:1234", "", 40, http.StatusCreated},
+		{"10.0.0.3:1234", "", 20, http.StatusInsufficientStorage},
+		{"10.0.0.3:1234", "", 10, http.StatusCreated},
+	}
+
+	for i, test := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/uploads/", nil)
+		r.RemoteAddr = test.remoteAddr
+		r.Header.Set("Upload-Length", strconv.Itoa(test.length))
+		r.Header.Set("Upload-Metadata", formatUploadMetadata(map[string]string{"filename": "test.txt"}))
+		if len(test.user) > 0 {
+			r.SetBasicAuth(test.user, "")
+		}
+
+		w := httptest.NewRecorder()
+		g.UploadsHandler(w, r)
+		if w.Code != test.status {
+			t.Errorf("%d: the upload of %d bytes from %s has status %d, %d is expected", i, test.length, test.remoteAddr, w.Code, test.status)
+		}
+	}
+
+	users := make(map[string]int64, 0)
+	for _, u := range g.uploads {
+		users[u.User] += u.Length
+	}
+
+	if len(users) != 3 || users["10.0.0.1"] != 80 || users["10.0.0.2"] != 60 || users["10.0.0.3"] != 10 {
+		t.Fatalf("unexpected uploads by user %v", users)
+	}
+}